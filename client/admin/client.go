@@ -283,6 +283,159 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return client.RefreshWorkflowTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ReindexWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ReindexWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ReindexWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DeleteWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) PauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.PauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PauseWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnpauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UnpauseWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UnpauseWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowExecutionAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpsertWorkflowExecutionAttributesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpsertWorkflowExecutionAttributes(ctx, request, opts...)
+}
+
+func (c *clientImpl) StreamWorkflowExecutionHistory(
+	ctx context.Context,
+	request *adminservice.StreamWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_StreamWorkflowExecutionHistoryClient, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	// stream lives until the workflow is closed, so it is bounded by the parent context only
+	return client.StreamWorkflowExecutionHistory(ctx, request, opts...)
+}
+
+func (c *clientImpl) CountWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.CountWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CountWorkflowExecutionsResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CountWorkflowExecutions(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskList(ctx, request, opts...)
+}
+
+func (c *clientImpl) PauseTaskList(
+	ctx context.Context,
+	request *adminservice.PauseTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseTaskListResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PauseTaskList(ctx, request, opts...)
+}
+
+func (c *clientImpl) ResumeTaskList(
+	ctx context.Context,
+	request *adminservice.ResumeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResumeTaskListResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ResumeTaskList(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetReplicationStatusResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetReplicationStatus(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -327,3 +327,190 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ReindexWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ReindexWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientReindexWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientReindexWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ReindexWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientReindexWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDeleteWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDeleteWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.DeleteWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDeleteWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.PauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPauseWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPauseWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.PauseWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPauseWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnpauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UnpauseWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUnpauseWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUnpauseWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUnpauseWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowExecutionAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpsertWorkflowExecutionAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpsertWorkflowExecutionAttributesScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpsertWorkflowExecutionAttributesScope, metrics.ClientLatency)
+	resp, err := c.client.UpsertWorkflowExecutionAttributes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpsertWorkflowExecutionAttributesScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) StreamWorkflowExecutionHistory(
+	ctx context.Context,
+	request *adminservice.StreamWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_StreamWorkflowExecutionHistoryClient, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientStreamWorkflowExecutionHistoryScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientStreamWorkflowExecutionHistoryScope, metrics.ClientLatency)
+	stream, err := c.client.StreamWorkflowExecutionHistory(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientStreamWorkflowExecutionHistoryScope, metrics.ClientFailures)
+	}
+	return stream, err
+}
+
+func (c *metricClient) CountWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.CountWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CountWorkflowExecutionsResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientCountWorkflowExecutionsScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientCountWorkflowExecutionsScope, metrics.ClientLatency)
+	resp, err := c.client.CountWorkflowExecutions(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientCountWorkflowExecutionsScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeTaskListScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeTaskList(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskListScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PauseTaskList(
+	ctx context.Context,
+	request *adminservice.PauseTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseTaskListResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPauseTaskListScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPauseTaskListScope, metrics.ClientLatency)
+	resp, err := c.client.PauseTaskList(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPauseTaskListScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ResumeTaskList(
+	ctx context.Context,
+	request *adminservice.ResumeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResumeTaskListResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientResumeTaskListScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientResumeTaskListScope, metrics.ClientLatency)
+	resp, err := c.client.ResumeTaskList(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientResumeTaskListScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetReplicationStatusResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetReplicationStatusScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetReplicationStatusScope, metrics.ClientLatency)
+	resp, err := c.client.GetReplicationStatus(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetReplicationStatusScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
@@ -301,3 +301,180 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ReindexWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ReindexWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.ReindexWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ReindexWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.DeleteWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.PauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.PauseWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PauseWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnpauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UnpauseWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.UnpauseWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowExecutionAttributesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpsertWorkflowExecutionAttributesResponse, error) {
+
+	var resp *adminservice.UpsertWorkflowExecutionAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpsertWorkflowExecutionAttributes(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) StreamWorkflowExecutionHistory(
+	ctx context.Context,
+	request *adminservice.StreamWorkflowExecutionHistoryRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_StreamWorkflowExecutionHistoryClient, error) {
+
+	// only opening the stream is retried, callers resume a broken stream from the last received event
+	var stream adminservice.AdminService_StreamWorkflowExecutionHistoryClient
+	op := func() error {
+		var err error
+		stream, err = c.client.StreamWorkflowExecutionHistory(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return stream, err
+}
+
+func (c *retryableClient) CountWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.CountWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.CountWorkflowExecutionsResponse, error) {
+
+	var resp *adminservice.CountWorkflowExecutionsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.CountWorkflowExecutions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskListResponse, error) {
+
+	var resp *adminservice.DescribeTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskList(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) PauseTaskList(
+	ctx context.Context,
+	request *adminservice.PauseTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseTaskListResponse, error) {
+
+	var resp *adminservice.PauseTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PauseTaskList(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ResumeTaskList(
+	ctx context.Context,
+	request *adminservice.ResumeTaskListRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResumeTaskListResponse, error) {
+
+	var resp *adminservice.ResumeTaskListResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ResumeTaskList(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetReplicationStatusResponse, error) {
+
+	var resp *adminservice.GetReplicationStatusResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationStatus(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -153,11 +153,12 @@ func (cf *rpcClientFactory) NewMatchingClientWithTimeout(
 		return matchingservice.NewMatchingServiceClient(connection), nil
 	}
 
+	clientCache := common.NewClientCache(keyResolver, clientProvider)
 	client := matching.NewClient(
 		timeout,
 		longPollTimeout,
-		common.NewClientCache(keyResolver, clientProvider),
-		matching.NewLoadBalancer(namespaceIDToName, cf.dynConfig),
+		clientCache,
+		matching.NewLoadBalancer(namespaceIDToName, cf.dynConfig, clientCache),
 	)
 
 	if cf.metricsClient != nil {
@@ -887,7 +887,8 @@ func (c *clientImpl) GetReplicationMessages(
 
 		if _, ok := requestsByClient[client]; !ok {
 			requestsByClient[client] = &historyservice.GetReplicationMessagesRequest{
-				ClusterName: request.ClusterName,
+				ClusterName:             request.ClusterName,
+				AcceptedCompressionType: request.AcceptedCompressionType,
 			}
 		}
 
@@ -926,6 +927,67 @@ func (c *clientImpl) GetReplicationMessages(
 	return response, nil
 }
 
+func (c *clientImpl) GetReplicationStatus(
+	ctx context.Context,
+	request *historyservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetReplicationStatusResponse, error) {
+	requestsByClient := make(map[historyservice.HistoryServiceClient]*historyservice.GetReplicationStatusRequest)
+
+	for _, shardID := range request.ShardIds {
+		client, err := c.getClientForShardID(int(shardID))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := requestsByClient[client]; !ok {
+			requestsByClient[client] = &historyservice.GetReplicationStatusRequest{
+				RemoteClusters:  request.RemoteClusters,
+				NamespaceId:     request.NamespaceId,
+				IncludeDlqTasks: request.IncludeDlqTasks,
+			}
+		}
+
+		req := requestsByClient[client]
+		req.ShardIds = append(req.ShardIds, shardID)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(requestsByClient))
+	respChan := make(chan *historyservice.GetReplicationStatusResponse, len(requestsByClient))
+	errChan := make(chan error, len(requestsByClient))
+	for client, req := range requestsByClient {
+		go func(client historyservice.HistoryServiceClient, request *historyservice.GetReplicationStatusRequest) {
+			defer wg.Done()
+
+			ctx, cancel := c.createContext(ctx)
+			defer cancel()
+			resp, err := client.GetReplicationStatus(ctx, request, opts...)
+			if err != nil {
+				errChan <- err
+				return
+			}
+			respChan <- resp
+		}(client, req)
+	}
+
+	wg.Wait()
+	close(respChan)
+	close(errChan)
+
+	// A partial answer would make shards look drained when they were never asked, so fail the whole call.
+	if err := <-errChan; err != nil {
+		return nil, err
+	}
+
+	response := &historyservice.GetReplicationStatusResponse{}
+	for resp := range respChan {
+		response.Shards = append(response.Shards, resp.Shards...)
+	}
+
+	return response, nil
+}
+
 func (c *clientImpl) GetDLQReplicationMessages(
 	ctx context.Context,
 	request *historyservice.GetDLQReplicationMessagesRequest,
@@ -1031,6 +1093,111 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return response, nil
 }
 
+func (c *clientImpl) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ReindexWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ReindexWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExecution().GetWorkflowId())
+	var response *historyservice.ReindexWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ReindexWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExecution().GetWorkflowId())
+	var response *historyservice.DeleteWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) PauseWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.PauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.PauseWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExecution().GetWorkflowId())
+	var response *historyservice.PauseWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.PauseWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.UnpauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UnpauseWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExecution().GetWorkflowId())
+	var response *historyservice.UnpauseWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UnpauseWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *historyservice.UpsertWorkflowExecutionAttributesRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpsertWorkflowExecutionAttributesResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExecution().GetWorkflowId())
+	var response *historyservice.UpsertWorkflowExecutionAttributesResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UpsertWorkflowExecutionAttributes(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -543,6 +543,23 @@ func (c *metricClient) GetReplicationMessages(
 	return resp, err
 }
 
+func (c *metricClient) GetReplicationStatus(
+	context context.Context,
+	request *historyservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption) (*historyservice.GetReplicationStatusResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationStatusScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetReplicationStatusScope, metrics.ClientLatency)
+	resp, err := c.client.GetReplicationStatus(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationStatusScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) GetDLQReplicationMessages(
 	context context.Context,
 	request *historyservice.GetDLQReplicationMessagesRequest,
@@ -660,3 +677,88 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ReindexWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ReindexWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientReindexWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientReindexWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ReindexWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientReindexWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.DeleteWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.PauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.PauseWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientPauseWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientPauseWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.PauseWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientPauseWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.UnpauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UnpauseWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientUnpauseWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUnpauseWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUnpauseWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *historyservice.UpsertWorkflowExecutionAttributesRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpsertWorkflowExecutionAttributesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientUpsertWorkflowExecutionAttributesScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpsertWorkflowExecutionAttributesScope, metrics.ClientLatency)
+	resp, err := c.client.UpsertWorkflowExecutionAttributes(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpsertWorkflowExecutionAttributesScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
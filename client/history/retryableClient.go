@@ -545,6 +545,21 @@ func (c *retryableClient) GetReplicationMessages(
 	return resp, err
 }
 
+func (c *retryableClient) GetReplicationStatus(
+	ctx context.Context,
+	request *historyservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption) (*historyservice.GetReplicationStatusResponse, error) {
+	var resp *historyservice.GetReplicationStatusResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationStatus(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) GetDLQReplicationMessages(
 	ctx context.Context,
 	request *historyservice.GetDLQReplicationMessagesRequest,
@@ -658,3 +673,88 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ReindexWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ReindexWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.ReindexWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ReindexWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.DeleteWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.PauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.PauseWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.PauseWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PauseWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.UnpauseWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UnpauseWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.UnpauseWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *historyservice.UpsertWorkflowExecutionAttributesRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpsertWorkflowExecutionAttributesResponse, error) {
+
+	var resp *historyservice.UpsertWorkflowExecutionAttributesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpsertWorkflowExecutionAttributes(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
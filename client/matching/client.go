@@ -203,6 +203,26 @@ func (c *clientImpl) ListTaskListPartitions(ctx context.Context, request *matchi
 	return client.ListTaskListPartitions(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetTaskListPartitionConfig(ctx context.Context, request *matchingservice.GetTaskListPartitionConfigRequest, opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionConfigResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskListPartitionConfig(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateTaskListPauseState(ctx context.Context, request *matchingservice.UpdateTaskListPauseStateRequest, opts ...grpc.CallOption) (*matchingservice.UpdateTaskListPauseStateResponse, error) {
+	client, err := c.getClientForTasklist(request.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskListPauseState(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
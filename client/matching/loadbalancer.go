@@ -32,6 +32,7 @@ import (
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -67,6 +68,8 @@ type (
 	defaultLoadBalancer struct {
 		nReadPartitions   dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		nWritePartitions  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		enableAutoScaling dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+		partitionConfigs  *partitionConfigCache
 		namespaceIDToName func(string) (string, error)
 	}
 )
@@ -76,15 +79,20 @@ const (
 )
 
 // NewLoadBalancer returns an instance of matching load balancer that
-// can help distribute api calls across task list partitions
+// can help distribute api calls across task list partitions. When partition
+// auto scaling is enabled for a task list, the number of partitions is
+// fetched from the root partition of the task list through the given clients
 func NewLoadBalancer(
 	namespaceIDToName func(string) (string, error),
 	dc *dynamicconfig.Collection,
+	clients common.ClientCache,
 ) LoadBalancer {
 	return &defaultLoadBalancer{
 		namespaceIDToName: namespaceIDToName,
 		nReadPartitions:   dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistReadPartitions, 1),
 		nWritePartitions:  dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingNumTasklistWritePartitions, 1),
+		enableAutoScaling: dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnablePartitionAutoScaling, false),
+		partitionConfigs:  newPartitionConfigCache(clients),
 	}
 }
 
@@ -94,7 +102,7 @@ func (lb *defaultLoadBalancer) PickWritePartition(
 	taskListType enumspb.TaskListType,
 	forwardedFrom string,
 ) string {
-	return lb.pickPartition(namespaceID, taskList, taskListType, forwardedFrom, lb.nWritePartitions)
+	return lb.pickPartition(namespaceID, taskList, taskListType, forwardedFrom, false)
 }
 
 func (lb *defaultLoadBalancer) PickReadPartition(
@@ -103,7 +111,7 @@ func (lb *defaultLoadBalancer) PickReadPartition(
 	taskListType enumspb.TaskListType,
 	forwardedFrom string,
 ) string {
-	return lb.pickPartition(namespaceID, taskList, taskListType, forwardedFrom, lb.nReadPartitions)
+	return lb.pickPartition(namespaceID, taskList, taskListType, forwardedFrom, true)
 }
 
 func (lb *defaultLoadBalancer) pickPartition(
@@ -111,7 +119,7 @@ func (lb *defaultLoadBalancer) pickPartition(
 	taskList tasklistpb.TaskList,
 	taskListType enumspb.TaskListType,
 	forwardedFrom string,
-	nPartitions dynamicconfig.IntPropertyFnWithTaskListInfoFilters,
+	read bool,
 ) string {
 
 	if forwardedFrom != "" || taskList.GetKind() == enumspb.TASK_LIST_KIND_STICKY {
@@ -128,7 +136,7 @@ func (lb *defaultLoadBalancer) pickPartition(
 		return taskList.GetName()
 	}
 
-	n := nPartitions(namespace, taskList.GetName(), taskListType)
+	n := lb.numPartitions(namespaceID, namespace, taskList.GetName(), taskListType, read)
 	if n <= 0 {
 		return taskList.GetName()
 	}
@@ -140,3 +148,24 @@ func (lb *defaultLoadBalancer) pickPartition(
 
 	return fmt.Sprintf("%v%v/%v", taskListPartitionPrefix, taskList.GetName(), p)
 }
+
+func (lb *defaultLoadBalancer) numPartitions(
+	namespaceID string,
+	namespace string,
+	taskList string,
+	taskListType enumspb.TaskListType,
+	read bool,
+) int {
+	if lb.enableAutoScaling(namespace, taskList, taskListType) {
+		if nRead, nWrite, ok := lb.partitionConfigs.get(namespaceID, taskList, taskListType); ok {
+			if read {
+				return nRead
+			}
+			return nWrite
+		}
+	}
+	if read {
+		return lb.nReadPartitions(namespace, taskList, taskListType)
+	}
+	return lb.nWritePartitions(namespace, taskList, taskListType)
+}
@@ -246,6 +246,42 @@ func (c *metricClient) ListTaskListPartitions(
 	return resp, err
 }
 
+func (c *metricClient) GetTaskListPartitionConfig(
+	ctx context.Context,
+	request *matchingservice.GetTaskListPartitionConfigRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionConfigResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListPartitionConfigScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetTaskListPartitionConfigScope, metrics.ClientLatency)
+	resp, err := c.client.GetTaskListPartitionConfig(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetTaskListPartitionConfigScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) UpdateTaskListPauseState(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskListPauseStateRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskListPauseStateResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskListPauseStateScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateTaskListPauseStateScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateTaskListPauseState(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskListPauseStateScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) emitForwardedFromStats(scope int, forwardedFrom string, taskList *tasklistpb.TaskList) {
 	if taskList == nil {
 		return
@@ -0,0 +1,136 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	"github.com/temporalio/temporal/common"
+)
+
+const (
+	// partitionConfigRefreshInterval is the interval at which the cached partition
+	// config of a task list is refreshed from the root partition
+	partitionConfigRefreshInterval = 30 * time.Second
+	// partitionConfigFetchTimeout is the timeout for fetching the partition config
+	partitionConfigFetchTimeout = 5 * time.Second
+)
+
+type (
+	// partitionConfigCache caches the partition config of auto scaled task lists. Entries
+	// are refreshed asynchronously so that load balancing never blocks on the root partition.
+	partitionConfigCache struct {
+		sync.RWMutex
+		clients common.ClientCache
+		entries map[partitionConfigKey]*partitionConfigEntry
+	}
+
+	partitionConfigKey struct {
+		namespaceID  string
+		taskList     string
+		taskListType enumspb.TaskListType
+	}
+
+	partitionConfigEntry struct {
+		numReadPartitions  int32
+		numWritePartitions int32
+		expiry             int64
+		refreshing         int32
+	}
+)
+
+func newPartitionConfigCache(clients common.ClientCache) *partitionConfigCache {
+	return &partitionConfigCache{
+		clients: clients,
+		entries: make(map[partitionConfigKey]*partitionConfigEntry),
+	}
+}
+
+// get returns the cached number of read and write partitions of a task list. Returns false if the
+// partition config is not cached yet, in which case it is fetched in the background.
+func (c *partitionConfigCache) get(
+	namespaceID string,
+	taskList string,
+	taskListType enumspb.TaskListType,
+) (numReadPartitions int, numWritePartitions int, ok bool) {
+	key := partitionConfigKey{namespaceID: namespaceID, taskList: taskList, taskListType: taskListType}
+
+	c.RLock()
+	entry, found := c.entries[key]
+	c.RUnlock()
+	if !found {
+		c.Lock()
+		if entry, found = c.entries[key]; !found {
+			entry = &partitionConfigEntry{}
+			c.entries[key] = entry
+		}
+		c.Unlock()
+	}
+
+	if time.Now().UnixNano() >= atomic.LoadInt64(&entry.expiry) &&
+		atomic.CompareAndSwapInt32(&entry.refreshing, 0, 1) {
+		go c.refresh(key, entry)
+	}
+
+	numRead := atomic.LoadInt32(&entry.numReadPartitions)
+	numWrite := atomic.LoadInt32(&entry.numWritePartitions)
+	if numRead <= 0 || numWrite <= 0 {
+		return 0, 0, false
+	}
+	return int(numRead), int(numWrite), true
+}
+
+func (c *partitionConfigCache) refresh(key partitionConfigKey, entry *partitionConfigEntry) {
+	defer atomic.StoreInt32(&entry.refreshing, 0)
+	// failures are not retried until the next refresh, load balancing
+	// falls back to dynamic config in the meantime
+	defer atomic.StoreInt64(&entry.expiry, time.Now().Add(partitionConfigRefreshInterval).UnixNano())
+
+	client, err := c.clients.GetClientForKey(key.taskList)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), partitionConfigFetchTimeout)
+	defer cancel()
+	resp, err := client.(matchingservice.MatchingServiceClient).GetTaskListPartitionConfig(ctx, &matchingservice.GetTaskListPartitionConfigRequest{
+		NamespaceId:  key.namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: key.taskList, Kind: enumspb.TASK_LIST_KIND_NORMAL},
+		TaskListType: key.taskListType,
+	})
+	if err != nil {
+		return
+	}
+
+	atomic.StoreInt32(&entry.numReadPartitions, resp.GetNumReadPartitions())
+	atomic.StoreInt32(&entry.numWritePartitions, resp.GetNumWritePartitions())
+}
@@ -193,3 +193,35 @@ func (c *retryableClient) ListTaskListPartitions(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) GetTaskListPartitionConfig(
+	ctx context.Context,
+	request *matchingservice.GetTaskListPartitionConfigRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskListPartitionConfigResponse, error) {
+
+	var resp *matchingservice.GetTaskListPartitionConfigResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskListPartitionConfig(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateTaskListPauseState(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskListPauseStateRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskListPauseStateResponse, error) {
+
+	var resp *matchingservice.UpdateTaskListPauseStateResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateTaskListPauseState(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -25,6 +25,7 @@
 package temporal
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -43,6 +44,7 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	payloadStoreProvider "github.com/temporalio/temporal/common/payloadstore/provider"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	"github.com/temporalio/temporal/common/primitives"
@@ -50,6 +52,7 @@ import (
 	"github.com/temporalio/temporal/common/rpc"
 	"github.com/temporalio/temporal/common/rpc/encryption"
 	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/config/dbmembership"
 	"github.com/temporalio/temporal/common/service/config/ringpop"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/frontend"
@@ -145,14 +148,28 @@ func (s *server) startService() common.Daemon {
 
 	params.MembershipFactoryInitializer =
 		func(persistenceBean persistenceClient.Bean, logger l.Logger) (resource.MembershipMonitorFactory, error) {
-			return ringpop.NewRingpopFactory(
-				&s.cfg.Global.Membership,
-				params.RPCFactory.GetRingpopChannel(),
-				params.Name,
-				servicePortMap,
-				logger,
-				persistenceBean.GetClusterMetadataManager(),
-			)
+			switch s.cfg.Global.Membership.Provider {
+			case config.MembershipProviderDatabase:
+				return dbmembership.NewDatabaseFactory(
+					&s.cfg.Global.Membership,
+					params.RPCFactory,
+					params.Name,
+					servicePortMap,
+					logger,
+					persistenceBean.GetClusterMetadataManager(),
+				)
+			case "", config.MembershipProviderRingpop:
+				return ringpop.NewRingpopFactory(
+					&s.cfg.Global.Membership,
+					params.RPCFactory.GetRingpopChannel(),
+					params.Name,
+					servicePortMap,
+					logger,
+					persistenceBean.GetClusterMetadataManager(),
+				)
+			default:
+				return nil, fmt.Errorf("unknown membership provider: %v", s.cfg.Global.Membership.Provider)
+			}
 		}
 
 	params.DCRedirectionPolicy = s.cfg.DCRedirectionPolicy
@@ -246,6 +263,7 @@ func (s *server) startService() common.Daemon {
 	)
 
 	params.ArchiverProvider = provider.NewArchiverProvider(s.cfg.Archival.History.Provider, s.cfg.Archival.Visibility.Provider)
+	params.PayloadStoreProvider = payloadStoreProvider.NewPayloadStoreProvider(s.cfg.PayloadStore.Provider)
 
 	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
 
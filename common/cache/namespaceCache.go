@@ -27,17 +27,21 @@
 package cache
 
 import (
+	"fmt"
 	"hash/fnv"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 	namespacepb "go.temporal.io/temporal-proto/namespace/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
 
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/clock"
@@ -45,6 +49,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -748,6 +753,12 @@ func (entry *NamespaceCacheEntry) IsNamespaceActive() bool {
 	return entry.clusterMetadata.GetCurrentClusterName() == entry.replicationConfig.ActiveClusterName
 }
 
+// IsNamespaceHandover return whether the namespace is draining replication to another cluster before a graceful failover
+func (entry *NamespaceCacheEntry) IsNamespaceHandover() bool {
+	return entry.isGlobalNamespace &&
+		entry.replicationConfig.GetState() == enumsgenpb.NAMESPACE_REPLICATION_STATE_HANDOVER
+}
+
 // GetReplicationPolicy return the derived workflow replication policy
 func (entry *NamespaceCacheEntry) GetReplicationPolicy() ReplicationPolicy {
 	// frontend guarantee that the clusters always contains the active namespace, so if the # of clusters is 1
@@ -851,3 +862,71 @@ func (entry *NamespaceCacheEntry) IsSampledForLongerRetention(
 	}
 	return false
 }
+
+// ReplicatedWorkflowTypesKey is key to specify the comma separated workflow types replicated to other clusters
+var ReplicatedWorkflowTypesKey = "replicated_workflow_types"
+
+// ReplicatedSearchAttributeKey is key to specify the search attribute, as key=value, which workflows replicated
+// to other clusters have at start
+var ReplicatedSearchAttributeKey = "replicated_search_attribute"
+
+// IsReplicationFilterEnabled return whether only a subset of the workflows of the namespace is replicated
+func (entry *NamespaceCacheEntry) IsReplicationFilterEnabled() bool {
+	_, workflowTypesOK := entry.info.Data[ReplicatedWorkflowTypesKey]
+	_, searchAttributeOK := entry.info.Data[ReplicatedSearchAttributeKey]
+	return workflowTypesOK || searchAttributeOK
+}
+
+// ShouldReplicateWorkflow return whether a workflow of given type and search attributes at start should be replicated
+// to other clusters, i.e. it matches either the replicated workflow types or the replicated search attribute
+func (entry *NamespaceCacheEntry) ShouldReplicateWorkflow(
+	workflowType string,
+	searchAttributes map[string]*commonpb.Payload,
+) bool {
+
+	if !entry.IsReplicationFilterEnabled() {
+		return true
+	}
+
+	if workflowTypes, ok := entry.info.Data[ReplicatedWorkflowTypesKey]; ok {
+		for _, replicatedWorkflowType := range strings.Split(workflowTypes, ",") {
+			if strings.TrimSpace(replicatedWorkflowType) == workflowType {
+				return true
+			}
+		}
+	}
+
+	if searchAttribute, ok := entry.info.Data[ReplicatedSearchAttributeKey]; ok {
+		key, value, ok := ParseReplicatedSearchAttribute(searchAttribute)
+		if !ok {
+			return false
+		}
+		attr, ok := searchAttributes[key]
+		if !ok {
+			return false
+		}
+		var attrValue interface{}
+		if err := payload.Decode(attr, &attrValue); err != nil {
+			return false
+		}
+		return fmt.Sprint(attrValue) == value
+	}
+	return false
+}
+
+// ParseReplicatedSearchAttribute parses the value of ReplicatedSearchAttributeKey into search attribute key and value
+func ParseReplicatedSearchAttribute(
+	searchAttribute string,
+) (string, string, bool) {
+
+	parts := strings.SplitN(searchAttribute, "=", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	key := strings.TrimSpace(parts[0])
+	value := strings.TrimSpace(parts[1])
+	if key == "" || value == "" {
+		return "", "", false
+	}
+	return key, value, true
+}
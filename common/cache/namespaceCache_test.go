@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 	namespacepb "go.temporal.io/temporal-proto/namespace/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
@@ -40,6 +41,7 @@ import (
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -672,3 +674,30 @@ func Test_IsSampledForLongerRetention(t *testing.T) {
 	d.info.Data[SampleRateKey] = "invalid-value"
 	require.False(t, d.IsSampledForLongerRetention(wid))
 }
+
+func Test_ShouldReplicateWorkflow(t *testing.T) {
+	d := &NamespaceCacheEntry{
+		info: &persistenceblobs.NamespaceInfo{
+			Data: make(map[string]string),
+		},
+	}
+	criticalAttributes := map[string]*commonpb.Payload{"CustomKeywordField": payload.EncodeString("critical")}
+	otherAttributes := map[string]*commonpb.Payload{"CustomKeywordField": payload.EncodeString("other")}
+
+	require.False(t, d.IsReplicationFilterEnabled())
+	require.True(t, d.ShouldReplicateWorkflow("some random workflow type", nil))
+
+	d.info.Data[ReplicatedWorkflowTypesKey] = "critical-workflow-type-0, critical-workflow-type-1"
+	require.True(t, d.IsReplicationFilterEnabled())
+	require.True(t, d.ShouldReplicateWorkflow("critical-workflow-type-1", nil))
+	require.False(t, d.ShouldReplicateWorkflow("some random workflow type", criticalAttributes))
+
+	d.info.Data[ReplicatedSearchAttributeKey] = "CustomKeywordField=critical"
+	require.True(t, d.ShouldReplicateWorkflow("critical-workflow-type-0", otherAttributes))
+	require.True(t, d.ShouldReplicateWorkflow("some random workflow type", criticalAttributes))
+	require.False(t, d.ShouldReplicateWorkflow("some random workflow type", otherAttributes))
+	require.False(t, d.ShouldReplicateWorkflow("some random workflow type", nil))
+
+	d.info.Data[ReplicatedSearchAttributeKey] = "invalid-value"
+	require.False(t, d.ShouldReplicateWorkflow("some random workflow type", criticalAttributes))
+}
@@ -148,3 +148,23 @@ const (
 	// AdvancedVisibilityWritingModeDual means write to both normal visibility and advanced visibility store
 	AdvancedVisibilityWritingModeDual = "dual"
 )
+
+// Signals with reserved names are recorded by the server and returned in history like any other event.
+// They are not decision events, so SDKs replay them without matching a decision and deliver them to
+// a signal channel that workflow code never reads.
+const (
+	// WorkflowPausedSignalName is the reserved signal name recorded in history when a workflow is paused
+	WorkflowPausedSignalName = "__temporal_workflow_paused"
+	// WorkflowUnpausedSignalName is the reserved signal name recorded in history when a workflow is unpaused
+	WorkflowUnpausedSignalName = "__temporal_workflow_unpaused"
+	// WorkflowAttributesUpsertedSignalName is the reserved signal name recorded in history when memo or
+	// search attributes of a workflow are upserted without a decision
+	WorkflowAttributesUpsertedSignalName = "__temporal_workflow_attributes_upserted"
+	// UpsertWorkflowMemoMarkerName is the reserved marker name of the decision which upserts the memo of
+	// a workflow, every marker detail is a memo field holding exactly one payload
+	UpsertWorkflowMemoMarkerName = "__temporal_upsert_workflow_memo"
+	// ActivityLabelSelectorHeaderField is the reserved field of the activity header which holds the label selector
+	// of the activity as a json encoded map of label keys to values. The activity task is only handed to pollers
+	// advertising all of these labels, until the selector times out
+	ActivityLabelSelectorHeaderField = "__temporal_label_selector"
+)
@@ -51,6 +51,8 @@ const (
 	CustomBoolField       = "CustomBoolField"
 	CustomDatetimeField   = "CustomDatetimeField"
 	TemporalChangeVersion = "TemporalChangeVersion"
+	TemporalPaused        = "TemporalPaused"
+	TemporalUnreplicated  = "TemporalUnreplicated"
 )
 
 // valid non-indexed fields on ES
@@ -74,6 +76,8 @@ func createDefaultIndexedKeys() map[string]interface{} {
 		CustomDatetimeField:   enumspb.INDEXED_VALUE_TYPE_DATETIME,
 		TemporalChangeVersion: enumspb.INDEXED_VALUE_TYPE_KEYWORD,
 		BinaryChecksums:       enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		TemporalPaused:        enumspb.INDEXED_VALUE_TYPE_BOOL,
+		TemporalUnreplicated:  enumspb.INDEXED_VALUE_TYPE_BOOL,
 	}
 	for k, v := range systemIndexedKeys {
 		defaultIndexedKeys[k] = v
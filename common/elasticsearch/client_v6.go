@@ -0,0 +1,314 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	elastic6 "github.com/olivere/elastic"
+	"github.com/olivere/elastic/v7"
+)
+
+type (
+	// elasticWrapperV6 implements Client for ElasticSearch 6.x clusters.
+	// Every result returned by the v6 library is converted to its v7 counterpart.
+	elasticWrapperV6 struct {
+		client *elastic6.Client
+	}
+
+	scrollServiceV6 struct {
+		scrollService *elastic6.ScrollService
+	}
+
+	bulkProcessorV6 struct {
+		processor *elastic6.BulkProcessor
+	}
+
+	bulkServiceV6 struct {
+		bulkService *elastic6.BulkService
+	}
+)
+
+var _ Client = (*elasticWrapperV6)(nil)
+
+func newClientV6(config *Config) (Client, error) {
+	client, err := elastic6.NewClient(
+		elastic6.SetURL(config.URL.String()),
+		elastic6.SetRetrier(elastic6.NewBackoffRetrier(elastic6.NewExponentialBackoff(128*time.Millisecond, 513*time.Millisecond))),
+		elastic6.SetDecoder(&elastic6.NumberDecoder{}), // critical to ensure decode of int64 won't lose precise
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewWrapperClientV6(client), nil
+}
+
+// NewWrapperClientV6 returns a new implementation of Client for ElasticSearch 6.x
+func NewWrapperClientV6(esClient *elastic6.Client) Client {
+	return &elasticWrapperV6{client: esClient}
+}
+
+func (c *elasticWrapperV6) Search(ctx context.Context, p *SearchParameters) (*elastic.SearchResult, error) {
+	searchService := c.client.Search(p.Index).
+		Query(p.Query).
+		From(p.From).
+		SortBy(convertSortersToV6(p.Sorter)...)
+
+	if p.PageSize != 0 {
+		searchService.Size(p.PageSize)
+	}
+
+	if len(p.SearchAfter) != 0 {
+		searchService.SearchAfter(p.SearchAfter...)
+	}
+
+	return convertSearchResultToV7(searchService.Do(ctx))
+}
+
+func (c *elasticWrapperV6) SearchWithDSL(ctx context.Context, index, query string) (*elastic.SearchResult, error) {
+	return convertSearchResultToV7(c.client.Search(index).Source(query).Do(ctx))
+}
+
+func (c *elasticWrapperV6) Scroll(ctx context.Context, scrollID string) (
+	*elastic.SearchResult, ScrollService, error) {
+
+	scrollService := elastic6.NewScrollService(c.client)
+	result, err := convertSearchResultToV7(scrollService.ScrollId(scrollID).Do(ctx))
+	return result, &scrollServiceV6{scrollService}, err
+}
+
+func (c *elasticWrapperV6) ScrollFirstPage(ctx context.Context, index, query string) (
+	*elastic.SearchResult, ScrollService, error) {
+
+	scrollService := elastic6.NewScrollService(c.client)
+	result, err := convertSearchResultToV7(scrollService.Index(index).Body(query).Do(ctx))
+	return result, &scrollServiceV6{scrollService}, err
+}
+
+func (c *elasticWrapperV6) Count(ctx context.Context, index, query string) (int64, error) {
+	count, err := c.client.Count(index).BodyString(query).Do(ctx)
+	return count, convertErrorToV7(err)
+}
+
+func (c *elasticWrapperV6) RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (BulkProcessor, error) {
+	service := c.client.BulkProcessor().
+		Name(p.Name).
+		Workers(p.NumOfWorkers).
+		BulkActions(p.BulkActions).
+		BulkSize(p.BulkSize).
+		FlushInterval(p.FlushInterval).
+		Backoff(p.Backoff)
+
+	if p.BeforeFunc != nil {
+		service.Before(func(executionID int64, requests []elastic6.BulkableRequest) {
+			p.BeforeFunc(executionID, convertBulkableRequestsToV7(requests))
+		})
+	}
+
+	if p.AfterFunc != nil {
+		service.After(func(executionID int64, requests []elastic6.BulkableRequest, response *elastic6.BulkResponse, err error) {
+			p.AfterFunc(executionID, convertBulkableRequestsToV7(requests), convertBulkResponseToV7(response), convertErrorToV7(err))
+		})
+	}
+
+	processor, err := service.Do(ctx)
+	if err != nil {
+		return nil, convertErrorToV7(err)
+	}
+	return &bulkProcessorV6{processor: processor}, nil
+}
+
+func (c *elasticWrapperV6) Bulk() BulkService {
+	return &bulkServiceV6{bulkService: c.client.Bulk()}
+}
+
+// root is for nested object like Attr property for search attributes.
+func (c *elasticWrapperV6) PutMapping(ctx context.Context, index, root, key, valueType string) error {
+	body := buildPutMappingBody(root, key, valueType)
+	_, err := c.client.PutMapping().Index(index).Type(docType).BodyJson(body).Do(ctx)
+	return convertErrorToV7(err)
+}
+
+func (c *elasticWrapperV6) CreateIndex(ctx context.Context, index string) error {
+	_, err := c.client.CreateIndex(index).Do(ctx)
+	return convertErrorToV7(err)
+}
+
+func (c *elasticWrapperV6) DeleteIndex(ctx context.Context, index string) error {
+	_, err := c.client.DeleteIndex(index).Do(ctx)
+	return convertErrorToV7(err)
+}
+
+func (c *elasticWrapperV6) IndexExists(ctx context.Context, index string) (bool, error) {
+	exists, err := c.client.IndexExists(index).Do(ctx)
+	return exists, convertErrorToV7(err)
+}
+
+func (c *elasticWrapperV6) PutTemplate(ctx context.Context, name, body string) error {
+	_, err := c.client.IndexPutTemplate(name).BodyString(body).Do(ctx)
+	return convertErrorToV7(err)
+}
+
+func (c *elasticWrapperV6) CatIndices(ctx context.Context) (elastic.CatIndicesResponse, error) {
+	response, err := c.client.CatIndices().Do(ctx)
+	if err != nil {
+		return nil, convertErrorToV7(err)
+	}
+	var result elastic.CatIndicesResponse
+	if err := convertViaJSON(response, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *scrollServiceV6) Clear(ctx context.Context) error {
+	return convertErrorToV7(s.scrollService.Clear(ctx))
+}
+
+func (p *bulkProcessorV6) Stop() error {
+	return convertErrorToV7(p.processor.Stop())
+}
+
+func (p *bulkProcessorV6) Add(request *BulkableRequest) {
+	p.processor.Add(convertBulkableRequestToV6(request))
+}
+
+func (b *bulkServiceV6) Add(requests ...*BulkableRequest) {
+	for _, request := range requests {
+		b.bulkService.Add(convertBulkableRequestToV6(request))
+	}
+}
+
+func (b *bulkServiceV6) NumberOfActions() int {
+	return b.bulkService.NumberOfActions()
+}
+
+func (b *bulkServiceV6) Do(ctx context.Context) (*elastic.BulkResponse, error) {
+	response, err := b.bulkService.Do(ctx)
+	return convertBulkResponseToV7(response), convertErrorToV7(err)
+}
+
+// convertBulkableRequestToV6 builds a request for the "_doc" mapping type
+func convertBulkableRequestToV6(request *BulkableRequest) elastic6.BulkableRequest {
+	switch request.RequestType {
+	case BulkableDeleteRequest:
+		return elastic6.NewBulkDeleteRequest().
+			Index(request.Index).
+			Type(docType).
+			Id(request.ID).
+			VersionType(versionTypeExternal).
+			Version(request.Version)
+	default:
+		return elastic6.NewBulkIndexRequest().
+			Index(request.Index).
+			Type(docType).
+			Id(request.ID).
+			VersionType(versionTypeExternal).
+			Version(request.Version).
+			Doc(request.Doc)
+	}
+}
+
+// v6 and v7 bulkable requests share the same method set, only the slice needs to be rebuilt
+func convertBulkableRequestsToV7(requests []elastic6.BulkableRequest) []elastic.BulkableRequest {
+	if requests == nil {
+		return nil
+	}
+	result := make([]elastic.BulkableRequest, len(requests))
+	for i, request := range requests {
+		result[i] = request
+	}
+	return result
+}
+
+// v6 and v7 sorters share the same method set, only the slice needs to be rebuilt
+func convertSortersToV6(sorters []elastic.Sorter) []elastic6.Sorter {
+	if sorters == nil {
+		return nil
+	}
+	result := make([]elastic6.Sorter, len(sorters))
+	for i, sorter := range sorters {
+		result[i] = sorter
+	}
+	return result
+}
+
+func convertSearchResultToV7(result *elastic6.SearchResult, err error) (*elastic.SearchResult, error) {
+	if err != nil {
+		return nil, convertErrorToV7(err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	// v7 library accepts both the integer total hits of 6.x and the object of 7.x
+	var converted elastic.SearchResult
+	if err := convertViaJSON(result, &converted); err != nil {
+		return nil, err
+	}
+	converted.Header = result.Header
+	return &converted, nil
+}
+
+func convertBulkResponseToV7(response *elastic6.BulkResponse) *elastic.BulkResponse {
+	if response == nil {
+		return nil
+	}
+	var converted elastic.BulkResponse
+	if err := convertViaJSON(response, &converted); err != nil {
+		// every v6 field has a v7 counterpart with the same json tag, so this should never happen
+		return &elastic.BulkResponse{Took: response.Took, Errors: response.Errors}
+	}
+	return &converted
+}
+
+// convertErrorToV7 converts errors returned by ElasticSearch so callers can use helpers like elastic.IsNotFound
+func convertErrorToV7(err error) error {
+	switch e := err.(type) {
+	case *elastic6.Error:
+		converted := &elastic.Error{Status: e.Status}
+		if e.Details != nil {
+			converted.Details = &elastic.ErrorDetails{}
+			if convertErr := convertViaJSON(e.Details, converted.Details); convertErr != nil {
+				converted.Details = &elastic.ErrorDetails{Type: e.Details.Type, Reason: e.Details.Reason}
+			}
+		}
+		return converted
+	default:
+		return err
+	}
+}
+
+// convertViaJSON converts between v6 and v7 types which have the same json representation
+func convertViaJSON(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	decoder := &elastic.NumberDecoder{}
+	return decoder.Decode(data, to)
+}
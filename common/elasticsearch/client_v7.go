@@ -0,0 +1,208 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+)
+
+type (
+	// elasticWrapperV7 implements Client for ElasticSearch 7.x and OpenSearch clusters
+	elasticWrapperV7 struct {
+		client *elastic.Client
+	}
+
+	scrollServiceV7 struct {
+		scrollService *elastic.ScrollService
+	}
+
+	bulkProcessorV7 struct {
+		processor *elastic.BulkProcessor
+	}
+
+	bulkServiceV7 struct {
+		bulkService *elastic.BulkService
+	}
+)
+
+var _ Client = (*elasticWrapperV7)(nil)
+
+func newClientV7(config *Config) (Client, error) {
+	client, err := elastic.NewClient(
+		elastic.SetURL(config.URL.String()),
+		elastic.SetRetrier(elastic.NewBackoffRetrier(elastic.NewExponentialBackoff(128*time.Millisecond, 513*time.Millisecond))),
+		elastic.SetDecoder(&elastic.NumberDecoder{}), // critical to ensure decode of int64 won't lose precise
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewWrapperClientV7(client), nil
+}
+
+// NewWrapperClientV7 returns a new implementation of Client for ElasticSearch 7.x
+func NewWrapperClientV7(esClient *elastic.Client) Client {
+	return &elasticWrapperV7{client: esClient}
+}
+
+func (c *elasticWrapperV7) Search(ctx context.Context, p *SearchParameters) (*elastic.SearchResult, error) {
+	searchService := c.client.Search(p.Index).
+		Query(p.Query).
+		From(p.From).
+		SortBy(p.Sorter...).
+		TrackTotalHits(true)
+
+	if p.PageSize != 0 {
+		searchService.Size(p.PageSize)
+	}
+
+	if len(p.SearchAfter) != 0 {
+		searchService.SearchAfter(p.SearchAfter...)
+	}
+
+	return searchService.Do(ctx)
+}
+
+func (c *elasticWrapperV7) SearchWithDSL(ctx context.Context, index, query string) (*elastic.SearchResult, error) {
+	return c.client.Search(index).Source(query).Do(ctx)
+}
+
+func (c *elasticWrapperV7) Scroll(ctx context.Context, scrollID string) (
+	*elastic.SearchResult, ScrollService, error) {
+
+	scrollService := elastic.NewScrollService(c.client)
+	result, err := scrollService.ScrollId(scrollID).Do(ctx)
+	return result, &scrollServiceV7{scrollService}, err
+}
+
+func (c *elasticWrapperV7) ScrollFirstPage(ctx context.Context, index, query string) (
+	*elastic.SearchResult, ScrollService, error) {
+
+	scrollService := elastic.NewScrollService(c.client)
+	result, err := scrollService.Index(index).Body(query).Do(ctx)
+	return result, &scrollServiceV7{scrollService}, err
+}
+
+func (c *elasticWrapperV7) Count(ctx context.Context, index, query string) (int64, error) {
+	return c.client.Count(index).BodyString(query).Do(ctx)
+}
+
+func (c *elasticWrapperV7) RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (BulkProcessor, error) {
+	processor, err := c.client.BulkProcessor().
+		Name(p.Name).
+		Workers(p.NumOfWorkers).
+		BulkActions(p.BulkActions).
+		BulkSize(p.BulkSize).
+		FlushInterval(p.FlushInterval).
+		Backoff(p.Backoff).
+		Before(p.BeforeFunc).
+		After(p.AfterFunc).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &bulkProcessorV7{processor: processor}, nil
+}
+
+func (c *elasticWrapperV7) Bulk() BulkService {
+	return &bulkServiceV7{bulkService: c.client.Bulk()}
+}
+
+// root is for nested object like Attr property for search attributes.
+func (c *elasticWrapperV7) PutMapping(ctx context.Context, index, root, key, valueType string) error {
+	body := buildPutMappingBody(root, key, valueType)
+	_, err := c.client.PutMapping().Index(index).BodyJson(body).Do(ctx)
+	return err
+}
+
+func (c *elasticWrapperV7) CreateIndex(ctx context.Context, index string) error {
+	_, err := c.client.CreateIndex(index).Do(ctx)
+	return err
+}
+
+func (c *elasticWrapperV7) DeleteIndex(ctx context.Context, index string) error {
+	_, err := c.client.DeleteIndex(index).Do(ctx)
+	return err
+}
+
+func (c *elasticWrapperV7) IndexExists(ctx context.Context, index string) (bool, error) {
+	return c.client.IndexExists(index).Do(ctx)
+}
+
+func (c *elasticWrapperV7) PutTemplate(ctx context.Context, name, body string) error {
+	_, err := c.client.IndexPutTemplate(name).BodyString(body).Do(ctx)
+	return err
+}
+
+func (c *elasticWrapperV7) CatIndices(ctx context.Context) (elastic.CatIndicesResponse, error) {
+	return c.client.CatIndices().Do(ctx)
+}
+
+func (s *scrollServiceV7) Clear(ctx context.Context) error {
+	return s.scrollService.Clear(ctx)
+}
+
+func (p *bulkProcessorV7) Stop() error {
+	return p.processor.Stop()
+}
+
+func (p *bulkProcessorV7) Add(request *BulkableRequest) {
+	p.processor.Add(convertBulkableRequestToV7(request))
+}
+
+func (b *bulkServiceV7) Add(requests ...*BulkableRequest) {
+	for _, request := range requests {
+		b.bulkService.Add(convertBulkableRequestToV7(request))
+	}
+}
+
+func (b *bulkServiceV7) NumberOfActions() int {
+	return b.bulkService.NumberOfActions()
+}
+
+func (b *bulkServiceV7) Do(ctx context.Context) (*elastic.BulkResponse, error) {
+	return b.bulkService.Do(ctx)
+}
+
+// convertBulkableRequestToV7 builds a typeless request
+func convertBulkableRequestToV7(request *BulkableRequest) elastic.BulkableRequest {
+	switch request.RequestType {
+	case BulkableDeleteRequest:
+		return elastic.NewBulkDeleteRequest().
+			Index(request.Index).
+			Id(request.ID).
+			VersionType(versionTypeExternal).
+			Version(request.Version)
+	default:
+		return elastic.NewBulkIndexRequest().
+			Index(request.Index).
+			Id(request.ID).
+			VersionType(versionTypeExternal).
+			Version(request.Version).
+			Doc(request.Doc)
+	}
+}
@@ -0,0 +1,161 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	elastic6 "github.com/olivere/elastic"
+	"github.com/olivere/elastic/v7"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	clientVersionSuite struct {
+		suite.Suite
+		server   *httptest.Server
+		handler  func(w http.ResponseWriter, r *http.Request)
+		requests []string
+		bodies   []string
+	}
+)
+
+func TestClientVersionSuite(t *testing.T) {
+	suite.Run(t, new(clientVersionSuite))
+}
+
+func (s *clientVersionSuite) SetupTest() {
+	s.requests = nil
+	s.bodies = nil
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.bodies = append(s.bodies, string(body))
+		s.handler(w, r)
+	}))
+}
+
+func (s *clientVersionSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *clientVersionSuite) newClientV6() Client {
+	client, err := elastic6.NewClient(
+		elastic6.SetURL(s.server.URL),
+		elastic6.SetSniff(false),
+		elastic6.SetHealthcheck(false),
+		elastic6.SetDecoder(&elastic6.NumberDecoder{}),
+	)
+	s.NoError(err)
+	return NewWrapperClientV6(client)
+}
+
+func (s *clientVersionSuite) newClientV7() Client {
+	client, err := elastic.NewClient(
+		elastic.SetURL(s.server.URL),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false),
+		elastic.SetDecoder(&elastic.NumberDecoder{}),
+	)
+	s.NoError(err)
+	return NewWrapperClientV7(client)
+}
+
+func (s *clientVersionSuite) TestPutMapping() {
+	s.NoError(s.newClientV6().PutMapping(context.Background(), "test-index", "Attr", "key", "keyword"))
+	s.NoError(s.newClientV7().PutMapping(context.Background(), "test-index", "Attr", "key", "keyword"))
+
+	s.Equal([]string{"PUT /test-index/_mapping/_doc", "PUT /test-index/_mapping"}, s.requests)
+}
+
+func (s *clientVersionSuite) TestBulk() {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"errors":false,"items":[]}`))
+	}
+	request := &BulkableRequest{
+		RequestType: BulkableIndexRequest,
+		Index:       "test-index",
+		ID:          "docID",
+		Version:     123,
+		Doc:         map[string]interface{}{"WorkflowID": "wid"},
+	}
+
+	for _, client := range []Client{s.newClientV6(), s.newClientV7()} {
+		bulk := client.Bulk()
+		bulk.Add(request)
+		s.Equal(1, bulk.NumberOfActions())
+		s.NoError(bulk.Do(context.Background()))
+	}
+
+	s.Len(s.bodies, 2)
+	s.True(strings.Contains(s.bodies[0], `"_type":"_doc"`))
+	s.False(strings.Contains(s.bodies[1], `"_type"`))
+	for _, body := range s.bodies {
+		s.True(strings.Contains(body, `"version":123`))
+		s.True(strings.Contains(body, `"version_type":"external"`))
+	}
+}
+
+func (s *clientVersionSuite) TestSearch_V6ResultConverted() {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"took":1,"hits":{"total":2,"hits":[{"_index":"test-index","_type":"_doc","_id":"docID","_source":{"RunID":"rid","StartTime":1547596872371000000}}]},"aggregations":{"groupby":{"buckets":[]}}}`))
+	}
+
+	result, err := s.newClientV6().SearchWithDSL(context.Background(), "test-index", `{}`)
+	s.NoError(err)
+	s.Equal(int64(2), result.Hits.TotalHits.Value)
+	s.Len(result.Hits.Hits, 1)
+	s.Equal("docID", result.Hits.Hits[0].Id)
+	s.Equal(`{"RunID":"rid","StartTime":1547596872371000000}`, string(result.Hits.Hits[0].Source))
+	s.Contains(result.Aggregations, "groupby")
+}
+
+func (s *clientVersionSuite) TestError_V6ErrorConverted() {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`))
+	}
+
+	for _, client := range []Client{s.newClientV6(), s.newClientV7()} {
+		err := client.DeleteIndex(context.Background(), "test-index")
+		s.Error(err)
+		s.True(elastic.IsNotFound(err))
+		esErr, ok := err.(*elastic.Error)
+		s.True(ok)
+		s.Equal("index_not_found_exception", esErr.Details.Type)
+	}
+}
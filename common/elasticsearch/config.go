@@ -30,14 +30,31 @@ import (
 	"github.com/temporalio/temporal/common"
 )
 
+const (
+	// VersionV6 is the client version for ElasticSearch 6.x clusters
+	VersionV6 = "v6"
+	// VersionV7 is the client version for ElasticSearch 7.x and OpenSearch clusters (typeless mapping)
+	VersionV7 = "v7"
+)
+
 // Config for connecting to ElasticSearch
 type (
 	Config struct {
+		// Version is the version of ElasticSearch client to use, v6 if not set
+		Version string            `yaml:version` //nolint:govet
 		URL     url.URL           `yaml:url`     //nolint:govet
 		Indices map[string]string `yaml:indices` //nolint:govet
 	}
 )
 
+// GetVersion return the version of ElasticSearch client to use
+func (cfg *Config) GetVersion() string {
+	if cfg.Version == "" {
+		return VersionV6
+	}
+	return cfg.Version
+}
+
 // GetVisibilityIndex return visibility index name
 func (cfg *Config) GetVisibilityIndex() string {
 	return cfg.Indices[common.VisibilityAppName]
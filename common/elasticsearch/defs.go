@@ -25,6 +25,8 @@
 package elasticsearch
 
 import (
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 )
 
@@ -53,3 +55,23 @@ var (
 	FieldTypeBool   = enumsgenpb.FIELD_TYPE_BOOL
 	FieldTypeBinary = enumsgenpb.FIELD_TYPE_BINARY
 )
+
+// ConvertIndexedValueTypeToESDataType returns the ElasticSearch data type of a search attribute type
+func ConvertIndexedValueTypeToESDataType(valueType enumspb.IndexedValueType) string {
+	switch valueType {
+	case enumspb.INDEXED_VALUE_TYPE_STRING:
+		return "text"
+	case enumspb.INDEXED_VALUE_TYPE_KEYWORD:
+		return "keyword"
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		return "long"
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		return "double"
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		return "boolean"
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		return "date"
+	default:
+		return ""
+	}
+}
@@ -28,7 +28,7 @@ package mocks
 import (
 	context "context"
 
-	elastic "github.com/olivere/elastic"
+	elastic "github.com/olivere/elastic/v7"
 	elasticsearch "github.com/temporalio/temporal/common/elasticsearch"
 
 	mock "github.com/stretchr/testify/mock"
@@ -39,6 +39,45 @@ type Client struct {
 	mock.Mock
 }
 
+// Bulk provides a mock function with given fields:
+func (_m *Client) Bulk() elasticsearch.BulkService {
+	ret := _m.Called()
+
+	var r0 elasticsearch.BulkService
+	if rf, ok := ret.Get(0).(func() elasticsearch.BulkService); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(elasticsearch.BulkService)
+		}
+	}
+
+	return r0
+}
+
+// CatIndices provides a mock function with given fields: ctx
+func (_m *Client) CatIndices(ctx context.Context) (elastic.CatIndicesResponse, error) {
+	ret := _m.Called(ctx)
+
+	var r0 elastic.CatIndicesResponse
+	if rf, ok := ret.Get(0).(func(context.Context) elastic.CatIndicesResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(elastic.CatIndicesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Count provides a mock function with given fields: ctx, index, query
 func (_m *Client) Count(ctx context.Context, index string, query string) (int64, error) {
 	ret := _m.Called(ctx, index, query)
@@ -74,6 +113,41 @@ func (_m *Client) CreateIndex(ctx context.Context, index string) error {
 	return r0
 }
 
+// DeleteIndex provides a mock function with given fields: ctx, index
+func (_m *Client) DeleteIndex(ctx context.Context, index string) error {
+	ret := _m.Called(ctx, index)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, index)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IndexExists provides a mock function with given fields: ctx, index
+func (_m *Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	ret := _m.Called(ctx, index)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, index)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, index)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PutMapping provides a mock function with given fields: ctx, index, root, key, valueType
 func (_m *Client) PutMapping(ctx context.Context, index string, root string, key string, valueType string) error {
 	ret := _m.Called(ctx, index, root, key, valueType)
@@ -88,16 +162,30 @@ func (_m *Client) PutMapping(ctx context.Context, index string, root string, key
 	return r0
 }
 
+// PutTemplate provides a mock function with given fields: ctx, name, body
+func (_m *Client) PutTemplate(ctx context.Context, name string, body string) error {
+	ret := _m.Called(ctx, name, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, name, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RunBulkProcessor provides a mock function with given fields: ctx, p
-func (_m *Client) RunBulkProcessor(ctx context.Context, p *elasticsearch.BulkProcessorParameters) (*elastic.BulkProcessor, error) {
+func (_m *Client) RunBulkProcessor(ctx context.Context, p *elasticsearch.BulkProcessorParameters) (elasticsearch.BulkProcessor, error) {
 	ret := _m.Called(ctx, p)
 
-	var r0 *elastic.BulkProcessor
-	if rf, ok := ret.Get(0).(func(context.Context, *elasticsearch.BulkProcessorParameters) *elastic.BulkProcessor); ok {
+	var r0 elasticsearch.BulkProcessor
+	if rf, ok := ret.Get(0).(func(context.Context, *elasticsearch.BulkProcessorParameters) elasticsearch.BulkProcessor); ok {
 		r0 = rf(ctx, p)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elastic.BulkProcessor)
+			r0 = ret.Get(0).(elasticsearch.BulkProcessor)
 		}
 	}
 
@@ -0,0 +1,82 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	rolloverIndexTimeFormat = "20060102-1504"
+
+	// DefaultRolloverInterval is the rollover interval of namespaces which have no rollover interval pinned
+	DefaultRolloverInterval = 24 * time.Hour
+)
+
+// GetRolloverInterval returns the rollover interval pinned on a namespace visibility index
+func GetRolloverInterval(intervalSeconds int64) time.Duration {
+	if intervalSeconds <= 0 {
+		return DefaultRolloverInterval
+	}
+	return time.Duration(intervalSeconds) * time.Second
+}
+
+// GetRolloverIndexName returns the concrete index behind a namespace visibility alias that a record belongs to.
+// Indices are rolled over by workflow start time, so that all writes of a workflow execution land in the same index.
+func GetRolloverIndexName(alias string, startTime time.Time, interval time.Duration) string {
+	return fmt.Sprintf("%v-%v", alias, GetRolloverPeriodStart(startTime, interval).Format(rolloverIndexTimeFormat))
+}
+
+// GetRolloverPeriodStart returns the start time of the rollover period containing given time
+func GetRolloverPeriodStart(t time.Time, interval time.Duration) time.Time {
+	return t.UTC().Truncate(interval)
+}
+
+// ParseRolloverIndexName returns the start time of the rollover period of a concrete index behind the alias,
+// the second return value is false if the index is not created by rollover of the alias.
+func ParseRolloverIndexName(alias string, index string) (time.Time, bool) {
+	prefix := alias + "-"
+	if !strings.HasPrefix(index, prefix) {
+		return time.Time{}, false
+	}
+	periodStart, err := time.Parse(rolloverIndexTimeFormat, strings.TrimPrefix(index, prefix))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return periodStart, true
+}
+
+// GetRolloverTemplateName returns the name of index template which adds concrete indices to the alias
+func GetRolloverTemplateName(alias string) string {
+	return alias + "-template"
+}
+
+// BuildRolloverTemplateBody returns the index template which adds every index created by rollover to the alias.
+// Mappings are not part of this template, they are merged from the visibility index template which must match the alias.
+func BuildRolloverTemplateBody(alias string) string {
+	return fmt.Sprintf(`{"order":1,"index_patterns":["%v-*"],"aliases":{"%v":{}}}`, alias, alias)
+}
@@ -0,0 +1,63 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRolloverIndexName(t *testing.T) {
+	startTime := time.Date(2020, 6, 15, 13, 45, 10, 0, time.UTC)
+
+	require.Equal(t, "temporal-visibility-ns-20200615-0000", GetRolloverIndexName("temporal-visibility-ns", startTime, 24*time.Hour))
+	require.Equal(t, "temporal-visibility-ns-20200615-1300", GetRolloverIndexName("temporal-visibility-ns", startTime, time.Hour))
+	// same period regardless of location of start time
+	require.Equal(t, "temporal-visibility-ns-20200615-0000",
+		GetRolloverIndexName("temporal-visibility-ns", startTime.In(time.FixedZone("UTC-8", -8*3600)), 24*time.Hour))
+}
+
+func Test_ParseRolloverIndexName(t *testing.T) {
+	alias := "temporal-visibility-ns"
+	startTime := time.Date(2020, 6, 15, 13, 45, 10, 0, time.UTC)
+
+	periodStart, ok := ParseRolloverIndexName(alias, GetRolloverIndexName(alias, startTime, time.Hour))
+	require.True(t, ok)
+	require.Equal(t, time.Date(2020, 6, 15, 13, 0, 0, 0, time.UTC), periodStart)
+
+	_, ok = ParseRolloverIndexName(alias, "temporal-visibility-dev")
+	require.False(t, ok)
+	_, ok = ParseRolloverIndexName(alias, alias+"-other-20200615-1300")
+	require.False(t, ok)
+}
+
+func Test_BuildRolloverTemplateBody(t *testing.T) {
+	require.Equal(t, "temporal-visibility-ns-template", GetRolloverTemplateName("temporal-visibility-ns"))
+	require.JSONEq(t,
+		`{"order":1,"index_patterns":["temporal-visibility-ns-*"],"aliases":{"temporal-visibility-ns":{}}}`,
+		BuildRolloverTemplateBody("temporal-visibility-ns"))
+}
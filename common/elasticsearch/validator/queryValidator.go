@@ -30,6 +30,7 @@ import (
 	"strings"
 
 	"github.com/xwb1989/sqlparser"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
@@ -54,7 +55,7 @@ func NewQueryValidator(validSearchAttributes dynamicconfig.MapPropertyFn) *Visib
 // and add prefix for custom keys
 func (qv *VisibilityQueryValidator) ValidateListRequestForQuery(listRequest *workflowservice.ListWorkflowExecutionsRequest) error {
 	whereClause := listRequest.GetQuery()
-	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause)
+	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause, false)
 	if err != nil {
 		return err
 	}
@@ -64,7 +65,7 @@ func (qv *VisibilityQueryValidator) ValidateListRequestForQuery(listRequest *wor
 
 func (qv *VisibilityQueryValidator) ValidateScanRequestForQuery(listRequest *workflowservice.ScanWorkflowExecutionsRequest) error {
 	whereClause := listRequest.GetQuery()
-	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause)
+	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause, false)
 	if err != nil {
 		return err
 	}
@@ -73,10 +74,10 @@ func (qv *VisibilityQueryValidator) ValidateScanRequestForQuery(listRequest *wor
 }
 
 // ValidateCountRequestForQuery validate that search attributes in countRequest query is legal,
-// and add prefix for custom keys
+// and add prefix for custom keys. Count query may group results by keyword attributes.
 func (qv *VisibilityQueryValidator) ValidateCountRequestForQuery(countRequest *workflowservice.CountWorkflowExecutionsRequest) error {
 	whereClause := countRequest.GetQuery()
-	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause)
+	newQuery, err := qv.validateListOrCountRequestForQuery(whereClause, true)
 	if err != nil {
 		return err
 	}
@@ -86,14 +87,14 @@ func (qv *VisibilityQueryValidator) ValidateCountRequestForQuery(countRequest *w
 
 // validateListOrCountRequestForQuery valid sql for visibility API
 // it also adds attr prefix for customized fields
-func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClause string) (string, error) {
+func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClause string, allowGroupBy bool) (string, error) {
 	if len(whereClause) != 0 {
 		// Build a placeholder query that allows us to easily parse the contents of the where clause.
 		// IMPORTANT: This query is never executed, it is just used to parse and validate whereClause
 		var placeholderQuery string
 		whereClause := strings.TrimSpace(whereClause)
 		// #nosec
-		if common.IsJustOrderByClause(whereClause) { // just order by
+		if common.IsJustOrderByClause(whereClause) || common.IsJustGroupByClause(whereClause) { // just order by or group by
 			placeholderQuery = fmt.Sprintf("SELECT * FROM dummy %s", whereClause)
 		} else {
 			placeholderQuery = fmt.Sprintf("SELECT * FROM dummy WHERE %s", whereClause)
@@ -117,6 +118,17 @@ func (qv *VisibilityQueryValidator) validateListOrCountRequestForQuery(whereClau
 			}
 			sel.Where.Expr.Format(buf)
 		}
+		// validate group by
+		if len(sel.GroupBy) != 0 {
+			if !allowGroupBy {
+				return "", serviceerror.NewInvalidArgument("GROUP BY is only supported by count query.")
+			}
+			err = qv.validateGroupByExpr(sel.GroupBy)
+			if err != nil {
+				return "", serviceerror.NewInvalidArgument(err.Error())
+			}
+			sel.GroupBy.Format(buf)
+		}
 		// validate order by
 		err = qv.validateOrderByExpr(sel.OrderBy)
 		if err != nil {
@@ -230,6 +242,47 @@ func (qv *VisibilityQueryValidator) validateOrderByExpr(orderBy sqlparser.OrderB
 	return nil
 }
 
+func (qv *VisibilityQueryValidator) validateGroupByExpr(groupBy sqlparser.GroupBy) error {
+	for i, groupByExpr := range groupBy {
+		colName, ok := groupByExpr.(*sqlparser.ColName)
+		if !ok {
+			return errors.New("invalid group by expression")
+		}
+		colNameStr := colName.Name.String()
+		if !qv.isValidSearchAttributes(colNameStr) {
+			return errors.New("invalid group by attribute")
+		}
+		// group by is limited to attributes with small set of exact values
+		if colNameStr != definition.ExecutionStatus && !isKeywordType(qv.validSearchAttributes()[colNameStr]) {
+			return fmt.Errorf("group by attribute %v is not keyword type", colNameStr)
+		}
+		if !definition.IsSystemIndexedKey(colNameStr) { // add search attribute prefix
+			groupBy[i] = &sqlparser.ColName{
+				Metadata:  colName.Metadata,
+				Name:      sqlparser.NewColIdent(definition.Attr + "." + colNameStr),
+				Qualifier: colName.Qualifier,
+			}
+		}
+	}
+	return nil
+}
+
+// isKeywordType returns true if value type from dynamic config is keyword,
+// different implementation of dynamic config client may lead to different types
+func isKeywordType(valueType interface{}) bool {
+	switch t := valueType.(type) {
+	case float64:
+		return enumspb.IndexedValueType(t) == enumspb.INDEXED_VALUE_TYPE_KEYWORD
+	case int:
+		return enumspb.IndexedValueType(t) == enumspb.INDEXED_VALUE_TYPE_KEYWORD
+	case string:
+		return t == enumspb.INDEXED_VALUE_TYPE_KEYWORD.String()
+	case enumspb.IndexedValueType:
+		return t == enumspb.INDEXED_VALUE_TYPE_KEYWORD
+	}
+	return false
+}
+
 // isValidSearchAttributes return true if key is registered
 func (qv *VisibilityQueryValidator) isValidSearchAttributes(key string) bool {
 	validAttr := qv.validSearchAttributes()
@@ -135,3 +135,48 @@ func (s *queryValidatorSuite) TestValidateListRequestForQuery() {
 	listRequest.Query = query
 	s.NotNil(qv.ValidateListRequestForQuery(listRequest))
 }
+
+func (s *queryValidatorSuite) TestValidateCountRequestForQuery() {
+	validSearchAttr := dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys())
+	qv := NewQueryValidator(validSearchAttr)
+
+	countRequest := &workflowservice.CountWorkflowExecutionsRequest{}
+	s.Nil(qv.ValidateCountRequestForQuery(countRequest))
+	s.Equal("", countRequest.GetQuery())
+
+	query := "WorkflowId = 'wid'"
+	countRequest.Query = query
+	s.Nil(qv.ValidateCountRequestForQuery(countRequest))
+	s.Equal(query, countRequest.GetQuery())
+
+	// only group by
+	query = "group by ExecutionStatus"
+	countRequest.Query = query
+	s.Nil(qv.ValidateCountRequestForQuery(countRequest))
+	s.Equal(" group by ExecutionStatus", countRequest.GetQuery())
+
+	// condition + group by search attribute
+	query = "WorkflowType = 'wt' group by ExecutionStatus, CustomKeywordField"
+	countRequest.Query = query
+	s.Nil(qv.ValidateCountRequestForQuery(countRequest))
+	s.Equal("WorkflowType = 'wt' group by ExecutionStatus, `Attr.CustomKeywordField`", countRequest.GetQuery())
+
+	// invalid group by attribute
+	query = "group by InvalidField"
+	countRequest.Query = query
+	s.Equal("invalid group by attribute", qv.ValidateCountRequestForQuery(countRequest).Error())
+
+	// invalid group by attribute type
+	query = "group by CustomIntField"
+	countRequest.Query = query
+	s.Equal("group by attribute CustomIntField is not keyword type", qv.ValidateCountRequestForQuery(countRequest).Error())
+
+	// invalid group by expression
+	query = "group by 123"
+	countRequest.Query = query
+	s.Equal("invalid group by expression", qv.ValidateCountRequestForQuery(countRequest).Error())
+
+	// group by is not allowed in list query
+	listRequest := &workflowservice.ListWorkflowExecutionsRequest{Query: "group by ExecutionStatus"}
+	s.Equal("GROUP BY is only supported by count query.", qv.ValidateListRequestForQuery(listRequest).Error())
+}
@@ -41,6 +41,18 @@ const (
 
 	// ClientImplHeaderName refers to the name of the gRPC metadata header that contains the client implementation.
 	ClientImplHeaderName = "temporal-client-name"
+
+	// EagerActivityTaskSlotsHeaderName refers to the name of the gRPC metadata header that contains the number of activity tasks
+	// the worker is ready to receive in the RespondDecisionTaskCompleted response.
+	EagerActivityTaskSlotsHeaderName = "temporal-eager-activity-task-slots"
+
+	// EagerActivityTasksHeaderName refers to the name of the gRPC response metadata header that contains the activity tasks
+	// dispatched to the worker in the RespondDecisionTaskCompleted response, each value is a serialized PollForActivityTaskResponse.
+	EagerActivityTasksHeaderName = "temporal-eager-activity-tasks-bin"
+
+	// PollerLabelsHeaderName refers to the name of the gRPC metadata header that contains the labels advertised by an
+	// activity poller, as comma separated key=value pairs.
+	PollerLabelsHeaderName = "temporal-poller-labels"
 )
 
 var (
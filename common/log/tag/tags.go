@@ -605,6 +605,11 @@ func ESDocID(id string) Tag {
 	return newStringTag("es-doc-id", id)
 }
 
+// ESIndex returns tag for ESIndex
+func ESIndex(index string) Tag {
+	return newStringTag("es-index", index)
+}
+
 // LoggingCallAtKey is reserved tag
 const LoggingCallAtKey = "logging-call-at"
 
@@ -842,3 +847,13 @@ func QueryLevel(s time.Time) Tag {
 func TaskListInfo(s interface{}) Tag {
 	return newObjectTag("task-list-info", s)
 }
+
+// TaskListReadPartitions returns tag for the number of read partitions of a task list
+func TaskListReadPartitions(n int32) Tag {
+	return newInt32("task-list-read-partitions", n)
+}
+
+// TaskListWritePartitions returns tag for the number of write partitions of a task list
+func TaskListWritePartitions(n int32) Tag {
+	return newInt32("task-list-write-partitions", n)
+}
@@ -40,6 +40,7 @@ var (
 	WorkflowActionWorkflowSignaled               = workflowAction("add-workflow-signaled-event")
 	WorkflowActionWorkflowRecordMarker           = workflowAction("add-workflow-marker-record-event")
 	WorkflowActionUpsertWorkflowSearchAttributes = workflowAction("add-workflow-upsert-search-attributes-event")
+	WorkflowActionUpsertWorkflowMemo             = workflowAction("add-workflow-upsert-memo-event")
 
 	// decision
 	WorkflowActionDecisionTaskScheduled = workflowAction("add-decisiontask-scheduled-event")
@@ -124,6 +125,7 @@ var (
 	ComponentArchiver                 = component("archiver")
 	ComponentBatcher                  = component("batcher")
 	ComponentWorker                   = component("worker")
+	ComponentNamespaceHandover        = component("namespace-handover")
 	ComponentServiceResolver          = component("service-resolver")
 	ComponentMetadataInitializer      = component("metadata-initializer")
 )
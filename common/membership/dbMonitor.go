@@ -0,0 +1,317 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pborman/uuid"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	// evictedRecordExpiry is the expiry of the membership record written by EvictSelf,
+	// after which the record is no longer returned to the other members
+	evictedRecordExpiry = time.Second
+	membersPageSize     = 1000
+)
+
+type databaseMonitor struct {
+	status int32
+
+	serviceName               string
+	services                  map[string]int
+	resolvers                 map[string]*databaseServiceResolver
+	logger                    log.Logger
+	metadataManager           persistence.ClusterMetadataManager
+	broadcastHostPortResolver func() (string, error)
+	hostID                    uuid.UUID
+	heartbeatInterval         time.Duration
+	heartbeatTTL              time.Duration
+	shutdownCh                chan struct{}
+	shutdownWG                sync.WaitGroup
+
+	heartbeatLock    sync.Mutex
+	heartbeatRequest *persistence.UpsertClusterMembershipRequest
+	evicted          bool
+}
+
+var _ Monitor = (*databaseMonitor)(nil)
+
+// NewDatabaseMonitor returns a membership monitor based on the heartbeats every member
+// writes to the cluster_membership table. Keys are assigned to the members of a service
+// by consistent hashing over the members whose last heartbeat is within heartbeatTTL.
+// The broadcastHostPortResolver must return the host:port other members use to reach
+// this member's gRPC listener.
+func NewDatabaseMonitor(
+	serviceName string,
+	services map[string]int,
+	metadataManager persistence.ClusterMetadataManager,
+	broadcastHostPortResolver func() (string, error),
+	heartbeatInterval time.Duration,
+	heartbeatTTL time.Duration,
+	refreshInterval time.Duration,
+	logger log.Logger,
+) Monitor {
+
+	dbm := &databaseMonitor{
+		status:                    common.DaemonStatusInitialized,
+		serviceName:               serviceName,
+		services:                  services,
+		resolvers:                 make(map[string]*databaseServiceResolver),
+		logger:                    logger,
+		metadataManager:           metadataManager,
+		broadcastHostPortResolver: broadcastHostPortResolver,
+		hostID:                    uuid.NewUUID(),
+		heartbeatInterval:         heartbeatInterval,
+		heartbeatTTL:              heartbeatTTL,
+		shutdownCh:                make(chan struct{}),
+	}
+	for service := range services {
+		dbm.resolvers[service] = newDatabaseServiceResolver(service, metadataManager, heartbeatTTL, refreshInterval, logger)
+	}
+	return dbm
+}
+
+func (dbm *databaseMonitor) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&dbm.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	broadcastHostPort, err := dbm.broadcastHostPortResolver()
+	if err != nil {
+		dbm.logger.Fatal("unable to resolve broadcast address", tag.Error(err))
+	}
+	broadcastAddress, broadcastPort, err := SplitHostPortTyped(broadcastHostPort)
+	if err != nil {
+		dbm.logger.Fatal("unable to parse broadcast address", tag.Error(err))
+	}
+	role, err := ServiceNameToServiceTypeEnum(dbm.serviceName)
+	if err != nil {
+		dbm.logger.Fatal("unable to initialize membership heartbeats", tag.Error(err))
+	}
+
+	// Start by cleaning up expired records to avoid growth
+	if err := dbm.metadataManager.PruneClusterMembership(&persistence.PruneClusterMembershipRequest{MaxRecordsPruned: 10}); err != nil {
+		dbm.logger.Warn("Membership prune failed.", tag.Error(err))
+	}
+
+	dbm.heartbeatRequest = &persistence.UpsertClusterMembershipRequest{
+		Role:         role,
+		RPCAddress:   broadcastAddress,
+		RPCPort:      broadcastPort,
+		SessionStart: time.Now().UTC(),
+		RecordExpiry: upsertMembershipRecordExpiryDefault,
+		HostID:       dbm.hostID,
+	}
+	// Upsert before refreshing the resolvers so this member owns its keys from the start
+	if err := dbm.heartbeat(); err != nil {
+		dbm.logger.Fatal("unable to initialize membership heartbeats", tag.Error(err))
+	}
+	dbm.logger.Info("Membership heartbeat upserted successfully",
+		tag.Address(broadcastAddress.String()),
+		tag.Port(int(broadcastPort)),
+		tag.HostID(dbm.hostID.String()))
+
+	dbm.shutdownWG.Add(1)
+	go dbm.heartbeatLoop()
+
+	for _, resolver := range dbm.resolvers {
+		resolver.Start()
+	}
+}
+
+func (dbm *databaseMonitor) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&dbm.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	close(dbm.shutdownCh)
+	if success := common.AwaitWaitGroup(&dbm.shutdownWG, time.Minute); !success {
+		dbm.logger.Warn("membership heartbeat timed out on shutdown.")
+	}
+
+	for _, resolver := range dbm.resolvers {
+		resolver.Stop()
+	}
+}
+
+func (dbm *databaseMonitor) heartbeat() error {
+	dbm.heartbeatLock.Lock()
+	defer dbm.heartbeatLock.Unlock()
+
+	if dbm.evicted {
+		return nil
+	}
+	return dbm.metadataManager.UpsertClusterMembership(dbm.heartbeatRequest)
+}
+
+func (dbm *databaseMonitor) heartbeatLoop() {
+	defer dbm.shutdownWG.Done()
+
+	heartbeatTicker := time.NewTicker(dbm.heartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	for {
+		select {
+		case <-dbm.shutdownCh:
+			return
+		case <-heartbeatTicker.C:
+			if err := dbm.heartbeat(); err != nil {
+				dbm.logger.Error("Membership upsert failed.", tag.Error(err))
+			}
+		}
+	}
+}
+
+// WhoAmI returns the address (host:port) and labels for a service
+// Database implementation of WhoAmI returns the broadcast address of the service gRPC listener,
+// which is the address other members find in the cluster_membership table.
+func (dbm *databaseMonitor) WhoAmI() (*HostInfo, error) {
+	address, err := dbm.broadcastHostPortResolver()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := dbm.services[dbm.serviceName]; !ok {
+		return nil, ErrUnknownService
+	}
+	return NewHostInfo(address, map[string]string{RoleKey: dbm.serviceName}), nil
+}
+
+// EvictSelf stops the heartbeats of this member and shortens the expiry of its membership record,
+// so the other members drop it from their rings on their next refresh.
+func (dbm *databaseMonitor) EvictSelf() error {
+	dbm.heartbeatLock.Lock()
+	defer dbm.heartbeatLock.Unlock()
+
+	if dbm.heartbeatRequest == nil || dbm.evicted {
+		return nil
+	}
+	request := *dbm.heartbeatRequest
+	request.RecordExpiry = evictedRecordExpiry
+	if err := dbm.metadataManager.UpsertClusterMembership(&request); err != nil {
+		return err
+	}
+	dbm.evicted = true
+	return nil
+}
+
+func (dbm *databaseMonitor) GetResolver(service string) (ServiceResolver, error) {
+	resolver, found := dbm.resolvers[service]
+	if !found {
+		return nil, ErrUnknownService
+	}
+	return resolver, nil
+}
+
+func (dbm *databaseMonitor) Lookup(service string, key string) (*HostInfo, error) {
+	resolver, err := dbm.GetResolver(service)
+	if err != nil {
+		return nil, err
+	}
+	return resolver.Lookup(key)
+}
+
+func (dbm *databaseMonitor) AddListener(service string, name string, notifyChannel chan<- *ChangedEvent) error {
+	resolver, err := dbm.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return resolver.AddListener(name, notifyChannel)
+}
+
+func (dbm *databaseMonitor) RemoveListener(service string, name string) error {
+	resolver, err := dbm.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return resolver.RemoveListener(name)
+}
+
+func (dbm *databaseMonitor) GetReachableMembers() ([]string, error) {
+	return fetchClusterMembers(dbm.metadataManager, persistence.All, dbm.heartbeatTTL)
+}
+
+func (dbm *databaseMonitor) GetMemberCount(service string) (int, error) {
+	resolver, err := dbm.GetResolver(service)
+	if err != nil {
+		return 0, err
+	}
+	return resolver.MemberCount(), nil
+}
+
+// fetchClusterMembers returns the deduplicated host:port of the members with the given role
+// whose last heartbeat is within heartbeatWithin, persistence.All returns the members of every role
+func fetchClusterMembers(
+	manager persistence.ClusterMetadataManager,
+	role persistence.ServiceType,
+	heartbeatWithin time.Duration,
+) ([]string, error) {
+
+	set := make(map[string]struct{})
+	var hostPorts []string
+	var nextPageToken []byte
+	for {
+		resp, err := manager.GetClusterMembers(&persistence.GetClusterMembersRequest{
+			LastHeartbeatWithin: heartbeatWithin,
+			RoleEquals:          role,
+			PageSize:            membersPageSize,
+			NextPageToken:       nextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, host := range resp.ActiveMembers {
+			hostPort := net.JoinHostPort(host.RPCAddress.String(), strconv.Itoa(int(host.RPCPort)))
+			if _, ok := set[hostPort]; ok {
+				continue
+			}
+			set[hostPort] = struct{}{}
+			hostPorts = append(hostPorts, hostPort)
+		}
+
+		if len(resp.NextPageToken) == 0 {
+			return hostPorts, nil
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
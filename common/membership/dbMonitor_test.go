@@ -0,0 +1,197 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/primitives"
+)
+
+type DatabaseMonitorSuite struct {
+	*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+	suite.Suite
+
+	controller      *gomock.Controller
+	metadataManager *mocks.MockClusterMetadataManager
+	members         map[persistence.ServiceType][]*persistence.ClusterMember
+}
+
+func TestDatabaseMonitorSuite(t *testing.T) {
+	suite.Run(t, new(DatabaseMonitorSuite))
+}
+
+func (s *DatabaseMonitorSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+
+	s.controller = gomock.NewController(s.T())
+	s.metadataManager = mocks.NewMockClusterMetadataManager(s.controller)
+	s.members = map[persistence.ServiceType][]*persistence.ClusterMember{
+		persistence.History: {
+			s.member("127.0.0.1", 7234, persistence.History),
+			s.member("127.0.0.2", 7234, persistence.History),
+		},
+		persistence.Matching: {
+			s.member("127.0.0.1", 7235, persistence.Matching),
+		},
+	}
+	s.metadataManager.EXPECT().GetClusterMembers(gomock.Any()).DoAndReturn(
+		func(request *persistence.GetClusterMembersRequest) (*persistence.GetClusterMembersResponse, error) {
+			var members []*persistence.ClusterMember
+			for role, roleMembers := range s.members {
+				if request.RoleEquals == persistence.All || request.RoleEquals == role {
+					members = append(members, roleMembers...)
+				}
+			}
+			return &persistence.GetClusterMembersResponse{ActiveMembers: members}, nil
+		}).AnyTimes()
+}
+
+func (s *DatabaseMonitorSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *DatabaseMonitorSuite) TestDatabaseMonitor() {
+	s.metadataManager.EXPECT().PruneClusterMembership(gomock.Any()).Return(nil).Times(1)
+	s.metadataManager.EXPECT().UpsertClusterMembership(gomock.Any()).DoAndReturn(
+		func(request *persistence.UpsertClusterMembershipRequest) error {
+			s.Equal(persistence.History, request.Role)
+			s.Equal("127.0.0.1", request.RPCAddress.String())
+			s.Equal(uint16(7234), request.RPCPort)
+			s.Equal(upsertMembershipRecordExpiryDefault, request.RecordExpiry)
+			return nil
+		}).MinTimes(1)
+
+	monitor := s.newMonitor()
+	monitor.Start()
+	defer monitor.Stop()
+
+	host, err := monitor.WhoAmI()
+	s.NoError(err)
+	s.Equal("127.0.0.1:7234", host.GetAddress())
+
+	count, err := monitor.GetMemberCount(primitives.HistoryService)
+	s.NoError(err)
+	s.Equal(2, count)
+	count, err = monitor.GetMemberCount(primitives.MatchingService)
+	s.NoError(err)
+	s.Equal(1, count)
+
+	host, err = monitor.Lookup(primitives.HistoryService, "key")
+	s.NoError(err)
+	s.Contains([]string{"127.0.0.1:7234", "127.0.0.2:7234"}, host.GetAddress())
+	host, err = monitor.Lookup(primitives.MatchingService, "key")
+	s.NoError(err)
+	s.Equal("127.0.0.1:7235", host.GetAddress())
+
+	_, err = monitor.Lookup(primitives.FrontendService, "key")
+	s.Equal(ErrUnknownService, err)
+
+	members, err := monitor.GetReachableMembers()
+	s.NoError(err)
+	s.Len(members, 3)
+}
+
+func (s *DatabaseMonitorSuite) TestEvictSelf() {
+	s.metadataManager.EXPECT().PruneClusterMembership(gomock.Any()).Return(nil).Times(1)
+	s.metadataManager.EXPECT().UpsertClusterMembership(gomock.Any()).Return(nil).Times(1)
+	s.metadataManager.EXPECT().UpsertClusterMembership(gomock.Any()).DoAndReturn(
+		func(request *persistence.UpsertClusterMembershipRequest) error {
+			s.Equal(evictedRecordExpiry, request.RecordExpiry)
+			return nil
+		}).Times(1)
+
+	monitor := s.newMonitor()
+	monitor.Start()
+	defer monitor.Stop()
+
+	s.NoError(monitor.EvictSelf())
+	// heartbeats are no longer written after eviction
+	s.NoError(monitor.(*databaseMonitor).heartbeat())
+}
+
+func (s *DatabaseMonitorSuite) TestResolverRefresh() {
+	resolver := newDatabaseServiceResolver(primitives.HistoryService, s.metadataManager, time.Minute, time.Minute, loggerimpl.NewNopLogger())
+	s.NoError(resolver.refresh())
+	s.Equal(2, resolver.MemberCount())
+
+	listenCh := make(chan *ChangedEvent, 1)
+	s.NoError(resolver.AddListener("test-listener", listenCh))
+	s.Equal(ErrListenerAlreadyExist, resolver.AddListener("test-listener", listenCh))
+
+	s.members[persistence.History] = []*persistence.ClusterMember{
+		s.member("127.0.0.1", 7234, persistence.History),
+		s.member("127.0.0.3", 7234, persistence.History),
+	}
+	s.NoError(resolver.refresh())
+
+	select {
+	case e := <-listenCh:
+		s.Len(e.HostsAdded, 1)
+		s.Equal("127.0.0.3:7234", e.HostsAdded[0].GetAddress())
+		s.Len(e.HostsRemoved, 1)
+		s.Equal("127.0.0.2:7234", e.HostsRemoved[0].GetAddress())
+	default:
+		s.Fail("resolver did not notify the listener of the membership change")
+	}
+
+	// no notification when the members did not change
+	s.NoError(resolver.refresh())
+	s.Len(listenCh, 0)
+}
+
+func (s *DatabaseMonitorSuite) newMonitor() Monitor {
+	return NewDatabaseMonitor(
+		primitives.HistoryService,
+		map[string]int{
+			primitives.HistoryService:  7234,
+			primitives.MatchingService: 7235,
+		},
+		s.metadataManager,
+		func() (string, error) { return "127.0.0.1:7234", nil },
+		time.Hour,
+		time.Minute,
+		time.Hour,
+		loggerimpl.NewNopLogger(),
+	)
+}
+
+func (s *DatabaseMonitorSuite) member(address string, port uint16, role persistence.ServiceType) *persistence.ClusterMember {
+	return &persistence.ClusterMember{
+		Role:          role,
+		RPCAddress:    net.ParseIP(address),
+		RPCPort:       port,
+		LastHeartbeat: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,302 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/ringpop-go/hashring"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type databaseServiceResolver struct {
+	status          int32
+	service         string
+	metadataManager persistence.ClusterMetadataManager
+	heartbeatTTL    time.Duration
+	refreshInterval time.Duration
+	refreshChan     chan struct{}
+	shutdownCh      chan struct{}
+	shutdownWG      sync.WaitGroup
+	logger          log.Logger
+
+	ringValue atomic.Value // this stores the current hashring
+
+	refreshLock     sync.Mutex
+	lastRefreshTime time.Time
+	membersMap      map[string]struct{} // for computing change notifications
+
+	listenerLock sync.RWMutex
+	listeners    map[string]chan<- *ChangedEvent
+}
+
+var _ ServiceResolver = (*databaseServiceResolver)(nil)
+
+func newDatabaseServiceResolver(
+	service string,
+	metadataManager persistence.ClusterMetadataManager,
+	heartbeatTTL time.Duration,
+	refreshInterval time.Duration,
+	logger log.Logger,
+) *databaseServiceResolver {
+
+	resolver := &databaseServiceResolver{
+		status:          common.DaemonStatusInitialized,
+		service:         service,
+		metadataManager: metadataManager,
+		heartbeatTTL:    heartbeatTTL,
+		refreshInterval: refreshInterval,
+		refreshChan:     make(chan struct{}),
+		shutdownCh:      make(chan struct{}),
+		logger:          logger.WithTags(tag.ComponentServiceResolver, tag.Service(service)),
+		membersMap:      make(map[string]struct{}),
+		listeners:       make(map[string]chan<- *ChangedEvent),
+	}
+	resolver.ringValue.Store(newHashRing())
+	return resolver
+}
+
+// Start starts the resolver
+func (r *databaseServiceResolver) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&r.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+
+	if err := r.refresh(); err != nil {
+		r.logger.Fatal("unable to start database service resolver", tag.Error(err))
+	}
+
+	r.shutdownWG.Add(1)
+	go r.refreshRingWorker()
+}
+
+// Stop stops the resolver
+func (r *databaseServiceResolver) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&r.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+
+	// wait for the refresh worker before taking the listener lock, which the worker takes to emit events
+	close(r.shutdownCh)
+	if success := common.AwaitWaitGroup(&r.shutdownWG, time.Minute); !success {
+		r.logger.Warn("service resolver timed out on shutdown.")
+	}
+
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	r.ringValue.Store(newHashRing())
+	r.listeners = make(map[string]chan<- *ChangedEvent)
+}
+
+// Lookup finds the host in the ring responsible for serving the given key
+func (r *databaseServiceResolver) Lookup(
+	key string,
+) (*HostInfo, error) {
+
+	addr, found := r.ring().Lookup(key)
+	if !found {
+		select {
+		case r.refreshChan <- struct{}{}:
+		default:
+		}
+		return nil, ErrInsufficientHosts
+	}
+	return NewHostInfo(addr, r.getLabelsMap()), nil
+}
+
+func (r *databaseServiceResolver) AddListener(
+	name string,
+	notifyChannel chan<- *ChangedEvent,
+) error {
+
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if ok {
+		return ErrListenerAlreadyExist
+	}
+	r.listeners[name] = notifyChannel
+	return nil
+}
+
+func (r *databaseServiceResolver) RemoveListener(
+	name string,
+) error {
+
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if !ok {
+		return nil
+	}
+	delete(r.listeners, name)
+	return nil
+}
+
+func (r *databaseServiceResolver) MemberCount() int {
+	return r.ring().ServerCount()
+}
+
+func (r *databaseServiceResolver) Members() []*HostInfo {
+	var servers []*HostInfo
+	for _, s := range r.ring().Servers() {
+		servers = append(servers, NewHostInfo(s, r.getLabelsMap()))
+	}
+
+	return servers
+}
+
+func (r *databaseServiceResolver) refresh() error {
+	r.refreshLock.Lock()
+	defer r.refreshLock.Unlock()
+	return r.refreshNoLock()
+}
+
+func (r *databaseServiceResolver) refreshWithBackoff() error {
+	r.refreshLock.Lock()
+	defer r.refreshLock.Unlock()
+	if r.lastRefreshTime.After(time.Now().Add(-minRefreshInternal)) {
+		// refresh too frequently
+		return nil
+	}
+	return r.refreshNoLock()
+}
+
+func (r *databaseServiceResolver) refreshNoLock() error {
+	role, err := ServiceNameToServiceTypeEnum(r.service)
+	if err != nil {
+		return err
+	}
+	addrs, err := fetchClusterMembers(r.metadataManager, role, r.heartbeatTTL)
+	if err != nil {
+		return err
+	}
+	r.lastRefreshTime = time.Now()
+
+	event, newMembersMap := r.diffMembers(addrs)
+	if event == nil {
+		return nil
+	}
+
+	ring := newHashRing()
+	for _, addr := range addrs {
+		host := NewHostInfo(addr, r.getLabelsMap())
+		ring.AddMembers(host)
+	}
+
+	r.membersMap = newMembersMap
+	r.ringValue.Store(ring)
+	r.logger.Info("Current reachable members", tag.Addresses(addrs))
+	r.emitEvent(event)
+	return nil
+}
+
+// diffMembers compares the given addresses with the current members and
+// returns the change event, which is nil if the members did not change
+func (r *databaseServiceResolver) diffMembers(addrs []string) (*ChangedEvent, map[string]struct{}) {
+	var event *ChangedEvent
+	newMembersMap := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		newMembersMap[addr] = struct{}{}
+		if _, ok := r.membersMap[addr]; !ok {
+			if event == nil {
+				event = &ChangedEvent{}
+			}
+			event.HostsAdded = append(event.HostsAdded, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	for addr := range r.membersMap {
+		if _, ok := newMembersMap[addr]; !ok {
+			if event == nil {
+				event = &ChangedEvent{}
+			}
+			event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	return event, newMembersMap
+}
+
+func (r *databaseServiceResolver) emitEvent(
+	event *ChangedEvent,
+) {
+
+	// Notify listeners
+	r.listenerLock.RLock()
+	defer r.listenerLock.RUnlock()
+
+	for name, ch := range r.listeners {
+		select {
+		case ch <- event:
+		default:
+			r.logger.Error("Failed to send listener notification, channel full", tag.ListenerName(name))
+		}
+	}
+}
+
+func (r *databaseServiceResolver) refreshRingWorker() {
+	defer r.shutdownWG.Done()
+
+	refreshTicker := time.NewTicker(r.refreshInterval)
+	defer refreshTicker.Stop()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-r.refreshChan:
+			if err := r.refreshWithBackoff(); err != nil {
+				r.logger.Error("error refreshing ring", tag.Error(err))
+			}
+		case <-refreshTicker.C:
+			if err := r.refresh(); err != nil {
+				r.logger.Error("error periodically refreshing ring", tag.Error(err))
+			}
+		}
+	}
+}
+
+func (r *databaseServiceResolver) ring() *hashring.HashRing {
+	return r.ringValue.Load().(*hashring.HashRing)
+}
+
+func (r *databaseServiceResolver) getLabelsMap() map[string]string {
+	labels := make(map[string]string)
+	labels[RoleKey] = r.service
+	return labels
+}
@@ -178,6 +178,12 @@ const (
 	PersistenceDeleteReplicationTaskFromDLQScope
 	// PersistenceRangeDeleteReplicationTaskFromDLQScope tracks PersistenceRangeDeleteReplicationTaskFromDLQScope calls made by service to persistence layer
 	PersistenceRangeDeleteReplicationTaskFromDLQScope
+	// PersistencePutHistoryTaskToDLQScope tracks PutHistoryTaskToDLQ calls made by service to persistence layer
+	PersistencePutHistoryTaskToDLQScope
+	// PersistenceGetHistoryTasksFromDLQScope tracks GetHistoryTasksFromDLQ calls made by service to persistence layer
+	PersistenceGetHistoryTasksFromDLQScope
+	// PersistenceRangeDeleteHistoryTaskFromDLQScope tracks RangeDeleteHistoryTaskFromDLQ calls made by service to persistence layer
+	PersistenceRangeDeleteHistoryTaskFromDLQScope
 	// PersistenceGetTimerTaskScope tracks GetTimerTask calls made by service to persistence layer
 	PersistenceGetTimerTaskScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
@@ -342,6 +348,8 @@ const (
 	HistoryClientGetReplicationTasksScope
 	// HistoryClientGetDLQReplicationTasksScope tracks RPC calls to history service
 	HistoryClientGetDLQReplicationTasksScope
+	// HistoryClientGetReplicationStatusScope tracks RPC calls to history service
+	HistoryClientGetReplicationStatusScope
 	// HistoryClientQueryWorkflowScope tracks RPC calls to history service
 	HistoryClientQueryWorkflowScope
 	// HistoryClientReapplyEventsScope tracks RPC calls to history service
@@ -354,6 +362,16 @@ const (
 	HistoryClientMergeDLQMessagesScope
 	// HistoryClientRefreshWorkflowTasksScope tracks RPC calls to history service
 	HistoryClientRefreshWorkflowTasksScope
+	// HistoryClientReindexWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientReindexWorkflowExecutionScope
+	// HistoryClientDeleteWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientDeleteWorkflowExecutionScope
+	// HistoryClientPauseWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientPauseWorkflowExecutionScope
+	// HistoryClientUnpauseWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientUnpauseWorkflowExecutionScope
+	// HistoryClientUpsertWorkflowExecutionAttributesScope tracks RPC calls to history service
+	HistoryClientUpsertWorkflowExecutionAttributesScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -372,6 +390,10 @@ const (
 	MatchingClientDescribeTaskListScope
 	// MatchingClientListTaskListPartitionsScope tracks RPC calls to matching service
 	MatchingClientListTaskListPartitionsScope
+	// MatchingClientGetTaskListPartitionConfigScope tracks RPC calls to matching service
+	MatchingClientGetTaskListPartitionConfigScope
+	// MatchingClientUpdateTaskListPauseStateScope tracks RPC calls to matching service
+	MatchingClientUpdateTaskListPauseStateScope
 	// FrontendClientDeprecateNamespaceScope tracks RPC calls to frontend service
 	FrontendClientDeprecateNamespaceScope
 	// FrontendClientDescribeNamespaceScope tracks RPC calls to frontend service
@@ -482,6 +504,28 @@ const (
 	AdminClientMergeDLQMessagesScope
 	// AdminClientRefreshWorkflowTasksScope tracks RPC calls to admin service
 	AdminClientRefreshWorkflowTasksScope
+	// AdminClientReindexWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientReindexWorkflowExecutionScope
+	// AdminClientCountWorkflowExecutionsScope tracks RPC calls to admin service
+	AdminClientCountWorkflowExecutionsScope
+	// AdminClientDeleteWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientDeleteWorkflowExecutionScope
+	// AdminClientPauseWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientPauseWorkflowExecutionScope
+	// AdminClientUnpauseWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientUnpauseWorkflowExecutionScope
+	// AdminClientUpsertWorkflowExecutionAttributesScope tracks RPC calls to admin service
+	AdminClientUpsertWorkflowExecutionAttributesScope
+	// AdminClientStreamWorkflowExecutionHistoryScope tracks RPC calls to admin service
+	AdminClientStreamWorkflowExecutionHistoryScope
+	// AdminClientDescribeTaskListScope tracks RPC calls to admin service
+	AdminClientDescribeTaskListScope
+	// AdminClientPauseTaskListScope tracks RPC calls to admin service
+	AdminClientPauseTaskListScope
+	// AdminClientResumeTaskListScope tracks RPC calls to admin service
+	AdminClientResumeTaskListScope
+	// AdminClientGetReplicationStatusScope tracks RPC calls to admin service
+	AdminClientGetReplicationStatusScope
 	// DCRedirectionDeprecateNamespaceScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateNamespaceScope
 	// DCRedirectionDescribeNamespaceScope tracks RPC calls for dc redirection
@@ -595,6 +639,8 @@ const (
 	PersistenceGetAllHistoryTreeBranchesScope
 	// PersistenceNamespaceReplicationQueueScope is the metrics scope for namespace replication queue
 	PersistenceNamespaceReplicationQueueScope
+	// PersistenceVisibilityIndexerDLQScope is the metrics scope for visibility indexer DLQ
+	PersistenceVisibilityIndexerDLQScope
 
 	// ClusterMetadataArchivalConfigScope tracks ArchivalConfig calls to ClusterMetadata
 	ClusterMetadataArchivalConfigScope
@@ -683,6 +729,28 @@ const (
 	AdminReapplyEventsScope
 	// AdminRefreshWorkflowTasksScope is the metric scope for admin.RefreshWorkflowTasks
 	AdminRefreshWorkflowTasksScope
+	// AdminReindexWorkflowExecutionScope is the metric scope for admin.ReindexWorkflowExecution
+	AdminReindexWorkflowExecutionScope
+	// AdminCountWorkflowExecutionsScope is the metric scope for admin.CountWorkflowExecutions
+	AdminCountWorkflowExecutionsScope
+	// AdminDeleteWorkflowExecutionScope is the metric scope for admin.DeleteWorkflowExecution
+	AdminDeleteWorkflowExecutionScope
+	// AdminPauseWorkflowExecutionScope is the metric scope for admin.PauseWorkflowExecution
+	AdminPauseWorkflowExecutionScope
+	// AdminUnpauseWorkflowExecutionScope is the metric scope for admin.UnpauseWorkflowExecution
+	AdminUnpauseWorkflowExecutionScope
+	// AdminUpsertWorkflowExecutionAttributesScope is the metric scope for admin.UpsertWorkflowExecutionAttributes
+	AdminUpsertWorkflowExecutionAttributesScope
+	// AdminStreamWorkflowExecutionHistoryScope is the metric scope for admin.StreamWorkflowExecutionHistory
+	AdminStreamWorkflowExecutionHistoryScope
+	// AdminDescribeTaskListScope is the metric scope for admin.DescribeTaskList
+	AdminDescribeTaskListScope
+	// AdminPauseTaskListScope is the metric scope for admin.PauseTaskList
+	AdminPauseTaskListScope
+	// AdminResumeTaskListScope is the metric scope for admin.ResumeTaskList
+	AdminResumeTaskListScope
+	// AdminGetReplicationStatusScope is the metric scope for admin.GetReplicationStatus
+	AdminGetReplicationStatusScope
 	// AdminRemoveTaskScope is the metric scope for admin.AdminRemoveTaskScope
 	AdminRemoveTaskScope
 	//AdminCloseShardTaskScope is the metric scope for admin.AdminRemoveTaskScope
@@ -839,6 +907,8 @@ const (
 	HistoryGetReplicationMessagesScope
 	// HistoryGetDLQReplicationMessagesScope tracks GetReplicationMessages API calls received by service
 	HistoryGetDLQReplicationMessagesScope
+	// HistoryGetReplicationStatusScope tracks GetReplicationStatus API calls received by service
+	HistoryGetReplicationStatusScope
 	// HistoryReadDLQMessagesScope tracks ReadDLQMessages API calls received by service
 	HistoryReadDLQMessagesScope
 	// HistoryPurgeDLQMessagesScope tracks PurgeDLQMessages API calls received by service
@@ -851,6 +921,16 @@ const (
 	HistoryReapplyEventsScope
 	// HistoryRefreshWorkflowTasksScope is the scope used by refresh workflow tasks API
 	HistoryRefreshWorkflowTasksScope
+	// HistoryReindexWorkflowExecutionScope is the scope used by reindex workflow execution API
+	HistoryReindexWorkflowExecutionScope
+	// HistoryDeleteWorkflowExecutionScope is the scope used by delete workflow execution API
+	HistoryDeleteWorkflowExecutionScope
+	// HistoryPauseWorkflowExecutionScope is the scope used by pause workflow execution API
+	HistoryPauseWorkflowExecutionScope
+	// HistoryUnpauseWorkflowExecutionScope is the scope used by unpause workflow execution API
+	HistoryUnpauseWorkflowExecutionScope
+	// HistoryUpsertWorkflowExecutionAttributesScope is the scope used by upsert workflow execution attributes API
+	HistoryUpsertWorkflowExecutionAttributesScope
 	// TaskPriorityAssignerScope is the scope used by all metric emitted by task priority assigner
 	TaskPriorityAssignerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -877,6 +957,8 @@ const (
 	TransferActiveTaskResetWorkflowScope
 	// TransferActiveTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferActiveTaskUpsertWorkflowSearchAttributesScope
+	// TransferActiveTaskDeleteExecutionScope is the scope used for delete execution task processing by transfer queue processor
+	TransferActiveTaskDeleteExecutionScope
 	// TransferStandbyTaskResetWorkflowScope is the scope used for record workflow started task processing by transfer queue processor
 	TransferStandbyTaskResetWorkflowScope
 	// TransferStandbyTaskActivityScope is the scope used for activity task processing by transfer queue processor
@@ -895,6 +977,8 @@ const (
 	TransferStandbyTaskRecordWorkflowStartedScope
 	// TransferStandbyTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	// TransferStandbyTaskDeleteExecutionScope is the scope used for delete execution task processing by transfer queue processor
+	TransferStandbyTaskDeleteExecutionScope
 	// TimerQueueProcessorScope is the scope used by all metric emitted by timer queue processor
 	TimerQueueProcessorScope
 	// TimerActiveQueueProcessorScope is the scope used by all metric emitted by timer queue processor
@@ -1009,6 +1093,10 @@ const (
 	MatchingDescribeTaskListScope
 	// MatchingListTaskListPartitionsScope tracks ListTaskListPartitions API calls received by service
 	MatchingListTaskListPartitionsScope
+	// MatchingGetTaskListPartitionConfigScope tracks GetTaskListPartitionConfig API calls received by service
+	MatchingGetTaskListPartitionConfigScope
+	// MatchingUpdateTaskListPauseStateScope tracks UpdateTaskListPauseState API calls received by service
+	MatchingUpdateTaskListPauseStateScope
 
 	NumMatchingScopes
 )
@@ -1033,6 +1121,10 @@ const (
 	ESProcessorScope
 	// IndexProcessorScope is scope used by all metric emitted by index processor
 	IndexProcessorScope
+	// ESIndexManagerScope is scope used by all metric emitted by index manager of namespace visibility indices
+	ESIndexManagerScope
+	// NamespaceHandoverScope is scope used by all metric emitted by namespace handover manager
+	NamespaceHandoverScope
 	// ArchiverDeleteHistoryActivityScope is scope used by all metrics emitted by archiver.DeleteHistoryActivity
 	ArchiverDeleteHistoryActivityScope
 	// ArchiverUploadHistoryActivityScope is scope used by all metrics emitted by archiver.UploadHistoryActivity
@@ -1055,6 +1147,10 @@ const (
 	HistoryScavengerScope
 	// ParentClosePolicyProcessorScope is scope used by all metrics emitted by worker.ParentClosePolicyProcessor
 	ParentClosePolicyProcessorScope
+	// ArchivalScavengerScope is scope used by all metrics emitted by worker.archival.Scavenger module
+	ArchivalScavengerScope
+	// ConsistencyScannerScope is scope used by all metrics emitted by worker.consistency.Checker module
+	ConsistencyScannerScope
 
 	NumWorkerScopes
 )
@@ -1087,6 +1183,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceGetReplicationTasksFromDLQScope:               {operation: "GetReplicationTasksFromDLQ"},
 		PersistenceDeleteReplicationTaskFromDLQScope:             {operation: "DeleteReplicationTaskFromDLQ"},
 		PersistenceRangeDeleteReplicationTaskFromDLQScope:        {operation: "RangeDeleteReplicationTaskFromDLQ"},
+		PersistencePutHistoryTaskToDLQScope:                      {operation: "PutHistoryTaskToDLQ"},
+		PersistenceGetHistoryTasksFromDLQScope:                   {operation: "GetHistoryTasksFromDLQ"},
+		PersistenceRangeDeleteHistoryTaskFromDLQScope:            {operation: "RangeDeleteHistoryTaskFromDLQ"},
 		PersistenceGetTimerTaskScope:                             {operation: "GetTimerTask"},
 		PersistenceGetTimerIndexTasksScope:                       {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:                        {operation: "CompleteTimerTask"},
@@ -1144,6 +1243,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceUpdateDLQAckLevelScope:                        {operation: "UpdateDLQAckLevel"},
 		PersistenceGetDLQAckLevelScope:                           {operation: "GetDLQAckLevel"},
 		PersistenceNamespaceReplicationQueueScope:                {operation: "NamespaceReplicationQueue"},
+		PersistenceVisibilityIndexerDLQScope:                     {operation: "VisibilityIndexerDLQ"},
 		PersistenceInitImmutableClusterMetadataScope:             {operation: "InitializeImmutableClusterMetadata"},
 		PersistenceGetImmutableClusterMetadataScope:              {operation: "GetImmutableClusterMetadata"},
 		PersistencePruneClusterMembershipScope:                   {operation: "PruneClusterMembership"},
@@ -1180,12 +1280,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientSyncActivityScope:                        {operation: "HistoryClientSyncActivityScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientGetReplicationTasksScope:                 {operation: "HistoryClientGetReplicationTasksScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientGetDLQReplicationTasksScope:              {operation: "HistoryClientGetDLQReplicationTasksScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetReplicationStatusScope:                {operation: "HistoryClientGetReplicationStatusScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientQueryWorkflowScope:                       {operation: "HistoryClientQueryWorkflowScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientReapplyEventsScope:                       {operation: "HistoryClientReapplyEventsScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientReadDLQMessagesScope:                     {operation: "HistoryClientReadDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientPurgeDLQMessagesScope:                    {operation: "HistoryClientPurgeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientMergeDLQMessagesScope:                    {operation: "HistoryClientMergeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRefreshWorkflowTasksScope:                {operation: "HistoryClientRefreshWorkflowTasksScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientReindexWorkflowExecutionScope:            {operation: "HistoryClientReindexWorkflowExecution", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDeleteWorkflowExecutionScope:             {operation: "HistoryClientDeleteWorkflowExecution", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientPauseWorkflowExecutionScope:              {operation: "HistoryClientPauseWorkflowExecution", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUnpauseWorkflowExecutionScope:            {operation: "HistoryClientUnpauseWorkflowExecution", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUpsertWorkflowExecutionAttributesScope:   {operation: "HistoryClientUpsertWorkflowExecutionAttributes", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:                {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:                {operation: "MatchingClientPollForActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddActivityTaskScope:                    {operation: "MatchingClientAddActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
@@ -1195,6 +1301,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientCancelOutstandingPollScope:              {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                   {operation: "MatchingClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientListTaskListPartitionsScope:             {operation: "MatchingClientListTaskListPartitions", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetTaskListPartitionConfigScope:         {operation: "MatchingClientGetTaskListPartitionConfig", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateTaskListPauseStateScope:           {operation: "MatchingClientUpdateTaskListPauseState", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateNamespaceScope:                 {operation: "FrontendClientDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeNamespaceScope:                  {operation: "FrontendClientDescribeNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                   {operation: "FrontendClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
@@ -1246,6 +1354,17 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientGetWorkflowExecutionRawHistoryV2Scope:      {operation: "AdminClientGetWorkflowExecutionRawHistoryV2", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientDescribeClusterScope:                       {operation: "AdminClientDescribeCluster", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientRefreshWorkflowTasksScope:                  {operation: "AdminClientRefreshWorkflowTasks", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientReindexWorkflowExecutionScope:              {operation: "AdminClientReindexWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientCountWorkflowExecutionsScope:               {operation: "AdminClientCountWorkflowExecutions", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDeleteWorkflowExecutionScope:               {operation: "AdminClientDeleteWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientPauseWorkflowExecutionScope:                {operation: "AdminClientPauseWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUnpauseWorkflowExecutionScope:              {operation: "AdminClientUnpauseWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpsertWorkflowExecutionAttributesScope:     {operation: "AdminClientUpsertWorkflowExecutionAttributes", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientStreamWorkflowExecutionHistoryScope:        {operation: "AdminClientStreamWorkflowExecutionHistory", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeTaskListScope:                      {operation: "AdminClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientPauseTaskListScope:                         {operation: "AdminClientPauseTaskList", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientResumeTaskListScope:                        {operation: "AdminClientResumeTaskList", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetReplicationStatusScope:                  {operation: "AdminClientGetReplicationStatus", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientCloseShardScope:                            {operation: "AdminClientCloseShard", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientReadDLQMessagesScope:                       {operation: "AdminClientReadDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientPurgeDLQMessagesScope:                      {operation: "AdminClientPurgeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
@@ -1332,21 +1451,32 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 	// Frontend Scope Names
 	Frontend: {
 		// Admin API scope co-locates with with frontend
-		AdminRemoveTaskScope:                       {operation: "AdminRemoveTask"},
-		AdminCloseShardTaskScope:                   {operation: "AdminCloseShardTask"},
-		AdminReadDLQMessagesScope:                  {operation: "AdminReadDLQMessages"},
-		AdminPurgeDLQMessagesScope:                 {operation: "AdminPurgeDLQMessages"},
-		AdminMergeDLQMessagesScope:                 {operation: "AdminMergeDLQMessages"},
-		AdminDescribeHistoryHostScope:              {operation: "DescribeHistoryHost"},
-		AdminAddSearchAttributeScope:               {operation: "AddSearchAttribute"},
-		AdminDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
-		AdminGetWorkflowExecutionRawHistoryScope:   {operation: "GetWorkflowExecutionRawHistory"},
-		AdminGetWorkflowExecutionRawHistoryV2Scope: {operation: "GetWorkflowExecutionRawHistoryV2"},
-		AdminGetReplicationMessagesScope:           {operation: "GetReplicationMessages"},
-		AdminGetNamespaceReplicationMessagesScope:  {operation: "GetNamespaceReplicationMessages"},
-		AdminGetDLQReplicationMessagesScope:        {operation: "AdminGetDLQReplicationMessages"},
-		AdminReapplyEventsScope:                    {operation: "ReapplyEvents"},
-		AdminRefreshWorkflowTasksScope:             {operation: "RefreshWorkflowTasks"},
+		AdminRemoveTaskScope:                        {operation: "AdminRemoveTask"},
+		AdminCloseShardTaskScope:                    {operation: "AdminCloseShardTask"},
+		AdminReadDLQMessagesScope:                   {operation: "AdminReadDLQMessages"},
+		AdminPurgeDLQMessagesScope:                  {operation: "AdminPurgeDLQMessages"},
+		AdminMergeDLQMessagesScope:                  {operation: "AdminMergeDLQMessages"},
+		AdminDescribeHistoryHostScope:               {operation: "DescribeHistoryHost"},
+		AdminAddSearchAttributeScope:                {operation: "AddSearchAttribute"},
+		AdminDescribeWorkflowExecutionScope:         {operation: "DescribeWorkflowExecution"},
+		AdminGetWorkflowExecutionRawHistoryScope:    {operation: "GetWorkflowExecutionRawHistory"},
+		AdminGetWorkflowExecutionRawHistoryV2Scope:  {operation: "GetWorkflowExecutionRawHistoryV2"},
+		AdminGetReplicationMessagesScope:            {operation: "GetReplicationMessages"},
+		AdminGetNamespaceReplicationMessagesScope:   {operation: "GetNamespaceReplicationMessages"},
+		AdminGetDLQReplicationMessagesScope:         {operation: "AdminGetDLQReplicationMessages"},
+		AdminReapplyEventsScope:                     {operation: "ReapplyEvents"},
+		AdminRefreshWorkflowTasksScope:              {operation: "RefreshWorkflowTasks"},
+		AdminReindexWorkflowExecutionScope:          {operation: "ReindexWorkflowExecution"},
+		AdminCountWorkflowExecutionsScope:           {operation: "AdminCountWorkflowExecutions"},
+		AdminDeleteWorkflowExecutionScope:           {operation: "AdminDeleteWorkflowExecution"},
+		AdminPauseWorkflowExecutionScope:            {operation: "AdminPauseWorkflowExecution"},
+		AdminUnpauseWorkflowExecutionScope:          {operation: "AdminUnpauseWorkflowExecution"},
+		AdminUpsertWorkflowExecutionAttributesScope: {operation: "AdminUpsertWorkflowExecutionAttributes"},
+		AdminStreamWorkflowExecutionHistoryScope:    {operation: "AdminStreamWorkflowExecutionHistory"},
+		AdminDescribeTaskListScope:                  {operation: "AdminDescribeTaskList"},
+		AdminPauseTaskListScope:                     {operation: "AdminPauseTaskList"},
+		AdminResumeTaskListScope:                    {operation: "AdminResumeTaskList"},
+		AdminGetReplicationStatusScope:              {operation: "AdminGetReplicationStatus"},
 
 		FrontendStartWorkflowExecutionScope:             {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:                {operation: "PollForDecisionTask"},
@@ -1421,12 +1551,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryDescribeMutableStateScope:                       {operation: "DescribeMutableState"},
 		HistoryGetReplicationMessagesScope:                     {operation: "GetReplicationMessages"},
 		HistoryGetDLQReplicationMessagesScope:                  {operation: "GetDLQReplicationMessages"},
+		HistoryGetReplicationStatusScope:                       {operation: "GetReplicationStatus"},
 		HistoryReadDLQMessagesScope:                            {operation: "ReadDLQMessages"},
 		HistoryPurgeDLQMessagesScope:                           {operation: "PurgeDLQMessages"},
 		HistoryMergeDLQMessagesScope:                           {operation: "MergeDLQMessages"},
 		HistoryShardControllerScope:                            {operation: "ShardController"},
 		HistoryReapplyEventsScope:                              {operation: "EventReapplication"},
 		HistoryRefreshWorkflowTasksScope:                       {operation: "RefreshWorkflowTasks"},
+		HistoryReindexWorkflowExecutionScope:                   {operation: "ReindexWorkflowExecution"},
+		HistoryDeleteWorkflowExecutionScope:                    {operation: "DeleteWorkflowExecution"},
+		HistoryPauseWorkflowExecutionScope:                     {operation: "PauseWorkflowExecution"},
+		HistoryUnpauseWorkflowExecutionScope:                   {operation: "UnpauseWorkflowExecution"},
+		HistoryUpsertWorkflowExecutionAttributesScope:          {operation: "UpsertWorkflowExecutionAttributes"},
 		TaskPriorityAssignerScope:                              {operation: "TaskPriorityAssigner"},
 		TransferQueueProcessorScope:                            {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:                      {operation: "TransferActiveQueueProcessor"},
@@ -1440,6 +1576,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferActiveTaskRecordWorkflowStartedScope:           {operation: "TransferActiveTaskRecordWorkflowStarted"},
 		TransferActiveTaskResetWorkflowScope:                   {operation: "TransferActiveTaskResetWorkflow"},
 		TransferActiveTaskUpsertWorkflowSearchAttributesScope:  {operation: "TransferActiveTaskUpsertWorkflowSearchAttributes"},
+		TransferActiveTaskDeleteExecutionScope:                 {operation: "TransferActiveTaskDeleteExecution"},
 		TransferStandbyTaskActivityScope:                       {operation: "TransferStandbyTaskActivity"},
 		TransferStandbyTaskDecisionScope:                       {operation: "TransferStandbyTaskDecision"},
 		TransferStandbyTaskCloseExecutionScope:                 {operation: "TransferStandbyTaskCloseExecution"},
@@ -1449,6 +1586,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferStandbyTaskRecordWorkflowStartedScope:          {operation: "TransferStandbyTaskRecordWorkflowStarted"},
 		TransferStandbyTaskResetWorkflowScope:                  {operation: "TransferStandbyTaskResetWorkflow"},
 		TransferStandbyTaskUpsertWorkflowSearchAttributesScope: {operation: "TransferStandbyTaskUpsertWorkflowSearchAttributes"},
+		TransferStandbyTaskDeleteExecutionScope:                {operation: "TransferStandbyTaskDeleteExecution"},
 		TimerQueueProcessorScope:                               {operation: "TimerQueueProcessor"},
 		TimerActiveQueueProcessorScope:                         {operation: "TimerActiveQueueProcessor"},
 		TimerStandbyQueueProcessorScope:                        {operation: "TimerStandbyQueueProcessor"},
@@ -1493,16 +1631,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 	},
 	// Matching Scope Names
 	Matching: {
-		MatchingPollForDecisionTaskScope:       {operation: "PollForDecisionTask"},
-		MatchingPollForActivityTaskScope:       {operation: "PollForActivityTask"},
-		MatchingAddActivityTaskScope:           {operation: "AddActivityTask"},
-		MatchingAddDecisionTaskScope:           {operation: "AddDecisionTask"},
-		MatchingTaskListMgrScope:               {operation: "TaskListMgr"},
-		MatchingQueryWorkflowScope:             {operation: "QueryWorkflow"},
-		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
-		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
-		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
-		MatchingListTaskListPartitionsScope:    {operation: "ListTaskListPartitions"},
+		MatchingPollForDecisionTaskScope:        {operation: "PollForDecisionTask"},
+		MatchingPollForActivityTaskScope:        {operation: "PollForActivityTask"},
+		MatchingAddActivityTaskScope:            {operation: "AddActivityTask"},
+		MatchingAddDecisionTaskScope:            {operation: "AddDecisionTask"},
+		MatchingTaskListMgrScope:                {operation: "TaskListMgr"},
+		MatchingQueryWorkflowScope:              {operation: "QueryWorkflow"},
+		MatchingRespondQueryTaskCompletedScope:  {operation: "RespondQueryTaskCompleted"},
+		MatchingCancelOutstandingPollScope:      {operation: "CancelOutstandingPoll"},
+		MatchingDescribeTaskListScope:           {operation: "DescribeTaskList"},
+		MatchingListTaskListPartitionsScope:     {operation: "ListTaskListPartitions"},
+		MatchingGetTaskListPartitionConfigScope: {operation: "GetTaskListPartitionConfig"},
+		MatchingUpdateTaskListPauseStateScope:   {operation: "UpdateTaskListPauseState"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1515,6 +1655,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		SyncActivityTaskScope:                  {operation: "SyncActivityTask"},
 		ESProcessorScope:                       {operation: "ESProcessor"},
 		IndexProcessorScope:                    {operation: "IndexProcessor"},
+		ESIndexManagerScope:                    {operation: "ESIndexManager"},
+		NamespaceHandoverScope:                 {operation: "NamespaceHandover"},
 		ArchiverDeleteHistoryActivityScope:     {operation: "ArchiverDeleteHistoryActivity"},
 		ArchiverUploadHistoryActivityScope:     {operation: "ArchiverUploadHistoryActivity"},
 		ArchiverArchiveVisibilityActivityScope: {operation: "ArchiverArchiveVisibilityActivity"},
@@ -1526,6 +1668,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryScavengerScope:                  {operation: "historyscavenger"},
 		BatcherScope:                           {operation: "batcher"},
 		ParentClosePolicyProcessorScope:        {operation: "ParentClosePolicyProcessor"},
+		ArchivalScavengerScope:                 {operation: "archivalscavenger"},
+		ConsistencyScannerScope:                {operation: "consistencyscanner"},
 	},
 }
 
@@ -1633,6 +1777,8 @@ const (
 	NamespaceReplicationTaskAckLevelGauge
 	NamespaceReplicationDLQAckLevelGauge
 	NamespaceReplicationDLQMaxLevelGauge
+	VisibilityIndexerDLQAckLevelGauge
+	VisibilityIndexerDLQMaxLevelGauge
 
 	// common metrics that are emitted per task list
 	ServiceRequestsPerTaskList
@@ -1668,6 +1814,8 @@ const (
 	TaskStandbyRetryCounter
 	TaskNotActiveCounter
 	TaskLimitExceededCounter
+	TaskDLQWrites
+	TaskDLQWriteFailures
 	TaskBatchCompleteCounter
 	TaskProcessingLatency
 	TaskQueueLatency
@@ -1679,6 +1827,10 @@ const (
 	ActivityE2ELatency
 	AckLevelUpdateCounter
 	AckLevelUpdateFailedCounter
+	NamespaceSplitCounter
+	NamespaceMergeCounter
+	SplitNamespaceCountGauge
+	SplitNamespaceTaskThrottledCounter
 	DecisionTypeScheduleActivityCounter
 	DecisionTypeCompleteWorkflowCounter
 	DecisionTypeFailWorkflowCounter
@@ -1692,6 +1844,7 @@ const (
 	DecisionTypeContinueAsNewCounter
 	DecisionTypeSignalExternalWorkflowCounter
 	DecisionTypeUpsertWorkflowSearchAttributesCounter
+	DecisionTypeUpsertWorkflowMemoCounter
 	EmptyCompletionDecisionsCounter
 	MultipleCompletionDecisionsCounter
 	FailedDecisionsCounter
@@ -1736,6 +1889,7 @@ const (
 	RemoveEngineForShardLatency
 	CompleteDecisionWithStickyEnabledCounter
 	CompleteDecisionWithStickyDisabledCounter
+	EagerActivityDispatchCounter
 	DecisionHeartbeatTimeoutCounter
 	HistoryEventNotificationQueueingLatency
 	HistoryEventNotificationFanoutLatency
@@ -1799,6 +1953,8 @@ const (
 	ReplicationTasksLag
 	ReplicationTasksFetched
 	ReplicationTasksReturned
+	ReplicationMessagesWireSize
+	ReplicationMessagesSize
 	ReplicationDLQFailed
 	ReplicationDLQMaxLevelGauge
 	ReplicationDLQAckLevelGauge
@@ -1858,6 +2014,16 @@ const (
 	LocalToRemoteMatchPerTaskListCounter
 	RemoteToLocalMatchPerTaskListCounter
 	RemoteToRemoteMatchPerTaskListCounter
+	ReadPartitionsPerTaskListGauge
+	WritePartitionsPerTaskListGauge
+	PartitionScaleUpPerTaskListCounter
+	PartitionScaleDownPerTaskListCounter
+	ApproximateBacklogCountPerTaskListGauge
+	ApproximateBacklogAgePerTaskListGauge
+	AddTaskRatePerTaskListGauge
+	DispatchTaskRatePerTaskListGauge
+	LabelMatchPerTaskListCounter
+	LabelSelectorFallbackPerTaskListCounter
 
 	NumMatchingMetrics
 )
@@ -1874,8 +2040,16 @@ const (
 	ESProcessorFailures
 	ESProcessorCorruptedData
 	ESProcessorProcessMsgLatency
+	ESProcessorDLQWrites
+	ESProcessorDLQWriteFailures
 	IndexProcessorCorruptedData
 	IndexProcessorProcessMsgLatency
+	ESIndexManagerIndexCreatedCount
+	ESIndexManagerIndexDeletedCount
+	ESIndexManagerFailures
+	NamespaceHandoverCompletedCount
+	NamespaceHandoverExpiredCount
+	NamespaceHandoverFailures
 	ArchiverNonRetryableErrorCount
 	ArchiverStartedCount
 	ArchiverStoppedCount
@@ -1917,6 +2091,17 @@ const (
 	HistoryScavengerSuccessCount
 	HistoryScavengerErrorCount
 	HistoryScavengerSkipCount
+	ArchivalScavengerVerifiedCount
+	ArchivalScavengerMismatchCount
+	ArchivalScavengerMissingCount
+	ArchivalScavengerRearchivedCount
+	ArchivalScavengerErrorCount
+	ArchivalScavengerSkipCount
+	ConsistencyScannerConsistentCount
+	ConsistencyScannerDivergentCount
+	ConsistencyScannerRepairedCount
+	ConsistencyScannerErrorCount
+	ConsistencyScannerSkipCount
 	ParentClosePolicyProcessorSuccess
 	ParentClosePolicyProcessorFailures
 	NamespaceReplicationEnqueueDLQCount
@@ -2014,6 +2199,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		NamespaceReplicationTaskAckLevelGauge: {metricName: "namespace_replication_task_ack_level", metricType: Gauge},
 		NamespaceReplicationDLQAckLevelGauge:  {metricName: "namespace_dlq_ack_level", metricType: Gauge},
 		NamespaceReplicationDLQMaxLevelGauge:  {metricName: "namespace_dlq_max_level", metricType: Gauge},
+		VisibilityIndexerDLQAckLevelGauge:     {metricName: "visibility_indexer_dlq_ack_level", metricType: Gauge},
+		VisibilityIndexerDLQMaxLevelGauge:     {metricName: "visibility_indexer_dlq_max_level", metricType: Gauge},
 
 		// per task list common metrics
 
@@ -2084,6 +2271,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskStandbyRetryCounter:                           {metricName: "task_errors_standby_retry_counter", metricType: Counter},
 		TaskNotActiveCounter:                              {metricName: "task_errors_not_active_counter", metricType: Counter},
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
+		TaskDLQWrites:                                     {metricName: "task_dlq_writes", metricType: Counter},
+		TaskDLQWriteFailures:                              {metricName: "task_dlq_write_errors", metricType: Counter},
 		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer},
 		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer},
 		TaskBatchCompleteCounter:                          {metricName: "task_batch_complete_counter", metricType: Counter},
@@ -2093,6 +2282,10 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ActivityE2ELatency:                                {metricName: "activity_end_to_end_latency", metricType: Timer},
 		AckLevelUpdateCounter:                             {metricName: "ack_level_update", metricType: Counter},
 		AckLevelUpdateFailedCounter:                       {metricName: "ack_level_update_failed", metricType: Counter},
+		NamespaceSplitCounter:                             {metricName: "namespace_split", metricType: Counter},
+		NamespaceMergeCounter:                             {metricName: "namespace_merge", metricType: Counter},
+		SplitNamespaceCountGauge:                          {metricName: "split_namespace_count", metricType: Gauge},
+		SplitNamespaceTaskThrottledCounter:                {metricName: "split_namespace_task_throttled_counter", metricType: Counter},
 		DecisionTypeScheduleActivityCounter:               {metricName: "schedule_activity_decision", metricType: Counter},
 		DecisionTypeCompleteWorkflowCounter:               {metricName: "complete_workflow_decision", metricType: Counter},
 		DecisionTypeFailWorkflowCounter:                   {metricName: "fail_workflow_decision", metricType: Counter},
@@ -2105,6 +2298,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DecisionTypeContinueAsNewCounter:                  {metricName: "continue_as_new_decision", metricType: Counter},
 		DecisionTypeSignalExternalWorkflowCounter:         {metricName: "signal_external_workflow_decision", metricType: Counter},
 		DecisionTypeUpsertWorkflowSearchAttributesCounter: {metricName: "upsert_workflow_search_attributes_decision", metricType: Counter},
+		DecisionTypeUpsertWorkflowMemoCounter:             {metricName: "upsert_workflow_memo_decision", metricType: Counter},
 		DecisionTypeChildWorkflowCounter:                  {metricName: "child_workflow_decision", metricType: Counter},
 		EmptyCompletionDecisionsCounter:                   {metricName: "empty_completion_decisions", metricType: Counter},
 		MultipleCompletionDecisionsCounter:                {metricName: "multiple_completion_decisions", metricType: Counter},
@@ -2150,6 +2344,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		RemoveEngineForShardLatency:                       {metricName: "remove_engine_for_shard_latency", metricType: Timer},
 		CompleteDecisionWithStickyEnabledCounter:          {metricName: "complete_decision_sticky_enabled_count", metricType: Counter},
 		CompleteDecisionWithStickyDisabledCounter:         {metricName: "complete_decision_sticky_disabled_count", metricType: Counter},
+		EagerActivityDispatchCounter:                      {metricName: "eager_activity_dispatch_count", metricType: Counter},
 		DecisionHeartbeatTimeoutCounter:                   {metricName: "decision_heartbeat_timeout_count", metricType: Counter},
 		HistoryEventNotificationQueueingLatency:           {metricName: "history_event_notification_queueing_latency", metricType: Timer},
 		HistoryEventNotificationFanoutLatency:             {metricName: "history_event_notification_fanout_latency", metricType: Timer},
@@ -2213,6 +2408,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationTasksLag:                               {metricName: "replication_tasks_lag", metricType: Timer},
 		ReplicationTasksFetched:                           {metricName: "replication_tasks_fetched", metricType: Timer},
 		ReplicationTasksReturned:                          {metricName: "replication_tasks_returned", metricType: Timer},
+		ReplicationMessagesWireSize:                       {metricName: "replication_messages_wire_size", metricType: Timer},
+		ReplicationMessagesSize:                           {metricName: "replication_messages_size", metricType: Timer},
 		ReplicationDLQFailed:                              {metricName: "replication_dlq_enqueue_failed", metricType: Counter},
 		ReplicationDLQMaxLevelGauge:                       {metricName: "replication_dlq_max_level", metricType: Gauge},
 		ReplicationDLQAckLevelGauge:                       {metricName: "replication_dlq_ack_level", metricType: Gauge},
@@ -2268,6 +2465,16 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		LocalToRemoteMatchPerTaskListCounter:     {metricName: "local_to_remote_matches_per_tl", metricRollupName: "local_to_remote_matches"},
 		RemoteToLocalMatchPerTaskListCounter:     {metricName: "remote_to_local_matches_per_tl", metricRollupName: "remote_to_local_matches"},
 		RemoteToRemoteMatchPerTaskListCounter:    {metricName: "remote_to_remote_matches_per_tl", metricRollupName: "remote_to_remote_matches"},
+		ReadPartitionsPerTaskListGauge:           {metricName: "read_partitions_per_tl", metricType: Gauge},
+		WritePartitionsPerTaskListGauge:          {metricName: "write_partitions_per_tl", metricType: Gauge},
+		PartitionScaleUpPerTaskListCounter:       {metricName: "partition_scale_up_per_tl", metricRollupName: "partition_scale_up"},
+		PartitionScaleDownPerTaskListCounter:     {metricName: "partition_scale_down_per_tl", metricRollupName: "partition_scale_down"},
+		ApproximateBacklogCountPerTaskListGauge:  {metricName: "approximate_backlog_count_per_tl", metricType: Gauge},
+		ApproximateBacklogAgePerTaskListGauge:    {metricName: "approximate_backlog_age_per_tl", metricType: Gauge},
+		AddTaskRatePerTaskListGauge:              {metricName: "add_task_rate_per_tl", metricType: Gauge},
+		DispatchTaskRatePerTaskListGauge:         {metricName: "dispatch_task_rate_per_tl", metricType: Gauge},
+		LabelMatchPerTaskListCounter:             {metricName: "label_matches_per_tl", metricRollupName: "label_matches"},
+		LabelSelectorFallbackPerTaskListCounter:  {metricName: "label_selector_fallbacks_per_tl", metricRollupName: "label_selector_fallbacks"},
 	},
 	Worker: {
 		ReplicatorMessages:                            {metricName: "replicator_messages"},
@@ -2280,8 +2487,16 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ESProcessorFailures:                           {metricName: "es_processor_errors"},
 		ESProcessorCorruptedData:                      {metricName: "es_processor_corrupted_data"},
 		ESProcessorProcessMsgLatency:                  {metricName: "es_processor_process_msg_latency", metricType: Timer},
+		ESProcessorDLQWrites:                          {metricName: "es_processor_dlq_writes", metricType: Counter},
+		ESProcessorDLQWriteFailures:                   {metricName: "es_processor_dlq_write_errors", metricType: Counter},
 		IndexProcessorCorruptedData:                   {metricName: "index_processor_corrupted_data"},
 		IndexProcessorProcessMsgLatency:               {metricName: "index_processor_process_msg_latency", metricType: Timer},
+		ESIndexManagerIndexCreatedCount:               {metricName: "es_index_manager_index_created", metricType: Counter},
+		ESIndexManagerIndexDeletedCount:               {metricName: "es_index_manager_index_deleted", metricType: Counter},
+		ESIndexManagerFailures:                        {metricName: "es_index_manager_errors", metricType: Counter},
+		NamespaceHandoverCompletedCount:               {metricName: "namespace_handover_completed", metricType: Counter},
+		NamespaceHandoverExpiredCount:                 {metricName: "namespace_handover_expired", metricType: Counter},
+		NamespaceHandoverFailures:                     {metricName: "namespace_handover_errors", metricType: Counter},
 		ArchiverNonRetryableErrorCount:                {metricName: "archiver_non_retryable_error"},
 		ArchiverStartedCount:                          {metricName: "archiver_started"},
 		ArchiverStoppedCount:                          {metricName: "archiver_stopped"},
@@ -2323,6 +2538,17 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		HistoryScavengerSuccessCount:                  {metricName: "scavenger_success", metricType: Counter},
 		HistoryScavengerErrorCount:                    {metricName: "scavenger_errors", metricType: Counter},
 		HistoryScavengerSkipCount:                     {metricName: "scavenger_skips", metricType: Counter},
+		ArchivalScavengerVerifiedCount:                {metricName: "archival_scavenger_verified", metricType: Counter},
+		ArchivalScavengerMismatchCount:                {metricName: "archival_scavenger_mismatch", metricType: Counter},
+		ArchivalScavengerMissingCount:                 {metricName: "archival_scavenger_missing", metricType: Counter},
+		ArchivalScavengerRearchivedCount:              {metricName: "archival_scavenger_rearchived", metricType: Counter},
+		ArchivalScavengerErrorCount:                   {metricName: "archival_scavenger_errors", metricType: Counter},
+		ArchivalScavengerSkipCount:                    {metricName: "archival_scavenger_skips", metricType: Counter},
+		ConsistencyScannerConsistentCount:             {metricName: "consistency_scanner_consistent", metricType: Counter},
+		ConsistencyScannerDivergentCount:              {metricName: "consistency_scanner_divergent", metricType: Counter},
+		ConsistencyScannerRepairedCount:               {metricName: "consistency_scanner_repaired", metricType: Counter},
+		ConsistencyScannerErrorCount:                  {metricName: "consistency_scanner_errors", metricType: Counter},
+		ConsistencyScannerSkipCount:                   {metricName: "consistency_scanner_skips", metricType: Counter},
 		ParentClosePolicyProcessorSuccess:             {metricName: "parent_close_policy_processor_requests", metricType: Counter},
 		ParentClosePolicyProcessorFailures:            {metricName: "parent_close_policy_processor_errors", metricType: Counter},
 		NamespaceReplicationEnqueueDLQCount:           {metricName: "namespace_replication_dlq_enqueue_requests", metricType: Counter},
@@ -38,6 +38,7 @@ const (
 	workflowType  = "workflowType"
 	activityType  = "activityType"
 	decisionType  = "decisionType"
+	esErrorType   = "es_error_type"
 
 	namespaceAllValue = "all"
 	unknownValue      = "_unknown_"
@@ -81,6 +82,10 @@ type (
 	decisionTypeTag struct {
 		value string
 	}
+
+	esErrorTypeTag struct {
+		value string
+	}
 )
 
 // NamespaceTag returns a new namespace tag. For timers, this also ensures that we
@@ -237,3 +242,21 @@ func (d decisionTypeTag) Key() string {
 func (d decisionTypeTag) Value() string {
 	return d.value
 }
+
+// ESErrorTypeTag returns a new ElasticSearch error type tag.
+func ESErrorTypeTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return esErrorTypeTag{value}
+}
+
+// Key returns the key of the ElasticSearch error type tag
+func (d esErrorTypeTag) Key() string {
+	return esErrorType
+}
+
+// Value returns the value of the ElasticSearch error type tag
+func (d esErrorTypeTag) Value() string {
+	return d.value
+}
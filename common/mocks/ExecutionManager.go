@@ -453,6 +453,63 @@ func (_m *ExecutionManager) RangeDeleteReplicationTaskFromDLQ(
 	return r0
 }
 
+// PutHistoryTaskToDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) PutHistoryTaskToDLQ(
+	request *persistence.PutHistoryTaskToDLQRequest,
+) error {
+
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.PutHistoryTaskToDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetHistoryTasksFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) GetHistoryTasksFromDLQ(request *persistence.GetHistoryTasksFromDLQRequest) (*persistence.GetHistoryTasksFromDLQResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetHistoryTasksFromDLQResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetHistoryTasksFromDLQRequest) *persistence.GetHistoryTasksFromDLQResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetHistoryTasksFromDLQResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetHistoryTasksFromDLQRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RangeDeleteHistoryTaskFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) RangeDeleteHistoryTaskFromDLQ(
+	request *persistence.RangeDeleteHistoryTaskFromDLQRequest,
+) error {
+
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.RangeDeleteHistoryTaskFromDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetTimerTask provides a mock function with given fields: request
 func (_m *ExecutionManager) GetTimerTask(request *persistence.GetTimerTaskRequest) (*persistence.GetTimerTaskResponse, error) {
 	ret := _m.Called(request)
@@ -26,6 +26,7 @@ package namespace
 
 import (
 	"fmt"
+	"regexp"
 
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
@@ -34,6 +35,11 @@ import (
 	"github.com/temporalio/temporal/common/cluster"
 )
 
+var (
+	// visibilityIndexRegex follows the ElasticSearch index naming restrictions
+	visibilityIndexRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,199}$`)
+)
+
 type (
 	// AttrValidatorImpl is namespace attr validator
 	AttrValidatorImpl struct {
@@ -64,6 +70,9 @@ func (d *AttrValidatorImpl) validateNamespaceConfig(config *persistenceblobs.Nam
 	if config.VisibilityArchivalStatus == enumspb.ARCHIVAL_STATUS_ENABLED && len(config.VisibilityArchivalURI) == 0 {
 		return errInvalidArchivalConfig
 	}
+	if len(config.VisibilityIndex) != 0 && !visibilityIndexRegex.MatchString(config.VisibilityIndex) {
+		return errInvalidVisibilityIndex
+	}
 	return nil
 }
 
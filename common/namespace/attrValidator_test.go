@@ -91,6 +91,40 @@ func (s *attrValidatorSuite) TestValidateConfigRetentionPeriod() {
 	}
 }
 
+func (s *attrValidatorSuite) TestValidateConfigVisibilityIndex() {
+	testCases := []struct {
+		visibilityIndex string
+		expectedErr     error
+	}{
+		{
+			visibilityIndex: "",
+			expectedErr:     nil,
+		},
+		{
+			visibilityIndex: "temporal-visibility-some_namespace.v1",
+			expectedErr:     nil,
+		},
+		{
+			visibilityIndex: "Temporal-Visibility",
+			expectedErr:     errInvalidVisibilityIndex,
+		},
+		{
+			visibilityIndex: "-temporal-visibility",
+			expectedErr:     errInvalidVisibilityIndex,
+		},
+		{
+			visibilityIndex: "temporal-visibility-*",
+			expectedErr:     errInvalidVisibilityIndex,
+		},
+	}
+	for _, tc := range testCases {
+		actualErr := s.validator.validateNamespaceConfig(
+			&persistenceblobs.NamespaceConfig{RetentionDays: 1, VisibilityIndex: tc.visibilityIndex},
+		)
+		s.Equal(tc.expectedErr, actualErr)
+	}
+}
+
 func (s *attrValidatorSuite) TestClusterName() {
 	s.mockClusterMetadata.On("GetAllClusterInfo").Return(
 		cluster.TestAllClusterInfo,
@@ -30,4 +30,24 @@ const (
 
 	// MaxBadBinaries is the maximal number of bad client binaries stored in a namespace
 	MaxBadBinaries = 10
+
+	// VisibilityIndexDataKey is the reserved key of namespace data to route visibility records of the namespace
+	// to its own ElasticSearch index alias. The value is moved into namespace config and removed from namespace data,
+	// an empty value routes the namespace back to the default visibility index.
+	VisibilityIndexDataKey = "temporal.visibilityIndex"
+
+	// GracefulFailoverTimeoutDataKey is the reserved key of namespace data to request a graceful failover to the
+	// active cluster of the same update request. The value is a duration after which the failover is forced even
+	// if replication to the new active cluster is not drained yet. The key is never persisted in namespace data.
+	GracefulFailoverTimeoutDataKey = "temporal.gracefulFailoverTimeout"
+
+	// ReplicationStateDataKey is the key of namespace data in describe responses holding the replication state.
+	// The replication state keys are ignored in register and update requests.
+	ReplicationStateDataKey = "temporal.replicationState"
+	// HandoverClusterDataKey is the key of namespace data in describe responses holding the cluster a graceful
+	// failover is handing over to
+	HandoverClusterDataKey = "temporal.handoverCluster"
+	// HandoverExpirationDataKey is the key of namespace data in describe responses holding the time (RFC3339)
+	// a graceful failover is forced at
+	HandoverExpirationDataKey = "temporal.handoverExpiration"
 )
@@ -36,4 +36,12 @@ var (
 	errCannotDoNamespaceFailoverAndUpdate = serviceerror.NewInvalidArgument("Cannot set active cluster to current cluster when other parameters are set.")
 	errInvalidRetentionPeriod             = serviceerror.NewInvalidArgument("A valid retention period is not set on request.")
 	errInvalidArchivalConfig              = serviceerror.NewInvalidArgument("Invalid to enable archival without specifying a uri.")
+	errInvalidVisibilityIndex             = serviceerror.NewInvalidArgument("Invalid visibility index, only lowercase letters, digits, '-', '_' and '.' are allowed.")
+	errInvalidGracefulFailoverTimeout     = serviceerror.NewInvalidArgument("Invalid graceful failover timeout, a positive duration is required.")
+	errInvalidReplicatedWorkflowTypes     = serviceerror.NewInvalidArgument("Invalid replicated workflow types, a comma separated list of workflow types is required.")
+	errInvalidReplicatedSearchAttribute   = serviceerror.NewInvalidArgument("Invalid replicated search attribute, key=value is required.")
+	errGracefulFailoverLocalNamespace     = serviceerror.NewInvalidArgument("Graceful failover is only supported for global namespaces.")
+	errGracefulFailoverNotActive          = serviceerror.NewInvalidArgument("Graceful failover must be started from the current active cluster.")
+	errGracefulFailoverSameCluster        = serviceerror.NewInvalidArgument("Graceful failover target is already the active cluster.")
+	errGracefulFailoverNoTarget           = serviceerror.NewInvalidArgument("Graceful failover requires the target active cluster to be set.")
 )
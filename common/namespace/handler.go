@@ -29,6 +29,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -44,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -78,14 +80,15 @@ type (
 
 	// HandlerImpl is the namespace operation handler implementation
 	HandlerImpl struct {
-		maxBadBinaryCount      dynamicconfig.IntPropertyFnWithNamespaceFilter
-		logger                 log.Logger
-		metadataMgr            persistence.MetadataManager
-		clusterMetadata        cluster.Metadata
-		namespaceReplicator    Replicator
-		namespaceAttrValidator *AttrValidatorImpl
-		archivalMetadata       archiver.ArchivalMetadata
-		archiverProvider       provider.ArchiverProvider
+		maxBadBinaryCount               dynamicconfig.IntPropertyFnWithNamespaceFilter
+		visibilityIndexRolloverInterval dynamicconfig.DurationPropertyFn
+		logger                          log.Logger
+		metadataMgr                     persistence.MetadataManager
+		clusterMetadata                 cluster.Metadata
+		namespaceReplicator             Replicator
+		namespaceAttrValidator          *AttrValidatorImpl
+		archivalMetadata                archiver.ArchivalMetadata
+		archiverProvider                provider.ArchiverProvider
 	}
 )
 
@@ -95,6 +98,7 @@ var _ Handler = (*HandlerImpl)(nil)
 func NewHandler(
 	minRetentionDays int,
 	maxBadBinaryCount dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	visibilityIndexRolloverInterval dynamicconfig.DurationPropertyFn,
 	logger log.Logger,
 	metadataMgr persistence.MetadataManager,
 	clusterMetadata cluster.Metadata,
@@ -103,14 +107,15 @@ func NewHandler(
 	archiverProvider provider.ArchiverProvider,
 ) *HandlerImpl {
 	return &HandlerImpl{
-		maxBadBinaryCount:      maxBadBinaryCount,
-		logger:                 logger,
-		metadataMgr:            metadataMgr,
-		clusterMetadata:        clusterMetadata,
-		namespaceReplicator:    namespaceReplicator,
-		namespaceAttrValidator: newAttrValidator(clusterMetadata, int32(minRetentionDays)),
-		archivalMetadata:       archivalMetadata,
-		archiverProvider:       archiverProvider,
+		maxBadBinaryCount:               maxBadBinaryCount,
+		visibilityIndexRolloverInterval: visibilityIndexRolloverInterval,
+		logger:                          logger,
+		metadataMgr:                     metadataMgr,
+		clusterMetadata:                 clusterMetadata,
+		namespaceReplicator:             namespaceReplicator,
+		namespaceAttrValidator:          newAttrValidator(clusterMetadata, int32(minRetentionDays)),
+		archivalMetadata:                archivalMetadata,
+		archiverProvider:                archiverProvider,
 	}
 }
 
@@ -200,13 +205,18 @@ func (d *HandlerImpl) RegisterNamespace(
 		}
 	}
 
+	if err := d.validateReplicationFilter(registerRequest.Data); err != nil {
+		return nil, err
+	}
+
+	data, visibilityIndex, _ := d.extractVisibilityIndex(d.removeReplicationState(registerRequest.Data))
 	info := &persistenceblobs.NamespaceInfo{
 		Id:          uuid.New(),
 		Name:        registerRequest.GetName(),
 		Status:      enumspb.NAMESPACE_STATUS_REGISTERED,
 		Owner:       registerRequest.GetOwnerEmail(),
 		Description: registerRequest.GetDescription(),
-		Data:        registerRequest.Data,
+		Data:        data,
 	}
 	config := &persistenceblobs.NamespaceConfig{
 		RetentionDays:            registerRequest.GetWorkflowExecutionRetentionPeriodInDays(),
@@ -217,6 +227,7 @@ func (d *HandlerImpl) RegisterNamespace(
 		VisibilityArchivalURI:    nextVisibilityArchivalState.URI,
 		BadBinaries:              &namespacepb.BadBinaries{Binaries: map[string]*namespacepb.BadBinaryInfo{}},
 	}
+	d.setVisibilityIndex(config, visibilityIndex)
 	replicationConfig := &persistenceblobs.NamespaceReplicationConfig{
 		ActiveClusterName: activeClusterName,
 		Clusters:          clusters,
@@ -421,6 +432,9 @@ func (d *HandlerImpl) UpdateNamespace(
 	activeClusterChanged := false
 	// whether anything other than active cluster is changed
 	configurationChanged := false
+	// whether a graceful failover is requested instead of changing active cluster right away
+	gracefulFailoverRequested := false
+	var gracefulFailoverTimeout time.Duration
 
 	if updateRequest.UpdatedInfo != nil {
 		updatedInfo := updateRequest.UpdatedInfo
@@ -433,9 +447,25 @@ func (d *HandlerImpl) UpdateNamespace(
 			info.Owner = updatedInfo.GetOwnerEmail()
 		}
 		if updatedInfo.Data != nil {
-			configurationChanged = true
-			// only do merging
-			info.Data = d.mergeNamespaceData(info.Data, updatedInfo.Data)
+			data := updatedInfo.Data
+			data, gracefulFailoverTimeout, gracefulFailoverRequested, err = d.extractGracefulFailoverTimeout(data)
+			if err != nil {
+				return nil, err
+			}
+			// data read from a describe response carries the replication state, which is never persisted
+			data = d.removeReplicationState(data)
+			if err := d.validateReplicationFilter(data); err != nil {
+				return nil, err
+			}
+			if !gracefulFailoverRequested || len(data) != 0 {
+				configurationChanged = true
+				data, visibilityIndex, visibilityIndexSet := d.extractVisibilityIndex(data)
+				if visibilityIndexSet {
+					d.setVisibilityIndex(config, visibilityIndex)
+				}
+				// only do merging
+				info.Data = d.mergeNamespaceData(info.Data, data)
+			}
 		}
 	}
 	if updateRequest.Configuration != nil {
@@ -497,10 +527,27 @@ func (d *HandlerImpl) UpdateNamespace(
 			replicationConfig.Clusters = clustersNew
 		}
 
-		if updateReplicationConfig.GetActiveClusterName() != "" {
+		if updateReplicationConfig.GetActiveClusterName() != "" && !gracefulFailoverRequested {
 			activeClusterChanged = true
 			replicationConfig.ActiveClusterName = updateReplicationConfig.GetActiveClusterName()
+			// a forced failover (including the one completing a graceful failover) ends any handover
+			replicationConfig.State = enumsgenpb.NAMESPACE_REPLICATION_STATE_NORMAL
+			replicationConfig.HandoverClusterName = ""
+			replicationConfig.HandoverExpirationTimeNanos = 0
+		}
+	}
+
+	// whether a handover is started, which is only recorded in the current cluster
+	handoverStarted := false
+	if gracefulFailoverRequested {
+		handoverCluster := updateRequest.GetReplicationConfiguration().GetActiveClusterName()
+		if err := d.validateGracefulFailover(isGlobalNamespace, replicationConfig, handoverCluster); err != nil {
+			return nil, err
 		}
+		handoverStarted = true
+		replicationConfig.State = enumsgenpb.NAMESPACE_REPLICATION_STATE_HANDOVER
+		replicationConfig.HandoverClusterName = handoverCluster
+		replicationConfig.HandoverExpirationTimeNanos = time.Now().Add(gracefulFailoverTimeout).UnixNano()
 	}
 
 	if err := d.namespaceAttrValidator.validateNamespaceConfig(config); err != nil {
@@ -520,9 +567,9 @@ func (d *HandlerImpl) UpdateNamespace(
 		}
 	}
 
-	if configurationChanged && activeClusterChanged && isGlobalNamespace {
+	if configurationChanged && (activeClusterChanged || handoverStarted) && isGlobalNamespace {
 		return nil, errCannotDoNamespaceFailoverAndUpdate
-	} else if configurationChanged || activeClusterChanged {
+	} else if configurationChanged || activeClusterChanged || handoverStarted {
 		if configurationChanged && isGlobalNamespace && !d.clusterMetadata.IsMasterCluster() {
 			return nil, errNotMasterCluster
 		}
@@ -560,7 +607,7 @@ func (d *HandlerImpl) UpdateNamespace(
 		return nil, errNotMasterCluster
 	}
 
-	if isGlobalNamespace {
+	if isGlobalNamespace && !handoverStarted {
 		err = d.namespaceReplicator.HandleTransmissionTask(enumsgenpb.NAMESPACE_OPERATION_UPDATE,
 			info, config, replicationConfig, configVersion, failoverVersion, isGlobalNamespace)
 		if err != nil {
@@ -639,7 +686,7 @@ func (d *HandlerImpl) createResponse(
 		Status:      info.Status,
 		Description: info.Description,
 		OwnerEmail:  info.Owner,
-		Data:        info.Data,
+		Data:        d.withReplicationState(info.Data, replicationConfig),
 		Id:          info.Id,
 	}
 
@@ -699,6 +746,153 @@ func (d *HandlerImpl) mergeNamespaceData(
 	return old
 }
 
+// extractVisibilityIndex removes the reserved visibility index key from namespace data
+func (d *HandlerImpl) extractVisibilityIndex(
+	data map[string]string,
+) (map[string]string, string, bool) {
+
+	visibilityIndex, ok := data[VisibilityIndexDataKey]
+	if !ok {
+		return data, "", false
+	}
+
+	result := make(map[string]string, len(data)-1)
+	for k, v := range data {
+		if k != VisibilityIndexDataKey {
+			result[k] = v
+		}
+	}
+	return result, visibilityIndex, true
+}
+
+// extractGracefulFailoverTimeout removes the reserved graceful failover key from namespace data
+func (d *HandlerImpl) extractGracefulFailoverTimeout(
+	data map[string]string,
+) (map[string]string, time.Duration, bool, error) {
+
+	value, ok := data[GracefulFailoverTimeoutDataKey]
+	if !ok {
+		return data, 0, false, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return nil, 0, false, errInvalidGracefulFailoverTimeout
+	}
+
+	result := make(map[string]string, len(data)-1)
+	for k, v := range data {
+		if k != GracefulFailoverTimeoutDataKey {
+			result[k] = v
+		}
+	}
+	return result, timeout, true, nil
+}
+
+// removeReplicationState removes the keys added to namespace data by withReplicationState
+func (d *HandlerImpl) removeReplicationState(
+	data map[string]string,
+) map[string]string {
+
+	_, hasState := data[ReplicationStateDataKey]
+	_, hasCluster := data[HandoverClusterDataKey]
+	_, hasExpiration := data[HandoverExpirationDataKey]
+	if !hasState && !hasCluster && !hasExpiration {
+		return data
+	}
+
+	result := make(map[string]string, len(data))
+	for k, v := range data {
+		if k != ReplicationStateDataKey && k != HandoverClusterDataKey && k != HandoverExpirationDataKey {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// validateReplicationFilter validates the reserved keys of namespace data which select the replicated workflows
+func (d *HandlerImpl) validateReplicationFilter(
+	data map[string]string,
+) error {
+
+	if workflowTypes, ok := data[cache.ReplicatedWorkflowTypesKey]; ok {
+		for _, workflowType := range strings.Split(workflowTypes, ",") {
+			if strings.TrimSpace(workflowType) == "" {
+				return errInvalidReplicatedWorkflowTypes
+			}
+		}
+	}
+	if searchAttribute, ok := data[cache.ReplicatedSearchAttributeKey]; ok {
+		if _, _, ok := cache.ParseReplicatedSearchAttribute(searchAttribute); !ok {
+			return errInvalidReplicatedSearchAttribute
+		}
+	}
+	return nil
+}
+
+func (d *HandlerImpl) validateGracefulFailover(
+	isGlobalNamespace bool,
+	replicationConfig *persistenceblobs.NamespaceReplicationConfig,
+	handoverCluster string,
+) error {
+
+	if !isGlobalNamespace {
+		return errGracefulFailoverLocalNamespace
+	}
+	if handoverCluster == "" {
+		return errGracefulFailoverNoTarget
+	}
+	if replicationConfig.ActiveClusterName != d.clusterMetadata.GetCurrentClusterName() {
+		return errGracefulFailoverNotActive
+	}
+	if handoverCluster == replicationConfig.ActiveClusterName {
+		return errGracefulFailoverSameCluster
+	}
+	for _, cluster := range replicationConfig.Clusters {
+		if cluster == handoverCluster {
+			return nil
+		}
+	}
+	return errActiveClusterNotInClusters
+}
+
+// withReplicationState exposes an ongoing handover through namespace data without changing the persisted data
+func (d *HandlerImpl) withReplicationState(
+	data map[string]string,
+	replicationConfig *persistenceblobs.NamespaceReplicationConfig,
+) map[string]string {
+
+	if replicationConfig.GetState() != enumsgenpb.NAMESPACE_REPLICATION_STATE_HANDOVER {
+		return data
+	}
+
+	result := make(map[string]string, len(data)+3)
+	for k, v := range data {
+		result[k] = v
+	}
+	result[ReplicationStateDataKey] = "Handover"
+	result[HandoverClusterDataKey] = replicationConfig.GetHandoverClusterName()
+	result[HandoverExpirationDataKey] = time.Unix(0, replicationConfig.GetHandoverExpirationTimeNanos()).UTC().Format(time.RFC3339)
+	return result
+}
+
+// setVisibilityIndex routes the namespace to the given visibility index. The rollover interval is pinned when
+// the index is set, so that changing the dynamic config never routes records of the namespace to other indices
+func (d *HandlerImpl) setVisibilityIndex(
+	config *persistenceblobs.NamespaceConfig,
+	visibilityIndex string,
+) {
+
+	if config.VisibilityIndex == visibilityIndex {
+		return
+	}
+	config.VisibilityIndex = visibilityIndex
+	config.VisibilityIndexRolloverIntervalSeconds = 0
+	if len(visibilityIndex) != 0 {
+		config.VisibilityIndexRolloverIntervalSeconds = int64(d.visibilityIndexRolloverInterval().Seconds())
+	}
+}
+
 func (d *HandlerImpl) toArchivalRegisterEvent(
 	status enumspb.ArchivalStatus,
 	URI string,
@@ -29,6 +29,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
@@ -108,6 +109,7 @@ func (s *namespaceHandlerGlobalNamespaceDisabledSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFn(24*time.Hour),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
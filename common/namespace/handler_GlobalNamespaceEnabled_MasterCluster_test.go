@@ -29,6 +29,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
@@ -109,6 +110,7 @@ func (s *namespaceHandlerGlobalNamespaceEnabledMasterClusterSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFn(24*time.Hour),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
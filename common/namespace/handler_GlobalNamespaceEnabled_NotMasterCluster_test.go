@@ -29,6 +29,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
@@ -110,6 +111,7 @@ func (s *namespaceHandlerGlobalNamespaceEnabledNotMasterClusterSuite) SetupTest(
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFn(24*time.Hour),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -744,6 +746,139 @@ func (s *namespaceHandlerGlobalNamespaceEnabledNotMasterClusterSuite) TestUpdate
 	)
 }
 
+func (s *namespaceHandlerGlobalNamespaceEnabledNotMasterClusterSuite) TestUpdateGetNamespace_GlobalNamespace_GracefulFailover() {
+	namespace := s.getRandomNamespace()
+	prevActiveClusterName := s.ClusterMetadata.GetCurrentClusterName()
+	nextActiveClusterName := ""
+	clustersDB := []string{}
+	for clusterName := range s.ClusterMetadata.GetAllClusterInfo() {
+		if clusterName != prevActiveClusterName {
+			nextActiveClusterName = clusterName
+		}
+		clustersDB = append(clustersDB, clusterName)
+	}
+	s.True(len(nextActiveClusterName) > 0)
+	data := map[string]string{"some random key": "some random value"}
+	failoverVersion := s.ClusterMetadata.GetNextFailoverVersion(prevActiveClusterName, 0)
+
+	_, err := s.MetadataManager.CreateNamespace(&persistence.CreateNamespaceRequest{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Info: &persistenceblobs.NamespaceInfo{
+				Id:     uuid.New(),
+				Name:   namespace,
+				Status: enumspb.NAMESPACE_STATUS_REGISTERED,
+				Data:   data,
+			},
+			Config: &persistenceblobs.NamespaceConfig{
+				RetentionDays:            7,
+				HistoryArchivalStatus:    enumspb.ARCHIVAL_STATUS_DISABLED,
+				VisibilityArchivalStatus: enumspb.ARCHIVAL_STATUS_DISABLED,
+			},
+			ReplicationConfig: &persistenceblobs.NamespaceReplicationConfig{
+				ActiveClusterName: prevActiveClusterName,
+				Clusters:          clustersDB,
+			},
+			FailoverVersion: failoverVersion,
+		},
+		IsGlobalNamespace: true,
+	})
+	s.NoError(err)
+
+	// the handover is local to the active cluster, so nothing is published
+	updateResp, err := s.handler.UpdateNamespace(context.Background(), &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		UpdatedInfo: &namespacepb.UpdateNamespaceInfo{
+			Data: map[string]string{GracefulFailoverTimeoutDataKey: "10m"},
+		},
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: nextActiveClusterName,
+		},
+	})
+	s.NoError(err)
+	s.Equal(prevActiveClusterName, updateResp.ReplicationConfiguration.GetActiveClusterName())
+	s.Equal(failoverVersion, updateResp.GetFailoverVersion())
+
+	getResp, err := s.handler.DescribeNamespace(context.Background(), &workflowservice.DescribeNamespaceRequest{
+		Name: namespace,
+	})
+	s.NoError(err)
+	s.Equal(prevActiveClusterName, getResp.ReplicationConfiguration.GetActiveClusterName())
+	s.Equal("some random value", getResp.NamespaceInfo.Data["some random key"])
+	s.Equal("Handover", getResp.NamespaceInfo.Data[ReplicationStateDataKey])
+	s.Equal(nextActiveClusterName, getResp.NamespaceInfo.Data[HandoverClusterDataKey])
+	s.NotEmpty(getResp.NamespaceInfo.Data[HandoverExpirationDataKey])
+	s.NotContains(getResp.NamespaceInfo.Data, GracefulFailoverTimeoutDataKey)
+
+	s.mockProducer.On("Publish", mock.Anything).Return(nil).Once()
+	updateResp, err = s.handler.UpdateNamespace(context.Background(), &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: nextActiveClusterName,
+		},
+	})
+	s.NoError(err)
+	s.Equal(nextActiveClusterName, updateResp.ReplicationConfiguration.GetActiveClusterName())
+	s.Equal(data, updateResp.NamespaceInfo.Data)
+	s.Equal(s.ClusterMetadata.GetNextFailoverVersion(nextActiveClusterName, failoverVersion), updateResp.GetFailoverVersion())
+}
+
+func (s *namespaceHandlerGlobalNamespaceEnabledNotMasterClusterSuite) TestUpdateNamespace_GlobalNamespace_GracefulFailover_NotActive() {
+	namespace := s.getRandomNamespace()
+	prevActiveClusterName := ""
+	clustersDB := []string{}
+	for clusterName := range s.ClusterMetadata.GetAllClusterInfo() {
+		if clusterName != s.ClusterMetadata.GetCurrentClusterName() {
+			prevActiveClusterName = clusterName
+		}
+		clustersDB = append(clustersDB, clusterName)
+	}
+	s.True(len(prevActiveClusterName) > 0)
+
+	_, err := s.MetadataManager.CreateNamespace(&persistence.CreateNamespaceRequest{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Info: &persistenceblobs.NamespaceInfo{
+				Id:     uuid.New(),
+				Name:   namespace,
+				Status: enumspb.NAMESPACE_STATUS_REGISTERED,
+			},
+			Config: &persistenceblobs.NamespaceConfig{
+				RetentionDays:            7,
+				HistoryArchivalStatus:    enumspb.ARCHIVAL_STATUS_DISABLED,
+				VisibilityArchivalStatus: enumspb.ARCHIVAL_STATUS_DISABLED,
+			},
+			ReplicationConfig: &persistenceblobs.NamespaceReplicationConfig{
+				ActiveClusterName: prevActiveClusterName,
+				Clusters:          clustersDB,
+			},
+			FailoverVersion: s.ClusterMetadata.GetNextFailoverVersion(prevActiveClusterName, 0),
+		},
+		IsGlobalNamespace: true,
+	})
+	s.NoError(err)
+
+	_, err = s.handler.UpdateNamespace(context.Background(), &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		UpdatedInfo: &namespacepb.UpdateNamespaceInfo{
+			Data: map[string]string{GracefulFailoverTimeoutDataKey: "10m"},
+		},
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: s.ClusterMetadata.GetCurrentClusterName(),
+		},
+	})
+	s.Equal(errGracefulFailoverNotActive, err)
+
+	_, err = s.handler.UpdateNamespace(context.Background(), &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		UpdatedInfo: &namespacepb.UpdateNamespaceInfo{
+			Data: map[string]string{GracefulFailoverTimeoutDataKey: "soon"},
+		},
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: s.ClusterMetadata.GetCurrentClusterName(),
+		},
+	})
+	s.Equal(errInvalidGracefulFailoverTimeout, err)
+}
+
 func (s *namespaceHandlerGlobalNamespaceEnabledNotMasterClusterSuite) getRandomNamespace() string {
 	return "namespace" + uuid.New()
 }
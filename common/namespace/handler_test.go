@@ -45,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/mocks"
@@ -113,6 +114,7 @@ func (s *namespaceHandlerCommonSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFn(24*time.Hour),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -429,6 +431,133 @@ func (s *namespaceHandlerCommonSuite) TestUpdateNamespace_InvalidRetentionPeriod
 	s.Nil(resp)
 }
 
+func (s *namespaceHandlerCommonSuite) TestExtractVisibilityIndex() {
+	data, visibilityIndex, ok := s.handler.extractVisibilityIndex(map[string]string{
+		"k0":                   "v0",
+		VisibilityIndexDataKey: "temporal-visibility-ns",
+	})
+	s.True(ok)
+	s.Equal("temporal-visibility-ns", visibilityIndex)
+	s.Equal(map[string]string{"k0": "v0"}, data)
+
+	data, visibilityIndex, ok = s.handler.extractVisibilityIndex(map[string]string{"k0": "v0"})
+	s.False(ok)
+	s.Equal("", visibilityIndex)
+	s.Equal(map[string]string{"k0": "v0"}, data)
+
+	data, _, ok = s.handler.extractVisibilityIndex(nil)
+	s.False(ok)
+	s.Nil(data)
+}
+
+func (s *namespaceHandlerCommonSuite) TestRegisterAndUpdateNamespace_VisibilityIndex() {
+	namespace := s.getRandomNamespace()
+	registerRequest := &workflowservice.RegisterNamespaceRequest{
+		Name:                                   namespace,
+		WorkflowExecutionRetentionPeriodInDays: int32(10),
+		IsGlobalNamespace:                      false,
+		Data: map[string]string{
+			"k0":                   "v0",
+			VisibilityIndexDataKey: "temporal-visibility-ns",
+		},
+	}
+	_, err := s.handler.RegisterNamespace(context.Background(), registerRequest)
+	s.NoError(err)
+
+	resp, err := s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.Equal("temporal-visibility-ns", resp.Namespace.Config.VisibilityIndex)
+	s.Equal(int64(24*time.Hour/time.Second), resp.Namespace.Config.VisibilityIndexRolloverIntervalSeconds)
+	s.Equal(map[string]string{"k0": "v0"}, resp.Namespace.Info.Data)
+
+	updateRequest := &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		UpdatedInfo: &namespacepb.UpdateNamespaceInfo{
+			Data: map[string]string{VisibilityIndexDataKey: "Invalid Index"},
+		},
+	}
+	_, err = s.handler.UpdateNamespace(context.Background(), updateRequest)
+	s.Equal(errInvalidVisibilityIndex, err)
+
+	updateRequest.UpdatedInfo.Data[VisibilityIndexDataKey] = ""
+	_, err = s.handler.UpdateNamespace(context.Background(), updateRequest)
+	s.NoError(err)
+
+	resp, err = s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.Equal("", resp.Namespace.Config.VisibilityIndex)
+	s.Zero(resp.Namespace.Config.VisibilityIndexRolloverIntervalSeconds)
+	s.Equal(map[string]string{"k0": "v0"}, resp.Namespace.Info.Data)
+}
+
+func (s *namespaceHandlerCommonSuite) TestRemoveReplicationState() {
+	data := map[string]string{"k0": "v0"}
+	s.Equal(data, s.handler.removeReplicationState(data))
+	s.Nil(s.handler.removeReplicationState(nil))
+
+	s.Equal(data, s.handler.removeReplicationState(map[string]string{
+		"k0":                      "v0",
+		ReplicationStateDataKey:   "Handover",
+		HandoverClusterDataKey:    "standby",
+		HandoverExpirationDataKey: "2020-01-01T00:00:00Z",
+	}))
+}
+
+func (s *namespaceHandlerCommonSuite) TestRegisterAndUpdateNamespace_ReplicationStateNotPersisted() {
+	namespace := s.getRandomNamespace()
+	registerRequest := &workflowservice.RegisterNamespaceRequest{
+		Name:                                   namespace,
+		WorkflowExecutionRetentionPeriodInDays: int32(10),
+		IsGlobalNamespace:                      false,
+		Data: map[string]string{
+			"k0":                    "v0",
+			ReplicationStateDataKey: "Handover",
+		},
+	}
+	_, err := s.handler.RegisterNamespace(context.Background(), registerRequest)
+	s.NoError(err)
+
+	resp, err := s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.Equal(map[string]string{"k0": "v0"}, resp.Namespace.Info.Data)
+
+	// data of a describe response during a handover written back as is
+	updateRequest := &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		UpdatedInfo: &namespacepb.UpdateNamespaceInfo{
+			Data: map[string]string{
+				"k1":                      "v1",
+				ReplicationStateDataKey:   "Handover",
+				HandoverClusterDataKey:    "standby",
+				HandoverExpirationDataKey: "2020-01-01T00:00:00Z",
+			},
+		},
+	}
+	_, err = s.handler.UpdateNamespace(context.Background(), updateRequest)
+	s.NoError(err)
+
+	resp, err = s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.Equal(map[string]string{"k0": "v0", "k1": "v1"}, resp.Namespace.Info.Data)
+}
+
+func (s *namespaceHandlerCommonSuite) TestValidateReplicationFilter() {
+	s.NoError(s.handler.validateReplicationFilter(nil))
+	s.NoError(s.handler.validateReplicationFilter(map[string]string{
+		cache.ReplicatedWorkflowTypesKey:   "workflow-type-0, workflow-type-1",
+		cache.ReplicatedSearchAttributeKey: "CustomKeywordField=critical",
+	}))
+	s.Equal(errInvalidReplicatedWorkflowTypes, s.handler.validateReplicationFilter(map[string]string{
+		cache.ReplicatedWorkflowTypesKey: "workflow-type-0,,workflow-type-1",
+	}))
+	s.Equal(errInvalidReplicatedSearchAttribute, s.handler.validateReplicationFilter(map[string]string{
+		cache.ReplicatedSearchAttributeKey: "CustomKeywordField",
+	}))
+	s.Equal(errInvalidReplicatedSearchAttribute, s.handler.validateReplicationFilter(map[string]string{
+		cache.ReplicatedSearchAttributeKey: "=critical",
+	}))
+}
+
 func (s *namespaceHandlerCommonSuite) getRandomNamespace() string {
 	return "namespace" + uuid.New()
 }
@@ -0,0 +1,116 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/payloadstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	// URIScheme is the scheme for the filestore implementation of payload store
+	URIScheme = "file"
+)
+
+var (
+	errInvalidFileMode = errors.New("invalid file mode")
+	errInvalidDirMode  = errors.New("invalid directory mode")
+	errEmptyPath       = errors.New("URI path is empty")
+)
+
+type (
+	store struct {
+		fileMode os.FileMode
+		dirMode  os.FileMode
+	}
+)
+
+var _ payloadstore.Store = (*store)(nil)
+
+// NewStore creates a new payloadstore.Store based on filestore
+func NewStore(
+	config *config.FilestorePayloadStore,
+) (payloadstore.Store, error) {
+	fileMode, err := strconv.ParseUint(config.FileMode, 0, 32)
+	if err != nil {
+		return nil, errInvalidFileMode
+	}
+	dirMode, err := strconv.ParseUint(config.DirMode, 0, 32)
+	if err != nil {
+		return nil, errInvalidDirMode
+	}
+	return &store{
+		fileMode: os.FileMode(fileMode),
+		dirMode:  os.FileMode(dirMode),
+	}, nil
+}
+
+func (s *store) Put(
+	_ context.Context,
+	URI archiver.URI,
+	data []byte,
+) error {
+	if err := s.ValidateURI(URI); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(URI.Path()), s.dirMode); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(URI.Path(), data, s.fileMode)
+}
+
+func (s *store) Get(
+	_ context.Context,
+	URI archiver.URI,
+) ([]byte, error) {
+	if err := s.ValidateURI(URI); err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(URI.Path())
+	if os.IsNotExist(err) {
+		return nil, payloadstore.ErrPayloadNotFound
+	}
+	return data, err
+}
+
+func (s *store) ValidateURI(URI archiver.URI) error {
+	if URI.Scheme() != URIScheme {
+		return payloadstore.ErrURISchemeMismatch
+	}
+	if len(URI.Path()) == 0 {
+		return errEmptyPath
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/payloadstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type storeSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	dir   string
+	store payloadstore.Store
+}
+
+func TestStoreSuite(t *testing.T) {
+	suite.Run(t, new(storeSuite))
+}
+
+func (s *storeSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	dir, err := ioutil.TempDir("", "TestPayloadStore")
+	s.NoError(err)
+	s.dir = dir
+	s.store, err = NewStore(&config.FilestorePayloadStore{
+		FileMode: "0600",
+		DirMode:  "0700",
+	})
+	s.NoError(err)
+}
+
+func (s *storeSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *storeSuite) TestNewStore_InvalidMode() {
+	_, err := NewStore(&config.FilestorePayloadStore{
+		FileMode: "invalid",
+		DirMode:  "0700",
+	})
+	s.Equal(errInvalidFileMode, err)
+
+	_, err = NewStore(&config.FilestorePayloadStore{
+		FileMode: "0600",
+		DirMode:  "invalid",
+	})
+	s.Equal(errInvalidDirMode, err)
+}
+
+func (s *storeSuite) TestValidateURI() {
+	URI, err := archiver.NewURI("s3://bucket/payloads")
+	s.NoError(err)
+	s.Equal(payloadstore.ErrURISchemeMismatch, s.store.ValidateURI(URI))
+
+	URI, err = archiver.NewURI("file://" + s.dir)
+	s.NoError(err)
+	s.NoError(s.store.ValidateURI(URI))
+}
+
+func (s *storeSuite) TestPutGet() {
+	URI, err := archiver.NewURI("file://" + filepath.Join(s.dir, "namespace-id", "key"))
+	s.NoError(err)
+
+	_, err = s.store.Get(context.Background(), URI)
+	s.Equal(payloadstore.ErrPayloadNotFound, err)
+
+	data := []byte("payload data")
+	s.NoError(s.store.Put(context.Background(), URI, data))
+	// put is idempotent for the same URI
+	s.NoError(s.store.Put(context.Background(), URI, data))
+
+	result, err := s.store.Get(context.Background(), URI)
+	s.NoError(err)
+	s.Equal(data, result)
+}
@@ -0,0 +1,136 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadstore
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+)
+
+type (
+	// inflatingHistoryManager replaces references in the history it reads with the offloaded payloads,
+	// it wraps the history manager of paths which send history out of the cluster
+	inflatingHistoryManager struct {
+		persistence.HistoryManager
+
+		provider   Provider
+		serializer persistence.PayloadSerializer
+	}
+)
+
+var _ persistence.HistoryManager = (*inflatingHistoryManager)(nil)
+
+// NewInflatingHistoryManager returns a history manager which inflates the history it reads,
+// so remote clusters and archives never see references to the payload store of this cluster
+func NewInflatingHistoryManager(
+	historyManager persistence.HistoryManager,
+	provider Provider,
+) persistence.HistoryManager {
+
+	return &inflatingHistoryManager{
+		HistoryManager: historyManager,
+		provider:       provider,
+		serializer:     persistence.NewPayloadSerializer(),
+	}
+}
+
+func (m *inflatingHistoryManager) ReadHistoryBranch(
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadHistoryBranchResponse, error) {
+
+	response, err := m.HistoryManager.ReadHistoryBranch(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	if err := InflateEvents(ctx, m.provider, response.HistoryEvents); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (m *inflatingHistoryManager) ReadHistoryBranchByBatch(
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadHistoryBranchByBatchResponse, error) {
+
+	response, err := m.HistoryManager.ReadHistoryBranchByBatch(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	for _, batch := range response.History {
+		if err := InflateEvents(ctx, m.provider, batch.Events); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func (m *inflatingHistoryManager) ReadRawHistoryBranch(
+	request *persistence.ReadHistoryBranchRequest,
+) (*persistence.ReadRawHistoryBranchResponse, error) {
+
+	response, err := m.HistoryManager.ReadRawHistoryBranch(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	for i, blob := range response.HistoryEventBlobs {
+		if response.HistoryEventBlobs[i], err = m.inflateBlob(ctx, blob); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// inflateBlob re-encodes the batch only if it may carry a reference, the reference encoding
+// is written verbatim by both proto3 and json so blobs without it are returned as is
+func (m *inflatingHistoryManager) inflateBlob(
+	ctx context.Context,
+	blob *serialization.DataBlob,
+) (*serialization.DataBlob, error) {
+
+	if !bytes.Contains(blob.Data, []byte(EncodingReference)) {
+		return blob, nil
+	}
+
+	events, err := m.serializer.DeserializeBatchEvents(blob)
+	if err != nil {
+		return nil, err
+	}
+	if err := InflateEvents(ctx, m.provider, events); err != nil {
+		return nil, err
+	}
+	return m.serializer.SerializeBatchEvents(events, blob.Encoding)
+}
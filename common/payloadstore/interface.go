@@ -0,0 +1,57 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination interface_mock.go
+
+package payloadstore
+
+import (
+	"context"
+	"errors"
+
+	"github.com/temporalio/temporal/common/archiver"
+)
+
+var (
+	// ErrURISchemeMismatch is the error for mismatch between URI scheme and payload store
+	ErrURISchemeMismatch = errors.New("URI scheme does not match the payload store")
+	// ErrPayloadNotFound is the error for payload not found in the payload store
+	ErrPayloadNotFound = errors.New("payload not found in the payload store")
+)
+
+type (
+	// Store is used to write and read offloaded payloads,
+	// the payloads are content addressed so writing the same URI twice must be idempotent
+	Store interface {
+		Put(ctx context.Context, URI archiver.URI, data []byte) error
+		Get(ctx context.Context, URI archiver.URI) ([]byte, error)
+		ValidateURI(URI archiver.URI) error
+	}
+
+	// Provider returns the payload store based on the URI scheme.
+	// The store for each scheme will be created only once and cached.
+	Provider interface {
+		GetStore(scheme string) (Store, error)
+	}
+)
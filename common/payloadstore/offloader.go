@@ -0,0 +1,331 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	historypb "go.temporal.io/temporal-proto/history/v1"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+const (
+	// EncodingReference is the encoding of a payload which only references a payload offloaded
+	// to the payload store, the data of such payload is the URI of the offloaded payload
+	EncodingReference = "temporal/payload-reference"
+
+	// DefaultTimeout bounds payload store calls made on paths which have no request context
+	DefaultTimeout = 10 * time.Second
+
+	metadataEncoding = "encoding"
+)
+
+type (
+	// Offloader replaces payloads of history events above the namespace threshold
+	// with references to payloads written to the payload store
+	Offloader interface {
+		OffloadEvents(ctx context.Context, namespaceID string, namespace string, events []*historypb.HistoryEvent) ([]*historypb.HistoryEvent, error)
+	}
+
+	offloader struct {
+		provider  Provider
+		threshold dynamicconfig.IntPropertyFnWithNamespaceFilter
+		baseURI   dynamicconfig.StringPropertyFnWithNamespaceFilter
+	}
+)
+
+var _ Offloader = (*offloader)(nil)
+
+// NewOffloader returns a new Offloader
+func NewOffloader(
+	provider Provider,
+	threshold dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	baseURI dynamicconfig.StringPropertyFnWithNamespaceFilter,
+) Offloader {
+
+	return &offloader{
+		provider:  provider,
+		threshold: threshold,
+		baseURI:   baseURI,
+	}
+}
+
+// OffloadEvents returns the events with payloads above the threshold replaced by references.
+// Events which are modified are copied first, so the given events are never mutated.
+func (o *offloader) OffloadEvents(
+	ctx context.Context,
+	namespaceID string,
+	namespace string,
+	events []*historypb.HistoryEvent,
+) ([]*historypb.HistoryEvent, error) {
+
+	threshold := o.threshold(namespace)
+	if threshold <= 0 {
+		return events, nil
+	}
+
+	var result []*historypb.HistoryEvent
+	var store Store
+	var baseURI archiver.URI
+	for i, event := range events {
+		if !hasPayloadAboveThreshold(event, threshold) {
+			continue
+		}
+
+		if result == nil {
+			var err error
+			if baseURI, err = archiver.NewURI(o.baseURI(namespace)); err != nil {
+				return nil, err
+			}
+			if store, err = o.provider.GetStore(baseURI.Scheme()); err != nil {
+				return nil, err
+			}
+			if err := store.ValidateURI(baseURI); err != nil {
+				return nil, err
+			}
+			result = make([]*historypb.HistoryEvent, len(events))
+			copy(result, events)
+		}
+
+		event = proto.Clone(event).(*historypb.HistoryEvent)
+		for _, payloads := range eventPayloads(event) {
+			for j, payload := range payloads.GetPayloads() {
+				if payload.Size() <= threshold {
+					continue
+				}
+				reference, err := offloadPayload(ctx, store, baseURI, namespaceID, payload)
+				if err != nil {
+					return nil, err
+				}
+				payloads.Payloads[j] = reference
+			}
+		}
+		result[i] = event
+	}
+
+	if result == nil {
+		return events, nil
+	}
+	return result, nil
+}
+
+// InflateEvent returns the event with all references replaced by the offloaded payloads.
+// The event is copied before modification, if the event has no reference it is returned as is.
+func InflateEvent(
+	ctx context.Context,
+	provider Provider,
+	event *historypb.HistoryEvent,
+) (*historypb.HistoryEvent, error) {
+
+	if !hasReference(event) {
+		return event, nil
+	}
+
+	event = proto.Clone(event).(*historypb.HistoryEvent)
+	for _, payloads := range eventPayloads(event) {
+		for i, payload := range payloads.GetPayloads() {
+			if !IsReference(payload) {
+				continue
+			}
+			inflated, err := inflatePayload(ctx, provider, payload)
+			if err != nil {
+				return nil, err
+			}
+			payloads.Payloads[i] = inflated
+		}
+	}
+	return event, nil
+}
+
+// InflateEvents replaces the events which have references with inflated copies
+func InflateEvents(
+	ctx context.Context,
+	provider Provider,
+	events []*historypb.HistoryEvent,
+) error {
+
+	for i, event := range events {
+		inflated, err := InflateEvent(ctx, provider, event)
+		if err != nil {
+			return err
+		}
+		events[i] = inflated
+	}
+	return nil
+}
+
+// PersistedSize returns the size the payloads take in history once payloads above the threshold
+// are replaced by references, a threshold of 0 disables offloading and the full size is returned
+func PersistedSize(
+	payloads *commonpb.Payloads,
+	threshold int,
+) int {
+
+	size := payloads.Size()
+	if threshold <= 0 {
+		return size
+	}
+	for _, payload := range payloads.GetPayloads() {
+		if payloadSize := payload.Size(); payloadSize > threshold {
+			size -= payloadSize
+		}
+	}
+	return size
+}
+
+// IsReference returns true if the payload references an offloaded payload
+func IsReference(payload *commonpb.Payload) bool {
+	return string(payload.GetMetadata()[metadataEncoding]) == EncodingReference
+}
+
+func offloadPayload(
+	ctx context.Context,
+	store Store,
+	baseURI archiver.URI,
+	namespaceID string,
+	payload *commonpb.Payload,
+) (*commonpb.Payload, error) {
+
+	data, err := payload.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(data)
+	URI, err := archiver.NewURI(strings.TrimRight(baseURI.String(), "/") + "/" + namespaceID + "/" + hex.EncodeToString(checksum[:]))
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Put(ctx, URI, data); err != nil {
+		return nil, err
+	}
+	return &commonpb.Payload{
+		Metadata: map[string][]byte{metadataEncoding: []byte(EncodingReference)},
+		Data:     []byte(URI.String()),
+	}, nil
+}
+
+func inflatePayload(
+	ctx context.Context,
+	provider Provider,
+	reference *commonpb.Payload,
+) (*commonpb.Payload, error) {
+
+	URI, err := archiver.NewURI(string(reference.GetData()))
+	if err != nil {
+		return nil, err
+	}
+	store, err := provider.GetStore(URI.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	data, err := store.Get(ctx, URI)
+	if err != nil {
+		return nil, err
+	}
+	payload := &commonpb.Payload{}
+	if err := payload.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func hasPayloadAboveThreshold(event *historypb.HistoryEvent, threshold int) bool {
+	for _, payloads := range eventPayloads(event) {
+		for _, payload := range payloads.GetPayloads() {
+			if payload.Size() > threshold {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasReference(event *historypb.HistoryEvent) bool {
+	for _, payloads := range eventPayloads(event) {
+		for _, payload := range payloads.GetPayloads() {
+			if IsReference(payload) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// eventPayloads returns the user payloads carried by the event, the returned payloads are not copied.
+// Reserved signals and the memo upsert marker are excluded as they are decoded by the server when rebuilding mutable state.
+func eventPayloads(event *historypb.HistoryEvent) []*commonpb.Payloads {
+	var result []*commonpb.Payloads
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+		attr := event.GetWorkflowExecutionStartedEventAttributes()
+		result = append(result, attr.GetInput(), attr.GetLastCompletionResult())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		result = append(result, event.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		result = append(result, event.GetWorkflowExecutionCanceledEventAttributes().GetDetails())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		attr := event.GetWorkflowExecutionContinuedAsNewEventAttributes()
+		result = append(result, attr.GetInput(), attr.GetLastCompletionResult())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		attr := event.GetWorkflowExecutionSignaledEventAttributes()
+		if !common.IsReservedSignalName(attr.GetSignalName()) {
+			result = append(result, attr.GetInput())
+		}
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		result = append(result, event.GetWorkflowExecutionTerminatedEventAttributes().GetDetails())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		result = append(result, event.GetActivityTaskScheduledEventAttributes().GetInput())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		result = append(result, event.GetActivityTaskCompletedEventAttributes().GetResult())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		result = append(result, event.GetActivityTaskCanceledEventAttributes().GetDetails())
+	case enumspb.EVENT_TYPE_MARKER_RECORDED:
+		attr := event.GetMarkerRecordedEventAttributes()
+		if attr.GetMarkerName() != common.UpsertWorkflowMemoMarkerName {
+			for _, details := range attr.GetDetails() {
+				result = append(result, details)
+			}
+		}
+	case enumspb.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+		result = append(result, event.GetSignalExternalWorkflowExecutionInitiatedEventAttributes().GetInput())
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+		result = append(result, event.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetInput())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+		result = append(result, event.GetChildWorkflowExecutionCompletedEventAttributes().GetResult())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+		result = append(result, event.GetChildWorkflowExecutionCanceledEventAttributes().GetDetails())
+	}
+	return result
+}
@@ -0,0 +1,276 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	historypb "go.temporal.io/temporal-proto/history/v1"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+)
+
+const (
+	testNamespaceID = "test-namespace-id"
+	testNamespace   = "test-namespace"
+	testBaseURI     = "file:///tmp/temporal_payloads"
+)
+
+type (
+	offloaderSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		controller   *gomock.Controller
+		mockProvider *MockProvider
+		mockStore    *MockStore
+
+		threshold int
+		offloader Offloader
+		// blobs written to the mock store keyed by URI
+		blobs map[string][]byte
+	}
+)
+
+func TestOffloaderSuite(t *testing.T) {
+	s := new(offloaderSuite)
+	suite.Run(t, s)
+}
+
+func (s *offloaderSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockProvider = NewMockProvider(s.controller)
+	s.mockStore = NewMockStore(s.controller)
+
+	// the encoded "small" payload stays below the threshold, the longer test payloads are above it
+	s.threshold = 40
+	s.offloader = NewOffloader(
+		s.mockProvider,
+		func(namespace string) int { return s.threshold },
+		func(namespace string) string { return testBaseURI },
+	)
+	s.blobs = make(map[string][]byte)
+
+	s.mockProvider.EXPECT().GetStore("file").Return(s.mockStore, nil).AnyTimes()
+	s.mockStore.EXPECT().ValidateURI(gomock.Any()).Return(nil).AnyTimes()
+	s.mockStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI, data []byte) error {
+			s.blobs[URI.String()] = data
+			return nil
+		},
+	).AnyTimes()
+	s.mockStore.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI) ([]byte, error) {
+			data, ok := s.blobs[URI.String()]
+			if !ok {
+				return nil, ErrPayloadNotFound
+			}
+			return data, nil
+		},
+	).AnyTimes()
+}
+
+func (s *offloaderSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *offloaderSuite) TestOffloadEvents_Disabled() {
+	s.threshold = 0
+	events := []*historypb.HistoryEvent{s.newActivityScheduledEvent(1, "a payload which is above the threshold")}
+
+	result, err := s.offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, events)
+	s.NoError(err)
+	s.Equal(events, result)
+	s.Empty(s.blobs)
+}
+
+func (s *offloaderSuite) TestOffloadEvents_BelowThreshold() {
+	events := []*historypb.HistoryEvent{
+		s.newActivityScheduledEvent(1, "small"),
+		{
+			EventId:   2,
+			EventType: enumspb.EVENT_TYPE_DECISION_TASK_SCHEDULED,
+			Attributes: &historypb.HistoryEvent_DecisionTaskScheduledEventAttributes{
+				DecisionTaskScheduledEventAttributes: &historypb.DecisionTaskScheduledEventAttributes{},
+			},
+		},
+	}
+
+	result, err := s.offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, events)
+	s.NoError(err)
+	s.Equal(events, result)
+	s.Empty(s.blobs)
+}
+
+func (s *offloaderSuite) TestOffloadEvents_InflateEvents() {
+	large := "a payload which is above the threshold"
+	events := []*historypb.HistoryEvent{
+		s.newActivityScheduledEvent(1, "small"),
+		s.newActivityScheduledEvent(2, large),
+		s.newActivityScheduledEvent(3, large),
+	}
+	var original []*historypb.HistoryEvent
+	for _, event := range events {
+		original = append(original, proto.Clone(event).(*historypb.HistoryEvent))
+	}
+
+	offloaded, err := s.offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, events)
+	s.NoError(err)
+	s.Len(offloaded, 3)
+	s.True(offloaded[0] == events[0])
+	s.Equal(original, events, "given events must not be modified")
+	// identical payloads are stored once
+	s.Len(s.blobs, 1)
+	for _, event := range offloaded[1:] {
+		reference := event.GetActivityTaskScheduledEventAttributes().GetInput().GetPayloads()[0]
+		s.True(IsReference(reference))
+		s.Contains(string(reference.GetData()), testBaseURI+"/"+testNamespaceID+"/")
+	}
+
+	err = InflateEvents(context.Background(), s.mockProvider, offloaded)
+	s.NoError(err)
+	s.Equal(events, offloaded)
+}
+
+func (s *offloaderSuite) TestOffloadEvents_ReservedSignal() {
+	event := &historypb.HistoryEvent{
+		EventId:   1,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{
+			WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+				SignalName: common.WorkflowAttributesUpsertedSignalName,
+				Input:      payloads.EncodeString("a payload which is above the threshold"),
+			},
+		},
+	}
+
+	result, err := s.offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, []*historypb.HistoryEvent{event})
+	s.NoError(err)
+	s.True(result[0] == event)
+	s.Empty(s.blobs)
+}
+
+func (s *offloaderSuite) TestOffloadEvents_UpsertMemoMarker() {
+	event := &historypb.HistoryEvent{
+		EventId:   1,
+		EventType: enumspb.EVENT_TYPE_MARKER_RECORDED,
+		Attributes: &historypb.HistoryEvent_MarkerRecordedEventAttributes{
+			MarkerRecordedEventAttributes: &historypb.MarkerRecordedEventAttributes{
+				MarkerName: common.UpsertWorkflowMemoMarkerName,
+				Details: map[string]*commonpb.Payloads{
+					common.UpsertWorkflowMemoMarkerName: payloads.EncodeString("a payload which is above the threshold"),
+				},
+			},
+		},
+	}
+
+	result, err := s.offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, []*historypb.HistoryEvent{event})
+	s.NoError(err)
+	s.True(result[0] == event)
+	s.Empty(s.blobs)
+}
+
+func (s *offloaderSuite) TestInflateEvent_NoReference() {
+	event := s.newActivityScheduledEvent(1, "a payload which is above the threshold")
+
+	result, err := InflateEvent(context.Background(), s.mockProvider, event)
+	s.NoError(err)
+	s.True(result == event)
+}
+
+func (s *offloaderSuite) TestInflateEvent_PayloadNotFound() {
+	event := s.newActivityScheduledEvent(1, "")
+	event.GetActivityTaskScheduledEventAttributes().Input = &commonpb.Payloads{Payloads: []*commonpb.Payload{{
+		Metadata: map[string][]byte{metadataEncoding: []byte(EncodingReference)},
+		Data:     []byte(testBaseURI + "/" + testNamespaceID + "/missing"),
+	}}}
+
+	_, err := InflateEvent(context.Background(), s.mockProvider, event)
+	s.Equal(ErrPayloadNotFound, err)
+}
+
+func (s *offloaderSuite) TestPersistedSize() {
+	small := payloads.EncodeString("small")
+	large := payloads.EncodeString("a payload which is above the threshold")
+	mixed := &commonpb.Payloads{Payloads: append(small.GetPayloads(), large.GetPayloads()...)}
+
+	s.Equal(large.Size(), PersistedSize(large, 0))
+	s.Equal(small.Size(), PersistedSize(small, s.threshold))
+	s.Equal(mixed.Size()-large.GetPayloads()[0].Size(), PersistedSize(mixed, s.threshold))
+}
+
+func (s *offloaderSuite) TestInflatingHistoryManager_ReadRawHistoryBranch() {
+	events := []*historypb.HistoryEvent{s.newActivityScheduledEvent(1, "a payload which is above the threshold")}
+	offloaded, err := s.offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, events)
+	s.NoError(err)
+
+	serializer := persistence.NewPayloadSerializer()
+	offloadedBlob, err := serializer.SerializeBatchEvents(offloaded, common.EncodingTypeProto3)
+	s.NoError(err)
+	plainBlob, err := serializer.SerializeBatchEvents([]*historypb.HistoryEvent{s.newActivityScheduledEvent(2, "small")}, common.EncodingTypeProto3)
+	s.NoError(err)
+
+	request := &persistence.ReadHistoryBranchRequest{BranchToken: []byte("some random branch token")}
+	mockHistoryManager := &mocks.HistoryV2Manager{}
+	defer mockHistoryManager.AssertExpectations(s.T())
+	mockHistoryManager.On("ReadRawHistoryBranch", request).Return(&persistence.ReadRawHistoryBranchResponse{
+		HistoryEventBlobs: []*serialization.DataBlob{offloadedBlob, plainBlob},
+	}, nil).Once()
+
+	response, err := NewInflatingHistoryManager(mockHistoryManager, s.mockProvider).ReadRawHistoryBranch(request)
+	s.NoError(err)
+	s.Len(response.HistoryEventBlobs, 2)
+	s.Equal(plainBlob, response.HistoryEventBlobs[1])
+
+	inflated, err := serializer.DeserializeBatchEvents(response.HistoryEventBlobs[0])
+	s.NoError(err)
+	s.True(proto.Equal(events[0], inflated[0]))
+}
+
+func (s *offloaderSuite) newActivityScheduledEvent(eventID int64, input string) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{
+		EventId:   eventID,
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+		Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+			ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+				Input: payloads.EncodeString(input),
+			},
+		},
+	}
+}
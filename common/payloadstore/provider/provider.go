@@ -0,0 +1,93 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package provider
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/temporalio/temporal/common/payloadstore"
+	"github.com/temporalio/temporal/common/payloadstore/filestore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+var (
+	// ErrUnknownScheme is the error for unknown payload store scheme
+	ErrUnknownScheme = errors.New("unknown payload store scheme")
+	// ErrPayloadStoreConfigNotFound is the error for unable to find the config for a payload store given scheme
+	ErrPayloadStoreConfigNotFound = errors.New("unable to find payload store config for the given scheme")
+)
+
+type (
+	payloadStoreProvider struct {
+		sync.RWMutex
+
+		configs *config.PayloadStoreProvider
+
+		// Key for the store is scheme
+		stores map[string]payloadstore.Store
+	}
+)
+
+// NewPayloadStoreProvider returns a new payload store provider
+func NewPayloadStoreProvider(
+	configs *config.PayloadStoreProvider,
+) payloadstore.Provider {
+	return &payloadStoreProvider{
+		configs: configs,
+		stores:  make(map[string]payloadstore.Store),
+	}
+}
+
+func (p *payloadStoreProvider) GetStore(scheme string) (store payloadstore.Store, err error) {
+	p.RLock()
+	if store, ok := p.stores[scheme]; ok {
+		p.RUnlock()
+		return store, nil
+	}
+	p.RUnlock()
+
+	switch scheme {
+	case filestore.URIScheme:
+		if p.configs == nil || p.configs.Filestore == nil {
+			return nil, ErrPayloadStoreConfigNotFound
+		}
+		store, err = filestore.NewStore(p.configs.Filestore)
+	default:
+		return nil, ErrUnknownScheme
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if existingStore, ok := p.stores[scheme]; ok {
+		return existingStore, nil
+	}
+	p.stores[scheme] = store
+	return store, nil
+}
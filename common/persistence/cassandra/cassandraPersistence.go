@@ -579,6 +579,23 @@ workflow_state = ? ` +
 
 	templateRangeCompleteReplicationTaskQuery = templateRangeCompleteTransferTaskQuery
 
+	templateCreateHistoryTaskDLQMessageQuery = `INSERT INTO history_task_dlq (` +
+		`shard_id, category, message_id, data, data_encoding) ` +
+		`VALUES(?, ?, ?, ?, ?)`
+
+	templateGetHistoryTaskDLQMessagesQuery = `SELECT data, data_encoding ` +
+		`FROM history_task_dlq ` +
+		`WHERE shard_id = ? ` +
+		`and category = ? ` +
+		`and message_id > ? ` +
+		`and message_id <= ?`
+
+	templateRangeDeleteHistoryTaskDLQMessagesQuery = `DELETE FROM history_task_dlq ` +
+		`WHERE shard_id = ? ` +
+		`and category = ? ` +
+		`and message_id > ? ` +
+		`and message_id <= ?`
+
 	templateGetTimerTaskQuery = `SELECT timer, timer_encoding ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -2807,6 +2824,80 @@ func (d *cassandraPersistence) RangeDeleteReplicationTaskFromDLQ(
 	return nil
 }
 
+func (d *cassandraPersistence) PutHistoryTaskToDLQ(
+	request *p.PutHistoryTaskToDLQRequest,
+) error {
+
+	datablob, err := serialization.HistoryTaskDLQMessageToBlob(request.Message)
+	if err != nil {
+		return convertCommonErrors("PutHistoryTaskToDLQ", err)
+	}
+
+	query := d.session.Query(templateCreateHistoryTaskDLQMessageQuery,
+		d.shardID,
+		int32(request.Message.GetType()),
+		request.Message.GetMessageId(),
+		datablob.Data,
+		datablob.Encoding,
+	)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("PutHistoryTaskToDLQ", err)
+	}
+	return nil
+}
+
+func (d *cassandraPersistence) GetHistoryTasksFromDLQ(
+	request *p.GetHistoryTasksFromDLQRequest,
+) (*p.GetHistoryTasksFromDLQResponse, error) {
+
+	query := d.session.Query(templateGetHistoryTaskDLQMessagesQuery,
+		d.shardID,
+		int32(request.Category),
+		request.ReadLevel,
+		request.MaxReadLevel,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("GetHistoryTasksFromDLQ operation failed.  Not able to create query iterator.")
+	}
+
+	response := &p.GetHistoryTasksFromDLQResponse{}
+	var data []byte
+	var encoding string
+	for iter.Scan(&data, &encoding) {
+		message, err := serialization.HistoryTaskDLQMessageFromBlob(data, encoding)
+		if err != nil {
+			return nil, convertCommonErrors("GetHistoryTasksFromDLQ", err)
+		}
+		response.Messages = append(response.Messages, message)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetHistoryTasksFromDLQ", err)
+	}
+	return response, nil
+}
+
+func (d *cassandraPersistence) RangeDeleteHistoryTaskFromDLQ(
+	request *p.RangeDeleteHistoryTaskFromDLQRequest,
+) error {
+
+	query := d.session.Query(templateRangeDeleteHistoryTaskDLQMessagesQuery,
+		d.shardID,
+		int32(request.Category),
+		request.ExclusiveBeginMessageID,
+		request.InclusiveEndMessageID,
+	)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("RangeDeleteHistoryTaskFromDLQ", err)
+	}
+	return nil
+}
+
 func workflowExecutionFromRow(result map[string]interface{}) (*p.InternalWorkflowExecutionInfo, *p.ReplicationState, error) {
 	eiBytes, ok := result["execution"].([]byte)
 	if !ok {
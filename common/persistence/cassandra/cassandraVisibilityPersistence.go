@@ -57,6 +57,22 @@ const (
 		`namespace_id, namespace_partition, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list) ` +
 		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
+	templateUpdateWorkflowExecutionMemoWithTTL = `UPDATE open_executions using TTL ? ` +
+		`SET memo = ?, encoding = ? ` +
+		`WHERE namespace_id = ? ` +
+		`AND namespace_partition = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ? ` +
+		`IF EXISTS`
+
+	templateUpdateWorkflowExecutionMemo = `UPDATE open_executions ` +
+		`SET memo = ?, encoding = ? ` +
+		`WHERE namespace_id = ? ` +
+		`AND namespace_partition = ? ` +
+		`AND start_time = ? ` +
+		`AND run_id = ? ` +
+		`IF EXISTS`
+
 	templateDeleteWorkflowExecutionStarted = `DELETE FROM open_executions ` +
 		`WHERE namespace_id = ? ` +
 		`AND namespace_partition = ? ` +
@@ -328,12 +344,44 @@ func (v *cassandraVisibilityPersistence) RecordWorkflowExecutionClosed(
 	return nil
 }
 
+// UpsertWorkflowExecution only updates the memo of the open record, search attributes are not indexed by this store.
+// The update is conditional so a record removed on close is not brought back.
 func (v *cassandraVisibilityPersistence) UpsertWorkflowExecution(
 	request *p.InternalUpsertWorkflowExecutionRequest) error {
 	if p.IsNopUpsertWorkflowRequest(request) {
 		return nil
 	}
-	return p.NewOperationNotSupportErrorForVis()
+
+	ttl := request.WorkflowTimeout + openExecutionTTLBuffer
+	var query *gocql.Query
+
+	if ttl > maxCassandraTTL {
+		query = v.session.Query(templateUpdateWorkflowExecutionMemo,
+			request.Memo.Data,
+			string(request.Memo.GetEncoding()),
+			request.NamespaceID,
+			namespacePartition,
+			p.UnixNanoToDBTimestamp(request.StartTimestamp),
+			request.RunID,
+		)
+	} else {
+		query = v.session.Query(templateUpdateWorkflowExecutionMemoWithTTL,
+			ttl,
+			request.Memo.Data,
+			string(request.Memo.GetEncoding()),
+			request.NamespaceID,
+			namespacePartition,
+			p.UnixNanoToDBTimestamp(request.StartTimestamp),
+			request.RunID,
+		)
+	}
+	if _, err := query.MapScanCAS(make(map[string]interface{})); err != nil {
+		if isThrottlingError(err) {
+			return serviceerror.NewResourceExhausted(fmt.Sprintf("UpsertWorkflowExecution operation failed. Error: %v", err))
+		}
+		return serviceerror.NewInternal(fmt.Sprintf("UpsertWorkflowExecution operation failed. Error: %v", err))
+	}
+	return nil
 }
 
 func (v *cassandraVisibilityPersistence) ListOpenWorkflowExecutions(
@@ -52,6 +52,9 @@ type (
 		GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue
 		SetNamespaceReplicationQueue(persistence.NamespaceReplicationQueue)
 
+		GetVisibilityIndexerDLQ() persistence.VisibilityIndexerDLQ
+		SetVisibilityIndexerDLQ(persistence.VisibilityIndexerDLQ)
+
 		GetShardManager() persistence.ShardManager
 		SetShardManager(persistence.ShardManager)
 
@@ -69,6 +72,7 @@ type (
 		taskManager               persistence.TaskManager
 		visibilityManager         persistence.VisibilityManager
 		namespaceReplicationQueue persistence.NamespaceReplicationQueue
+		visibilityIndexerDLQ      persistence.VisibilityIndexerDLQ
 		shardManager              persistence.ShardManager
 		historyManager            persistence.HistoryManager
 		executionManagerFactory   persistence.ExecutionManagerFactory
@@ -109,6 +113,11 @@ func NewBeanFromFactory(
 		return nil, err
 	}
 
+	visibilityIndexerDLQ, err := factory.NewVisibilityIndexerDLQ()
+	if err != nil {
+		return nil, err
+	}
+
 	shardMgr, err := factory.NewShardManager()
 	if err != nil {
 		return nil, err
@@ -125,6 +134,7 @@ func NewBeanFromFactory(
 		taskMgr,
 		visibilityMgr,
 		namespaceReplicationQueue,
+		visibilityIndexerDLQ,
 		shardMgr,
 		historyMgr,
 		factory,
@@ -138,6 +148,7 @@ func NewBean(
 	taskManager persistence.TaskManager,
 	visibilityManager persistence.VisibilityManager,
 	namespaceReplicationQueue persistence.NamespaceReplicationQueue,
+	visibilityIndexerDLQ persistence.VisibilityIndexerDLQ,
 	shardManager persistence.ShardManager,
 	historyManager persistence.HistoryManager,
 	executionManagerFactory persistence.ExecutionManagerFactory,
@@ -148,6 +159,7 @@ func NewBean(
 		taskManager:               taskManager,
 		visibilityManager:         visibilityManager,
 		namespaceReplicationQueue: namespaceReplicationQueue,
+		visibilityIndexerDLQ:      visibilityIndexerDLQ,
 		shardManager:              shardManager,
 		historyManager:            historyManager,
 		executionManagerFactory:   executionManagerFactory,
@@ -255,6 +267,26 @@ func (s *BeanImpl) SetNamespaceReplicationQueue(
 	s.namespaceReplicationQueue = namespaceReplicationQueue
 }
 
+// GetVisibilityIndexerDLQ get VisibilityIndexerDLQ
+func (s *BeanImpl) GetVisibilityIndexerDLQ() persistence.VisibilityIndexerDLQ {
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.visibilityIndexerDLQ
+}
+
+// SetVisibilityIndexerDLQ set VisibilityIndexerDLQ
+func (s *BeanImpl) SetVisibilityIndexerDLQ(
+	visibilityIndexerDLQ persistence.VisibilityIndexerDLQ,
+) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.visibilityIndexerDLQ = visibilityIndexerDLQ
+}
+
 // GetShardManager get ShardManager
 func (s *BeanImpl) GetShardManager() persistence.ShardManager {
 
@@ -199,6 +199,32 @@ func (mr *MockBeanMockRecorder) SetNamespaceReplicationQueue(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNamespaceReplicationQueue", reflect.TypeOf((*MockBean)(nil).SetNamespaceReplicationQueue), arg0)
 }
 
+// GetVisibilityIndexerDLQ mocks base method
+func (m *MockBean) GetVisibilityIndexerDLQ() persistence.VisibilityIndexerDLQ {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVisibilityIndexerDLQ")
+	ret0, _ := ret[0].(persistence.VisibilityIndexerDLQ)
+	return ret0
+}
+
+// GetVisibilityIndexerDLQ indicates an expected call of GetVisibilityIndexerDLQ
+func (mr *MockBeanMockRecorder) GetVisibilityIndexerDLQ() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVisibilityIndexerDLQ", reflect.TypeOf((*MockBean)(nil).GetVisibilityIndexerDLQ))
+}
+
+// SetVisibilityIndexerDLQ mocks base method
+func (m *MockBean) SetVisibilityIndexerDLQ(arg0 persistence.VisibilityIndexerDLQ) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVisibilityIndexerDLQ", arg0)
+}
+
+// SetVisibilityIndexerDLQ indicates an expected call of SetVisibilityIndexerDLQ
+func (mr *MockBeanMockRecorder) SetVisibilityIndexerDLQ(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVisibilityIndexerDLQ", reflect.TypeOf((*MockBean)(nil).SetVisibilityIndexerDLQ), arg0)
+}
+
 // GetShardManager mocks base method
 func (m *MockBean) GetShardManager() persistence.ShardManager {
 	m.ctrl.T.Helper()
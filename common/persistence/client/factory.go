@@ -58,6 +58,8 @@ type (
 		NewVisibilityManager() (p.VisibilityManager, error)
 		// NewNamespaceReplicationQueue returns a new queue for namespace replication
 		NewNamespaceReplicationQueue() (p.NamespaceReplicationQueue, error)
+		// NewVisibilityIndexerDLQ returns a new dead-letter queue for rejected visibility requests
+		NewVisibilityIndexerDLQ() (p.VisibilityIndexerDLQ, error)
 		// NewClusterMetadata returns a new manager for cluster specific metadata
 		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 	}
@@ -303,6 +305,22 @@ func (f *factoryImpl) NewNamespaceReplicationQueue() (p.NamespaceReplicationQueu
 	return p.NewNamespaceReplicationQueue(result, f.clusterName, f.metricsClient, f.logger), nil
 }
 
+func (f *factoryImpl) NewVisibilityIndexerDLQ() (p.VisibilityIndexerDLQ, error) {
+	ds := f.datastores[storeTypeQueue]
+	result, err := ds.factory.NewQueue(p.VisibilityIndexerQueueType)
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+
+	return p.NewVisibilityIndexerDLQ(result, f.metricsClient, f.logger), nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	ds := f.datastores[storeTypeExecution]
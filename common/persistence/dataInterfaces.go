@@ -67,6 +67,7 @@ type QueueType int
 // Negative numbers are reserved for DLQ
 const (
 	NamespaceReplicationQueueType QueueType = iota + 1
+	VisibilityIndexerQueueType
 )
 
 // Create Workflow Execution Mode
@@ -263,6 +264,12 @@ type (
 		BranchToken            []byte
 		// Cron
 		CronSchedule string
+		// Pause
+		Paused        bool
+		PauseIdentity string
+		PauseReason   string
+		// Replication
+		Unreplicated bool
 	}
 
 	// ExecutionStats is the statistics about workflow execution
@@ -402,6 +409,13 @@ type (
 		Version int64
 	}
 
+	// DeleteExecutionTask identifies a transfer task for deleting a workflow execution
+	DeleteExecutionTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
 	// StartChildExecutionTask identifies a transfer task for starting child execution
 	StartChildExecutionTask struct {
 		VisibilityTimestamp time.Time
@@ -874,6 +888,34 @@ type (
 	// GetReplicationTasksFromDLQResponse is the response for GetReplicationTasksFromDLQ
 	GetReplicationTasksFromDLQResponse = GetReplicationTasksResponse
 
+	// PutHistoryTaskToDLQRequest is used to put a transfer or timer task which keeps failing to the DLQ of the shard,
+	// the ID of the task is used as the message ID
+	PutHistoryTaskToDLQRequest struct {
+		Message *persistenceblobs.HistoryTaskDLQMessage
+	}
+
+	// GetHistoryTasksFromDLQRequest is used to get transfer or timer tasks from the DLQ of the shard
+	GetHistoryTasksFromDLQRequest struct {
+		Category      enumsgenpb.DeadLetterQueueType
+		ReadLevel     int64
+		MaxReadLevel  int64
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetHistoryTasksFromDLQResponse is the response for GetHistoryTasksFromDLQ
+	GetHistoryTasksFromDLQResponse struct {
+		Messages      []*persistenceblobs.HistoryTaskDLQMessage
+		NextPageToken []byte
+	}
+
+	// RangeDeleteHistoryTaskFromDLQRequest is used to delete transfer or timer tasks from the DLQ of the shard
+	RangeDeleteHistoryTaskFromDLQRequest struct {
+		Category                enumsgenpb.DeadLetterQueueType
+		ExclusiveBeginMessageID int64
+		InclusiveEndMessageID   int64
+	}
+
 	// RangeCompleteTimerTaskRequest is used to complete a range of tasks in the timer task queue
 	RangeCompleteTimerTaskRequest struct {
 		InclusiveBeginTimestamp time.Time
@@ -1376,6 +1418,11 @@ type (
 		DeleteReplicationTaskFromDLQ(request *DeleteReplicationTaskFromDLQRequest) error
 		RangeDeleteReplicationTaskFromDLQ(request *RangeDeleteReplicationTaskFromDLQRequest) error
 
+		// Transfer and timer task DLQ related methods
+		PutHistoryTaskToDLQ(request *PutHistoryTaskToDLQRequest) error
+		GetHistoryTasksFromDLQ(request *GetHistoryTasksFromDLQRequest) (*GetHistoryTasksFromDLQResponse, error)
+		RangeDeleteHistoryTaskFromDLQ(request *RangeDeleteHistoryTaskFromDLQRequest) error
+
 		// Timer related methods.
 		GetTimerTask(request *GetTimerTaskRequest) (*GetTimerTaskResponse, error)
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
@@ -2038,6 +2085,41 @@ func (u *UpsertWorkflowSearchAttributesTask) SetVisibilityTimestamp(timestamp ti
 	u.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the delete execution transfer task
+func (u *DeleteExecutionTask) GetType() enumsgenpb.TaskType {
+	return enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION
+}
+
+// GetVersion returns the version of the delete execution transfer task
+func (u *DeleteExecutionTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion returns the version of the delete execution transfer task
+func (u *DeleteExecutionTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the delete execution transfer task.
+func (u *DeleteExecutionTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the delete execution transfer task.
+func (u *DeleteExecutionTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (u *DeleteExecutionTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (u *DeleteExecutionTask) SetVisibilityTimestamp(timestamp time.Time) {
+	u.VisibilityTimestamp = timestamp
+}
+
 // GetType returns the type of the start child transfer task
 func (u *StartChildExecutionTask) GetType() enumsgenpb.TaskType {
 	return enumsgenpb.TASK_TYPE_TRANSFER_START_CHILD_EXECUTION
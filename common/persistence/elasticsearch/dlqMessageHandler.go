@@ -0,0 +1,231 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../../LICENSE -package $GOPACKAGE -source $GOFILE -destination dlqMessageHandler_mock.go
+
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/olivere/elastic/v7"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// DLQMessageHandler is the interface handles visibility indexer DLQ messages
+	DLQMessageHandler interface {
+		Read(lastMessageID int64, pageSize int, pageToken []byte) ([]*indexergenpb.DLQMessage, []byte, error)
+		Purge(lastMessageID int64) error
+		Merge(ctx context.Context, lastMessageID int64, pageSize int, pageToken []byte) ([]byte, error)
+	}
+
+	dlqMessageHandlerImpl struct {
+		esClient es.Client
+		dlq      p.VisibilityIndexerDLQ
+		logger   log.Logger
+	}
+)
+
+// NewDLQMessageHandler returns a DLQMessageHandler instance
+func NewDLQMessageHandler(
+	esClient es.Client,
+	dlq p.VisibilityIndexerDLQ,
+	logger log.Logger,
+) DLQMessageHandler {
+	return &dlqMessageHandlerImpl{
+		esClient: esClient,
+		dlq:      dlq,
+		logger:   logger,
+	}
+}
+
+// Read reads visibility indexer DLQ messages
+func (d *dlqMessageHandlerImpl) Read(
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]*indexergenpb.DLQMessage, []byte, error) {
+
+	ackLevel, err := d.dlq.GetAckLevel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d.dlq.GetMessages(
+		ackLevel,
+		lastMessageID,
+		pageSize,
+		pageToken,
+	)
+}
+
+// Purge purges visibility indexer DLQ messages
+func (d *dlqMessageHandlerImpl) Purge(
+	lastMessageID int64,
+) error {
+
+	ackLevel, err := d.dlq.GetAckLevel()
+	if err != nil {
+		return err
+	}
+
+	if err := d.dlq.RangeDeleteMessages(
+		ackLevel,
+		lastMessageID,
+	); err != nil {
+		return err
+	}
+
+	if err := d.dlq.UpdateAckLevel(
+		lastMessageID,
+	); err != nil {
+		d.logger.Error("Failed to update visibility DLQ ack level after purging messages", tag.Error(err))
+	}
+
+	return nil
+}
+
+// Merge replays visibility indexer DLQ messages to ElasticSearch
+func (d *dlqMessageHandlerImpl) Merge(
+	ctx context.Context,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]byte, error) {
+
+	ackLevel, err := d.dlq.GetAckLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	messages, token, err := d.dlq.GetMessages(
+		ackLevel,
+		lastMessageID,
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return token, nil
+	}
+
+	bulk := d.esClient.Bulk()
+	for _, message := range messages {
+		request, err := toBulkableRequest(message)
+		if err != nil {
+			return nil, err
+		}
+		bulk.Add(request)
+	}
+	response, err := bulk.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// bulk requests are not atomic, messages before the first failed one are applied and can be acked
+	ackedMessageID, mergeErr := getLastMergedMessageID(ackLevel, messages, response)
+	if ackedMessageID > ackLevel {
+		if err := d.dlq.RangeDeleteMessages(
+			ackLevel,
+			ackedMessageID,
+		); err != nil {
+			d.logger.Error("failed to delete merged messages on merging visibility DLQ message", tag.Error(err))
+			return nil, err
+		}
+		if err := d.dlq.UpdateAckLevel(ackedMessageID); err != nil {
+			d.logger.Error("failed to update ack level on merging visibility DLQ message", tag.Error(err))
+		}
+	}
+	if mergeErr != nil {
+		d.logger.Error("failed to merge visibility DLQ message", tag.Error(mergeErr))
+		return nil, mergeErr
+	}
+
+	return token, nil
+}
+
+// getLastMergedMessageID returns the ID of the last message before the first one ElasticSearch failed to apply,
+// along with the failure if there is one
+func getLastMergedMessageID(
+	ackLevel int64,
+	messages []*indexergenpb.DLQMessage,
+	response *elastic.BulkResponse,
+) (int64, error) {
+
+	lastMessageID := ackLevel
+	for i, message := range messages {
+		if response == nil || i >= len(response.Items) {
+			return lastMessageID, fmt.Errorf("no bulk response item for DLQ message %v", message.GetMessageId())
+		}
+		for _, item := range response.Items[i] {
+			if !isBulkResponseItemSuccess(item.Status) {
+				var reason string
+				if item.Error != nil {
+					reason = item.Error.Reason
+				}
+				return lastMessageID, fmt.Errorf("failed to merge DLQ message %v, status: %v, reason: %v",
+					message.GetMessageId(), item.Status, reason)
+			}
+		}
+		lastMessageID = message.GetMessageId()
+	}
+	return lastMessageID, nil
+}
+
+// 409 - Version Conflict, a newer version of the document is already indexed
+// 404 - Not Found, the document to delete is already gone
+func isBulkResponseItemSuccess(status int) bool {
+	return status >= 200 && status < 300 || status == 409 || status == 404
+}
+
+func toBulkableRequest(message *indexergenpb.DLQMessage) (*es.BulkableRequest, error) {
+	request := &es.BulkableRequest{
+		Index:   message.GetIndex(),
+		ID:      message.GetDocId(),
+		Version: message.GetVersion(),
+	}
+	switch message.GetMessageType() {
+	case enumsgenpb.MESSAGE_TYPE_INDEX:
+		request.RequestType = es.BulkableIndexRequest
+		if err := json.Unmarshal(message.GetDoc(), &request.Doc); err != nil {
+			return nil, fmt.Errorf("failed to decode document of DLQ message %v: %v", message.GetMessageId(), err)
+		}
+	case enumsgenpb.MESSAGE_TYPE_DELETE:
+		request.RequestType = es.BulkableDeleteRequest
+	default:
+		return nil, fmt.Errorf("unknown message type %v of DLQ message %v", message.GetMessageType(), message.GetMessageId())
+	}
+	return request, nil
+}
@@ -0,0 +1,254 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/olivere/elastic/v7"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+	esMocks "github.com/temporalio/temporal/common/elasticsearch/mocks"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	dlqMessageHandlerSuite struct {
+		suite.Suite
+
+		*require.Assertions
+		controller *gomock.Controller
+
+		mockDLQ           *p.MockVisibilityIndexerDLQ
+		mockESClient      *esMocks.Client
+		mockBulkService   *esMocks.BulkService
+		dlqMessageHandler *dlqMessageHandlerImpl
+	}
+)
+
+func TestDLQMessageHandlerSuite(t *testing.T) {
+	suite.Run(t, new(dlqMessageHandlerSuite))
+}
+
+func (s *dlqMessageHandlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.mockDLQ = p.NewMockVisibilityIndexerDLQ(s.controller)
+	s.mockESClient = &esMocks.Client{}
+	s.mockBulkService = &esMocks.BulkService{}
+
+	s.dlqMessageHandler = NewDLQMessageHandler(
+		s.mockESClient,
+		s.mockDLQ,
+		loggerimpl.NewNopLogger(),
+	).(*dlqMessageHandlerImpl)
+}
+
+func (s *dlqMessageHandlerSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockESClient.AssertExpectations(s.T())
+	s.mockBulkService.AssertExpectations(s.T())
+}
+
+func (s *dlqMessageHandlerSuite) TestReadMessages() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+	pageSize := 100
+	pageToken := []byte{}
+	messages := []*indexergenpb.DLQMessage{{MessageId: 11}}
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().GetMessages(ackLevel, lastMessageID, pageSize, pageToken).Return(messages, nil, nil).Times(1)
+
+	result, token, err := s.dlqMessageHandler.Read(lastMessageID, pageSize, pageToken)
+	s.NoError(err)
+	s.Nil(token)
+	s.Equal(messages, result)
+}
+
+func (s *dlqMessageHandlerSuite) TestPurgeMessages() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().RangeDeleteMessages(ackLevel, lastMessageID).Return(nil).Times(1)
+	s.mockDLQ.EXPECT().UpdateAckLevel(lastMessageID).Return(nil).Times(1)
+
+	s.NoError(s.dlqMessageHandler.Purge(lastMessageID))
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeMessages() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+	pageSize := 100
+	pageToken := []byte{}
+	messages := []*indexergenpb.DLQMessage{
+		{
+			MessageId:   11,
+			MessageType: enumsgenpb.MESSAGE_TYPE_INDEX,
+			Index:       "test-index",
+			DocId:       "wid~rid",
+			Version:     3,
+			Doc:         []byte(`{"WorkflowID":"wid"}`),
+		},
+		{
+			MessageId:   12,
+			MessageType: enumsgenpb.MESSAGE_TYPE_DELETE,
+			Index:       "test-index",
+			DocId:       "wid2~rid2",
+			Version:     4,
+		},
+	}
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().GetMessages(ackLevel, lastMessageID, pageSize, pageToken).Return(messages, nil, nil).Times(1)
+	s.mockESClient.On("Bulk").Return(s.mockBulkService).Once()
+	s.mockBulkService.On("Add", &es.BulkableRequest{
+		RequestType: es.BulkableIndexRequest,
+		Index:       "test-index",
+		ID:          "wid~rid",
+		Version:     3,
+		Doc:         map[string]interface{}{"WorkflowID": "wid"},
+	}).Once()
+	s.mockBulkService.On("Add", &es.BulkableRequest{
+		RequestType: es.BulkableDeleteRequest,
+		Index:       "test-index",
+		ID:          "wid2~rid2",
+		Version:     4,
+	}).Once()
+	s.mockBulkService.On("Do", mock.Anything).Return(newBulkResponse(200, 404), nil).Once()
+	s.mockDLQ.EXPECT().RangeDeleteMessages(ackLevel, int64(12)).Return(nil).Times(1)
+	s.mockDLQ.EXPECT().UpdateAckLevel(int64(12)).Return(nil).Times(1)
+
+	token, err := s.dlqMessageHandler.Merge(context.Background(), lastMessageID, pageSize, pageToken)
+	s.NoError(err)
+	s.Nil(token)
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeMessages_ThrowErrorOnBulk() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+	pageSize := 100
+	messages := []*indexergenpb.DLQMessage{
+		{
+			MessageId:   11,
+			MessageType: enumsgenpb.MESSAGE_TYPE_DELETE,
+			Index:       "test-index",
+			DocId:       "wid~rid",
+		},
+	}
+	testError := errors.New("test")
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().GetMessages(ackLevel, lastMessageID, pageSize, gomock.Any()).Return(messages, nil, nil).Times(1)
+	s.mockESClient.On("Bulk").Return(s.mockBulkService).Once()
+	s.mockBulkService.On("Add", mock.Anything).Once()
+	s.mockBulkService.On("Do", mock.Anything).Return(nil, testError).Once()
+	s.mockDLQ.EXPECT().RangeDeleteMessages(gomock.Any(), gomock.Any()).Times(0)
+	s.mockDLQ.EXPECT().UpdateAckLevel(gomock.Any()).Times(0)
+
+	token, err := s.dlqMessageHandler.Merge(context.Background(), lastMessageID, pageSize, nil)
+	s.Equal(testError, err)
+	s.Nil(token)
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeMessages_ItemFailed() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+	pageSize := 100
+	messages := []*indexergenpb.DLQMessage{
+		{MessageId: 11, MessageType: enumsgenpb.MESSAGE_TYPE_DELETE, Index: "test-index", DocId: "wid1~rid1"},
+		{MessageId: 12, MessageType: enumsgenpb.MESSAGE_TYPE_DELETE, Index: "test-index", DocId: "wid2~rid2"},
+		{MessageId: 13, MessageType: enumsgenpb.MESSAGE_TYPE_DELETE, Index: "test-index", DocId: "wid3~rid3"},
+	}
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().GetMessages(ackLevel, lastMessageID, pageSize, gomock.Any()).Return(messages, nil, nil).Times(1)
+	s.mockESClient.On("Bulk").Return(s.mockBulkService).Once()
+	s.mockBulkService.On("Add", mock.Anything).Times(3)
+	s.mockBulkService.On("Do", mock.Anything).Return(newBulkResponse(200, 400, 200), nil).Once()
+	s.mockDLQ.EXPECT().RangeDeleteMessages(ackLevel, int64(11)).Return(nil).Times(1)
+	s.mockDLQ.EXPECT().UpdateAckLevel(int64(11)).Return(nil).Times(1)
+
+	token, err := s.dlqMessageHandler.Merge(context.Background(), lastMessageID, pageSize, nil)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeMessages_FirstItemFailed() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+	pageSize := 100
+	messages := []*indexergenpb.DLQMessage{
+		{MessageId: 11, MessageType: enumsgenpb.MESSAGE_TYPE_DELETE, Index: "test-index", DocId: "wid1~rid1"},
+	}
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().GetMessages(ackLevel, lastMessageID, pageSize, gomock.Any()).Return(messages, nil, nil).Times(1)
+	s.mockESClient.On("Bulk").Return(s.mockBulkService).Once()
+	s.mockBulkService.On("Add", mock.Anything).Once()
+	s.mockBulkService.On("Do", mock.Anything).Return(newBulkResponse(429), nil).Once()
+	s.mockDLQ.EXPECT().RangeDeleteMessages(gomock.Any(), gomock.Any()).Times(0)
+	s.mockDLQ.EXPECT().UpdateAckLevel(gomock.Any()).Times(0)
+
+	token, err := s.dlqMessageHandler.Merge(context.Background(), lastMessageID, pageSize, nil)
+	s.Error(err)
+	s.Nil(token)
+}
+
+func (s *dlqMessageHandlerSuite) TestMergeMessages_EmptyPage() {
+	ackLevel := int64(10)
+	lastMessageID := int64(20)
+	pageSize := 100
+
+	s.mockDLQ.EXPECT().GetAckLevel().Return(ackLevel, nil).Times(1)
+	s.mockDLQ.EXPECT().GetMessages(ackLevel, lastMessageID, pageSize, gomock.Any()).Return(nil, nil, nil).Times(1)
+
+	token, err := s.dlqMessageHandler.Merge(context.Background(), lastMessageID, pageSize, nil)
+	s.NoError(err)
+	s.Nil(token)
+}
+
+func newBulkResponse(statuses ...int) *elastic.BulkResponse {
+	response := &elastic.BulkResponse{}
+	for _, status := range statuses {
+		response.Items = append(response.Items, map[string]*elastic.BulkResponseItem{
+			"delete": {Status: status},
+		})
+		if status >= 300 {
+			response.Errors = true
+		}
+	}
+	return response
+}
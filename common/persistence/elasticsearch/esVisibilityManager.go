@@ -37,10 +37,10 @@ import (
 // NewESVisibilityManager create a visibility manager for ElasticSearch
 // In history, it only needs kafka producer for writing data;
 // In frontend, it only needs ES client and related config for reading data
-func NewESVisibilityManager(indexName string, esClient es.Client, config *config.VisibilityConfig,
+func NewESVisibilityManager(indexName string, esClient es.Client, indexResolver NamespaceIndexResolver, config *config.VisibilityConfig,
 	producer messaging.Producer, metricsClient metrics.Client, log log.Logger) p.VisibilityManager {
 
-	visibilityFromESStore := NewElasticSearchVisibilityStore(esClient, indexName, producer, config, log)
+	visibilityFromESStore := NewElasticSearchVisibilityStore(esClient, indexName, indexResolver, producer, config, log)
 	visibilityFromES := p.NewVisibilityManagerImpl(visibilityFromESStore, log)
 
 	if config != nil {
@@ -38,8 +38,9 @@ import (
 	"time"
 
 	"github.com/cch123/elasticsql"
-	"github.com/olivere/elastic"
+	"github.com/olivere/elastic/v7"
 	"github.com/valyala/fastjson"
+	"github.com/xwb1989/sqlparser"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
@@ -62,11 +63,12 @@ const (
 
 type (
 	esVisibilityStore struct {
-		esClient es.Client
-		index    string
-		producer messaging.Producer
-		logger   log.Logger
-		config   *config.VisibilityConfig
+		esClient      es.Client
+		index         string
+		indexResolver NamespaceIndexResolver
+		producer      messaging.Producer
+		logger        log.Logger
+		config        *config.VisibilityConfig
 	}
 
 	esVisibilityPageToken struct {
@@ -102,13 +104,16 @@ var (
 )
 
 // NewElasticSearchVisibilityStore create a visibility store connecting to ElasticSearch
-func NewElasticSearchVisibilityStore(esClient es.Client, index string, producer messaging.Producer, config *config.VisibilityConfig, logger log.Logger) p.VisibilityStore {
+// indexResolver is optional, when it is nil all namespaces are read from the default index
+func NewElasticSearchVisibilityStore(esClient es.Client, index string, indexResolver NamespaceIndexResolver, producer messaging.Producer,
+	config *config.VisibilityConfig, logger log.Logger) p.VisibilityStore {
 	return &esVisibilityStore{
-		esClient: esClient,
-		index:    index,
-		producer: producer,
-		logger:   logger.WithTags(tag.ComponentESVisibilityManager),
-		config:   config,
+		esClient:      esClient,
+		index:         index,
+		indexResolver: indexResolver,
+		producer:      producer,
+		logger:        logger.WithTags(tag.ComponentESVisibilityManager),
+		config:        config,
 	}
 }
 
@@ -346,9 +351,14 @@ func (v *esVisibilityStore) GetClosedWorkflowExecution(
 		boolQuery = boolQuery.Must(matchRunIDQuery)
 	}
 
+	index, err := v.getIndex(request.NamespaceID)
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetClosedWorkflowExecution failed. Error: %v", err))
+	}
+
 	ctx := context.Background()
 	params := &es.SearchParameters{
-		Index: v.index,
+		Index: index,
 		Query: boolQuery,
 	}
 	searchResult, err := v.esClient.Search(ctx, params)
@@ -372,6 +382,7 @@ func (v *esVisibilityStore) DeleteWorkflowExecution(request *p.VisibilityDeleteW
 		request.NamespaceID,
 		request.WorkflowID,
 		request.RunID,
+		request.StartTimestamp,
 		request.TaskID,
 	)
 	return v.producer.Publish(msg)
@@ -392,8 +403,13 @@ func (v *esVisibilityStore) ListWorkflowExecutions(
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Error when parse query: %v", err))
 	}
 
+	index, err := v.getIndex(request.NamespaceID)
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("ListWorkflowExecutions failed. Error: %v", err))
+	}
+
 	ctx := context.Background()
-	searchResult, err := v.esClient.SearchWithDSL(ctx, v.index, queryDSL)
+	searchResult, err := v.esClient.SearchWithDSL(ctx, index, queryDSL)
 	if err != nil {
 		return nil, serviceerror.NewInternal(fmt.Sprintf("ListWorkflowExecutions failed. Error: %v", err))
 	}
@@ -420,7 +436,12 @@ func (v *esVisibilityStore) ScanWorkflowExecutions(
 		if err != nil {
 			return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Error when parse query: %v", err))
 		}
-		searchResult, scrollService, err = v.esClient.ScrollFirstPage(ctx, v.index, queryDSL)
+		var index string
+		index, err = v.getIndex(request.NamespaceID)
+		if err != nil {
+			return nil, serviceerror.NewInternal(fmt.Sprintf("ScanWorkflowExecutions failed. Error: %v", err))
+		}
+		searchResult, scrollService, err = v.esClient.ScrollFirstPage(ctx, index, queryDSL)
 	} else {
 		searchResult, scrollService, err = v.esClient.Scroll(ctx, token.ScrollID)
 	}
@@ -439,13 +460,28 @@ func (v *esVisibilityStore) ScanWorkflowExecutions(
 func (v *esVisibilityStore) CountWorkflowExecutions(request *p.CountWorkflowExecutionsRequest) (
 	*p.CountWorkflowExecutionsResponse, error) {
 
+	query, groupBy, err := splitGroupByFromCountQuery(request.Query)
+	if err != nil {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Error when parse query: %v", err))
+	}
+	if len(groupBy) != 0 {
+		countRequest := *request
+		countRequest.Query = query
+		return v.countWorkflowExecutionsGroupBy(&countRequest, groupBy)
+	}
+
 	queryDSL, err := getESQueryDSLForCount(request)
 	if err != nil {
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Error when parse query: %v", err))
 	}
 
+	index, err := v.getIndex(request.NamespaceID)
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("CountWorkflowExecutions failed. Error: %v", err))
+	}
+
 	ctx := context.Background()
-	count, err := v.esClient.Count(ctx, v.index, queryDSL)
+	count, err := v.esClient.Count(ctx, index, queryDSL)
 	if err != nil {
 		return nil, serviceerror.NewInternal(fmt.Sprintf("CountWorkflowExecutions failed. Error: %v", err))
 	}
@@ -454,6 +490,60 @@ func (v *esVisibilityStore) CountWorkflowExecutions(request *p.CountWorkflowExec
 	return response, nil
 }
 
+// countWorkflowExecutionsGroupBy pages through composite aggregation buckets and returns count per group,
+// the request fails once the number of groups exceeds ESCountGroupByMaxGroups
+func (v *esVisibilityStore) countWorkflowExecutionsGroupBy(request *p.CountWorkflowExecutionsRequest, groupBy []string) (
+	*p.CountWorkflowExecutionsResponse, error) {
+
+	index, err := v.getIndex(request.NamespaceID)
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("CountWorkflowExecutions failed. Error: %v", err))
+	}
+
+	response := &p.CountWorkflowExecutionsResponse{}
+	for _, field := range groupBy {
+		response.GroupBy = append(response.GroupBy, strings.TrimPrefix(field, definition.Attr+"."))
+	}
+
+	maxGroups := v.config.ESCountGroupByMaxGroups()
+	ctx := context.Background()
+	var afterKey map[string]interface{}
+	for {
+		queryDSL, err := getESQueryDSLForGroupByCount(request, groupBy, afterKey)
+		if err != nil {
+			return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Error when parse query: %v", err))
+		}
+
+		searchResult, err := v.esClient.SearchWithDSL(ctx, index, queryDSL)
+		if err != nil {
+			return nil, serviceerror.NewInternal(fmt.Sprintf("CountWorkflowExecutions failed. Error: %v", err))
+		}
+
+		items, ok := searchResult.Aggregations.Composite(dslAggGroupBy)
+		if !ok {
+			break
+		}
+		if len(response.Groups)+len(items.Buckets) > maxGroups {
+			return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Too many groups, group by result is limited to %d groups.", maxGroups))
+		}
+		for _, bucket := range items.Buckets {
+			values := make([]string, len(groupBy))
+			for i, field := range groupBy {
+				values[i] = groupByValueToString(field, bucket.Key[field])
+			}
+			response.Groups = append(response.Groups, &p.CountWorkflowExecutionsGroup{Values: values, Count: bucket.DocCount})
+			response.Count += bucket.DocCount
+		}
+
+		if len(items.Buckets) < countGroupByBucketSize || len(items.AfterKey) == 0 {
+			break
+		}
+		afterKey = items.AfterKey
+	}
+
+	return response, nil
+}
+
 const (
 	jsonMissingCloseTime     = `{"missing":{"field":"CloseTime"}}`
 	jsonRangeOnExecutionTime = `{"range":{"ExecutionTime":`
@@ -464,6 +554,10 @@ const (
 	dslFieldSearchAfter = "search_after"
 	dslFieldFrom        = "from"
 	dslFieldSize        = "size"
+	dslFieldAggs        = "aggs"
+	dslAggGroupBy       = "groupby"
+
+	countGroupByBucketSize = 1000
 
 	defaultDateTimeFormat = time.RFC3339 // used for converting UnixNano to string like 2018-02-15T16:16:36-08:00
 )
@@ -510,6 +604,87 @@ func getESQueryDSLForCount(request *p.CountWorkflowExecutionsRequest) (string, e
 	return dsl.String(), nil
 }
 
+func getESQueryDSLForGroupByCount(request *p.CountWorkflowExecutionsRequest, groupBy []string, afterKey map[string]interface{}) (string, error) {
+	dslStr, err := getESQueryDSLForCount(request)
+	if err != nil {
+		return "", err
+	}
+	dsl, err := fastjson.Parse(dslStr)
+	if err != nil {
+		return "", err
+	}
+
+	sources := make([]elastic.CompositeAggregationValuesSource, 0, len(groupBy))
+	for _, field := range groupBy {
+		sources = append(sources, elastic.NewCompositeAggregationTermsValuesSource(field).Field(field).MissingBucket(true))
+	}
+	agg := elastic.NewCompositeAggregation().Size(countGroupByBucketSize).Sources(sources...)
+	if len(afterKey) != 0 {
+		agg = agg.AggregateAfter(afterKey)
+	}
+	aggSource, err := agg.Source()
+	if err != nil {
+		return "", err
+	}
+	aggJSON, err := json.Marshal(map[string]interface{}{dslAggGroupBy: aggSource})
+	if err != nil {
+		return "", err
+	}
+	aggs, err := fastjson.ParseBytes(aggJSON)
+	if err != nil {
+		return "", err
+	}
+
+	dsl.Set(dslFieldAggs, aggs)
+	dsl.Set(dslFieldSize, fastjson.MustParse("0"))
+	return dsl.String(), nil
+}
+
+// splitGroupByFromCountQuery separates group by fields from the rest of count query,
+// order by is dropped as it doesn't affect count
+func splitGroupByFromCountQuery(query string) (string, []string, error) {
+	if !strings.Contains(strings.ToLower(query), "group") {
+		return query, nil, nil
+	}
+
+	sql := getSQLFromCountRequest(&p.CountWorkflowExecutionsRequest{Query: query})
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return "", nil, err
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.GroupBy) == 0 {
+		return query, nil, nil
+	}
+
+	var groupBy []string
+	for _, expr := range sel.GroupBy {
+		colName, ok := expr.(*sqlparser.ColName)
+		if !ok {
+			return "", nil, errors.New("invalid group by expression")
+		}
+		groupBy = append(groupBy, colName.Name.String())
+	}
+
+	buf := sqlparser.NewTrackedBuffer(nil)
+	if sel.Where != nil {
+		sel.Where.Expr.Format(buf)
+	}
+	return buf.String(), groupBy, nil
+}
+
+func groupByValueToString(field string, value interface{}) string {
+	if value == nil { // missing bucket
+		return ""
+	}
+	if field == definition.ExecutionStatus {
+		if status, err := strconv.Atoi(fmt.Sprint(value)); err == nil {
+			return enumspb.WorkflowExecutionStatus(status).String()
+		}
+	}
+	return fmt.Sprint(value)
+}
+
 func (v *esVisibilityStore) getESQueryDSL(request *p.ListWorkflowExecutionsRequestV2, token *esVisibilityPageToken) (string, error) {
 	sql := getSQLFromListRequest(request)
 	dsl, err := getCustomizedDSLFromSQL(sql, request.NamespaceID)
@@ -552,8 +727,11 @@ func getSQLFromListRequest(request *p.ListWorkflowExecutionsRequestV2) string {
 
 func getSQLFromCountRequest(request *p.CountWorkflowExecutionsRequest) string {
 	var sql string
-	if strings.TrimSpace(request.Query) == "" {
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
 		sql = "select * from dummy"
+	} else if common.IsJustOrderByClause(query) || common.IsJustGroupByClause(query) {
+		sql = fmt.Sprintf("select * from dummy %s", request.Query)
 	} else {
 		sql = fmt.Sprintf("select * from dummy where %s", request.Query)
 	}
@@ -756,9 +934,14 @@ func (v *esVisibilityStore) getSearchResult(request *p.ListWorkflowExecutionsReq
 		boolQuery = boolQuery.Must(existExecutionStatusQuery)
 	}
 
+	index, err := v.getIndex(request.NamespaceID)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
 	params := &es.SearchParameters{
-		Index:    v.index,
+		Index:    index,
 		Query:    boolQuery,
 		From:     token.From,
 		PageSize: request.PageSize,
@@ -826,7 +1009,7 @@ func (v *esVisibilityStore) getListWorkflowExecutionsResponse(searchHits *elasti
 
 		// ES Search API support pagination using From and PageSize, but has limit that From+PageSize cannot exceed a threshold
 		// to retrieve deeper pages, use ES SearchAfter
-		if searchHits.TotalHits <= int64(v.config.ESIndexMaxResultWindow()-pageSize) { // use ES Search From+Size
+		if getTotalHits(searchHits) <= int64(v.config.ESIndexMaxResultWindow()-pageSize) { // use ES Search From+Size
 			nextPageToken, err = v.serializePageToken(&esVisibilityPageToken{From: token.From + numOfActualHits})
 		} else { // use ES Search After
 			var sortVal interface{}
@@ -868,7 +1051,7 @@ func (v *esVisibilityStore) serializePageToken(token *esVisibilityPageToken) ([]
 
 func (v *esVisibilityStore) convertSearchResultToVisibilityRecord(hit *elastic.SearchHit) *p.VisibilityWorkflowExecutionInfo {
 	var source *visibilityRecord
-	err := json.Unmarshal(*hit.Source, &source)
+	err := json.Unmarshal(hit.Source, &source)
 	if err != nil { // log and skip error
 		v.logger.Error("unable to unmarshal search hit source",
 			tag.Error(err), tag.ESDocID(hit.Id))
@@ -964,7 +1147,7 @@ func getVisibilityMessageForCloseExecution(namespaceID string, wid, rid string,
 	return msg
 }
 
-func getVisibilityMessageForDeletion(namespaceID, workflowID, runID string, docVersion int64) *indexergenpb.Message {
+func getVisibilityMessageForDeletion(namespaceID, workflowID, runID string, startTimeUnixNano int64, docVersion int64) *indexergenpb.Message {
 	msgType := enumsgenpb.MESSAGE_TYPE_DELETE
 	msg := &indexergenpb.Message{
 		MessageType: msgType,
@@ -973,6 +1156,12 @@ func getVisibilityMessageForDeletion(namespaceID, workflowID, runID string, docV
 		RunId:       runID,
 		Version:     docVersion,
 	}
+	// start time is needed by indexer to locate the rollover index of the document
+	if startTimeUnixNano != 0 {
+		msg.Fields = map[string]*indexergenpb.Field{
+			es.StartTime: {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: startTimeUnixNano}},
+		}
+	}
 	return msg
 }
 
@@ -982,6 +1171,14 @@ func checkPageSize(request *p.ListWorkflowExecutionsRequestV2) {
 	}
 }
 
+// getTotalHits returns math.MaxInt64 when the total is not tracked, so callers fall back to ES Search After
+func getTotalHits(searchHits *elastic.SearchHits) int64 {
+	if searchHits.TotalHits == nil {
+		return math.MaxInt64
+	}
+	return searchHits.TotalHits.Value
+}
+
 func processAllValuesForKey(dsl *fastjson.Value, keyFilter func(k string) bool,
 	processFunc func(obj *fastjson.Object, key string, v *fastjson.Value) error,
 ) error {
@@ -1050,3 +1247,18 @@ func cleanDSL(input string) string {
 	result := re.ReplaceAllString(input, `$2`)
 	return result
 }
+
+// getIndex returns the index (or alias) which holds visibility records of the namespace
+func (v *esVisibilityStore) getIndex(namespaceID string) (string, error) {
+	if v.indexResolver == nil {
+		return v.index, nil
+	}
+	index, err := v.indexResolver.GetNamespaceIndex(namespaceID)
+	if err != nil {
+		return "", err
+	}
+	if len(index) == 0 {
+		return v.index, nil
+	}
+	return index, nil
+}
@@ -33,8 +33,9 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/olivere/elastic"
+	"github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -109,12 +110,13 @@ func (s *ESVisibilitySuite) SetupTest() {
 
 	s.mockESClient = &esMocks.Client{}
 	config := &config.VisibilityConfig{
-		ESIndexMaxResultWindow: dynamicconfig.GetIntPropertyFn(3),
-		ValidSearchAttributes:  dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+		ESIndexMaxResultWindow:  dynamicconfig.GetIntPropertyFn(3),
+		ESCountGroupByMaxGroups: dynamicconfig.GetIntPropertyFn(3),
+		ValidSearchAttributes:   dynamicconfig.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
 	}
 
 	s.mockProducer = &mocks.KafkaProducer{}
-	mgr := NewElasticSearchVisibilityStore(s.mockESClient, testIndex, s.mockProducer, config, loggerimpl.NewNopLogger())
+	mgr := NewElasticSearchVisibilityStore(s.mockESClient, testIndex, nil, s.mockProducer, config, loggerimpl.NewNopLogger())
 	s.visibilityStore = mgr.(*esVisibilityStore)
 }
 
@@ -510,7 +512,7 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 	token := &esVisibilityPageToken{From: 0}
 
 	// test for empty hits
-	searchHits := &elastic.SearchHits{}
+	searchHits := &elastic.SearchHits{TotalHits: &elastic.TotalHits{}}
 	resp, err := s.visibilityStore.getListWorkflowExecutionsResponse(searchHits, token, 1, nil)
 	s.NoError(err)
 	s.Equal(0, len(resp.NextPageToken))
@@ -526,7 +528,7 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
           "StartTime": 1547596872371000000,
           "WorkflowId": "6bfbc1e5-6ce4-4e22-bbfb-e0faa9a7a604-1-2256",
           "WorkflowType": "basic.stressWorkflowExecute"}`)
-	source := (*json.RawMessage)(&data)
+	source := json.RawMessage(data)
 	searchHit := &elastic.SearchHit{
 		Source: source,
 		Sort:   []interface{}{1547596872371000000, "e481009e-14b3-45ae-91af-dce6e2a88365"},
@@ -547,8 +549,8 @@ func (s *ESVisibilitySuite) TestGetListWorkflowExecutionsResponse() {
 	// test for search after
 	token = &esVisibilityPageToken{}
 	searchHits.Hits = []*elastic.SearchHit{}
-	searchHits.TotalHits = int64(s.visibilityStore.config.ESIndexMaxResultWindow() + 1)
-	for i := int64(0); i < searchHits.TotalHits; i++ {
+	searchHits.TotalHits.Value = int64(s.visibilityStore.config.ESIndexMaxResultWindow() + 1)
+	for i := int64(0); i < searchHits.TotalHits.Value; i++ {
 		searchHits.Hits = append(searchHits.Hits, searchHit)
 	}
 	numOfHits := len(searchHits.Hits)
@@ -635,7 +637,7 @@ func (s *ESVisibilitySuite) TestConvertSearchResultToVisibilityRecord() {
           "StartTime": 1547596872371000000,
           "WorkflowId": "6bfbc1e5-6ce4-4e22-bbfb-e0faa9a7a604-1-2256",
           "WorkflowType": "TestWorkflowExecute"}`)
-	source := (*json.RawMessage)(&data)
+	source := json.RawMessage(data)
 	searchHit := &elastic.SearchHit{
 		Source: source,
 	}
@@ -661,7 +663,7 @@ func (s *ESVisibilitySuite) TestConvertSearchResultToVisibilityRecord() {
 
 	// test for error case
 	badData := []byte(`corrupted data`)
-	source = (*json.RawMessage)(&badData)
+	source = json.RawMessage(badData)
 	searchHit = &elastic.SearchHit{
 		Source: source,
 	}
@@ -960,6 +962,117 @@ func (s *ESVisibilitySuite) TestCountWorkflowExecutions() {
 	s.True(strings.Contains(err.Error(), "Error when parse query"))
 }
 
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupBy() {
+	aggs := elastic.Aggregations{
+		dslAggGroupBy: json.RawMessage(`{"buckets":[{"key":{"ExecutionStatus":1,"Attr.CustomKeywordField":"a"},"doc_count":3},{"key":{"ExecutionStatus":2,"Attr.CustomKeywordField":null},"doc_count":2}]}`),
+	}
+	s.mockESClient.On("SearchWithDSL", mock.Anything, testIndex, mock.MatchedBy(func(input string) bool {
+		s.True(strings.Contains(input, `{"match_phrase":{"WorkflowType":{"query":"wt"}}}`))
+		s.True(strings.Contains(input, `"aggs":{"groupby":{"composite":{"size":1000,"sources":[{"ExecutionStatus":{"terms":{"field":"ExecutionStatus","missing_bucket":true}}},{"Attr.CustomKeywordField":{"terms":{"field":"Attr.CustomKeywordField","missing_bucket":true}}}]}}}`))
+		s.True(strings.Contains(input, `"size":0`))
+		return true
+	})).Return(&elastic.SearchResult{Aggregations: aggs}, nil).Once()
+
+	request := &p.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		Query:       "WorkflowType = 'wt' group by ExecutionStatus, `Attr.CustomKeywordField`",
+	}
+	resp, err := s.visibilityStore.CountWorkflowExecutions(request)
+	s.NoError(err)
+	s.Equal(int64(5), resp.Count)
+	s.Equal([]string{definition.ExecutionStatus, definition.CustomKeywordField}, resp.GroupBy)
+	s.Equal([]*p.CountWorkflowExecutionsGroup{
+		{Values: []string{enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String(), "a"}, Count: 3},
+		{Values: []string{enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED.String(), ""}, Count: 2},
+	}, resp.Groups)
+
+	// test internal error
+	s.mockESClient.On("SearchWithDSL", mock.Anything, testIndex, mock.Anything).Return(nil, errTestESSearch).Once()
+	_, err = s.visibilityStore.CountWorkflowExecutions(request)
+	s.Error(err)
+	_, ok := err.(*serviceerror.Internal)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), "CountWorkflowExecutions failed"))
+}
+
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupByTooManyGroups() {
+	aggs := elastic.Aggregations{
+		dslAggGroupBy: json.RawMessage(`{"buckets":[{"key":{"WorkflowType":"a"},"doc_count":1},{"key":{"WorkflowType":"b"},"doc_count":1},{"key":{"WorkflowType":"c"},"doc_count":1},{"key":{"WorkflowType":"d"},"doc_count":1}]}`),
+	}
+	s.mockESClient.On("SearchWithDSL", mock.Anything, testIndex, mock.Anything).Return(&elastic.SearchResult{Aggregations: aggs}, nil).Once()
+
+	request := &p.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		Query:       "group by WorkflowType",
+	}
+	_, err := s.visibilityStore.CountWorkflowExecutions(request)
+	s.Error(err)
+	_, ok := err.(*serviceerror.InvalidArgument)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), "Too many groups"))
+}
+
+func (s *ESVisibilitySuite) TestSplitGroupByFromCountQuery() {
+	query, groupBy, err := splitGroupByFromCountQuery("WorkflowId = 'wid'")
+	s.NoError(err)
+	s.Equal("WorkflowId = 'wid'", query)
+	s.Nil(groupBy)
+
+	query, groupBy, err = splitGroupByFromCountQuery("group by ExecutionStatus")
+	s.NoError(err)
+	s.Equal("", query)
+	s.Equal([]string{"ExecutionStatus"}, groupBy)
+
+	query, groupBy, err = splitGroupByFromCountQuery("WorkflowId = 'wid' group by `Attr.CustomKeywordField` order by StartTime desc")
+	s.NoError(err)
+	s.Equal("WorkflowId = 'wid'", query)
+	s.Equal([]string{"Attr.CustomKeywordField"}, groupBy)
+
+	_, _, err = splitGroupByFromCountQuery("WorkflowId = 'wid' group by 1")
+	s.Error(err)
+}
+
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_NamespaceIndex() {
+	s.visibilityStore.indexResolver = testIndexResolver{testNamespaceID: "test-namespace-index"}
+	s.mockESClient.On("Count", mock.Anything, "test-namespace-index", mock.Anything).Return(int64(1), nil).Once()
+	s.mockESClient.On("Count", mock.Anything, testIndex, mock.Anything).Return(int64(2), nil).Once()
+
+	resp, err := s.visibilityStore.CountWorkflowExecutions(&p.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+	})
+	s.NoError(err)
+	s.Equal(int64(1), resp.Count)
+
+	// namespaces without their own index are read from default index
+	resp, err = s.visibilityStore.CountWorkflowExecutions(&p.CountWorkflowExecutionsRequest{
+		NamespaceID: "other-namespace-id",
+		Namespace:   "other-namespace",
+	})
+	s.NoError(err)
+	s.Equal(int64(2), resp.Count)
+}
+
+func (s *ESVisibilitySuite) TestDeleteWorkflowExecution() {
+	s.mockProducer.On("Publish", mock.MatchedBy(func(input *indexergenpb.Message) bool {
+		s.Equal(enumsgenpb.MESSAGE_TYPE_DELETE, input.GetMessageType())
+		s.Equal(testRunID, input.GetRunId())
+		s.Equal(testEarliestTime, input.GetFields()[es.StartTime].GetIntData())
+		return true
+	})).Return(nil).Once()
+
+	err := s.visibilityStore.DeleteWorkflowExecution(&p.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID:    testNamespaceID,
+		WorkflowID:     testWorkflowID,
+		RunID:          testRunID,
+		TaskID:         1,
+		StartTimestamp: testEarliestTime,
+	})
+	s.NoError(err)
+}
+
 func (s *ESVisibilitySuite) TestTimeProcessFunc() {
 	cases := []struct {
 		key   string
@@ -1120,3 +1233,13 @@ func (s *ESVisibilitySuite) TestCleanDSL() {
 	expected = `{"query":{"bool":{"must":[{"match_phrase":{"NamespaceId":{"query":"2b8344db-0ed6-47a4-92fd-bdeb6ead93e3"}}},{"bool":{"must":[{"range":{"Attr.CustomIntField":{"from":"1","to":"5"}}},{"range":{"Attr.CustomDoubleField":{"from":"1.0","to":"2.0"}}},{"range":{"StartTime":{"gt":"0"}}}]}}]}},"from":0,"size":10,"sort":[{"StartTime":"desc"},{"RunId":"desc"}]}`
 	s.Equal(expected, res)
 }
+
+type testIndexResolver map[string]string
+
+func (r testIndexResolver) GetNamespaceIndex(namespaceID string) (string, error) {
+	return r[namespaceID], nil
+}
+
+func (r testIndexResolver) GetNamespaceIndexRolloverInterval(namespaceID string) (time.Duration, error) {
+	return es.DefaultRolloverInterval, nil
+}
@@ -0,0 +1,110 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/cache"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	namespaceIndexCacheMaxSize = 10000
+	namespaceIndexCacheTTL     = time.Minute
+)
+
+type (
+	// NamespaceIndexResolver resolves the ElasticSearch index (or alias) holding visibility records of a namespace
+	NamespaceIndexResolver interface {
+		// GetNamespaceIndex returns the index of the namespace, or empty string if the namespace uses the default index
+		GetNamespaceIndex(namespaceID string) (string, error)
+		// GetNamespaceIndexRolloverInterval returns the rollover interval pinned on the index of the namespace
+		GetNamespaceIndexRolloverInterval(namespaceID string) (time.Duration, error)
+	}
+
+	namespaceIndexResolverImpl struct {
+		metadataMgr p.MetadataManager
+		cache       cache.Cache
+	}
+
+	namespaceIndex struct {
+		index            string
+		rolloverInterval time.Duration
+	}
+)
+
+var _ NamespaceIndexResolver = (*namespaceIndexResolverImpl)(nil)
+
+// NewNamespaceIndexResolver creates a NamespaceIndexResolver which reads the index from namespace config
+func NewNamespaceIndexResolver(metadataMgr p.MetadataManager) NamespaceIndexResolver {
+	return &namespaceIndexResolverImpl{
+		metadataMgr: metadataMgr,
+		cache: cache.New(namespaceIndexCacheMaxSize, &cache.Options{
+			TTL: namespaceIndexCacheTTL,
+		}),
+	}
+}
+
+func (r *namespaceIndexResolverImpl) GetNamespaceIndex(namespaceID string) (string, error) {
+	index, err := r.getNamespaceIndex(namespaceID)
+	if err != nil {
+		return "", err
+	}
+	return index.index, nil
+}
+
+func (r *namespaceIndexResolverImpl) GetNamespaceIndexRolloverInterval(namespaceID string) (time.Duration, error) {
+	index, err := r.getNamespaceIndex(namespaceID)
+	if err != nil {
+		return 0, err
+	}
+	return index.rolloverInterval, nil
+}
+
+func (r *namespaceIndexResolverImpl) getNamespaceIndex(namespaceID string) (*namespaceIndex, error) {
+	if index, ok := r.cache.Get(namespaceID).(*namespaceIndex); ok {
+		return index, nil
+	}
+
+	resp, err := r.metadataMgr.GetNamespace(&p.GetNamespaceRequest{ID: namespaceID})
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			return &namespaceIndex{}, nil
+		}
+		return nil, err
+	}
+
+	config := resp.Namespace.GetConfig()
+	index := &namespaceIndex{
+		index:            config.GetVisibilityIndex(),
+		rolloverInterval: es.GetRolloverInterval(config.GetVisibilityIndexRolloverIntervalSeconds()),
+	}
+	r.cache.Put(namespaceID, index)
+	return index, nil
+}
@@ -0,0 +1,120 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package elasticsearch
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/mocks"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	namespaceIndexResolverSuite struct {
+		suite.Suite
+		mockMetadataMgr *mocks.MetadataManager
+		resolver        NamespaceIndexResolver
+	}
+)
+
+func TestNamespaceIndexResolverSuite(t *testing.T) {
+	suite.Run(t, new(namespaceIndexResolverSuite))
+}
+
+func (s *namespaceIndexResolverSuite) SetupTest() {
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.resolver = NewNamespaceIndexResolver(s.mockMetadataMgr)
+}
+
+func (s *namespaceIndexResolverSuite) TearDownTest() {
+	s.mockMetadataMgr.AssertExpectations(s.T())
+}
+
+func (s *namespaceIndexResolverSuite) TestGetNamespaceIndex_Cached() {
+	s.mockMetadataMgr.On("GetNamespace", &p.GetNamespaceRequest{ID: "ns-id"}).Return(&p.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Config: &persistenceblobs.NamespaceConfig{VisibilityIndex: "temporal-visibility-ns"},
+		},
+	}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		index, err := s.resolver.GetNamespaceIndex("ns-id")
+		s.NoError(err)
+		s.Equal("temporal-visibility-ns", index)
+	}
+}
+
+func (s *namespaceIndexResolverSuite) TestGetNamespaceIndex_DefaultIndex() {
+	s.mockMetadataMgr.On("GetNamespace", &p.GetNamespaceRequest{ID: "ns-id"}).Return(&p.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{Config: &persistenceblobs.NamespaceConfig{}},
+	}, nil).Once()
+	s.mockMetadataMgr.On("GetNamespace", &p.GetNamespaceRequest{ID: "deleted-ns-id"}).
+		Return(nil, serviceerror.NewNotFound("namespace not found")).Once()
+
+	index, err := s.resolver.GetNamespaceIndex("ns-id")
+	s.NoError(err)
+	s.Empty(index)
+	index, err = s.resolver.GetNamespaceIndex("deleted-ns-id")
+	s.NoError(err)
+	s.Empty(index)
+}
+
+func (s *namespaceIndexResolverSuite) TestGetNamespaceIndexRolloverInterval() {
+	s.mockMetadataMgr.On("GetNamespace", &p.GetNamespaceRequest{ID: "ns-id"}).Return(&p.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Config: &persistenceblobs.NamespaceConfig{
+				VisibilityIndex:                        "temporal-visibility-ns",
+				VisibilityIndexRolloverIntervalSeconds: 3600,
+			},
+		},
+	}, nil).Once()
+	s.mockMetadataMgr.On("GetNamespace", &p.GetNamespaceRequest{ID: "unpinned-ns-id"}).Return(&p.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Config: &persistenceblobs.NamespaceConfig{VisibilityIndex: "temporal-visibility-ns"},
+		},
+	}, nil).Once()
+
+	interval, err := s.resolver.GetNamespaceIndexRolloverInterval("ns-id")
+	s.NoError(err)
+	s.Equal(time.Hour, interval)
+	interval, err = s.resolver.GetNamespaceIndexRolloverInterval("unpinned-ns-id")
+	s.NoError(err)
+	s.Equal(es.DefaultRolloverInterval, interval)
+}
+
+func (s *namespaceIndexResolverSuite) TestGetNamespaceIndex_Error() {
+	s.mockMetadataMgr.On("GetNamespace", mock.Anything).Return(nil, errors.New("some error")).Once()
+
+	_, err := s.resolver.GetNamespaceIndex("ns-id")
+	s.Error(err)
+}
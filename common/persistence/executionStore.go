@@ -182,6 +182,10 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 		AutoResetPoints:                    autoResetPoints,
 		SearchAttributes:                   info.SearchAttributes,
 		Memo:                               info.Memo,
+		Paused:                             info.Paused,
+		PauseIdentity:                      info.PauseIdentity,
+		PauseReason:                        info.PauseReason,
+		Unreplicated:                       info.Unreplicated,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -499,6 +503,10 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		CronSchedule:                       info.CronSchedule,
 		Memo:                               info.Memo,
 		SearchAttributes:                   info.SearchAttributes,
+		Paused:                             info.Paused,
+		PauseIdentity:                      info.PauseIdentity,
+		PauseReason:                        info.PauseReason,
+		Unreplicated:                       info.Unreplicated,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
@@ -875,6 +883,24 @@ func (m *executionManagerImpl) RangeDeleteReplicationTaskFromDLQ(
 	return m.persistence.RangeDeleteReplicationTaskFromDLQ(request)
 }
 
+func (m *executionManagerImpl) PutHistoryTaskToDLQ(
+	request *PutHistoryTaskToDLQRequest,
+) error {
+	return m.persistence.PutHistoryTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetHistoryTasksFromDLQ(
+	request *GetHistoryTasksFromDLQRequest,
+) (*GetHistoryTasksFromDLQResponse, error) {
+	return m.persistence.GetHistoryTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) RangeDeleteHistoryTaskFromDLQ(
+	request *RangeDeleteHistoryTaskFromDLQRequest,
+) error {
+	return m.persistence.RangeDeleteHistoryTaskFromDLQ(request)
+}
+
 // Timer related methods.
 func (m *executionManagerImpl) GetTimerTask(
 	request *GetTimerTaskRequest,
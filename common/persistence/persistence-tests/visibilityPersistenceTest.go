@@ -700,21 +700,6 @@ func (s *VisibilityPersistenceSuite) TestUpsertWorkflowExecution() {
 			},
 			expected: nil,
 		},
-		{
-			request: &p.UpsertWorkflowExecutionRequest{
-				NamespaceID:        "",
-				Namespace:          "",
-				Execution:          commonpb.WorkflowExecution{},
-				WorkflowTypeName:   "",
-				StartTimestamp:     0,
-				ExecutionTimestamp: 0,
-				WorkflowTimeout:    0,
-				TaskID:             0,
-				Memo:               nil,
-				SearchAttributes:   nil,
-			},
-			expected: p.NewOperationNotSupportErrorForVis(),
-		},
 	}
 
 	for _, test := range tests {
@@ -722,6 +707,54 @@ func (s *VisibilityPersistenceSuite) TestUpsertWorkflowExecution() {
 	}
 }
 
+// TestUpsertWorkflowExecutionMemo test
+func (s *VisibilityPersistenceSuite) TestUpsertWorkflowExecutionMemo() {
+	testNamespaceUUID := uuid.New()
+
+	workflowExecution := commonpb.WorkflowExecution{
+		WorkflowId: "visibility-upsert-memo-test",
+		RunId:      "2dd29ab7-2dd8-4668-83e0-89cae261cfb1",
+	}
+
+	startTime := time.Now().Add(time.Second * -5).UnixNano()
+	startReq := &p.RecordWorkflowExecutionStartedRequest{
+		NamespaceID:      testNamespaceUUID,
+		Execution:        workflowExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		RunTimeout:       3600,
+		Memo: &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+			"existing key": payload.EncodeString("existing value"),
+		}},
+	}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(startReq)
+	s.Nil(err0)
+
+	upsertMemo := &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+		"existing key": payload.EncodeString("existing value"),
+		"upserted key": payload.EncodeString("upserted value"),
+	}}
+	err1 := s.VisibilityMgr.UpsertWorkflowExecution(&p.UpsertWorkflowExecutionRequest{
+		NamespaceID:      testNamespaceUUID,
+		Execution:        workflowExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		WorkflowTimeout:  3600,
+		Memo:             upsertMemo,
+	})
+	s.Nil(err1)
+
+	resp, err2 := s.VisibilityMgr.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{
+		NamespaceID:       testNamespaceUUID,
+		PageSize:          1,
+		EarliestStartTime: startTime,
+		LatestStartTime:   startTime,
+	})
+	s.Nil(err2)
+	s.Equal(1, len(resp.Executions))
+	s.Equal(upsertMemo, resp.Executions[0].GetMemo())
+}
+
 func (s *VisibilityPersistenceSuite) assertClosedExecutionEquals(
 	req *p.RecordWorkflowExecutionClosedRequest, resp *workflowpb.WorkflowExecutionInfo) {
 	s.Equal(req.Execution.RunId, resp.Execution.RunId)
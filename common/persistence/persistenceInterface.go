@@ -113,6 +113,11 @@ type (
 		DeleteReplicationTaskFromDLQ(request *DeleteReplicationTaskFromDLQRequest) error
 		RangeDeleteReplicationTaskFromDLQ(request *RangeDeleteReplicationTaskFromDLQRequest) error
 
+		// Transfer and timer task DLQ related methods
+		PutHistoryTaskToDLQ(request *PutHistoryTaskToDLQRequest) error
+		GetHistoryTasksFromDLQ(request *GetHistoryTasksFromDLQRequest) (*GetHistoryTasksFromDLQResponse, error)
+		RangeDeleteHistoryTaskFromDLQ(request *RangeDeleteHistoryTaskFromDLQRequest) error
+
 		// Timer related methods.
 		GetTimerTask(request *GetTimerTaskRequest) (*GetTimerTaskResponse, error)
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
@@ -261,6 +266,10 @@ type (
 		CronSchedule           string
 		Memo                   map[string]*commonpb.Payload
 		SearchAttributes       map[string]*commonpb.Payload
+		Paused                 bool
+		PauseIdentity          string
+		PauseReason            string
+		Unreplicated           bool
 
 		// attributes which are not related to mutable state at all
 		HistorySize int64
@@ -820,6 +829,14 @@ func InternalWorkflowExecutionInfoToProto(executionInfo *InternalWorkflowExecuti
 		info.CancelRequested = true
 		info.CancelRequestId = executionInfo.CancelRequestID
 	}
+
+	if executionInfo.Paused {
+		info.Paused = true
+		info.PauseIdentity = executionInfo.PauseIdentity
+		info.PauseReason = executionInfo.PauseReason
+	}
+
+	info.Unreplicated = executionInfo.Unreplicated
 	return info, state, nil
 }
 
@@ -890,6 +907,14 @@ func ProtoWorkflowExecutionToPartialInternalExecution(info *persistenceblobs.Wor
 		executionInfo.CancelRequestID = info.GetCancelRequestId()
 	}
 
+	if info.GetPaused() {
+		executionInfo.Paused = true
+		executionInfo.PauseIdentity = info.GetPauseIdentity()
+		executionInfo.PauseReason = info.GetPauseReason()
+	}
+
+	executionInfo.Unreplicated = info.GetUnreplicated()
+
 	executionInfo.CompletionEventBatchID = info.CompletionEventBatchId
 
 	if info.CompletionEvent != nil {
@@ -245,6 +245,15 @@ func ReplicationTaskInfoFromBlob(b []byte, proto string) (*persistenceblobs.Repl
 	return result, proto3Decode(b, proto, result)
 }
 
+func HistoryTaskDLQMessageToBlob(message *persistenceblobs.HistoryTaskDLQMessage) (DataBlob, error) {
+	return proto3Encode(message)
+}
+
+func HistoryTaskDLQMessageFromBlob(b []byte, proto string) (*persistenceblobs.HistoryTaskDLQMessage, error) {
+	result := &persistenceblobs.HistoryTaskDLQMessage{}
+	return result, proto3Decode(b, proto, result)
+}
+
 func ReplicationVersionsToBlob(info *persistenceblobs.ReplicationVersions) (DataBlob, error) {
 	return proto3Encode(info)
 }
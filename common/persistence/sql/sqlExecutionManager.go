@@ -995,6 +995,83 @@ func (m *sqlExecutionManager) RangeDeleteReplicationTaskFromDLQ(
 	return nil
 }
 
+func (m *sqlExecutionManager) PutHistoryTaskToDLQ(
+	request *p.PutHistoryTaskToDLQRequest,
+) error {
+
+	blob, err := serialization.HistoryTaskDLQMessageToBlob(request.Message)
+	if err != nil {
+		return err
+	}
+
+	row := &sqlplugin.HistoryTaskDLQRow{
+		ShardID:      m.shardID,
+		Category:     int32(request.Message.GetType()),
+		MessageID:    request.Message.GetMessageId(),
+		Data:         blob.Data,
+		DataEncoding: string(blob.Encoding),
+	}
+
+	// Message ID is the task ID, so a task published again after a retry is already persisted.
+	if _, err := m.db.InsertIntoHistoryTaskDLQ(row); err != nil && !m.db.IsDupEntryError(err) {
+		return serviceerror.NewInternal(fmt.Sprintf("PutHistoryTaskToDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetHistoryTasksFromDLQ(
+	request *p.GetHistoryTasksFromDLQRequest,
+) (*p.GetHistoryTasksFromDLQResponse, error) {
+
+	readLevel := request.ReadLevel
+	if len(request.NextPageToken) > 0 {
+		var err error
+		readLevel, err = deserializePageToken(request.NextPageToken)
+		if err != nil {
+			return nil, serviceerror.NewInternal(fmt.Sprintf("GetHistoryTasksFromDLQ operation failed. Error: %v", err))
+		}
+	}
+
+	rows, err := m.db.SelectFromHistoryTaskDLQ(&sqlplugin.HistoryTaskDLQFilter{
+		ShardID:      m.shardID,
+		Category:     int32(request.Category),
+		MinMessageID: readLevel,
+		MaxMessageID: request.MaxReadLevel,
+		PageSize:     request.BatchSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetHistoryTasksFromDLQ operation failed. Select failed: %v", err))
+	}
+
+	response := &p.GetHistoryTasksFromDLQResponse{}
+	for _, row := range rows {
+		message, err := serialization.HistoryTaskDLQMessageFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		response.Messages = append(response.Messages, message)
+	}
+	if len(rows) == request.BatchSize && len(rows) > 0 {
+		response.NextPageToken = serializePageToken(rows[len(rows)-1].MessageID)
+	}
+	return response, nil
+}
+
+func (m *sqlExecutionManager) RangeDeleteHistoryTaskFromDLQ(
+	request *p.RangeDeleteHistoryTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.RangeDeleteFromHistoryTaskDLQ(&sqlplugin.HistoryTaskDLQFilter{
+		ShardID:      m.shardID,
+		Category:     int32(request.Category),
+		MinMessageID: request.ExclusiveBeginMessageID,
+		MaxMessageID: request.InclusiveEndMessageID,
+	}); err != nil {
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteHistoryTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
 type timerTaskPageToken struct {
 	TaskID    int64
 	Timestamp time.Time
@@ -740,7 +740,8 @@ func createTransferTasks(
 		case enumsgenpb.TASK_TYPE_TRANSFER_CLOSE_EXECUTION,
 			enumsgenpb.TASK_TYPE_TRANSFER_RECORD_WORKFLOW_STARTED,
 			enumsgenpb.TASK_TYPE_TRANSFER_RESET_WORKFLOW,
-			enumsgenpb.TASK_TYPE_TRANSFER_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES:
+			enumsgenpb.TASK_TYPE_TRANSFER_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES,
+			enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION:
 			// No explicit property needs to be set
 
 		default:
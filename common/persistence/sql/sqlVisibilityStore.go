@@ -109,11 +109,18 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionClosed(request *p.InternalRe
 	return nil
 }
 
+// UpsertWorkflowExecution only updates the memo of the open record, search attributes are not indexed by this store
 func (s *sqlVisibilityStore) UpsertWorkflowExecution(request *p.InternalUpsertWorkflowExecutionRequest) error {
 	if p.IsNopUpsertWorkflowRequest(request) {
 		return nil
 	}
-	return p.NewOperationNotSupportErrorForVis()
+	_, err := s.db.UpdateVisibilityMemo(&sqlplugin.VisibilityRow{
+		NamespaceID: request.NamespaceID,
+		RunID:       request.RunID,
+		Memo:        request.Memo.Data,
+		Encoding:    string(request.Memo.GetEncoding()),
+	})
+	return err
 }
 
 func (s *sqlVisibilityStore) ListOpenWorkflowExecutions(request *p.ListWorkflowExecutionsRequest) (*p.InternalListWorkflowExecutionsResponse, error) {
@@ -287,6 +287,25 @@ type (
 		SourceClusterName string
 	}
 
+	// HistoryTaskDLQRow represents a row in history_task_dlq table
+	HistoryTaskDLQRow struct {
+		ShardID      int
+		Category     int32
+		MessageID    int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// HistoryTaskDLQFilter contains the column names within history_task_dlq table that
+	// can be used to filter results through a WHERE clause
+	HistoryTaskDLQFilter struct {
+		ShardID      int
+		Category     int32
+		MinMessageID int64
+		MaxMessageID int64
+		PageSize     int
+	}
+
 	// TimerTasksRow represents a row in timer_tasks table
 	TimerTasksRow struct {
 		ShardID             int
@@ -664,6 +683,14 @@ type (
 		// RangeDeleteMessageFromReplicationTasksDLQ deletes one or more rows from replication_tasks_dlq table
 		// Required filter params - {sourceClusterName, shardID, taskID, inclusiveTaskID}
 		RangeDeleteMessageFromReplicationTasksDLQ(filter *ReplicationTasksDLQFilter) (sql.Result, error)
+		// InsertIntoHistoryTaskDLQ puts the history task into DLQ
+		InsertIntoHistoryTaskDLQ(row *HistoryTaskDLQRow) (sql.Result, error)
+		// SelectFromHistoryTaskDLQ returns one or more rows from history_task_dlq table
+		// Required filter params - {shardID, category, minMessageID, maxMessageID, pageSize}
+		SelectFromHistoryTaskDLQ(filter *HistoryTaskDLQFilter) ([]HistoryTaskDLQRow, error)
+		// RangeDeleteFromHistoryTaskDLQ deletes one or more rows from history_task_dlq table
+		// Required filter params - {shardID, category, minMessageID, maxMessageID}
+		RangeDeleteFromHistoryTaskDLQ(filter *HistoryTaskDLQFilter) (sql.Result, error)
 
 		ReplaceIntoActivityInfoMaps(rows []ActivityInfoMapsRow) (sql.Result, error)
 		// SelectFromActivityInfoMaps returns one or more rows from activity_info_maps
@@ -730,6 +757,9 @@ type (
 		InsertIntoVisibility(row *VisibilityRow) (sql.Result, error)
 		// ReplaceIntoVisibility deletes old row (if it exist) and inserts new row into visibility table
 		ReplaceIntoVisibility(row *VisibilityRow) (sql.Result, error)
+		// UpdateVisibilityMemo updates the memo of an open row in visibility table, closed rows are left as such
+		// Required row params - {namespaceID, runID, memo, encoding}
+		UpdateVisibilityMemo(row *VisibilityRow) (sql.Result, error)
 		// SelectFromVisibility returns one or more rows from visibility table
 		// Required filter params:
 		// - getClosedWorkflowExecution - retrieves single row - {namespaceID, runID, closed=true}
@@ -156,6 +156,22 @@ VALUES     (:source_cluster_name,
 		AND shard_id = ? 
 		AND task_id > ?
 		AND task_id <= ?`
+
+	insertHistoryTaskDLQQuery = `INSERT INTO history_task_dlq (shard_id, category, message_id, data, data_encoding)
+VALUES (:shard_id, :category, :message_id, :data, :data_encoding)`
+
+	getHistoryTaskDLQQuery = `SELECT shard_id, category, message_id, data, data_encoding FROM history_task_dlq WHERE
+shard_id = ? AND
+category = ? AND
+message_id > ? AND
+message_id <= ?
+ORDER BY message_id LIMIT ?`
+
+	rangeDeleteHistoryTaskDLQQuery = `DELETE FROM history_task_dlq WHERE
+shard_id = ? AND
+category = ? AND
+message_id > ? AND
+message_id <= ?`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -381,3 +397,35 @@ func (mdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 		filter.InclusiveEndTaskID,
 	)
 }
+
+// InsertIntoHistoryTaskDLQ inserts a row into history_task_dlq table
+func (mdb *db) InsertIntoHistoryTaskDLQ(row *sqlplugin.HistoryTaskDLQRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(insertHistoryTaskDLQQuery, row)
+}
+
+// SelectFromHistoryTaskDLQ reads one or more rows from history_task_dlq table
+func (mdb *db) SelectFromHistoryTaskDLQ(filter *sqlplugin.HistoryTaskDLQFilter) ([]sqlplugin.HistoryTaskDLQRow, error) {
+	var rows []sqlplugin.HistoryTaskDLQRow
+	err := mdb.conn.Select(
+		&rows, getHistoryTaskDLQQuery,
+		filter.ShardID,
+		filter.Category,
+		filter.MinMessageID,
+		filter.MaxMessageID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromHistoryTaskDLQ deletes one or more rows from history_task_dlq table
+func (mdb *db) RangeDeleteFromHistoryTaskDLQ(
+	filter *sqlplugin.HistoryTaskDLQFilter,
+) (sql.Result, error) {
+
+	return mdb.conn.Exec(
+		rangeDeleteHistoryTaskDLQQuery,
+		filter.ShardID,
+		filter.Category,
+		filter.MinMessageID,
+		filter.MaxMessageID,
+	)
+}
@@ -75,6 +75,9 @@ const (
 		 AND run_id = ?`
 
 	templateDeleteWorkflowExecution = "DELETE FROM executions_visibility WHERE namespace_id=? AND run_id=?"
+
+	templateUpdateWorkflowExecutionMemo = `UPDATE executions_visibility SET memo = ?, encoding = ? ` +
+		`WHERE namespace_id = ? AND run_id = ? AND status IS NULL`
 )
 
 var errCloseParams = errors.New("missing one of {status, closeTime, historyLength} params")
@@ -117,6 +120,15 @@ func (mdb *db) ReplaceIntoVisibility(row *sqlplugin.VisibilityRow) (sql.Result,
 	}
 }
 
+// UpdateVisibilityMemo updates the memo of an open row in visibility table
+func (mdb *db) UpdateVisibilityMemo(row *sqlplugin.VisibilityRow) (sql.Result, error) {
+	return mdb.conn.Exec(templateUpdateWorkflowExecutionMemo,
+		row.Memo,
+		row.Encoding,
+		row.NamespaceID,
+		row.RunID)
+}
+
 // DeleteFromVisibility deletes a row from visibility table if it exist
 func (mdb *db) DeleteFromVisibility(filter *sqlplugin.VisibilityFilter) (sql.Result, error) {
 	return mdb.conn.Exec(templateDeleteWorkflowExecution, filter.NamespaceID, filter.RunID)
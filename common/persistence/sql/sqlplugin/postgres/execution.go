@@ -155,6 +155,22 @@ VALUES     (:source_cluster_name,
 		AND shard_id = $2 
 		AND task_id > $3
 		AND task_id <= $4`
+
+	insertHistoryTaskDLQQuery = `INSERT INTO history_task_dlq (shard_id, category, message_id, data, data_encoding)
+VALUES (:shard_id, :category, :message_id, :data, :data_encoding)`
+
+	getHistoryTaskDLQQuery = `SELECT shard_id, category, message_id, data, data_encoding FROM history_task_dlq WHERE
+shard_id = $1 AND
+category = $2 AND
+message_id > $3 AND
+message_id <= $4
+ORDER BY message_id LIMIT $5`
+
+	rangeDeleteHistoryTaskDLQQuery = `DELETE FROM history_task_dlq WHERE
+shard_id = $1 AND
+category = $2 AND
+message_id > $3 AND
+message_id <= $4`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -380,3 +396,35 @@ func (pdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 		filter.InclusiveEndTaskID,
 	)
 }
+
+// InsertIntoHistoryTaskDLQ inserts a row into history_task_dlq table
+func (pdb *db) InsertIntoHistoryTaskDLQ(row *sqlplugin.HistoryTaskDLQRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(insertHistoryTaskDLQQuery, row)
+}
+
+// SelectFromHistoryTaskDLQ reads one or more rows from history_task_dlq table
+func (pdb *db) SelectFromHistoryTaskDLQ(filter *sqlplugin.HistoryTaskDLQFilter) ([]sqlplugin.HistoryTaskDLQRow, error) {
+	var rows []sqlplugin.HistoryTaskDLQRow
+	err := pdb.conn.Select(
+		&rows, getHistoryTaskDLQQuery,
+		filter.ShardID,
+		filter.Category,
+		filter.MinMessageID,
+		filter.MaxMessageID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromHistoryTaskDLQ deletes one or more rows from history_task_dlq table
+func (pdb *db) RangeDeleteFromHistoryTaskDLQ(
+	filter *sqlplugin.HistoryTaskDLQFilter,
+) (sql.Result, error) {
+
+	return pdb.conn.Exec(
+		rangeDeleteHistoryTaskDLQQuery,
+		filter.ShardID,
+		filter.Category,
+		filter.MinMessageID,
+		filter.MaxMessageID,
+	)
+}
@@ -94,6 +94,9 @@ const (
 		 AND run_id = $2`
 
 	templateDeleteWorkflowExecution = "DELETE FROM executions_visibility WHERE namespace_id=$1 AND run_id=$2"
+
+	templateUpdateWorkflowExecutionMemo = `UPDATE executions_visibility SET memo = $1, encoding = $2 ` +
+		`WHERE namespace_id = $3 AND run_id = $4 AND status IS NULL`
 )
 
 var errCloseParams = errors.New("missing one of {status, closeTime, historyLength} params")
@@ -136,6 +139,15 @@ func (pdb *db) ReplaceIntoVisibility(row *sqlplugin.VisibilityRow) (sql.Result,
 	}
 }
 
+// UpdateVisibilityMemo updates the memo of an open row in visibility table
+func (pdb *db) UpdateVisibilityMemo(row *sqlplugin.VisibilityRow) (sql.Result, error) {
+	return pdb.conn.Exec(templateUpdateWorkflowExecutionMemo,
+		row.Memo,
+		row.Encoding,
+		row.NamespaceID,
+		row.RunID)
+}
+
 // DeleteFromVisibility deletes a row from visibility table if it exist
 func (pdb *db) DeleteFromVisibility(filter *sqlplugin.VisibilityFilter) (sql.Result, error) {
 	return pdb.conn.Exec(templateDeleteWorkflowExecution, filter.NamespaceID, filter.RunID)
@@ -0,0 +1,160 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination visibilityIndexerDLQ_mock.go -self_package github.com/temporalio/temporal/common/persistence
+
+package persistence
+
+import (
+	"fmt"
+
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+const (
+	localVisibilityIndexerCluster = "visibilityIndexer"
+)
+
+var _ VisibilityIndexerDLQ = (*visibilityIndexerDLQImpl)(nil)
+
+type (
+	visibilityIndexerDLQImpl struct {
+		queue         Queue
+		metricsClient metrics.Client
+		logger        log.Logger
+	}
+
+	// VisibilityIndexerDLQ is used to keep visibility requests rejected by ElasticSearch until they are purged or merged
+	VisibilityIndexerDLQ interface {
+		Publish(message *indexergenpb.DLQMessage) error
+		GetMessages(firstMessageID int64, lastMessageID int64, pageSize int, pageToken []byte) ([]*indexergenpb.DLQMessage, []byte, error)
+		RangeDeleteMessages(firstMessageID int64, lastMessageID int64) error
+		UpdateAckLevel(lastProcessedMessageID int64) error
+		GetAckLevel() (int64, error)
+	}
+)
+
+// NewVisibilityIndexerDLQ creates a new VisibilityIndexerDLQ instance
+func NewVisibilityIndexerDLQ(
+	queue Queue,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) VisibilityIndexerDLQ {
+	return &visibilityIndexerDLQImpl{
+		queue:         queue,
+		metricsClient: metricsClient,
+		logger:        logger,
+	}
+}
+
+func (q *visibilityIndexerDLQImpl) Publish(
+	message *indexergenpb.DLQMessage,
+) error {
+
+	bytes, err := message.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %v", err)
+	}
+	messageID, err := q.queue.EnqueueMessageToDLQ(bytes)
+	if err != nil {
+		return err
+	}
+
+	q.metricsClient.Scope(
+		metrics.PersistenceVisibilityIndexerDLQScope,
+	).UpdateGauge(
+		metrics.VisibilityIndexerDLQMaxLevelGauge,
+		float64(messageID),
+	)
+	return nil
+}
+
+func (q *visibilityIndexerDLQImpl) GetMessages(
+	firstMessageID int64,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]*indexergenpb.DLQMessage, []byte, error) {
+
+	messages, token, err := q.queue.ReadMessagesFromDLQ(firstMessageID, lastMessageID, pageSize, pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dlqMessages []*indexergenpb.DLQMessage
+	for _, message := range messages {
+		dlqMessage := &indexergenpb.DLQMessage{}
+		if err := dlqMessage.Unmarshal(message.Payload); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode dlq message: %v", err)
+		}
+
+		dlqMessage.MessageId = message.ID
+		dlqMessages = append(dlqMessages, dlqMessage)
+	}
+
+	return dlqMessages, token, nil
+}
+
+func (q *visibilityIndexerDLQImpl) RangeDeleteMessages(
+	firstMessageID int64,
+	lastMessageID int64,
+) error {
+
+	return q.queue.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID)
+}
+
+func (q *visibilityIndexerDLQImpl) UpdateAckLevel(
+	lastProcessedMessageID int64,
+) error {
+
+	if err := q.queue.UpdateDLQAckLevel(
+		lastProcessedMessageID,
+		localVisibilityIndexerCluster,
+	); err != nil {
+		return err
+	}
+
+	q.metricsClient.Scope(
+		metrics.PersistenceVisibilityIndexerDLQScope,
+	).UpdateGauge(
+		metrics.VisibilityIndexerDLQAckLevelGauge,
+		float64(lastProcessedMessageID),
+	)
+	return nil
+}
+
+func (q *visibilityIndexerDLQImpl) GetAckLevel() (int64, error) {
+	dlqMetadata, err := q.queue.GetDLQAckLevels()
+	if err != nil {
+		return emptyMessageID, err
+	}
+
+	ackLevel, ok := dlqMetadata[localVisibilityIndexerCluster]
+	if !ok {
+		return emptyMessageID, nil
+	}
+	return ackLevel, nil
+}
@@ -132,6 +132,15 @@ type (
 	// CountWorkflowExecutionsResponse is response to CountWorkflowExecutions
 	CountWorkflowExecutionsResponse struct {
 		Count int64
+		// GroupBy and Groups are only set when query contains GROUP BY clause
+		GroupBy []string
+		Groups  []*CountWorkflowExecutionsGroup
+	}
+
+	// CountWorkflowExecutionsGroup is the count of executions sharing the same group by values
+	CountWorkflowExecutionsGroup struct {
+		Values []string
+		Count  int64
 	}
 
 	// ListWorkflowExecutionsByTypeRequest is used to list executions of
@@ -169,10 +178,11 @@ type (
 
 	// VisibilityDeleteWorkflowExecutionRequest contains the request params for DeleteWorkflowExecution call
 	VisibilityDeleteWorkflowExecutionRequest struct {
-		NamespaceID string
-		RunID       string
-		WorkflowID  string
-		TaskID      int64
+		NamespaceID    string
+		RunID          string
+		WorkflowID     string
+		TaskID         int64
+		StartTimestamp int64
 	}
 
 	// VisibilityManager is used to manage the visibility store
@@ -38,6 +38,7 @@ import (
 	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -68,6 +69,7 @@ type (
 		PublicClient                 sdkclient.Client
 		ArchivalMetadata             archiver.ArchivalMetadata
 		ArchiverProvider             provider.ArchiverProvider
+		PayloadStoreProvider         payloadstore.Provider
 		Authorizer                   authorization.Authorizer
 	}
 
@@ -44,6 +44,7 @@ import (
 	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 )
@@ -68,6 +69,7 @@ type (
 		GetPayloadSerializer() persistence.PayloadSerializer
 		GetMetricsClient() metrics.Client
 		GetArchiverProvider() provider.ArchiverProvider
+		GetPayloadStoreProvider() payloadstore.Provider
 		GetMessagingClient() messaging.Client
 
 		// membership infos
@@ -97,6 +99,7 @@ type (
 		GetTaskManager() persistence.TaskManager
 		GetVisibilityManager() persistence.VisibilityManager
 		GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue
+		GetVisibilityIndexerDLQ() persistence.VisibilityIndexerDLQ
 		GetShardManager() persistence.ShardManager
 		GetHistoryManager() persistence.HistoryManager
 		GetExecutionManager(int) (persistence.ExecutionManager, error)
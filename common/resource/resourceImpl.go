@@ -52,6 +52,7 @@ import (
 	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
@@ -81,13 +82,14 @@ type (
 
 		// other common resources
 
-		namespaceCache    cache.NamespaceCache
-		timeSource        clock.TimeSource
-		payloadSerializer persistence.PayloadSerializer
-		metricsClient     metrics.Client
-		messagingClient   messaging.Client
-		archivalMetadata  archiver.ArchivalMetadata
-		archiverProvider  provider.ArchiverProvider
+		namespaceCache       cache.NamespaceCache
+		timeSource           clock.TimeSource
+		payloadSerializer    persistence.PayloadSerializer
+		metricsClient        metrics.Client
+		messagingClient      messaging.Client
+		archivalMetadata     archiver.ArchivalMetadata
+		archiverProvider     provider.ArchiverProvider
+		payloadStoreProvider payloadstore.Provider
 
 		// membership infos
 
@@ -265,7 +267,8 @@ func New(
 	)
 
 	historyArchiverBootstrapContainer := &archiver.HistoryBootstrapContainer{
-		HistoryV2Manager: persistenceBean.GetHistoryManager(),
+		// archives are read outside of the cluster, so offloaded payloads are archived inline
+		HistoryV2Manager: payloadstore.NewInflatingHistoryManager(persistenceBean.GetHistoryManager(), params.PayloadStoreProvider),
 		Logger:           logger,
 		MetricsClient:    params.MetricsClient,
 		ClusterMetadata:  params.ClusterMetadata,
@@ -298,13 +301,14 @@ func New(
 
 		// other common resources
 
-		namespaceCache:    namespaceCache,
-		timeSource:        clock.NewRealTimeSource(),
-		payloadSerializer: persistence.NewPayloadSerializer(),
-		metricsClient:     params.MetricsClient,
-		messagingClient:   params.MessagingClient,
-		archivalMetadata:  params.ArchivalMetadata,
-		archiverProvider:  params.ArchiverProvider,
+		namespaceCache:       namespaceCache,
+		timeSource:           clock.NewRealTimeSource(),
+		payloadSerializer:    persistence.NewPayloadSerializer(),
+		metricsClient:        params.MetricsClient,
+		messagingClient:      params.MessagingClient,
+		archivalMetadata:     params.ArchivalMetadata,
+		archiverProvider:     params.ArchiverProvider,
+		payloadStoreProvider: params.PayloadStoreProvider,
 
 		// membership infos
 
@@ -461,6 +465,11 @@ func (h *Impl) GetArchiverProvider() provider.ArchiverProvider {
 	return h.archiverProvider
 }
 
+// GetPayloadStoreProvider return payload store provider
+func (h *Impl) GetPayloadStoreProvider() payloadstore.Provider {
+	return h.payloadStoreProvider
+}
+
 // membership infos
 
 // GetMembershipMonitor return the membership monitor
@@ -573,6 +582,11 @@ func (h *Impl) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQu
 	return h.persistenceBean.GetNamespaceReplicationQueue()
 }
 
+// GetVisibilityIndexerDLQ return visibility indexer dead-letter queue
+func (h *Impl) GetVisibilityIndexerDLQ() persistence.VisibilityIndexerDLQ {
+	return h.persistenceBean.GetVisibilityIndexerDLQ()
+}
+
 // GetShardManager return shard manager
 func (h *Impl) GetShardManager() persistence.ShardManager {
 	return h.persistenceBean.GetShardManager()
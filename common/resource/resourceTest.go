@@ -55,6 +55,7 @@ import (
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 )
@@ -67,12 +68,13 @@ type (
 
 		// other common resources
 
-		NamespaceCache    *cache.MockNamespaceCache
-		TimeSource        clock.TimeSource
-		PayloadSerializer persistence.PayloadSerializer
-		MetricsClient     metrics.Client
-		ArchivalMetadata  *archiver.MockArchivalMetadata
-		ArchiverProvider  *provider.MockArchiverProvider
+		NamespaceCache       *cache.MockNamespaceCache
+		TimeSource           clock.TimeSource
+		PayloadSerializer    persistence.PayloadSerializer
+		MetricsClient        metrics.Client
+		ArchivalMetadata     *archiver.MockArchivalMetadata
+		ArchiverProvider     *provider.MockArchiverProvider
+		PayloadStoreProvider *payloadstore.MockProvider
 
 		// membership infos
 
@@ -98,6 +100,7 @@ type (
 		TaskMgr                   *mocks.TaskManager
 		VisibilityMgr             *mocks.VisibilityManager
 		NamespaceReplicationQueue persistence.NamespaceReplicationQueue
+		VisibilityIndexerDLQ      *persistence.MockVisibilityIndexerDLQ
 		ShardMgr                  *mocks.ShardManager
 		HistoryMgr                *mocks.HistoryV2Manager
 		ExecutionMgr              *mocks.ExecutionManager
@@ -150,6 +153,7 @@ func NewTest(
 	namespaceReplicationQueue := persistence.NewMockNamespaceReplicationQueue(controller)
 	namespaceReplicationQueue.EXPECT().Start().AnyTimes()
 	namespaceReplicationQueue.EXPECT().Stop().AnyTimes()
+	visibilityIndexerDLQ := persistence.NewMockVisibilityIndexerDLQ(controller)
 	persistenceBean := persistenceClient.NewMockBean(controller)
 	persistenceBean.EXPECT().GetMetadataManager().Return(metadataMgr).AnyTimes()
 	persistenceBean.EXPECT().GetTaskManager().Return(taskMgr).AnyTimes()
@@ -158,6 +162,7 @@ func NewTest(
 	persistenceBean.EXPECT().GetShardManager().Return(shardMgr).AnyTimes()
 	persistenceBean.EXPECT().GetExecutionManager(gomock.Any()).Return(executionMgr, nil).AnyTimes()
 	persistenceBean.EXPECT().GetNamespaceReplicationQueue().Return(namespaceReplicationQueue).AnyTimes()
+	persistenceBean.EXPECT().GetVisibilityIndexerDLQ().Return(visibilityIndexerDLQ).AnyTimes()
 
 	membershipMonitor := membership.NewMockMonitor(controller)
 	frontendServiceResolver := membership.NewMockServiceResolver(controller)
@@ -177,12 +182,13 @@ func NewTest(
 
 		// other common resources
 
-		NamespaceCache:    cache.NewMockNamespaceCache(controller),
-		TimeSource:        clock.NewRealTimeSource(),
-		PayloadSerializer: persistence.NewPayloadSerializer(),
-		MetricsClient:     metrics.NewClient(scope, serviceMetricsIndex),
-		ArchivalMetadata:  &archiver.MockArchivalMetadata{},
-		ArchiverProvider:  &provider.MockArchiverProvider{},
+		NamespaceCache:       cache.NewMockNamespaceCache(controller),
+		TimeSource:           clock.NewRealTimeSource(),
+		PayloadSerializer:    persistence.NewPayloadSerializer(),
+		MetricsClient:        metrics.NewClient(scope, serviceMetricsIndex),
+		ArchivalMetadata:     &archiver.MockArchivalMetadata{},
+		ArchiverProvider:     &provider.MockArchiverProvider{},
+		PayloadStoreProvider: payloadstore.NewMockProvider(controller),
 
 		// membership infos
 
@@ -208,6 +214,7 @@ func NewTest(
 		TaskMgr:                   taskMgr,
 		VisibilityMgr:             visibilityMgr,
 		NamespaceReplicationQueue: namespaceReplicationQueue,
+		VisibilityIndexerDLQ:      visibilityIndexerDLQ,
 		ShardMgr:                  shardMgr,
 		HistoryMgr:                historyMgr,
 		ExecutionMgr:              executionMgr,
@@ -288,6 +295,11 @@ func (s *Test) GetArchiverProvider() provider.ArchiverProvider {
 	return s.ArchiverProvider
 }
 
+// GetPayloadStoreProvider for testing
+func (s *Test) GetPayloadStoreProvider() payloadstore.Provider {
+	return s.PayloadStoreProvider
+}
+
 // membership infos
 
 // GetMembershipMonitor for testing
@@ -396,6 +408,11 @@ func (s *Test) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQu
 	return s.NamespaceReplicationQueue
 }
 
+// GetVisibilityIndexerDLQ for testing
+func (s *Test) GetVisibilityIndexerDLQ() persistence.VisibilityIndexerDLQ {
+	return s.VisibilityIndexerDLQ
+}
+
 // GetShardManager for testing
 func (s *Test) GetShardManager() persistence.ShardManager {
 	return s.ShardMgr
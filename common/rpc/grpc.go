@@ -27,11 +27,15 @@ package rpc
 import (
 	"context"
 	"crypto/tls"
+	"sync/atomic"
 
 	"github.com/gogo/status"
 	"go.temporal.io/temporal-proto/serviceerror"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	// Registers the gzip compressor, so that clients can request compressed calls and servers can serve them.
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/stats"
 
 	"github.com/temporalio/temporal/common/headers"
 )
@@ -61,6 +65,7 @@ func Dial(hostName string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
 			errorInterceptor),
 		grpc.WithDefaultServiceConfig(DefaultServiceConfig),
 		grpc.WithDisableServiceConfig(),
+		grpc.WithStatsHandler(&wireSizeStatsHandler{}),
 	)
 }
 
@@ -74,3 +79,57 @@ func versionHeadersInterceptor(ctx context.Context, method string, req, reply in
 	ctx = headers.PropagateVersions(ctx)
 	return invoker(ctx, method, req, reply, cc, opts...)
 }
+
+type (
+	// WireSize accumulates the bytes sent and received on the wire, i.e. after compression, by the gRPC calls made
+	// with a context created by NewContextWithWireSize.
+	WireSize struct {
+		sent     int64
+		received int64
+	}
+
+	wireSizeKey struct{}
+
+	wireSizeStatsHandler struct{}
+)
+
+// NewContextWithWireSize creates context from parent context which records the wire size of the gRPC calls made with it.
+func NewContextWithWireSize(parentCtx context.Context) (context.Context, *WireSize) {
+	wireSize := &WireSize{}
+	return context.WithValue(parentCtx, wireSizeKey{}, wireSize), wireSize
+}
+
+// Sent returns the bytes sent on the wire so far.
+func (s *WireSize) Sent() int64 {
+	return atomic.LoadInt64(&s.sent)
+}
+
+// Received returns the bytes received on the wire so far.
+func (s *WireSize) Received() int64 {
+	return atomic.LoadInt64(&s.received)
+}
+
+func (h *wireSizeStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *wireSizeStatsHandler) HandleRPC(ctx context.Context, rpcStats stats.RPCStats) {
+	wireSize, ok := ctx.Value(wireSizeKey{}).(*WireSize)
+	if !ok {
+		return
+	}
+
+	switch payload := rpcStats.(type) {
+	case *stats.OutPayload:
+		atomic.AddInt64(&wireSize.sent, int64(payload.WireLength))
+	case *stats.InPayload:
+		atomic.AddInt64(&wireSize.received, int64(payload.WireLength))
+	}
+}
+
+func (h *wireSizeStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *wireSizeStatsHandler) HandleConn(_ context.Context, _ stats.ConnStats) {
+}
@@ -44,6 +44,13 @@ const (
 	ReplicationConsumerTypeRPC = "rpc"
 )
 
+const (
+	// MembershipProviderRingpop means discovering cluster members by ringpop gossip over TChannel.
+	MembershipProviderRingpop = "ringpop"
+	// MembershipProviderDatabase means discovering cluster members by their heartbeats in the cluster_membership table.
+	MembershipProviderDatabase = "database"
+)
+
 type (
 	// Config contains the configuration for a set of temporal services
 	Config struct {
@@ -63,6 +70,8 @@ type (
 		Kafka messaging.KafkaConfig `yaml:"kafka"`
 		// Archival is the config for archival
 		Archival Archival `yaml:"archival"`
+		// PayloadStore is the config for offloading large payloads of history events
+		PayloadStore PayloadStore `yaml:"payloadStore"`
 		// PublicClient is config for connecting to temporal frontend
 		PublicClient PublicClient `yaml:"publicClient"`
 		// DynamicConfigClient is the config for setting up the file based dynamic config client
@@ -156,6 +165,9 @@ type (
 
 	// Membership contains config items related to the membership layer of temporal
 	Membership struct {
+		// Provider is the implementation used to discover the other nodes of the cluster,
+		// one of MembershipProviderRingpop (default) or MembershipProviderDatabase
+		Provider string `yaml:"provider"`
 		// Name to be used in advertisement to other nodes
 		Name string `yaml:"name" validate:"nonzero"`
 		// MaxJoinDuration is the max wait time to join the gossip ring
@@ -164,6 +176,15 @@ type (
 		// This is generally used when BindOnIP would be the same across several nodes (ie: 0.0.0.0)
 		// and for nat traversal scenarios. Check net.ParseIP for supported syntax, only IPv4 is supported.
 		BroadcastAddress string `yaml:"broadcastAddress"`
+		// HeartbeatInterval is how often a node writes its heartbeat to the cluster_membership table,
+		// only used by the database provider
+		HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+		// HeartbeatTTL is how long a node is considered a member after its last heartbeat,
+		// only used by the database provider
+		HeartbeatTTL time.Duration `yaml:"heartbeatTTL"`
+		// RefreshInterval is how often the members are read from the cluster_membership table,
+		// only used by the database provider
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
 	}
 
 	// Persistence contains the configuration for data store / persistence layer
@@ -214,6 +235,8 @@ type (
 		VisibilityListMaxQPS dynamicconfig.IntPropertyFnWithNamespaceFilter `yaml:"-" json:"-"`
 		// ESIndexMaxResultWindow ElasticSearch index setting max_result_window
 		ESIndexMaxResultWindow dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// ESCountGroupByMaxGroups is max number of groups returned by a group by count
+		ESCountGroupByMaxGroups dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
 		// MaxQPS is overall max QPS
 		MaxQPS dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
 		// ValidSearchAttributes is legal indexed keys that can be used in list APIs
@@ -426,6 +449,24 @@ type (
 		S3ForcePathStyle bool    `yaml:"s3ForcePathStyle"`
 	}
 
+	// PayloadStore contains the config for offloading large payloads of history events,
+	// the store must be reachable from all history and frontend hosts of every cluster the namespace is replicated to
+	PayloadStore struct {
+		// Provider contains the config for all payload stores
+		Provider *PayloadStoreProvider `yaml:"provider"`
+	}
+
+	// PayloadStoreProvider contains the config for all payload stores
+	PayloadStoreProvider struct {
+		Filestore *FilestorePayloadStore `yaml:"filestore"`
+	}
+
+	// FilestorePayloadStore contain the config for filestore payload store
+	FilestorePayloadStore struct {
+		FileMode string `yaml:"fileMode"`
+		DirMode  string `yaml:"dirMode"`
+	}
+
 	// PublicClient is config for connecting to temporal frontend
 	PublicClient struct {
 		// HostPort is the host port to connect on. Host can be DNS name
@@ -0,0 +1,163 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dbmembership
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	defaultHeartbeatInterval = 10 * time.Second
+	defaultHeartbeatTTL      = 30 * time.Second
+	defaultRefreshInterval   = 5 * time.Second
+)
+
+// DatabaseFactory implements the MembershipMonitorFactory interface
+// with a monitor based on the heartbeats in the cluster_membership table
+type DatabaseFactory struct {
+	config         *config.Membership
+	rpcFactory     common.RPCFactory
+	serviceName    string
+	servicePortMap map[string]int
+	logger         log.Logger
+
+	sync.Mutex
+	membershipMonitor membership.Monitor
+	metadataManager   persistence.ClusterMetadataManager
+}
+
+// NewDatabaseFactory builds a database membership factory conforming
+// to the underlying configuration
+func NewDatabaseFactory(
+	membershipConfig *config.Membership,
+	rpcFactory common.RPCFactory,
+	serviceName string,
+	servicePortMap map[string]int,
+	logger log.Logger,
+	metadataManager persistence.ClusterMetadataManager,
+) (*DatabaseFactory, error) {
+
+	if err := ValidateDatabaseConfig(membershipConfig); err != nil {
+		return nil, err
+	}
+	if membershipConfig.HeartbeatInterval == 0 {
+		membershipConfig.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if membershipConfig.HeartbeatTTL == 0 {
+		membershipConfig.HeartbeatTTL = defaultHeartbeatTTL
+	}
+	if membershipConfig.RefreshInterval == 0 {
+		membershipConfig.RefreshInterval = defaultRefreshInterval
+	}
+	return &DatabaseFactory{
+		config:          membershipConfig,
+		rpcFactory:      rpcFactory,
+		serviceName:     serviceName,
+		servicePortMap:  servicePortMap,
+		logger:          logger,
+		metadataManager: metadataManager,
+	}, nil
+}
+
+// ValidateDatabaseConfig validates that database membership config is valid
+func ValidateDatabaseConfig(membershipConfig *config.Membership) error {
+	if membershipConfig.BroadcastAddress != "" && net.ParseIP(membershipConfig.BroadcastAddress) == nil {
+		return fmt.Errorf("membership config malformed `broadcastAddress` param")
+	}
+	heartbeatInterval := membershipConfig.HeartbeatInterval
+	if heartbeatInterval == 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+	heartbeatTTL := membershipConfig.HeartbeatTTL
+	if heartbeatTTL == 0 {
+		heartbeatTTL = defaultHeartbeatTTL
+	}
+	if heartbeatTTL <= heartbeatInterval {
+		return fmt.Errorf("membership config `heartbeatTTL` must be larger than `heartbeatInterval`")
+	}
+	return nil
+}
+
+// GetMembershipMonitor return a membership monitor
+func (factory *DatabaseFactory) GetMembershipMonitor() (membership.Monitor, error) {
+	factory.Lock()
+	defer factory.Unlock()
+
+	if factory.membershipMonitor != nil {
+		return factory.membershipMonitor, nil
+	}
+
+	factory.membershipMonitor = membership.NewDatabaseMonitor(
+		factory.serviceName,
+		factory.servicePortMap,
+		factory.metadataManager,
+		factory.broadcastAddressResolver,
+		factory.config.HeartbeatInterval,
+		factory.config.HeartbeatTTL,
+		factory.config.RefreshInterval,
+		factory.logger,
+	)
+	return factory.membershipMonitor, nil
+}
+
+func (factory *DatabaseFactory) broadcastAddressResolver() (string, error) {
+	return buildBroadcastHostPort(factory.rpcFactory.GetGRPCListener().Addr(), factory.config.BroadcastAddress)
+}
+
+// buildBroadcastHostPort return the hostport of the gRPC listener
+// and overrides the address with broadcastAddress if specified
+func buildBroadcastHostPort(listenerAddr net.Addr, broadcastAddress string) (string, error) {
+	listenerIP, port, err := net.SplitHostPort(listenerAddr.String())
+	if err != nil {
+		return "", err
+	}
+
+	if broadcastAddress != "" {
+		ip := net.ParseIP(broadcastAddress)
+		if ip == nil || ip.To4() == nil {
+			return "", errors.New("broadcastAddress set but unknown failure encountered while parsing")
+		}
+		return net.JoinHostPort(ip.To4().String(), port), nil
+	}
+
+	ip := net.ParseIP(listenerIP)
+	if ip == nil {
+		return "", errors.New("unable to parse listenerIp")
+	}
+	if ip.IsUnspecified() {
+		return "", errors.New("broadcastAddress required when listening on all interfaces (0.0.0.0/[::])")
+	}
+	return net.JoinHostPort(ip.String(), port), nil
+}
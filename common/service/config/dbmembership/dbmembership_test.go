@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dbmembership
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type DatabaseMembershipSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestDatabaseMembershipSuite(t *testing.T) {
+	suite.Run(t, new(DatabaseMembershipSuite))
+}
+
+func (s *DatabaseMembershipSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *DatabaseMembershipSuite) TestConfig() {
+	var cfg config.Membership
+	err := yaml.Unmarshal([]byte(getDatabaseConfig()), &cfg)
+	s.Nil(err)
+	s.Equal(config.MembershipProviderDatabase, cfg.Provider)
+	s.Equal("1.2.3.4", cfg.BroadcastAddress)
+	s.Equal(time.Second*5, cfg.HeartbeatInterval)
+	s.Nil(ValidateDatabaseConfig(&cfg))
+
+	f, err := NewDatabaseFactory(&cfg, nil, "test", nil, loggerimpl.NewNopLogger(), nil)
+	s.Nil(err)
+	s.NotNil(f)
+	s.Equal(defaultHeartbeatTTL, cfg.HeartbeatTTL)
+	s.Equal(defaultRefreshInterval, cfg.RefreshInterval)
+}
+
+func (s *DatabaseMembershipSuite) TestInvalidConfig() {
+	s.NotNil(ValidateDatabaseConfig(&config.Membership{BroadcastAddress: "invalid"}))
+	s.NotNil(ValidateDatabaseConfig(&config.Membership{HeartbeatInterval: time.Minute, HeartbeatTTL: time.Second}))
+	s.NotNil(ValidateDatabaseConfig(&config.Membership{HeartbeatInterval: time.Minute}))
+}
+
+func (s *DatabaseMembershipSuite) TestBuildBroadcastHostPort() {
+	hostPort, err := buildBroadcastHostPort(&net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 7234}, "")
+	s.Nil(err)
+	s.Equal("127.0.0.1:7234", hostPort)
+
+	hostPort, err = buildBroadcastHostPort(&net.TCPAddr{IP: net.IPv4zero, Port: 7234}, "1.2.3.4")
+	s.Nil(err)
+	s.Equal("1.2.3.4:7234", hostPort)
+
+	_, err = buildBroadcastHostPort(&net.TCPAddr{IP: net.IPv4zero, Port: 7234}, "")
+	s.NotNil(err)
+}
+
+func getDatabaseConfig() string {
+	return `provider: database
+name: "test"
+broadcastAddress: "1.2.3.4"
+heartbeatInterval: 5s
+`
+}
@@ -62,6 +62,11 @@ func GetBoolPropertyFnFilteredByNamespace(value bool) func(namespace string) boo
 	return func(namespace string) bool { return value }
 }
 
+// GetBoolPropertyFnFilteredByTaskListInfo returns value as BoolPropertyFnWithTaskListInfoFilters
+func GetBoolPropertyFnFilteredByTaskListInfo(value bool) func(namespace string, taskList string, taskType enumspb.TaskListType) bool {
+	return func(namespace string, taskList string, taskType enumspb.TaskListType) bool { return value }
+}
+
 // GetDurationPropertyFnFilteredByNamespace returns value as DurationPropertyFnFilteredByNamespace
 func GetDurationPropertyFnFilteredByNamespace(value time.Duration) func(namespace string) time.Duration {
 	return func(namespace string) time.Duration { return value }
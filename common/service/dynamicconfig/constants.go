@@ -70,6 +70,7 @@ var keys = map[Key]string{
 	VisibilityArchivalStatus:               "system.visibilityArchivalStatus",
 	EnableReadFromVisibilityArchival:       "system.enableReadFromVisibilityArchival",
 	EnableNamespaceNotActiveAutoForwarding: "system.enableNamespaceNotActiveAutoForwarding",
+	EnableStandbyTaskListForwarding:        "system.enableStandbyTaskListForwarding",
 	TransactionSizeLimit:                   "system.transactionSizeLimit",
 	MinRetentionDays:                       "system.minRetentionDays",
 	MaxWorkflowTaskTimeout:                 "system.maxWorkflowTaskTimeout",
@@ -96,6 +97,8 @@ var keys = map[Key]string{
 	FrontendESVisibilityListMaxQPS:        "frontend.esVisibilityListMaxQPS",
 	FrontendMaxBadBinaries:                "frontend.maxBadBinaries",
 	FrontendESIndexMaxResultWindow:        "frontend.esIndexMaxResultWindow",
+	FrontendESIndexRolloverInterval:       "frontend.esIndexRolloverInterval",
+	FrontendESCountGroupByMaxGroups:       "frontend.esCountGroupByMaxGroups",
 	FrontendHistoryMaxPageSize:            "frontend.historyMaxPageSize",
 	FrontendRPS:                           "frontend.rps",
 	FrontendMaxNamespaceRPSPerInstance:    "frontend.namespacerps",
@@ -136,6 +139,11 @@ var keys = map[Key]string{
 	MatchingForwarderMaxRatePerSecond:       "matching.forwarderMaxRatePerSecond",
 	MatchingForwarderMaxChildrenPerNode:     "matching.forwarderMaxChildrenPerNode",
 	MatchingShutdownDrainDuration:           "matching.shutdownDrainDuration",
+	MatchingEnablePartitionAutoScaling:      "matching.enablePartitionAutoScaling",
+	MatchingPartitionAutoScalingInterval:    "matching.partitionAutoScalingInterval",
+	MatchingPartitionTargetRatePerSecond:    "matching.partitionTargetRatePerSecond",
+	MatchingMaxTasklistPartitions:           "matching.maxTasklistPartitions",
+	MatchingLabelSelectorTimeout:            "matching.labelSelectorTimeout",
 
 	// history settings
 	HistoryRPS:                                             "history.rps",
@@ -158,6 +166,11 @@ var keys = map[Key]string{
 	StandbyTaskMissingEventsResendDelay:                    "history.standbyTaskMissingEventsResendDelay",
 	StandbyTaskMissingEventsDiscardDelay:                   "history.standbyTaskMissingEventsDiscardDelay",
 	TaskProcessRPS:                                         "history.taskProcessRPS",
+	QueueProcessorEnableNamespaceSplit:                     "history.queueProcessorEnableNamespaceSplit",
+	QueueProcessorSplitMaxPendingTasks:                     "history.queueProcessorSplitMaxPendingTasks",
+	QueueProcessorSplitMaxPendingDuration:                  "history.queueProcessorSplitMaxPendingDuration",
+	QueueProcessorSplitNamespaceMaxDispatchRPS:             "history.queueProcessorSplitNamespaceMaxDispatchRPS",
+	EnableTaskDLQ:                                          "history.enableTaskDLQ",
 	TaskSchedulerType:                                      "history.taskSchedulerType",
 	TaskSchedulerWorkerCount:                               "history.taskSchedulerWorkerCount",
 	TaskSchedulerQueueSize:                                 "history.taskSchedulerQueueSize",
@@ -200,6 +213,7 @@ var keys = map[Key]string{
 	TransferProcessorEnablePriorityTaskProcessor:           "history.transferProcessorEnablePriorityTaskProcessor",
 	TransferProcessorVisibilityArchivalTimeLimit:           "history.transferProcessorVisibilityArchivalTimeLimit",
 	ReplicatorTaskBatchSize:                                "history.replicatorTaskBatchSize",
+	ReplicatorTaskMaxBatchSize:                             "history.replicatorTaskMaxBatchSize",
 	ReplicatorTaskWorkerCount:                              "history.replicatorTaskWorkerCount",
 	ReplicatorTaskMaxRetryCount:                            "history.replicatorTaskMaxRetryCount",
 	ReplicatorProcessorMaxPollRPS:                          "history.replicatorProcessorMaxPollRPS",
@@ -240,6 +254,9 @@ var keys = map[Key]string{
 	ReplicationTaskFetcherAggregationInterval:              "history.ReplicationTaskFetcherAggregationInterval",
 	ReplicationTaskFetcherTimerJitterCoefficient:           "history.ReplicationTaskFetcherTimerJitterCoefficient",
 	ReplicationTaskFetcherErrorRetryWait:                   "history.ReplicationTaskFetcherErrorRetryWait",
+	ReplicationTaskFetcherMinPageSize:                      "history.ReplicationTaskFetcherMinPageSize",
+	ReplicationTaskFetcherMaxPageSize:                      "history.ReplicationTaskFetcherMaxPageSize",
+	ReplicationTaskFetcherEnableCompression:                "history.ReplicationTaskFetcherEnableCompression",
 	ReplicationTaskProcessorErrorRetryWait:                 "history.ReplicationTaskProcessorErrorRetryWait",
 	ReplicationTaskProcessorErrorRetryMaxAttempts:          "history.ReplicationTaskProcessorErrorRetryMaxAttempts",
 	ReplicationTaskProcessorNoTaskInitialWait:              "history.ReplicationTaskProcessorNoTaskInitialWait",
@@ -252,6 +269,10 @@ var keys = map[Key]string{
 	MutableStateChecksumVerifyProbability:                  "history.mutableStateChecksumVerifyProbability",
 	MutableStateChecksumInvalidateBefore:                   "history.mutableStateChecksumInvalidateBefore",
 	ReplicationEventsFromCurrentCluster:                    "history.ReplicationEventsFromCurrentCluster",
+	PayloadOffloadThreshold:                                "history.payloadOffloadThreshold",
+	PayloadOffloadURI:                                      "history.payloadOffloadURI",
+	EnableEagerActivityDispatch:                            "history.enableEagerActivityDispatch",
+	EagerActivityMaxPayloadSize:                            "history.eagerActivityMaxPayloadSize",
 
 	WorkerPersistenceMaxQPS:                         "worker.persistenceMaxQPS",
 	WorkerPersistenceGlobalMaxQPS:                   "worker.persistenceGlobalMaxQPS",
@@ -269,6 +290,8 @@ var keys = map[Key]string{
 	WorkerESProcessorBulkActions:                    "worker.ESProcessorBulkActions",
 	WorkerESProcessorBulkSize:                       "worker.ESProcessorBulkSize",
 	WorkerESProcessorFlushInterval:                  "worker.ESProcessorFlushInterval",
+	WorkerESIndexManagerInterval:                    "worker.ESIndexManagerInterval",
+	WorkerNamespaceHandoverCheckInterval:            "worker.namespaceHandoverCheckInterval",
 	EnableArchivalCompression:                       "worker.EnableArchivalCompression",
 	WorkerHistoryPageSize:                           "worker.WorkerHistoryPageSize",
 	WorkerTargetArchivalBlobSize:                    "worker.WorkerTargetArchivalBlobSize",
@@ -282,6 +305,11 @@ var keys = map[Key]string{
 	TaskListScannerEnabled:                          "worker.taskListScannerEnabled",
 	HistoryScannerEnabled:                           "worker.historyScannerEnabled",
 	ExecutionsScannerEnabled:                        "worker.executionsScannerEnabled",
+	ArchivalScannerEnabled:                          "worker.archivalScannerEnabled",
+	ArchivalScannerSamplingRate:                     "worker.archivalScannerSamplingRate",
+	ArchivalScannerCloseGracePeriod:                 "worker.archivalScannerCloseGracePeriod",
+	ConsistencyScannerEnabled:                       "worker.consistencyScannerEnabled",
+	ConsistencyScannerRepairEnabled:                 "worker.consistencyScannerRepairEnabled",
 }
 
 const (
@@ -330,6 +358,9 @@ const (
 	// EnableNamespaceNotActiveAutoForwarding whether enabling DC auto forwarding to active cluster
 	// for signal / start / signal with start API if namespace is not active
 	EnableNamespaceNotActiveAutoForwarding
+	// EnableStandbyTaskListForwarding whether enabling DC forwarding of task list polls and task
+	// completions to active cluster if namespace is not active
+	EnableStandbyTaskListForwarding
 	// TransactionSizeLimit is the largest allowed transaction size to persistence
 	TransactionSizeLimit
 	// MinRetentionDays is the minimal allowed retention days for namespace
@@ -372,6 +403,8 @@ const (
 	FrontendESVisibilityListMaxQPS
 	// FrontendESIndexMaxResultWindow is ElasticSearch index setting max_result_window
 	FrontendESIndexMaxResultWindow
+	// FrontendESCountGroupByMaxGroups is max number of groups returned by a group by count from ElasticSearch
+	FrontendESCountGroupByMaxGroups
 	// FrontendHistoryMaxPageSize is default max size for GetWorkflowExecutionHistory in one page
 	FrontendHistoryMaxPageSize
 	// FrontendRPS is workflow rate limit per second
@@ -391,6 +424,9 @@ const (
 
 	// FrontendMaxBadBinaries is the max number of bad binaries in namespace config
 	FrontendMaxBadBinaries
+	// FrontendESIndexRolloverInterval is the time range of workflow start time covered by one index of a namespace
+	// with its own visibility index, pinned on the namespace when its visibility index is set
+	FrontendESIndexRolloverInterval
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes
 	// SendRawWorkflowHistory is whether to enable raw history retrieving
@@ -452,6 +488,18 @@ const (
 	MatchingForwarderMaxChildrenPerNode
 	// MatchingShutdownDrainDuration is the duration of traffic drain during shutdown
 	MatchingShutdownDrainDuration
+	// MatchingEnablePartitionAutoScaling indicates whether the number of task list partitions is scaled automatically
+	// instead of being taken from MatchingNumTasklistReadPartitions and MatchingNumTasklistWritePartitions
+	MatchingEnablePartitionAutoScaling
+	// MatchingPartitionAutoScalingInterval is the interval at which the root partition re-evaluates the number of partitions
+	MatchingPartitionAutoScalingInterval
+	// MatchingPartitionTargetRatePerSecond is the task rate a single task list partition is scaled for
+	MatchingPartitionTargetRatePerSecond
+	// MatchingMaxTasklistPartitions is the max number of partitions a task list is scaled to
+	MatchingMaxTasklistPartitions
+	// MatchingLabelSelectorTimeout is the max time after creation an activity task with a label selector waits for a poller
+	// with matching labels, before it is dispatched to any poller
+	MatchingLabelSelectorTimeout
 
 	// key for history
 
@@ -495,6 +543,16 @@ const (
 	StandbyTaskMissingEventsDiscardDelay
 	// TaskProcessRPS is the task processing rate per second for each namespace
 	TaskProcessRPS
+	// QueueProcessorEnableNamespaceSplit indicates whether transfer and timer queue processors split stuck namespaces into their own queues
+	QueueProcessorEnableNamespaceSplit
+	// QueueProcessorSplitMaxPendingTasks is the number of pending tasks of a namespace after which the namespace is split
+	QueueProcessorSplitMaxPendingTasks
+	// QueueProcessorSplitMaxPendingDuration is the age of the oldest pending task of a namespace after which the namespace is split
+	QueueProcessorSplitMaxPendingDuration
+	// QueueProcessorSplitNamespaceMaxDispatchRPS is the task dispatch rate per second of a split namespace
+	QueueProcessorSplitNamespaceMaxDispatchRPS
+	// EnableTaskDLQ indicates whether transfer and timer tasks exceeding their max retry count are moved to the task DLQ
+	EnableTaskDLQ
 	// TaskSchedulerType is the task scheduler type for priority task processor
 	TaskSchedulerType
 	// TaskSchedulerWorkerCount is the number of workers per shard in task scheduler
@@ -579,6 +637,8 @@ const (
 	TransferProcessorVisibilityArchivalTimeLimit
 	// ReplicatorTaskBatchSize is batch size for ReplicatorProcessor
 	ReplicatorTaskBatchSize
+	// ReplicatorTaskMaxBatchSize is the max batch size a remote cluster can ask for when fetching replication tasks
+	ReplicatorTaskMaxBatchSize
 	// ReplicatorTaskWorkerCount is number of worker for ReplicatorProcessor
 	ReplicatorTaskWorkerCount
 	// ReplicatorTaskMaxRetryCount is max times of retry for ReplicatorProcessor
@@ -690,6 +750,10 @@ const (
 	WorkerESProcessorBulkSize
 	// WorkerESProcessorFlushInterval is flush interval for esProcessor
 	WorkerESProcessorFlushInterval
+	// WorkerESIndexManagerInterval is the interval at which indexer creates and deletes indices of namespaces with their own visibility index
+	WorkerESIndexManagerInterval
+	// WorkerNamespaceHandoverCheckInterval is the interval at which worker checks whether namespaces in handover can be promoted
+	WorkerNamespaceHandoverCheckInterval
 	// EnableArchivalCompression indicates whether blobs are compressed before they are archived
 	EnableArchivalCompression
 	// WorkerHistoryPageSize indicates the page size of history fetched from persistence for archival
@@ -716,6 +780,18 @@ const (
 	HistoryScannerEnabled
 	// ExecutionsScannerEnabled indicates if executions scanner should be started as part of worker.Scanner
 	ExecutionsScannerEnabled
+	// ArchivalScannerEnabled indicates if archival scanner should be started as part of worker.Scanner
+	ArchivalScannerEnabled
+	// ArchivalScannerSamplingRate is the fraction of closed executions verified by the archival scanner on each run
+	ArchivalScannerSamplingRate
+	// ArchivalScannerCloseGracePeriod is the time after close during which executions are not verified by the archival scanner,
+	// so that executions whose archival has not run yet are not reported missing
+	ArchivalScannerCloseGracePeriod
+	// ConsistencyScannerEnabled indicates if multi-cluster consistency scanner should be started as part of worker.Scanner
+	ConsistencyScannerEnabled
+	// ConsistencyScannerRepairEnabled indicates if consistency scanner should resend history from the remote cluster
+	// for executions on which the local cluster is behind or diverged
+	ConsistencyScannerRepairEnabled
 	// EnableBatcher decides whether start batcher in our worker
 	EnableBatcher
 	// EnableParentClosePolicyWorker decides whether or not enable system workers for processing parent close policy task
@@ -731,6 +807,13 @@ const (
 	ReplicationTaskFetcherTimerJitterCoefficient
 	// ReplicationTaskFetcherErrorRetryWait is the wait time when fetcher encounters error
 	ReplicationTaskFetcherErrorRetryWait
+	// ReplicationTaskFetcherMinPageSize is the number of replication tasks fetcher asks for a shard without backlog
+	ReplicationTaskFetcherMinPageSize
+	// ReplicationTaskFetcherMaxPageSize is the number of replication tasks fetcher asks for a shard at most,
+	// the page size doubles up to it for every fetch which leaves tasks behind on the shard
+	ReplicationTaskFetcherMaxPageSize
+	// ReplicationTaskFetcherEnableCompression indicates whether fetcher asks for compressed calls and history event blobs
+	ReplicationTaskFetcherEnableCompression
 	// ReplicationTaskProcessorErrorRetryWait is the initial retry wait when we see errors in applying replication tasks
 	ReplicationTaskProcessorErrorRetryWait
 	// ReplicationTaskProcessorErrorRetryMaxAttempts is the max retry attempts for applying replication tasks
@@ -757,6 +840,18 @@ const (
 	//ReplicationEventsFromCurrentCluster is a feature flag to allow cross DC replicate events that generated from the current cluster
 	ReplicationEventsFromCurrentCluster
 
+	// PayloadOffloadThreshold is the size in bytes above which payloads of history events are offloaded to the payload store, 0 disables offloading
+	PayloadOffloadThreshold
+	// PayloadOffloadURI is the payload store URI to which payloads of history events are offloaded
+	PayloadOffloadURI
+
+	// EnableEagerActivityDispatch indicates if activities scheduled by a decision can be started and returned
+	// directly to the worker completing the decision
+	EnableEagerActivityDispatch
+	// EagerActivityMaxPayloadSize is the max total size of activity inputs and headers returned directly
+	// to the worker completing a decision, activities exceeding it are dispatched through matching
+	EagerActivityMaxPayloadSize
+
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
 )
@@ -411,6 +411,16 @@ func CreateHistoryStartWorkflowRequest(
 	return histRequest
 }
 
+// IsReservedSignalName checks if the signal name is reserved for signals recorded by the server
+func IsReservedSignalName(signalName string) bool {
+	switch signalName {
+	case WorkflowPausedSignalName, WorkflowUnpausedSignalName, WorkflowAttributesUpsertedSignalName:
+		return true
+	default:
+		return false
+	}
+}
+
 // CheckEventBlobSizeLimit checks if a blob data exceeds limits. It logs a warning if it exceeds warnLimit,
 // and return ErrBlobSizeExceedsLimit if it exceeds errorLimit.
 func CheckEventBlobSizeLimit(
@@ -494,6 +504,13 @@ func IsJustOrderByClause(clause string) bool {
 	return strings.HasPrefix(whereClause, "order by")
 }
 
+// IsJustGroupByClause return true is query start with group by
+func IsJustGroupByClause(clause string) bool {
+	whereClause := strings.TrimSpace(clause)
+	whereClause = strings.ToLower(whereClause)
+	return strings.HasPrefix(whereClause, "group by")
+}
+
 // ConvertIndexedValueTypeToProtoType takes fieldType as interface{} and convert to IndexedValueType.
 // Because different implementation of dynamic config client may lead to different types
 func ConvertIndexedValueTypeToProtoType(fieldType interface{}, logger log.Logger) enumspb.IndexedValueType {
@@ -693,6 +693,8 @@ func (c *temporalImpl) startWorkerIndexer(params *resource.BootstrapParams, serv
 		c.messagingClient,
 		c.esClient,
 		c.esConfig,
+		c.metadataMgr,
+		service.GetVisibilityIndexerDLQ(),
 		c.logger,
 		service.GetMetricsClient())
 	if err := c.indexer.Start(); err != nil {
@@ -119,6 +119,16 @@ CREATE TABLE replication_tasks_dlq (
   PRIMARY KEY (source_cluster_name, shard_id, task_id)
 );
 
+CREATE TABLE history_task_dlq (
+  shard_id INT NOT NULL,
+  category INT NOT NULL,
+  message_id BIGINT NOT NULL,
+  --
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, category, message_id)
+);
+
 CREATE TABLE timer_tasks (
   shard_id INT NOT NULL,
   visibility_timestamp DATETIME(6) NOT NULL,
@@ -119,6 +119,16 @@ CREATE TABLE replication_tasks_dlq (
   PRIMARY KEY (source_cluster_name, shard_id, task_id)
 );
 
+CREATE TABLE history_task_dlq (
+  shard_id INTEGER NOT NULL,
+  category INTEGER NOT NULL,
+  message_id BIGINT NOT NULL,
+  --
+  data BYTEA NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, category, message_id)
+);
+
 CREATE TABLE timer_tasks (
   shard_id INTEGER NOT NULL,
   visibility_timestamp TIMESTAMP NOT NULL,
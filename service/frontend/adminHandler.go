@@ -30,30 +30,38 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/olivere/elastic"
+	"github.com/olivere/elastic/v7"
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	historypb "go.temporal.io/temporal-proto/history/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 	versionpb "go.temporal.io/temporal-proto/version/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/definition"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/elasticsearch/validator"
 	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/namespace"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
+	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/history"
@@ -62,6 +70,7 @@ import (
 const (
 	getNamespaceReplicationMessageBatchSize = 100
 	defaultLastMessageID                    = -1
+	listNamespacesPageSize                  = 100
 )
 
 type (
@@ -73,6 +82,7 @@ type (
 		params                *resource.BootstrapParams
 		config                *Config
 		namespaceDLQHandler   namespace.DLQMessageHandler
+		visibilityDLQHandler  espersistence.DLQMessageHandler
 	}
 )
 
@@ -103,6 +113,11 @@ func NewAdminHandler(
 			resource.GetNamespaceReplicationQueue(),
 			resource.GetLogger(),
 		),
+		visibilityDLQHandler: espersistence.NewDLQMessageHandler(
+			params.ESClient,
+			resource.GetVisibilityIndexerDLQ(),
+			resource.GetLogger(),
+		),
 	}
 }
 
@@ -160,6 +175,10 @@ func (adh *AdminHandler) AddSearchAttribute(ctx context.Context, request *admins
 
 	// update elasticsearch mapping, new added field will not be able to remove or update
 	index := adh.params.ESConfig.GetVisibilityIndex()
+	namespaceIndices, err := adh.getNamespaceVisibilityIndices()
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
 	for k, v := range searchAttr {
 		valueType := adh.convertIndexedValueTypeToESDataType(v)
 		if len(valueType) == 0 {
@@ -176,11 +195,49 @@ func (adh *AdminHandler) AddSearchAttribute(ctx context.Context, request *admins
 		if err != nil {
 			return nil, adh.error(errFailedToUpdateESMapping.MessageArgs(err), scope)
 		}
+
+		// aliases of namespaces with their own index, indices created later get the mapping from indexer
+		for _, namespaceIndex := range namespaceIndices {
+			err := adh.params.ESClient.PutMapping(ctx, namespaceIndex, definition.Attr, k, valueType)
+			if err != nil && !elastic.IsNotFound(err) {
+				return nil, adh.error(errFailedToUpdateESMapping.MessageArgs(err), scope)
+			}
+		}
 	}
 
 	return &adminservice.AddSearchAttributeResponse{}, nil
 }
 
+// getNamespaceVisibilityIndices returns the visibility index aliases of namespaces which don't use the default index
+func (adh *AdminHandler) getNamespaceVisibilityIndices() ([]string, error) {
+	indices := make(map[string]struct{})
+	var pageToken []byte
+	for {
+		resp, err := adh.GetMetadataManager().ListNamespaces(&persistence.ListNamespacesRequest{
+			PageSize:      listNamespacesPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.Namespaces {
+			if visibilityIndex := ns.Namespace.GetConfig().GetVisibilityIndex(); len(visibilityIndex) != 0 {
+				indices[visibilityIndex] = struct{}{}
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	var result []string
+	for index := range indices {
+		result = append(result, index)
+	}
+	return result, nil
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request *adminservice.DescribeWorkflowExecutionRequest) (_ *adminservice.DescribeWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(adh.GetLogger(), &retError)
@@ -528,7 +585,9 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 		execution.GetWorkflowId(),
 		adh.numberOfHistoryShards,
 	)
-	rawHistoryResponse, err := adh.GetHistoryManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+	// raw history is applied by remote clusters, so offloaded payloads are returned inline
+	historyManager := payloadstore.NewInflatingHistoryManager(adh.GetHistoryManager(), adh.GetPayloadStoreProvider())
+	rawHistoryResponse, err := historyManager.ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken: targetVersionHistory.GetBranchToken(),
 		// GetWorkflowExecutionRawHistoryV2 is exclusive exclusive.
 		// ReadRawHistoryBranch is inclusive exclusive.
@@ -652,8 +711,9 @@ func (adh *AdminHandler) GetReplicationMessages(ctx context.Context, request *ad
 	}
 
 	resp, err := adh.GetHistoryClient().GetReplicationMessages(ctx, &historyservice.GetReplicationMessagesRequest{
-		Tokens:      request.GetTokens(),
-		ClusterName: request.GetClusterName(),
+		Tokens:                  request.GetTokens(),
+		ClusterName:             request.GetClusterName(),
+		AcceptedCompressionType: request.GetAcceptedCompressionType(),
 	})
 	if err != nil {
 		return nil, adh.error(err, scope)
@@ -789,10 +849,13 @@ func (adh *AdminHandler) ReadDLQMessages(
 	}
 
 	var tasks []*replicationgenpb.ReplicationTask
+	var visibilityMessages []*indexergenpb.DLQMessage
 	var token []byte
 	var op func() error
 	switch request.GetType() {
-	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION:
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
 		resp, err := adh.GetHistoryClient().ReadDLQMessages(ctx, &historyservice.ReadDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -810,6 +873,7 @@ func (adh *AdminHandler) ReadDLQMessages(
 			Type:             resp.GetType(),
 			ReplicationTasks: resp.GetReplicationTasks(),
 			NextPageToken:    resp.GetNextPageToken(),
+			TaskMessages:     resp.GetTaskMessages(),
 		}, err
 	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_NAMESPACE:
 		op = func() error {
@@ -825,6 +889,20 @@ func (adh *AdminHandler) ReadDLQMessages(
 				return err
 			}
 		}
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY:
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				var err error
+				visibilityMessages, token, err = adh.visibilityDLQHandler.Read(
+					request.GetInclusiveEndMessageId(),
+					int(request.GetMaximumPageSize()),
+					request.GetNextPageToken())
+				return err
+			}
+		}
 	default:
 		return nil, adh.error(errDLQTypeIsNotSupported, scope)
 	}
@@ -834,8 +912,9 @@ func (adh *AdminHandler) ReadDLQMessages(
 	}
 
 	return &adminservice.ReadDLQMessagesResponse{
-		ReplicationTasks: tasks,
-		NextPageToken:    token,
+		ReplicationTasks:   tasks,
+		VisibilityMessages: visibilityMessages,
+		NextPageToken:      token,
 	}, nil
 }
 
@@ -859,7 +938,9 @@ func (adh *AdminHandler) PurgeDLQMessages(
 
 	var op func() error
 	switch request.GetType() {
-	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION:
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
 		resp, err := adh.GetHistoryClient().PurgeDLQMessages(ctx, &historyservice.PurgeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -881,6 +962,15 @@ func (adh *AdminHandler) PurgeDLQMessages(
 				return adh.namespaceDLQHandler.Purge(request.GetInclusiveEndMessageId())
 			}
 		}
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY:
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				return adh.visibilityDLQHandler.Purge(request.GetInclusiveEndMessageId())
+			}
+		}
 	default:
 		return nil, adh.error(errDLQTypeIsNotSupported, scope)
 	}
@@ -913,7 +1003,9 @@ func (adh *AdminHandler) MergeDLQMessages(
 	var token []byte
 	var op func() error
 	switch request.GetType() {
-	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION:
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
 		resp, err := adh.GetHistoryClient().MergeDLQMessages(ctx, &historyservice.MergeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -945,6 +1037,26 @@ func (adh *AdminHandler) MergeDLQMessages(
 				return err
 			}
 		}
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY:
+		if err := adh.validateConfigForAdvanceVisibility(); err != nil {
+			return nil, adh.error(err, scope)
+		}
+
+		op = func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				var err error
+				token, err = adh.visibilityDLQHandler.Merge(
+					ctx,
+					request.GetInclusiveEndMessageId(),
+					int(request.GetMaximumPageSize()),
+					request.GetNextPageToken(),
+				)
+				return err
+			}
+		}
 	default:
 		return nil, adh.error(errDLQTypeIsNotSupported, scope)
 	}
@@ -988,6 +1100,574 @@ func (adh *AdminHandler) RefreshWorkflowTasks(
 	return &adminservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+// ReindexWorkflowExecution re-sends the visibility record of the workflow to ElasticSearch
+func (adh *AdminHandler) ReindexWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ReindexWorkflowExecutionRequest,
+) (_ *adminservice.ReindexWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminReindexWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if err := adh.validateConfigForAdvanceVisibility(); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().ReindexWorkflowExecution(ctx, &historyservice.ReindexWorkflowExecutionRequest{
+		NamespaceId: namespaceEntry.GetInfo().Id,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ReindexWorkflowExecutionResponse{}, nil
+}
+
+// DeleteWorkflowExecution terminates the workflow if it is running and deletes all of its records
+func (adh *AdminHandler) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.DeleteWorkflowExecutionRequest,
+) (_ *adminservice.DeleteWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminDeleteWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().DeleteWorkflowExecution(ctx, &historyservice.DeleteWorkflowExecutionRequest{
+		NamespaceId: namespaceEntry.GetInfo().Id,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DeleteWorkflowExecutionResponse{}, nil
+}
+
+// PauseWorkflowExecution stops dispatching of decision and activity tasks of a running workflow until it is unpaused
+func (adh *AdminHandler) PauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.PauseWorkflowExecutionRequest,
+) (_ *adminservice.PauseWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminPauseWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().PauseWorkflowExecution(ctx, &historyservice.PauseWorkflowExecutionRequest{
+		NamespaceId: namespaceEntry.GetInfo().Id,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.PauseWorkflowExecutionResponse{}, nil
+}
+
+// UnpauseWorkflowExecution resumes dispatching of decision and activity tasks of a paused workflow
+func (adh *AdminHandler) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.UnpauseWorkflowExecutionRequest,
+) (_ *adminservice.UnpauseWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminUnpauseWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().UnpauseWorkflowExecution(ctx, &historyservice.UnpauseWorkflowExecutionRequest{
+		NamespaceId: namespaceEntry.GetInfo().Id,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UnpauseWorkflowExecutionResponse{}, nil
+}
+
+// UpsertWorkflowExecutionAttributes merges memo and search attributes into a running workflow
+// without requiring a decision from the workflow
+func (adh *AdminHandler) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	request *adminservice.UpsertWorkflowExecutionAttributesRequest,
+) (_ *adminservice.UpsertWorkflowExecutionAttributesResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpsertWorkflowExecutionAttributesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if len(request.GetMemo().GetFields()) == 0 && len(request.GetSearchAttributes().GetIndexedFields()) == 0 {
+		return nil, adh.error(errMemoAndSearchAttributesNotSet, scope)
+	}
+	namespace := request.GetNamespace()
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(namespace)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	if request.SearchAttributes != nil {
+		saValidator := validator.NewSearchAttributesValidator(
+			adh.GetLogger(),
+			adh.config.ValidSearchAttributes,
+			adh.config.SearchAttributesNumberOfKeysLimit,
+			adh.config.SearchAttributesSizeOfValueLimit,
+			adh.config.SearchAttributesTotalSizeLimit,
+		)
+		if err := saValidator.ValidateSearchAttributes(request.SearchAttributes, namespace); err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+	if err := common.CheckEventBlobSizeLimit(
+		request.GetMemo().Size()+request.GetSearchAttributes().Size(),
+		adh.config.BlobSizeLimitWarn(namespace),
+		adh.config.BlobSizeLimitError(namespace),
+		namespaceEntry.GetInfo().Id,
+		request.Execution.GetWorkflowId(),
+		request.Execution.GetRunId(),
+		scope,
+		adh.GetThrottledLogger(),
+		tag.BlobSizeViolationOperation("UpsertWorkflowExecutionAttributes"),
+	); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetHistoryClient().UpsertWorkflowExecutionAttributes(ctx, &historyservice.UpsertWorkflowExecutionAttributesRequest{
+		NamespaceId: namespaceEntry.GetInfo().Id,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpsertWorkflowExecutionAttributesResponse{}, nil
+}
+
+// StreamWorkflowExecutionHistory streams the history of a workflow execution, new event batches are sent
+// as they are appended to the history and the stream ends after the close event is sent
+func (adh *AdminHandler) StreamWorkflowExecutionHistory(
+	request *adminservice.StreamWorkflowExecutionHistoryRequest,
+	stream adminservice.AdminService_StreamWorkflowExecutionHistoryServer,
+) (err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminStreamWorkflowExecutionHistoryScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+	namespace := request.GetNamespace()
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(namespace)
+	if err != nil {
+		return adh.error(err, scope)
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 || pageSize > adh.config.HistoryMaxPageSize(namespace) {
+		pageSize = adh.config.HistoryMaxPageSize(namespace)
+	}
+	firstEventID := common.MaxInt64(request.GetFirstEventId(), common.FirstEventID)
+	shardID := common.WorkflowIDToHistoryShard(request.Execution.GetWorkflowId(), adh.numberOfHistoryShards)
+
+	ctx := stream.Context()
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: request.Execution.GetWorkflowId(),
+		RunId:      request.Execution.GetRunId(),
+	}
+	var branchToken []byte
+	for {
+		// long polls until there are events after firstEventID or the workflow is closed
+		response, err := adh.GetHistoryClient().PollMutableState(ctx, &historyservice.PollMutableStateRequest{
+			NamespaceId:         namespaceID,
+			Execution:           execution,
+			ExpectedNextEventId: firstEventID,
+			CurrentBranchToken:  branchToken,
+		})
+		if err != nil {
+			return adh.error(err, scope)
+		}
+		// the run is resolved on the first poll, later polls must follow the same run
+		execution.RunId = response.Execution.GetRunId()
+		branchToken = response.GetCurrentBranchToken()
+		nextEventID := response.GetNextEventId()
+
+		// the stream only reads a page ahead of the client, gRPC flow control blocks Send when the client falls behind
+		var pageToken []byte
+		for firstEventID < nextEventID {
+			events, _, token, err := persistence.ReadFullPageV2Events(adh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
+				BranchToken:   branchToken,
+				MinEventID:    firstEventID,
+				MaxEventID:    nextEventID,
+				PageSize:      pageSize,
+				NextPageToken: pageToken,
+				ShardID:       &shardID,
+			})
+			if err != nil {
+				return adh.error(err, scope)
+			}
+			if err := payloadstore.InflateEvents(ctx, adh.GetPayloadStoreProvider(), events); err != nil {
+				return adh.error(err, scope)
+			}
+			if len(events) > 0 {
+				if err := stream.Send(&adminservice.StreamWorkflowExecutionHistoryResponse{
+					RunId:   execution.GetRunId(),
+					History: &historypb.History{Events: events},
+				}); err != nil {
+					return err
+				}
+			}
+			if len(token) == 0 {
+				break
+			}
+			pageToken = token
+		}
+		firstEventID = nextEventID
+
+		if response.GetWorkflowStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			return nil
+		}
+	}
+}
+
+// CountWorkflowExecutions counts workflow executions, the counts are grouped when query contains GROUP BY clause
+func (adh *AdminHandler) CountWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.CountWorkflowExecutionsRequest,
+) (_ *adminservice.CountWorkflowExecutionsResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminCountWorkflowExecutionsScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := adh.validateConfigForAdvanceVisibility(); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	countRequest := &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: request.GetNamespace(),
+		Query:     request.GetQuery(),
+	}
+	if err := validator.NewQueryValidator(adh.config.ValidSearchAttributes).ValidateCountRequestForQuery(countRequest); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetVisibilityManager().CountWorkflowExecutions(&persistence.CountWorkflowExecutionsRequest{
+		NamespaceID: namespaceID,
+		Namespace:   request.GetNamespace(),
+		Query:       countRequest.GetQuery(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	groups := make([]*adminservice.CountWorkflowExecutionsGroup, 0, len(resp.Groups))
+	for _, group := range resp.Groups {
+		groups = append(groups, &adminservice.CountWorkflowExecutionsGroup{
+			Values: group.Values,
+			Count:  group.Count,
+		})
+	}
+	return &adminservice.CountWorkflowExecutionsResponse{
+		Count:   resp.Count,
+		GroupBy: resp.GroupBy,
+		Groups:  groups,
+	}, nil
+}
+
+// DescribeTaskList returns the pollers, status and backlog stats of a task list, backlog
+// stats are aggregated across all partitions of the task list
+func (adh *AdminHandler) DescribeTaskList(
+	ctx context.Context,
+	request *adminservice.DescribeTaskListRequest,
+) (_ *adminservice.DescribeTaskListResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeTaskListScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if request.GetTaskList().GetName() == "" {
+		return nil, adh.error(errTaskListNotSet, scope)
+	}
+	if request.GetTaskListType() == enumspb.TASK_LIST_TYPE_UNSPECIFIED {
+		return nil, adh.error(errTaskListTypeNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+		NamespaceId: namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			Namespace:             request.GetNamespace(),
+			TaskList:              request.GetTaskList(),
+			TaskListType:          request.GetTaskListType(),
+			IncludeTaskListStatus: true,
+		},
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DescribeTaskListResponse{
+		Pollers:        resp.GetPollers(),
+		TaskListStatus: resp.GetTaskListStatus(),
+		BacklogStats:   resp.GetBacklogStats(),
+		PauseInfo:      resp.GetPauseInfo(),
+	}, nil
+}
+
+// PauseTaskList stops a task list from dispatching tasks to pollers, tasks are still accepted and persisted
+func (adh *AdminHandler) PauseTaskList(
+	ctx context.Context,
+	request *adminservice.PauseTaskListRequest,
+) (_ *adminservice.PauseTaskListResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminPauseTaskListScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.updateTaskListPauseState(ctx, request.GetNamespace(), request.GetTaskList(), request.GetTaskListType(), true, request.GetReason(), request.GetIdentity()); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.PauseTaskListResponse{}, nil
+}
+
+// ResumeTaskList resumes dispatch of a task list paused by PauseTaskList
+func (adh *AdminHandler) ResumeTaskList(
+	ctx context.Context,
+	request *adminservice.ResumeTaskListRequest,
+) (_ *adminservice.ResumeTaskListResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminResumeTaskListScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.updateTaskListPauseState(ctx, request.GetNamespace(), request.GetTaskList(), request.GetTaskListType(), false, "", request.GetIdentity()); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ResumeTaskListResponse{}, nil
+}
+
+func (adh *AdminHandler) updateTaskListPauseState(
+	ctx context.Context,
+	namespace string,
+	taskList *tasklistpb.TaskList,
+	taskListType enumspb.TaskListType,
+	paused bool,
+	reason string,
+	identity string,
+) error {
+	if namespace == "" {
+		return errNamespaceNotSet
+	}
+	if taskList.GetName() == "" {
+		return errTaskListNotSet
+	}
+	if taskListType == enumspb.TASK_LIST_TYPE_UNSPECIFIED {
+		return errTaskListTypeNotSet
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(namespace)
+	if err != nil {
+		return err
+	}
+
+	// the root partition owns the pause state which all other partitions follow
+	_, err = adh.GetMatchingClient().UpdateTaskListPauseState(ctx, &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: taskList.GetName(), Kind: enumspb.TASK_LIST_KIND_NORMAL},
+		TaskListType: taskListType,
+		Paused:       paused,
+		Reason:       reason,
+		Identity:     identity,
+	})
+	return err
+}
+
+// GetReplicationStatus returns how far remote clusters are behind on replication tasks of this cluster,
+// aggregated across all shards, and the size of the replication DLQs of this cluster
+func (adh *AdminHandler) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+) (_ *adminservice.GetReplicationStatusResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminGetReplicationStatusScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+
+	clusterMetadata := adh.GetClusterMetadata()
+	remoteClusters := request.GetRemoteClusters()
+	if len(remoteClusters) == 0 {
+		for clusterName, info := range clusterMetadata.GetAllClusterInfo() {
+			if info.Enabled && clusterName != clusterMetadata.GetCurrentClusterName() {
+				remoteClusters = append(remoteClusters, clusterName)
+			}
+		}
+	}
+	for _, clusterName := range remoteClusters {
+		if _, ok := clusterMetadata.GetAllClusterInfo()[clusterName]; !ok || clusterName == clusterMetadata.GetCurrentClusterName() {
+			return nil, adh.error(errUnknownRemoteCluster.MessageArgs(clusterName), scope)
+		}
+	}
+
+	var namespaceID string
+	if request.GetNamespace() != "" {
+		if namespaceID, err = adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace()); err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	shardIDs := make([]int32, 0, adh.numberOfHistoryShards)
+	for shardID := 0; shardID < adh.numberOfHistoryShards; shardID++ {
+		shardIDs = append(shardIDs, int32(shardID))
+	}
+	resp, err := adh.GetHistoryClient().GetReplicationStatus(ctx, &historyservice.GetReplicationStatusRequest{
+		ShardIds:        shardIDs,
+		RemoteClusters:  remoteClusters,
+		NamespaceId:     namespaceID,
+		IncludeDlqTasks: true,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	response := &adminservice.GetReplicationStatusResponse{
+		RemoteClusters: aggregateReplicationStatus(remoteClusters, resp.GetShards(), time.Now()),
+	}
+	if request.GetIncludeShards() {
+		response.Shards = resp.GetShards()
+	}
+	return response, nil
+}
+
+// aggregateReplicationStatus summarizes the replication status of all shards for each remote cluster
+func aggregateReplicationStatus(
+	remoteClusters []string,
+	shards []*replicationgenpb.ShardReplicationStatus,
+	now time.Time,
+) map[string]*replicationgenpb.ClusterReplicationStatus {
+
+	statusByCluster := make(map[string]*replicationgenpb.ClusterReplicationStatus, len(remoteClusters))
+	for _, clusterName := range remoteClusters {
+		statusByCluster[clusterName] = &replicationgenpb.ClusterReplicationStatus{}
+	}
+	for _, shard := range shards {
+		for clusterName, shardStatus := range shard.GetRemoteClusters() {
+			status, ok := statusByCluster[clusterName]
+			if !ok {
+				continue
+			}
+			if shard.GetMaxReplicationTaskIdUnknown() {
+				status.UnknownTaskIdLagShards++
+			} else if taskIDLag := shard.GetMaxReplicationTaskId() - shardStatus.GetAckedTaskId(); taskIDLag > 0 {
+				status.LaggingShards++
+				if taskIDLag > status.MaxTaskIdLag {
+					status.MaxTaskIdLag = taskIDLag
+				}
+			}
+			if shardStatus.GetLastCaughtUpTime() == 0 {
+				status.UnknownTimeLagShards++
+			} else {
+				timeLag := now.UnixNano() - shardStatus.GetLastCaughtUpTime()
+				if timeLag > status.MaxTimeLag {
+					status.MaxTimeLag = timeLag
+				}
+				// tasks of the namespace pending on the shard were written after the remote cluster was last caught up
+				namespacePending := shardStatus.GetPendingNamespaceTasks() > 0 || shardStatus.GetPendingNamespaceTasksIncomplete()
+				if namespacePending && timeLag > status.MaxNamespaceTimeLag {
+					status.MaxNamespaceTimeLag = timeLag
+				}
+			}
+			status.PendingNamespaceTasks += int64(shardStatus.GetPendingNamespaceTasks())
+			if shardStatus.GetPendingNamespaceTasksIncomplete() {
+				status.PendingNamespaceTasksIncompleteShards++
+			}
+			status.DlqTasks += int64(shardStatus.GetDlqTasks())
+		}
+	}
+	return statusByCluster
+}
+
 func (adh *AdminHandler) validateGetWorkflowExecutionRawHistoryV2Request(
 	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
 ) error {
@@ -1145,22 +1825,7 @@ func (adh *AdminHandler) error(err error, scope metrics.Scope) error {
 }
 
 func (adh *AdminHandler) convertIndexedValueTypeToESDataType(valueType enumspb.IndexedValueType) string {
-	switch valueType {
-	case enumspb.INDEXED_VALUE_TYPE_STRING:
-		return "text"
-	case enumspb.INDEXED_VALUE_TYPE_KEYWORD:
-		return "keyword"
-	case enumspb.INDEXED_VALUE_TYPE_INT:
-		return "long"
-	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
-		return "double"
-	case enumspb.INDEXED_VALUE_TYPE_BOOL:
-		return "boolean"
-	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
-		return "date"
-	default:
-		return ""
-	}
+	return es.ConvertIndexedValueTypeToESDataType(valueType)
 }
 
 func (adh *AdminHandler) checkPermission(
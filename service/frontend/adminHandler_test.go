@@ -29,9 +29,11 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 	"github.com/temporalio/temporal/common/persistence/serialization"
 
@@ -42,17 +44,23 @@ import (
 	"github.com/stretchr/testify/suite"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/elasticsearch"
 	esmock "github.com/temporalio/temporal/common/elasticsearch/mocks"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/config"
@@ -540,6 +548,7 @@ func (s *adminHandlerSuite) Test_AddSearchAttribute_Validate() {
 
 	// ES operations tests
 	dynamicConfig.EXPECT().UpdateValue(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	s.mockResource.MetadataMgr.On("ListNamespaces", mock.Anything).Return(&persistence.ListNamespacesResponse{}, nil)
 
 	convertFailedTest := test{
 		Name: "unknown value type",
@@ -570,6 +579,44 @@ func (s *adminHandlerSuite) Test_AddSearchAttribute_Validate() {
 	s.Nil(resp)
 }
 
+func (s *adminHandlerSuite) Test_AddSearchAttribute_NamespaceIndex() {
+	ctx := context.Background()
+	handler := s.handler
+
+	dynamicConfig := dynamicconfig.NewMockClient(s.controller)
+	handler.params.DynamicConfig = dynamicConfig
+	handler.params.ESConfig = &elasticsearch.Config{
+		Indices: map[string]string{
+			common.VisibilityAppName: "temporal-visibility-dev",
+		},
+	}
+	esClient := &esmock.Client{}
+	defer func() { esClient.AssertExpectations(s.T()) }()
+	handler.params.ESClient = esClient
+
+	dynamicConfig.EXPECT().GetMapValue(dynamicconfig.ValidSearchAttributes, nil, definition.GetDefaultIndexedKeys()).
+		Return(map[string]interface{}{}, nil)
+	dynamicConfig.EXPECT().UpdateValue(dynamicconfig.ValidSearchAttributes, gomock.Any()).Return(nil)
+	s.mockResource.MetadataMgr.On("ListNamespaces", &persistence.ListNamespacesRequest{
+		PageSize: listNamespacesPageSize,
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			{Namespace: &persistenceblobs.NamespaceDetail{Config: &persistenceblobs.NamespaceConfig{}}},
+			{Namespace: &persistenceblobs.NamespaceDetail{Config: &persistenceblobs.NamespaceConfig{VisibilityIndex: "temporal-visibility-ns"}}},
+		},
+	}, nil)
+	esClient.On("PutMapping", mock.Anything, "temporal-visibility-dev", definition.Attr, "testkey", "keyword").Return(nil).Once()
+	esClient.On("PutMapping", mock.Anything, "temporal-visibility-ns", definition.Attr, "testkey", "keyword").Return(nil).Once()
+
+	resp, err := handler.AddSearchAttribute(ctx, &adminservice.AddSearchAttributeRequest{
+		SearchAttribute: map[string]enumspb.IndexedValueType{
+			"testkey": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		},
+	})
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
 func (s *adminHandlerSuite) Test_AddSearchAttribute_Permission() {
 	ctx := context.Background()
 	handler := s.handler
@@ -605,3 +652,309 @@ func (s *adminHandlerSuite) Test_AddSearchAttribute_Permission() {
 		s.Nil(resp)
 	}
 }
+
+func (s *adminHandlerSuite) Test_DeleteWorkflowExecution() {
+	ctx := context.Background()
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: "workflowID",
+		RunId:      uuid.New(),
+	}
+
+	_, err := s.handler.DeleteWorkflowExecution(ctx, &adminservice.DeleteWorkflowExecutionRequest{
+		Execution: execution,
+	})
+	s.Equal(errNamespaceNotSet, err)
+
+	_, err = s.handler.DeleteWorkflowExecution(ctx, &adminservice.DeleteWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: &commonpb.WorkflowExecution{RunId: uuid.New()},
+	})
+	s.Equal(errWorkflowIDNotSet, err)
+
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(&persistenceblobs.NamespaceInfo{Id: s.namespaceID, Name: s.namespace}, &persistenceblobs.NamespaceConfig{}, "", nil)
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.namespace).Return(namespaceEntry, nil).Times(1)
+	request := &adminservice.DeleteWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+		Reason:    "some random reason",
+	}
+	s.mockHistoryClient.EXPECT().DeleteWorkflowExecution(gomock.Any(), &historyservice.DeleteWorkflowExecutionRequest{
+		NamespaceId: s.namespaceID,
+		Request:     request,
+	}).Return(&historyservice.DeleteWorkflowExecutionResponse{}, nil).Times(1)
+	resp, err := s.handler.DeleteWorkflowExecution(ctx, request)
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *adminHandlerSuite) Test_PauseWorkflowExecution() {
+	ctx := context.Background()
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: "workflowID",
+		RunId:      uuid.New(),
+	}
+
+	_, err := s.handler.PauseWorkflowExecution(ctx, &adminservice.PauseWorkflowExecutionRequest{
+		Execution: execution,
+	})
+	s.Equal(errNamespaceNotSet, err)
+
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(&persistenceblobs.NamespaceInfo{Id: s.namespaceID, Name: s.namespace}, &persistenceblobs.NamespaceConfig{}, "", nil)
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.namespace).Return(namespaceEntry, nil).Times(2)
+	pauseRequest := &adminservice.PauseWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+		Reason:    "some random reason",
+		Identity:  "some random identity",
+	}
+	s.mockHistoryClient.EXPECT().PauseWorkflowExecution(gomock.Any(), &historyservice.PauseWorkflowExecutionRequest{
+		NamespaceId: s.namespaceID,
+		Request:     pauseRequest,
+	}).Return(&historyservice.PauseWorkflowExecutionResponse{}, nil).Times(1)
+	pauseResp, err := s.handler.PauseWorkflowExecution(ctx, pauseRequest)
+	s.NoError(err)
+	s.NotNil(pauseResp)
+
+	unpauseRequest := &adminservice.UnpauseWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+		Identity:  "some random identity",
+	}
+	s.mockHistoryClient.EXPECT().UnpauseWorkflowExecution(gomock.Any(), &historyservice.UnpauseWorkflowExecutionRequest{
+		NamespaceId: s.namespaceID,
+		Request:     unpauseRequest,
+	}).Return(&historyservice.UnpauseWorkflowExecutionResponse{}, nil).Times(1)
+	unpauseResp, err := s.handler.UnpauseWorkflowExecution(ctx, unpauseRequest)
+	s.NoError(err)
+	s.NotNil(unpauseResp)
+}
+
+func (s *adminHandlerSuite) Test_UpsertWorkflowExecutionAttributes() {
+	ctx := context.Background()
+	s.handler.config = NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), s.mockResource.GetLogger()), 1, false)
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: "workflowID",
+		RunId:      uuid.New(),
+	}
+
+	_, err := s.handler.UpsertWorkflowExecutionAttributes(ctx, &adminservice.UpsertWorkflowExecutionAttributesRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+	})
+	s.Equal(errMemoAndSearchAttributesNotSet, err)
+
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(&persistenceblobs.NamespaceInfo{Id: s.namespaceID, Name: s.namespace}, &persistenceblobs.NamespaceConfig{}, "", nil)
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.namespace).Return(namespaceEntry, nil).Times(2)
+
+	invalidKeyPayload, err := payload.Encode("some random value")
+	s.NoError(err)
+	_, err = s.handler.UpsertWorkflowExecutionAttributes(ctx, &adminservice.UpsertWorkflowExecutionAttributesRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			"some random key": invalidKeyPayload,
+		}},
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+
+	keywordPayload, err := payload.Encode("some random keyword")
+	s.NoError(err)
+	memoPayload, err := payload.Encode("some random memo")
+	s.NoError(err)
+	upsertRequest := &adminservice.UpsertWorkflowExecutionAttributesRequest{
+		Namespace: s.namespace,
+		Execution: execution,
+		Memo: &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+			"some random memo key": memoPayload,
+		}},
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			definition.CustomKeywordField: keywordPayload,
+		}},
+		Identity: "some random identity",
+	}
+	s.mockHistoryClient.EXPECT().UpsertWorkflowExecutionAttributes(gomock.Any(), &historyservice.UpsertWorkflowExecutionAttributesRequest{
+		NamespaceId: s.namespaceID,
+		Request:     upsertRequest,
+	}).Return(&historyservice.UpsertWorkflowExecutionAttributesResponse{}, nil).Times(1)
+	resp, err := s.handler.UpsertWorkflowExecutionAttributes(ctx, upsertRequest)
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *adminHandlerSuite) Test_DescribeTaskList() {
+	ctx := context.Background()
+	taskList := &tasklistpb.TaskList{Name: "some random task list"}
+
+	_, err := s.handler.DescribeTaskList(ctx, &adminservice.DescribeTaskListRequest{
+		Namespace:    s.namespace,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+	})
+	s.Equal(errTaskListNotSet, err)
+
+	_, err = s.handler.DescribeTaskList(ctx, &adminservice.DescribeTaskListRequest{
+		Namespace: s.namespace,
+		TaskList:  taskList,
+	})
+	s.Equal(errTaskListTypeNotSet, err)
+
+	backlogStats := &tasklistgenpb.TaskListBacklogStats{
+		ApproximateBacklogCount:    10,
+		ApproximateBacklogAgeNanos: 1000,
+		AddTasksPerSecond:          1.5,
+		DispatchTasksPerSecond:     0.5,
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockResource.MatchingClient.EXPECT().DescribeTaskList(gomock.Any(), &matchingservice.DescribeTaskListRequest{
+		NamespaceId: s.namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			Namespace:             s.namespace,
+			TaskList:              taskList,
+			TaskListType:          enumspb.TASK_LIST_TYPE_ACTIVITY,
+			IncludeTaskListStatus: true,
+		},
+	}).Return(&matchingservice.DescribeTaskListResponse{BacklogStats: backlogStats}, nil).Times(1)
+
+	resp, err := s.handler.DescribeTaskList(ctx, &adminservice.DescribeTaskListRequest{
+		Namespace:    s.namespace,
+		TaskList:     taskList,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+	})
+	s.NoError(err)
+	s.Equal(backlogStats, resp.GetBacklogStats())
+}
+
+func (s *adminHandlerSuite) Test_PauseResumeTaskList() {
+	ctx := context.Background()
+	taskList := &tasklistpb.TaskList{Name: "some random task list"}
+
+	_, err := s.handler.PauseTaskList(ctx, &adminservice.PauseTaskListRequest{
+		Namespace:    s.namespace,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+	})
+	s.Equal(errTaskListNotSet, err)
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(2)
+	s.mockResource.MatchingClient.EXPECT().UpdateTaskListPauseState(gomock.Any(), &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  s.namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: taskList.GetName(), Kind: enumspb.TASK_LIST_KIND_NORMAL},
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Paused:       true,
+		Reason:       "bad deployment",
+		Identity:     "operator",
+	}).Return(&matchingservice.UpdateTaskListPauseStateResponse{}, nil).Times(1)
+	_, err = s.handler.PauseTaskList(ctx, &adminservice.PauseTaskListRequest{
+		Namespace:    s.namespace,
+		TaskList:     taskList,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Reason:       "bad deployment",
+		Identity:     "operator",
+	})
+	s.NoError(err)
+
+	s.mockResource.MatchingClient.EXPECT().UpdateTaskListPauseState(gomock.Any(), &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  s.namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: taskList.GetName(), Kind: enumspb.TASK_LIST_KIND_NORMAL},
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Paused:       false,
+		Identity:     "operator",
+	}).Return(&matchingservice.UpdateTaskListPauseStateResponse{}, nil).Times(1)
+	_, err = s.handler.ResumeTaskList(ctx, &adminservice.ResumeTaskListRequest{
+		Namespace:    s.namespace,
+		TaskList:     taskList,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Identity:     "operator",
+	})
+	s.NoError(err)
+}
+
+func (s *adminHandlerSuite) Test_GetReplicationStatus() {
+	ctx := context.Background()
+	s.mockResource.ClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockResource.ClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+
+	_, err := s.handler.GetReplicationStatus(ctx, &adminservice.GetReplicationStatusRequest{
+		RemoteClusters: []string{cluster.TestCurrentClusterName},
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+
+	shard := &replicationgenpb.ShardReplicationStatus{
+		ShardId:              0,
+		MaxReplicationTaskId: 10,
+		RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+			cluster.TestAlternativeClusterName: {
+				AckedTaskId: 4,
+				DlqTasks:    3,
+			},
+		},
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockHistoryClient.EXPECT().GetReplicationStatus(gomock.Any(), &historyservice.GetReplicationStatusRequest{
+		ShardIds:        []int32{0},
+		RemoteClusters:  []string{cluster.TestAlternativeClusterName},
+		NamespaceId:     s.namespaceID,
+		IncludeDlqTasks: true,
+	}).Return(&historyservice.GetReplicationStatusResponse{
+		Shards: []*replicationgenpb.ShardReplicationStatus{shard},
+	}, nil).Times(1)
+
+	resp, err := s.handler.GetReplicationStatus(ctx, &adminservice.GetReplicationStatusRequest{
+		Namespace:     s.namespace,
+		IncludeShards: true,
+	})
+	s.NoError(err)
+	s.Equal([]*replicationgenpb.ShardReplicationStatus{shard}, resp.GetShards())
+	s.Equal(&replicationgenpb.ClusterReplicationStatus{
+		MaxTaskIdLag:         6,
+		LaggingShards:        1,
+		UnknownTimeLagShards: 1,
+		DlqTasks:             3,
+	}, resp.GetRemoteClusters()[cluster.TestAlternativeClusterName])
+}
+
+func (s *adminHandlerSuite) Test_AggregateReplicationStatus() {
+	now := time.Now()
+	shards := []*replicationgenpb.ShardReplicationStatus{
+		{
+			ShardId:              0,
+			MaxReplicationTaskId: 100,
+			RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+				"cluster-a": {AckedTaskId: 100, LastCaughtUpTime: now.Add(-time.Second).UnixNano(), PendingNamespaceTasks: 1},
+				"cluster-b": {AckedTaskId: 40, LastCaughtUpTime: now.Add(-time.Minute).UnixNano(), DlqTasks: 2},
+			},
+		},
+		{
+			ShardId:              1,
+			MaxReplicationTaskId: 200,
+			RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+				"cluster-a": {AckedTaskId: 150, LastCaughtUpTime: now.Add(-time.Hour).UnixNano(), PendingNamespaceTasks: 5, PendingNamespaceTasksIncomplete: true},
+				"cluster-b": {AckedTaskId: 200, DlqTasks: 1},
+			},
+		},
+		{
+			ShardId:                     2,
+			MaxReplicationTaskIdUnknown: true,
+			RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+				"cluster-a": {AckedTaskId: 10, LastCaughtUpTime: now.Add(-2 * time.Hour).UnixNano()},
+			},
+		},
+	}
+
+	status := aggregateReplicationStatus([]string{"cluster-a", "cluster-b"}, shards, now)
+	s.Equal(&replicationgenpb.ClusterReplicationStatus{
+		MaxTaskIdLag:                          50,
+		MaxTimeLag:                            int64(2 * time.Hour),
+		LaggingShards:                         1,
+		UnknownTaskIdLagShards:                1,
+		PendingNamespaceTasks:                 6,
+		PendingNamespaceTasksIncompleteShards: 1,
+		// shard 2 has no pending task of the namespace
+		MaxNamespaceTimeLag: int64(time.Hour),
+	}, status["cluster-a"])
+	s.Equal(&replicationgenpb.ClusterReplicationStatus{
+		MaxTaskIdLag:         60,
+		MaxTimeLag:           int64(time.Minute),
+		LaggingShards:        1,
+		UnknownTimeLagShards: 1,
+		DlqTasks:             3,
+	}, status["cluster-b"])
+}
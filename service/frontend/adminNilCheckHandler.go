@@ -197,3 +197,98 @@ func (adh *AdminNilCheckHandler) RefreshWorkflowTasks(ctx context.Context, reque
 	}
 	return resp, err
 }
+
+// ReindexWorkflowExecution re-sends the visibility record of a workflow to ElasticSearch
+func (adh *AdminNilCheckHandler) ReindexWorkflowExecution(ctx context.Context, request *adminservice.ReindexWorkflowExecutionRequest) (*adminservice.ReindexWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.ReindexWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ReindexWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// CountWorkflowExecutions counts workflow executions, optionally grouped by search attributes
+func (adh *AdminNilCheckHandler) CountWorkflowExecutions(ctx context.Context, request *adminservice.CountWorkflowExecutionsRequest) (*adminservice.CountWorkflowExecutionsResponse, error) {
+	resp, err := adh.parentHandler.CountWorkflowExecutions(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.CountWorkflowExecutionsResponse{}
+	}
+	return resp, err
+}
+
+// DeleteWorkflowExecution terminates the workflow if it is running and deletes all of its records
+func (adh *AdminNilCheckHandler) DeleteWorkflowExecution(ctx context.Context, request *adminservice.DeleteWorkflowExecutionRequest) (*adminservice.DeleteWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.DeleteWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DeleteWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// PauseWorkflowExecution stops dispatching of decision and activity tasks of a running workflow until it is unpaused
+func (adh *AdminNilCheckHandler) PauseWorkflowExecution(ctx context.Context, request *adminservice.PauseWorkflowExecutionRequest) (*adminservice.PauseWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.PauseWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.PauseWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// UnpauseWorkflowExecution resumes dispatching of decision and activity tasks of a paused workflow
+func (adh *AdminNilCheckHandler) UnpauseWorkflowExecution(ctx context.Context, request *adminservice.UnpauseWorkflowExecutionRequest) (*adminservice.UnpauseWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.UnpauseWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UnpauseWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// UpsertWorkflowExecutionAttributes merges memo and search attributes into a running workflow
+func (adh *AdminNilCheckHandler) UpsertWorkflowExecutionAttributes(ctx context.Context, request *adminservice.UpsertWorkflowExecutionAttributesRequest) (*adminservice.UpsertWorkflowExecutionAttributesResponse, error) {
+	resp, err := adh.parentHandler.UpsertWorkflowExecutionAttributes(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpsertWorkflowExecutionAttributesResponse{}
+	}
+	return resp, err
+}
+
+// StreamWorkflowExecutionHistory streams the history of a workflow execution
+func (adh *AdminNilCheckHandler) StreamWorkflowExecutionHistory(request *adminservice.StreamWorkflowExecutionHistoryRequest, stream adminservice.AdminService_StreamWorkflowExecutionHistoryServer) error {
+	return adh.parentHandler.StreamWorkflowExecutionHistory(request, stream)
+}
+
+// DescribeTaskList returns the pollers, status and backlog stats of a task list
+func (adh *AdminNilCheckHandler) DescribeTaskList(ctx context.Context, request *adminservice.DescribeTaskListRequest) (*adminservice.DescribeTaskListResponse, error) {
+	resp, err := adh.parentHandler.DescribeTaskList(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeTaskListResponse{}
+	}
+	return resp, err
+}
+
+// PauseTaskList stops a task list from dispatching tasks to pollers
+func (adh *AdminNilCheckHandler) PauseTaskList(ctx context.Context, request *adminservice.PauseTaskListRequest) (*adminservice.PauseTaskListResponse, error) {
+	resp, err := adh.parentHandler.PauseTaskList(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.PauseTaskListResponse{}
+	}
+	return resp, err
+}
+
+// ResumeTaskList resumes dispatch of a paused task list
+func (adh *AdminNilCheckHandler) ResumeTaskList(ctx context.Context, request *adminservice.ResumeTaskListRequest) (*adminservice.ResumeTaskListResponse, error) {
+	resp, err := adh.parentHandler.ResumeTaskList(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ResumeTaskListResponse{}
+	}
+	return resp, err
+}
+
+// GetReplicationStatus returns how far remote clusters are behind on replication tasks of this cluster
+func (adh *AdminNilCheckHandler) GetReplicationStatus(ctx context.Context, request *adminservice.GetReplicationStatusRequest) (*adminservice.GetReplicationStatusResponse, error) {
+	resp, err := adh.parentHandler.GetReplicationStatus(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetReplicationStatusResponse{}
+	}
+	return resp, err
+}
@@ -48,6 +48,8 @@ const (
 	// 5. TerminateWorkflowExecution
 	// 6. QueryWorkflow
 	// please also reference selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs
+	// if standby task list forwarding is enabled for the namespace, polls and task completions are
+	// forwarded as well, please reference selectedAPIsForwardingRedirectionPolicyTaskListAPIs
 	DCRedirectionPolicySelectedAPIsForwarding = "selected-apis-forwarding"
 )
 
@@ -82,6 +84,24 @@ var selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs = map[string]struct{}
 	"QueryWorkflow":                    {},
 }
 
+// selectedAPIsForwardingRedirectionPolicyTaskListAPIs contains a list of APIs which can be redirected
+// so that workers connected to a standby cluster keep processing tasks of the active cluster
+var selectedAPIsForwardingRedirectionPolicyTaskListAPIs = map[string]struct{}{
+	"PollForDecisionTask":              {},
+	"PollForActivityTask":              {},
+	"RespondDecisionTaskCompleted":     {},
+	"RespondDecisionTaskFailed":        {},
+	"RespondQueryTaskCompleted":        {},
+	"RecordActivityTaskHeartbeat":      {},
+	"RecordActivityTaskHeartbeatById":  {},
+	"RespondActivityTaskCompleted":     {},
+	"RespondActivityTaskCompletedById": {},
+	"RespondActivityTaskFailed":        {},
+	"RespondActivityTaskFailedById":    {},
+	"RespondActivityTaskCanceled":      {},
+	"RespondActivityTaskCanceledById":  {},
+}
+
 // RedirectionPolicyGenerator generate corresponding redirection policy
 func RedirectionPolicyGenerator(clusterMetadata cluster.Metadata, config *Config,
 	namespaceCache cache.NamespaceCache, policy config.DCRedirectionPolicy) DCRedirectionPolicy {
@@ -173,6 +193,14 @@ func (policy *SelectedAPIsForwardingRedirectionPolicy) getTargetClusterAndIsName
 		return policy.currentClusterName, false
 	}
 
+	if _, ok := selectedAPIsForwardingRedirectionPolicyTaskListAPIs[apiName]; ok {
+		if !policy.config.EnableStandbyTaskListForwarding(namespaceEntry.GetInfo().Name) {
+			// do not do dc redirection if task list forwarding dynamic config flag is not enabled
+			return policy.currentClusterName, false
+		}
+		return namespaceEntry.GetReplicationConfig().ActiveClusterName, true
+	}
+
 	if !policy.config.EnableNamespaceNotActiveAutoForwarding(namespaceEntry.GetInfo().Name) {
 		// do not do dc redirection if auto-forwarding dynamic config flag is not enabled
 		return policy.currentClusterName, false
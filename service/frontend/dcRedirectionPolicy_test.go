@@ -335,6 +335,72 @@ func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_G
 	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyWhitelistedAPIs), alternativeClustercallCount)
 }
 
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_GlobalNamespace_TaskListForwarding_Disabled() {
+	s.setupGlobalNamespaceWithTwoReplicationCluster(true, false)
+	s.mockConfig.EnableStandbyTaskListForwarding = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
+
+	callCount := 0
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.currentClusterName, targetCluster)
+		return nil
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyTaskListAPIs {
+		err := s.policy.WithNamespaceIDRedirect(context.Background(), s.namespaceID, apiName, callFn)
+		s.Nil(err)
+
+		err = s.policy.WithNamespaceRedirect(context.Background(), s.namespace, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyTaskListAPIs), callCount)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_GlobalNamespace_TaskListForwarding_AlternativeCluster() {
+	s.setupGlobalNamespaceWithTwoReplicationCluster(false, false)
+	s.mockConfig.EnableStandbyTaskListForwarding = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+
+	callCount := 0
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.alternativeClusterName, targetCluster)
+		return nil
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyTaskListAPIs {
+		err := s.policy.WithNamespaceIDRedirect(context.Background(), s.namespaceID, apiName, callFn)
+		s.Nil(err)
+
+		err = s.policy.WithNamespaceRedirect(context.Background(), s.namespace, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyTaskListAPIs), callCount)
+}
+
+func (s *selectedAPIsForwardingRedirectionPolicySuite) TestGetTargetDataCenter_GlobalNamespace_TaskListForwarding_CurrentCluster() {
+	s.setupGlobalNamespaceWithTwoReplicationCluster(false, true)
+	s.mockConfig.EnableStandbyTaskListForwarding = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+
+	callCount := 0
+	callFn := func(targetCluster string) error {
+		callCount++
+		s.Equal(s.currentClusterName, targetCluster)
+		return nil
+	}
+
+	for apiName := range selectedAPIsForwardingRedirectionPolicyTaskListAPIs {
+		err := s.policy.WithNamespaceIDRedirect(context.Background(), s.namespaceID, apiName, callFn)
+		s.Nil(err)
+
+		err = s.policy.WithNamespaceRedirect(context.Background(), s.namespace, apiName, callFn)
+		s.Nil(err)
+	}
+
+	s.Equal(2*len(selectedAPIsForwardingRedirectionPolicyTaskListAPIs), callCount)
+}
+
 func (s *selectedAPIsForwardingRedirectionPolicySuite) setupLocalNamespace() {
 	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
 		&persistenceblobs.NamespaceInfo{Id: s.namespaceID, Name: s.namespace},
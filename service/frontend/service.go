@@ -38,6 +38,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/definition"
+	es "github.com/temporalio/temporal/common/elasticsearch"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
@@ -63,6 +64,7 @@ type Config struct {
 	EnableReadVisibilityFromES      dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ESVisibilityListMaxQPS          dynamicconfig.IntPropertyFnWithNamespaceFilter
 	ESIndexMaxResultWindow          dynamicconfig.IntPropertyFn
+	ESCountGroupByMaxGroups         dynamicconfig.IntPropertyFn
 	HistoryMaxPageSize              dynamicconfig.IntPropertyFnWithNamespaceFilter
 	RPS                             dynamicconfig.IntPropertyFn
 	MaxNamespaceRPSPerInstance      dynamicconfig.IntPropertyFnWithNamespaceFilter
@@ -77,6 +79,8 @@ type Config struct {
 	HistoryMgrNumConns dynamicconfig.IntPropertyFn
 
 	MaxBadBinaries dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// ESIndexRolloverInterval is pinned on namespaces when their visibility index is set
+	ESIndexRolloverInterval dynamicconfig.DurationPropertyFn
 
 	// security protection settings
 	EnableAdminProtection         dynamicconfig.BoolPropertyFn
@@ -86,11 +90,14 @@ type Config struct {
 	// size limit system protection
 	BlobSizeLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
 	BlobSizeLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// PayloadOffloadThreshold mirrors the history setting, payloads history offloads don't count towards blob size limits
+	PayloadOffloadThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter
 
 	ThrottledLogRPS dynamicconfig.IntPropertyFn
 
 	// Namespace specific config
 	EnableNamespaceNotActiveAutoForwarding dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	EnableStandbyTaskListForwarding        dynamicconfig.BoolPropertyFnWithNamespaceFilter
 
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
@@ -117,6 +124,7 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		EnableReadVisibilityFromES:             dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableReadVisibilityFromES, enableReadFromES),
 		ESVisibilityListMaxQPS:                 dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendESVisibilityListMaxQPS, 3),
 		ESIndexMaxResultWindow:                 dc.GetIntProperty(dynamicconfig.FrontendESIndexMaxResultWindow, 10000),
+		ESCountGroupByMaxGroups:                dc.GetIntProperty(dynamicconfig.FrontendESCountGroupByMaxGroups, 10000),
 		HistoryMaxPageSize:                     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendHistoryMaxPageSize, common.GetHistoryMaxPageSize),
 		RPS:                                    dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
 		MaxNamespaceRPSPerInstance:             dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxNamespaceRPSPerInstance, 1200),
@@ -124,14 +132,17 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		MaxIDLengthLimit:                       dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
 		HistoryMgrNumConns:                     dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
 		MaxBadBinaries:                         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxBadBinaries, namespace.MaxBadBinaries),
+		ESIndexRolloverInterval:                dc.GetDurationProperty(dynamicconfig.FrontendESIndexRolloverInterval, es.DefaultRolloverInterval),
 		EnableAdminProtection:                  dc.GetBoolProperty(dynamicconfig.EnableAdminProtection, false),
 		AdminOperationToken:                    dc.GetStringProperty(dynamicconfig.AdminOperationToken, common.DefaultAdminOperationToken),
 		DisableListVisibilityByFilter:          dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.DisableListVisibilityByFilter, false),
 		BlobSizeLimitError:                     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
 		BlobSizeLimitWarn:                      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitWarn, 256*1024),
+		PayloadOffloadThreshold:                dc.GetIntPropertyFilteredByNamespace(dynamicconfig.PayloadOffloadThreshold, 0),
 		ThrottledLogRPS:                        dc.GetIntProperty(dynamicconfig.FrontendThrottledLogRPS, 20),
 		ShutdownDrainDuration:                  dc.GetDurationProperty(dynamicconfig.FrontendShutdownDrainDuration, 0),
 		EnableNamespaceNotActiveAutoForwarding: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableNamespaceNotActiveAutoForwarding, true),
+		EnableStandbyTaskListForwarding:        dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStandbyTaskListForwarding, false),
 		EnableClientVersionCheck:               dc.GetBoolProperty(dynamicconfig.EnableClientVersionCheck, false),
 		ValidSearchAttributes:                  dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, definition.GetDefaultIndexedKeys()),
 		SearchAttributesNumberOfKeysLimit:      dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
@@ -182,13 +193,15 @@ func NewService(
 		if params.ESConfig != nil {
 			visibilityIndexName := params.ESConfig.Indices[common.VisibilityAppName]
 			visibilityConfigForES := &config.VisibilityConfig{
-				MaxQPS:                 serviceConfig.PersistenceMaxQPS,
-				VisibilityListMaxQPS:   serviceConfig.ESVisibilityListMaxQPS,
-				ESIndexMaxResultWindow: serviceConfig.ESIndexMaxResultWindow,
-				ValidSearchAttributes:  serviceConfig.ValidSearchAttributes,
+				MaxQPS:                  serviceConfig.PersistenceMaxQPS,
+				VisibilityListMaxQPS:    serviceConfig.ESVisibilityListMaxQPS,
+				ESIndexMaxResultWindow:  serviceConfig.ESIndexMaxResultWindow,
+				ESCountGroupByMaxGroups: serviceConfig.ESCountGroupByMaxGroups,
+				ValidSearchAttributes:   serviceConfig.ValidSearchAttributes,
 			}
-			visibilityFromES = espersistence.NewESVisibilityManager(visibilityIndexName, params.ESClient, visibilityConfigForES,
-				nil, params.MetricsClient, logger)
+			indexResolver := espersistence.NewNamespaceIndexResolver(persistenceBean.GetMetadataManager())
+			visibilityFromES = espersistence.NewESVisibilityManager(visibilityIndexName, params.ESClient, indexResolver,
+				visibilityConfigForES, nil, params.MetricsClient, logger)
 		}
 		return persistence.NewVisibilityManagerWrapper(
 			visibilityFromDB,
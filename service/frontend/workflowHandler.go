@@ -27,6 +27,8 @@ package frontend
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -38,6 +40,8 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 	versionpb "go.temporal.io/temporal-proto/version/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
 	historygenpb "github.com/temporalio/temporal/.gen/proto/history/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
@@ -57,6 +61,7 @@ import (
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/namespace"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/resource"
@@ -127,6 +132,7 @@ func NewWorkflowHandler(
 		namespaceHandler: namespace.NewHandler(
 			config.MinRetentionDays(),
 			config.MaxBadBinaries,
+			config.ESIndexRolloverInterval,
 			resource.GetLogger(),
 			resource.GetMetadataManager(),
 			resource.GetClusterMetadata(),
@@ -471,7 +477,7 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 	sizeLimitError := wh.config.BlobSizeLimitError(namespace)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespace)
 
-	actualSize := request.GetInput().Size()
+	actualSize := wh.historyPayloadsSize(namespace, request.GetInput())
 	actualSize += request.GetMemo().Size()
 
 	if err := common.CheckEventBlobSizeLimit(
@@ -672,6 +678,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 				historyBlob = historyBlob[len(historyBlob)-1 : len(historyBlob)]
 			} else {
 				history, _, err = wh.getHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -718,6 +725,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 				)
 			} else {
 				history, continuationToken.PersistenceToken, err = wh.getHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -904,16 +912,33 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(ctx context.Context, req
 		return nil, errShuttingDown
 	}
 
+	// the request is validated before it reaches history, as activities may be started there on behalf of the worker
+	if len(request.GetIdentity()) > wh.config.MaxIDLengthLimit() {
+		return nil, wh.error(errIdentityTooLong, scope)
+	}
+	eagerActivityTaskSlots, err := wh.getEagerActivityTaskSlots(ctx)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	histResp, err := wh.GetHistoryClient().RespondDecisionTaskCompleted(ctx, &historyservice.RespondDecisionTaskCompletedRequest{
-		NamespaceId:     namespaceId,
-		CompleteRequest: request},
-	)
+		NamespaceId:            namespaceId,
+		CompleteRequest:        request,
+		EagerActivityTaskSlots: eagerActivityTaskSlots,
+	})
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
 
-	if len(request.GetIdentity()) > wh.config.MaxIDLengthLimit() {
-		return nil, wh.error(errIdentityTooLong, scope)
+	if len(histResp.GetActivityTasks()) > 0 {
+		if err := wh.setEagerActivityTasks(ctx, histResp.GetActivityTasks()); err != nil {
+			// activities are already started, they will be retried by the server after start to close timeout
+			wh.GetLogger().Error("Unable to return eager activity tasks to the worker.",
+				tag.WorkflowNamespaceID(namespaceId),
+				tag.WorkflowID(taskToken.GetWorkflowId()),
+				tag.WorkflowRunID(taskToken.GetRunId()),
+				tag.Error(err))
+		}
 	}
 
 	completedResp := &workflowservice.RespondDecisionTaskCompletedResponse{}
@@ -942,6 +967,51 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(ctx context.Context, req
 	return completedResp, nil
 }
 
+// getEagerActivityTaskSlots returns the number of activity tasks the worker is ready to receive
+// in the RespondDecisionTaskCompleted response, as the public API has no field for it the worker sends it in a header.
+func (wh *WorkflowHandler) getEagerActivityTaskSlots(ctx context.Context) (int32, error) {
+	value := headers.GetValues(ctx, headers.EagerActivityTaskSlotsHeaderName)[0]
+	if value == "" {
+		return 0, nil
+	}
+	slots, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || slots < 0 {
+		return 0, errInvalidEagerActivityTaskSlots
+	}
+	return int32(slots), nil
+}
+
+// setEagerActivityTasks returns the activity tasks started on behalf of the worker in the response header.
+func (wh *WorkflowHandler) setEagerActivityTasks(ctx context.Context, activityTasks []*workflowservice.PollForActivityTaskResponse) error {
+	md := metadata.MD{}
+	for _, activityTask := range activityTasks {
+		data, err := activityTask.Marshal()
+		if err != nil {
+			return err
+		}
+		md.Append(headers.EagerActivityTasksHeaderName, string(data))
+	}
+	return grpc.SetHeader(ctx, md)
+}
+
+// getPollerLabels returns the labels the activity worker advertises for label based routing, as the public API
+// has no field for them the worker sends them in a header as comma separated key=value pairs.
+func (wh *WorkflowHandler) getPollerLabels(ctx context.Context) (map[string]string, error) {
+	value := headers.GetValues(ctx, headers.PollerLabelsHeaderName)[0]
+	if value == "" {
+		return nil, nil
+	}
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errInvalidPollerLabels
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return labels, nil
+}
+
 // RespondDecisionTaskFailed is called by application worker to indicate failure.  This results in
 // DecisionTaskFailedEvent written to the history and a new DecisionTask created.  This API can be used by client to
 // either clear sticky tasklist or report any panics during DecisionTask processing.  Temporal will only append first
@@ -1067,6 +1137,10 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 	if len(request.GetIdentity()) > wh.config.MaxIDLengthLimit() {
 		return nil, wh.error(errIdentityTooLong, scope)
 	}
+	pollerLabels, err := wh.getPollerLabels(ctx)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
 
 	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
 	if err != nil {
@@ -1078,9 +1152,10 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 	op := func() error {
 		var err error
 		matchingResponse, err = wh.GetMatchingClient().PollForActivityTask(ctx, &matchingservice.PollForActivityTaskRequest{
-			NamespaceId: namespaceID,
-			PollerId:    pollerID,
-			PollRequest: request,
+			NamespaceId:  namespaceID,
+			PollerId:     pollerID,
+			PollRequest:  request,
+			PollerLabels: pollerLabels,
 		})
 		return err
 	}
@@ -1381,7 +1456,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(ctx context.Context, req
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
-		request.GetResult().Size(),
+		wh.historyPayloadsSize(namespaceEntry.GetInfo().Name, request.GetResult()),
 		sizeLimitWarn,
 		sizeLimitError,
 		namespaceId,
@@ -1489,7 +1564,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedById(ctx context.Context,
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
-		request.GetResult().Size(),
+		wh.historyPayloadsSize(namespaceEntry.GetInfo().Name, request.GetResult()),
 		sizeLimitWarn,
 		sizeLimitError,
 		namespaceID,
@@ -1770,7 +1845,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, requ
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
-		request.GetDetails().Size(),
+		wh.historyPayloadsSize(namespaceEntry.GetInfo().Name, request.GetDetails()),
 		sizeLimitWarn,
 		sizeLimitError,
 		namespaceID,
@@ -1877,7 +1952,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledById(ctx context.Context,
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespaceEntry.GetInfo().Name)
 
 	if err := common.CheckEventBlobSizeLimit(
-		request.GetDetails().Size(),
+		wh.historyPayloadsSize(namespaceEntry.GetInfo().Name, request.GetDetails()),
 		sizeLimitWarn,
 		sizeLimitError,
 		namespaceID,
@@ -2013,6 +2088,10 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context, request
 		return nil, wh.error(errSignalNameTooLong, scope)
 	}
 
+	if common.IsReservedSignalName(request.GetSignalName()) {
+		return nil, wh.error(errSignalNameReserved, scope)
+	}
+
 	if len(request.GetRequestId()) > wh.config.MaxIDLengthLimit() {
 		return nil, wh.error(errRequestIDTooLong, scope)
 	}
@@ -2025,7 +2104,7 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context, request
 	sizeLimitError := wh.config.BlobSizeLimitError(request.GetNamespace())
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(request.GetNamespace())
 	if err := common.CheckEventBlobSizeLimit(
-		request.GetInput().Size(),
+		wh.historyPayloadsSize(request.GetNamespace(), request.GetInput()),
 		sizeLimitWarn,
 		sizeLimitError,
 		namespaceID,
@@ -2101,6 +2180,10 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(errSignalNameTooLong, scope)
 	}
 
+	if common.IsReservedSignalName(request.GetSignalName()) {
+		return nil, wh.error(errSignalNameReserved, scope)
+	}
+
 	if request.WorkflowType == nil || request.WorkflowType.GetName() == "" {
 		return nil, wh.error(errWorkflowTypeNotSet, scope)
 	}
@@ -2151,7 +2234,7 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 	sizeLimitError := wh.config.BlobSizeLimitError(namespace)
 	sizeLimitWarn := wh.config.BlobSizeLimitWarn(namespace)
 	if err := common.CheckEventBlobSizeLimit(
-		request.GetSignalInput().Size(),
+		wh.historyPayloadsSize(namespace, request.GetSignalInput()),
 		sizeLimitWarn,
 		sizeLimitError,
 		namespaceID,
@@ -2163,7 +2246,7 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 	); err != nil {
 		return nil, wh.error(err, scope)
 	}
-	actualSize := request.GetInput().Size() + request.GetMemo().Size()
+	actualSize := wh.historyPayloadsSize(namespace, request.GetInput()) + request.GetMemo().Size()
 	if err := common.CheckEventBlobSizeLimit(
 		actualSize,
 		sizeLimitWarn,
@@ -3163,6 +3246,15 @@ func (wh *WorkflowHandler) ListTaskListPartitions(ctx context.Context, request *
 	}, err
 }
 
+// historyPayloadsSize returns the size the payloads take in history, payloads above the offload threshold
+// are written to the payload store by history and only their references are checked against the blob size limit
+func (wh *WorkflowHandler) historyPayloadsSize(
+	namespace string,
+	data *commonpb.Payloads,
+) int {
+	return payloadstore.PersistedSize(data, wh.config.PayloadOffloadThreshold(namespace))
+}
+
 func (wh *WorkflowHandler) getRawHistory(
 	scope metrics.Scope,
 	namespaceID string,
@@ -3177,7 +3269,8 @@ func (wh *WorkflowHandler) getRawHistory(
 	var rawHistory []*commonpb.DataBlob
 	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
 
-	resp, err := wh.GetHistoryManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
+	historyManager := payloadstore.NewInflatingHistoryManager(wh.GetHistoryManager(), wh.GetPayloadStoreProvider())
+	resp, err := historyManager.ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
 		MinEventID:    firstEventID,
 		MaxEventID:    nextEventID,
@@ -3238,6 +3331,7 @@ func (wh *WorkflowHandler) getRawHistory(
 }
 
 func (wh *WorkflowHandler) getHistory(
+	ctx context.Context,
 	scope metrics.Scope,
 	namespaceID string,
 	execution commonpb.WorkflowExecution,
@@ -3285,6 +3379,11 @@ func (wh *WorkflowHandler) getHistory(
 		return nil, nil, err
 	}
 
+	// replace references to offloaded payloads so callers always see the original payloads
+	if err := payloadstore.InflateEvents(ctx, wh.GetPayloadStoreProvider(), historyEvents); err != nil {
+		return nil, nil, err
+	}
+
 	if len(nextPageToken) == 0 && transientDecision != nil {
 		if err := wh.validateTransientDecisionEvents(nextEventID, transientDecision); err != nil {
 			scope.IncCounter(metrics.ServiceErrIncompleteHistoryCounter)
@@ -3455,6 +3554,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 		}
 		scope = scope.Tagged(metrics.NamespaceTag(namespace.GetInfo().Name))
 		history, persistenceToken, err = wh.getHistory(
+			ctx,
 			scope,
 			namespaceID,
 			*matchingResp.GetWorkflowExecution(),
@@ -44,15 +44,18 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/convert"
+	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
@@ -479,6 +482,37 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskTime
 	s.Equal(errInvalidWorkflowTaskTimeoutSeconds, err)
 }
 
+func (s *workflowHandlerSuite) TestRespondDecisionTaskCompleted_Failed_IdentityTooLong() {
+	config := s.newConfig()
+	config.MaxIDLengthLimit = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(
+		cache.NewLocalNamespaceCacheEntryForTest(&persistenceblobs.NamespaceInfo{Name: s.testNamespace}, &persistenceblobs.NamespaceConfig{}, "", nil), nil,
+	)
+
+	// history is never called, so no activity is started on behalf of the worker
+	resp, err := wh.RespondDecisionTaskCompleted(context.Background(), &workflowservice.RespondDecisionTaskCompletedRequest{
+		TaskToken: s.newDecisionTaskToken(wh),
+		Identity:  "an identity which is too long",
+	})
+	s.Nil(resp)
+	s.Equal(errIdentityTooLong, err)
+}
+
+func (s *workflowHandlerSuite) TestRespondDecisionTaskCompleted_Failed_InvalidEagerActivityTaskSlots() {
+	wh := s.getWorkflowHandler(s.newConfig())
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(
+		cache.NewLocalNamespaceCacheEntryForTest(&persistenceblobs.NamespaceInfo{Name: s.testNamespace}, &persistenceblobs.NamespaceConfig{}, "", nil), nil,
+	)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(headers.EagerActivityTaskSlotsHeaderName, "-1"))
+	resp, err := wh.RespondDecisionTaskCompleted(ctx, &workflowservice.RespondDecisionTaskCompletedRequest{
+		TaskToken: s.newDecisionTaskToken(wh),
+	})
+	s.Nil(resp)
+	s.Equal(errInvalidEagerActivityTaskSlots, err)
+}
+
 func (s *workflowHandlerSuite) TestRegisterNamespace_Failure_InvalidArchivalURI() {
 	s.mockClusterMetadata.EXPECT().IsGlobalNamespaceEnabled().Return(false)
 	s.mockArchivalMetadata.On("GetHistoryConfig").Return(archiver.NewArchivalConfig("enabled", dc.GetStringPropertyFn("enabled"), dc.GetBoolPropertyFn(true), "disabled", "random URI"))
@@ -1030,12 +1064,68 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
 	scope := metrics.NoopScope(metrics.Frontend)
-	history, token, err := wh.getHistory(scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
+	history, token, err := wh.getHistory(context.Background(), scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
 	s.NoError(err)
 	s.NotNil(history)
 	s.Equal([]byte{}, token)
 }
 
+func (s *workflowHandlerSuite) TestGetHistory_KeepReservedSignals() {
+	namespaceID := uuid.New()
+	firstEventID := int64(100)
+	nextEventID := int64(103)
+	branchToken := []byte{1}
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wid",
+		RunId:      "rid",
+	}
+	shardID := common.WorkflowIDToHistoryShard(we.WorkflowId, numHistoryShards)
+	req := &persistence.ReadHistoryBranchRequest{
+		BranchToken:   branchToken,
+		MinEventID:    firstEventID,
+		MaxEventID:    nextEventID,
+		PageSize:      0,
+		NextPageToken: []byte{},
+		ShardID:       convert.IntPtr(shardID),
+	}
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", req).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*historypb.HistoryEvent{
+			{
+				EventId:   int64(100),
+				EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+				Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+					SignalName: "my signal name",
+				}},
+			},
+			{
+				EventId:   int64(101),
+				EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+				Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+					SignalName: common.WorkflowPausedSignalName,
+				}},
+			},
+			{
+				EventId:   int64(102),
+				EventType: enumspb.EVENT_TYPE_DECISION_TASK_SCHEDULED,
+			},
+		},
+		NextPageToken:    []byte{},
+		Size:             3,
+		LastFirstEventID: nextEventID,
+	}, nil).Once()
+
+	wh := s.getWorkflowHandler(s.newConfig())
+
+	scope := metrics.NoopScope(metrics.Frontend)
+	history, _, err := wh.getHistory(context.Background(), scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
+	s.NoError(err)
+	s.Len(history.Events, 3)
+	for i, event := range history.Events {
+		s.Equal(firstEventID+int64(i), event.GetEventId())
+	}
+	s.Equal(common.WorkflowPausedSignalName, history.Events[1].GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+}
+
 func (s *workflowHandlerSuite) TestListArchivedVisibility_Failure_InvalidRequest() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
@@ -1332,6 +1422,17 @@ func (s *workflowHandlerSuite) TestVerifyHistoryIsComplete() {
 	}
 }
 
+func (s *workflowHandlerSuite) newDecisionTaskToken(wh *WorkflowHandler) []byte {
+	token, err := wh.tokenSerializer.Serialize(&tokengenpb.Task{
+		NamespaceId: s.testNamespaceID,
+		WorkflowId:  testWorkflowID,
+		RunId:       testRunID,
+		ScheduleId:  2,
+	})
+	s.NoError(err)
+	return token
+}
+
 func (s *workflowHandlerSuite) newConfig() *Config {
 	return NewConfig(dc.NewCollection(dc.NewNopClient(), s.mockResource.GetLogger()), numHistoryShards, false)
 }
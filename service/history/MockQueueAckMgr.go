@@ -125,3 +125,29 @@ func (_m *MockQueueAckMgr) updateQueueAckLevel() error {
 	}
 	return r0
 }
+
+// isNamespaceSplit is mock implementation for isNamespaceSplit of QueueAckMgr
+func (_m *MockQueueAckMgr) isNamespaceSplit(namespaceID string) bool {
+	ret := _m.Called(namespaceID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(namespaceID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// hasPendingTasksBefore is mock implementation for hasPendingTasksBefore of QueueAckMgr
+func (_m *MockQueueAckMgr) hasPendingTasksBefore(namespaceID string, workflowID string, runID string, taskID int64) bool {
+	ret := _m.Called(namespaceID, workflowID, runID, taskID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, string, string, int64) bool); ok {
+		r0 = rf(namespaceID, workflowID, runID, taskID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
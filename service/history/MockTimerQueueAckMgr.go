@@ -134,3 +134,15 @@ func (_m *MockTimerQueueAckMgr) updateAckLevel() error {
 	}
 	return r0
 }
+
+func (_m *MockTimerQueueAckMgr) isNamespaceSplit(namespaceID string) bool {
+	ret := _m.Called(namespaceID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(namespaceID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
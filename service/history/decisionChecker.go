@@ -45,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -240,6 +241,10 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 		return serviceerror.NewInvalidArgument("Namespace exceeds length limit.")
 	}
 
+	if _, err := getActivityLabelSelector(attributes.GetHeader()); err != nil {
+		return serviceerror.NewInvalidArgument("Activity label selector is not a valid map of labels.")
+	}
+
 	// Only attempt to deduce and fill in unspecified timeouts only when all timeouts are non-negative.
 	if attributes.GetScheduleToCloseTimeoutSeconds() < 0 || attributes.GetScheduleToStartTimeoutSeconds() < 0 ||
 		attributes.GetStartToCloseTimeoutSeconds() < 0 || attributes.GetHeartbeatTimeoutSeconds() < 0 {
@@ -359,6 +364,25 @@ func (v *decisionAttrValidator) validateRecordMarkerAttributes(
 	return nil
 }
 
+func (v *decisionAttrValidator) validateUpsertWorkflowMemoAttributes(
+	attributes *decisionpb.RecordMarkerDecisionAttributes,
+) error {
+
+	if len(attributes.GetDetails()) == 0 {
+		return serviceerror.NewInvalidArgument("Memo fields are not set on UpsertWorkflowMemo decision.")
+	}
+	for key, fieldPayloads := range attributes.GetDetails() {
+		if key == "" {
+			return serviceerror.NewInvalidArgument("Memo field key is empty on UpsertWorkflowMemo decision.")
+		}
+		if len(fieldPayloads.GetPayloads()) != 1 {
+			return serviceerror.NewInvalidArgument(fmt.Sprintf("Memo field %v must hold exactly one payload on UpsertWorkflowMemo decision.", key))
+		}
+	}
+
+	return nil
+}
+
 func (v *decisionAttrValidator) validateCompleteWorkflowExecutionAttributes(
 	attributes *decisionpb.CompleteWorkflowExecutionDecisionAttributes,
 ) error {
@@ -461,6 +485,9 @@ func (v *decisionAttrValidator) validateSignalExternalWorkflowExecutionAttribute
 	if attributes.GetSignalName() == "" {
 		return serviceerror.NewInvalidArgument("SignalName is not set on decision.")
 	}
+	if common.IsReservedSignalName(attributes.GetSignalName()) {
+		return serviceerror.NewInvalidArgument("SignalName is reserved on decision.")
+	}
 
 	return nil
 }
@@ -688,3 +715,20 @@ func (v *decisionAttrValidator) createCrossNamespaceCallError(
 ) error {
 	return serviceerror.NewInvalidArgument(fmt.Sprintf("cannot make cross namespace call between %v and %v", namespaceEntry.GetInfo().Name, targetNamespaceEntry.GetInfo().Name))
 }
+
+// getActivityLabelSelector returns the label selector carried in the reserved field of the activity header,
+// or nil if the activity is not restricted to labeled pollers
+func getActivityLabelSelector(
+	header *commonpb.Header,
+) (map[string]string, error) {
+
+	p, ok := header.GetFields()[common.ActivityLabelSelectorHeaderField]
+	if !ok {
+		return nil, nil
+	}
+	var selector map[string]string
+	if err := payload.Decode(p, &selector); err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
@@ -37,6 +37,7 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
@@ -133,6 +134,10 @@ func (s *decisionAttrValidatorSuite) TestValidateSignalExternalWorkflowExecution
 	s.EqualError(err, "Invalid RunId set on decision.")
 	attributes.Execution.RunId = testRunID
 
+	attributes.SignalName = common.WorkflowPausedSignalName
+	err = s.validator.validateSignalExternalWorkflowExecutionAttributes(s.testNamespaceID, s.testTargetNamespaceID, attributes)
+	s.EqualError(err, "SignalName is reserved on decision.")
+
 	attributes.SignalName = "my signal name"
 	err = s.validator.validateSignalExternalWorkflowExecutionAttributes(s.testNamespaceID, s.testTargetNamespaceID, attributes)
 	s.NoError(err)
@@ -162,6 +167,48 @@ func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowSearchAttributes(
 	s.Nil(err)
 }
 
+func (s *decisionAttrValidatorSuite) TestValidateUpsertWorkflowMemoAttributes() {
+	attributes := &decisionpb.RecordMarkerDecisionAttributes{
+		MarkerName: common.UpsertWorkflowMemoMarkerName,
+	}
+	err := s.validator.validateUpsertWorkflowMemoAttributes(attributes)
+	s.EqualError(err, "Memo fields are not set on UpsertWorkflowMemo decision.")
+
+	attributes.Details = map[string]*commonpb.Payloads{"": payloads.EncodeString("value")}
+	err = s.validator.validateUpsertWorkflowMemoAttributes(attributes)
+	s.EqualError(err, "Memo field key is empty on UpsertWorkflowMemo decision.")
+
+	attributes.Details = map[string]*commonpb.Payloads{"key": {Payloads: []*commonpb.Payload{payload.EncodeString("value"), payload.EncodeString("another value")}}}
+	err = s.validator.validateUpsertWorkflowMemoAttributes(attributes)
+	s.EqualError(err, "Memo field key must hold exactly one payload on UpsertWorkflowMemo decision.")
+
+	attributes.Details = map[string]*commonpb.Payloads{"key": payloads.EncodeString("value")}
+	err = s.validator.validateUpsertWorkflowMemoAttributes(attributes)
+	s.Nil(err)
+}
+
+func (s *decisionAttrValidatorSuite) TestGetActivityLabelSelector() {
+	selector, err := getActivityLabelSelector(nil)
+	s.NoError(err)
+	s.Nil(selector)
+
+	header := &commonpb.Header{Fields: map[string]*commonpb.Payload{"other": payload.EncodeString("value")}}
+	selector, err = getActivityLabelSelector(header)
+	s.NoError(err)
+	s.Nil(selector)
+
+	header.Fields[common.ActivityLabelSelectorHeaderField] = payload.EncodeString("gpu")
+	_, err = getActivityLabelSelector(header)
+	s.Error(err)
+
+	p, err := payload.Encode(map[string]string{"gpu": "true"})
+	s.NoError(err)
+	header.Fields[common.ActivityLabelSelectorHeaderField] = p
+	selector, err = getActivityLabelSelector(header)
+	s.NoError(err)
+	s.Equal(map[string]string{"gpu": "true"}, selector)
+}
+
 func (s *decisionAttrValidatorSuite) TestValidateCrossNamespaceCall_LocalToLocal() {
 	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
 		&persistenceblobs.NamespaceInfo{Name: s.testNamespaceID},
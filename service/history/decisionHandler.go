@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	historypb "go.temporal.io/temporal-proto/history/v1"
@@ -39,6 +40,7 @@ import (
 
 	historygenpb "github.com/temporalio/temporal/.gen/proto/history/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
@@ -174,6 +176,10 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 			if !mutableState.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
+			if mutableState.IsWorkflowExecutionPaused() {
+				// decision task is regenerated when the workflow is unpaused
+				return nil, ErrWorkflowPaused
+			}
 
 			decision, isRunning := mutableState.GetDecisionInfo(scheduleID)
 
@@ -375,6 +381,7 @@ Update_History_Loop:
 			failDecision                *failDecisionInfo
 			activityNotStartedCancelled bool
 			continueAsNewBuilder        mutableState
+			eagerActivityCandidates     []eagerActivity
 
 			hasUnhandledEvents bool
 		)
@@ -445,6 +452,8 @@ Update_History_Loop:
 
 			continueAsNewBuilder = decisionTaskHandler.continueAsNewBuilder
 
+			eagerActivityCandidates = decisionTaskHandler.eagerActivities
+
 			hasUnhandledEvents = decisionTaskHandler.hasUnhandledEventsBeforeDecisions
 		}
 
@@ -462,6 +471,23 @@ Update_History_Loop:
 			continueAsNewBuilder = nil
 		}
 
+		// start activities for the worker completing the decision, the rest are dispatched through matching
+		var eagerActivities []*persistence.ActivityInfo
+		if failDecision == nil && req.GetEagerActivityTaskSlots() > 0 &&
+			msBuilder.IsWorkflowExecutionRunning() && !msBuilder.IsWorkflowExecutionPaused() &&
+			handler.config.EnableEagerActivityDispatch(namespaceEntry.GetInfo().Name) {
+			eagerActivities, err = handler.startEagerActivities(
+				msBuilder,
+				eagerActivityCandidates,
+				int(req.GetEagerActivityTaskSlots()),
+				handler.config.EagerActivityMaxPayloadSize(namespaceEntry.GetInfo().Name),
+				request.GetIdentity(),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		createNewDecisionTask := msBuilder.IsWorkflowExecutionRunning() && (hasUnhandledEvents || request.GetForceCreateNewDecisionTask() || activityNotStartedCancelled)
 		var newDecisionTaskScheduledID int64
 		if createNewDecisionTask {
@@ -572,6 +598,20 @@ Update_History_Loop:
 			// sticky is always enabled when worker request for new decision task from RespondDecisionTaskCompleted
 			resp.StartedResponse.StickyExecutionEnabled = true
 		}
+		for _, ai := range eagerActivities {
+			activityTask, err := handler.createPollForActivityTaskResponse(namespaceEntry, msBuilder, ai)
+			if err != nil {
+				return nil, err
+			}
+			resp.ActivityTasks = append(resp.ActivityTasks, activityTask)
+		}
+		if len(eagerActivities) > 0 {
+			handler.metricsClient.AddCounter(
+				metrics.HistoryRespondDecisionTaskCompletedScope,
+				metrics.EagerActivityDispatchCounter,
+				int64(len(eagerActivities)),
+			)
+		}
 
 		return resp, nil
 	}
@@ -579,6 +619,88 @@ Update_History_Loop:
 	return nil, ErrMaxAttemptsExceeded
 }
 
+func (handler *decisionHandlerImpl) startEagerActivities(
+	msBuilder mutableState,
+	candidates []eagerActivity,
+	slots int,
+	maxPayloadSize int,
+	identity string,
+) ([]*persistence.ActivityInfo, error) {
+
+	var activities []*persistence.ActivityInfo
+	payloadSize := 0
+	for _, candidate := range candidates {
+		if len(activities) >= slots {
+			break
+		}
+		// activity tasks are returned in the response header, activities which would make it
+		// too large are dispatched through matching instead
+		if payloadSize+candidate.payloadSize > maxPayloadSize {
+			continue
+		}
+		ai, ok := msBuilder.GetActivityInfo(candidate.scheduleID)
+		if !ok {
+			// activity is already cancelled by a following decision
+			continue
+		}
+		if _, err := msBuilder.AddActivityTaskStartedEvent(ai, candidate.scheduleID, uuid.New(), identity); err != nil {
+			return nil, err
+		}
+		payloadSize += candidate.payloadSize
+		activities = append(activities, ai)
+	}
+	return activities, nil
+}
+
+func (handler *decisionHandlerImpl) createPollForActivityTaskResponse(
+	namespaceEntry *cache.NamespaceCacheEntry,
+	msBuilder mutableState,
+	ai *persistence.ActivityInfo,
+) (*workflowservice.PollForActivityTaskResponse, error) {
+
+	scheduledEvent, err := msBuilder.GetActivityScheduledEvent(ai.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	attributes := scheduledEvent.GetActivityTaskScheduledEventAttributes()
+	executionInfo := msBuilder.GetExecutionInfo()
+
+	taskToken, err := handler.tokenSerializer.Serialize(&tokengenpb.Task{
+		NamespaceId:     executionInfo.NamespaceID,
+		WorkflowId:      executionInfo.WorkflowID,
+		RunId:           executionInfo.RunID,
+		ScheduleId:      ai.ScheduleID,
+		ScheduleAttempt: int64(ai.Attempt),
+		ActivityId:      attributes.GetActivityId(),
+		ActivityType:    attributes.GetActivityType().GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &workflowservice.PollForActivityTaskResponse{
+		TaskToken: taskToken,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: executionInfo.WorkflowID,
+			RunId:      executionInfo.RunID,
+		},
+		ActivityId:                      attributes.GetActivityId(),
+		ActivityType:                    attributes.GetActivityType(),
+		Input:                           attributes.GetInput(),
+		ScheduledTimestamp:              scheduledEvent.GetTimestamp(),
+		ScheduleToCloseTimeoutSeconds:   attributes.GetScheduleToCloseTimeoutSeconds(),
+		StartedTimestamp:                ai.StartedTime.UnixNano(),
+		StartToCloseTimeoutSeconds:      attributes.GetStartToCloseTimeoutSeconds(),
+		HeartbeatTimeoutSeconds:         attributes.GetHeartbeatTimeoutSeconds(),
+		Attempt:                         ai.Attempt,
+		ScheduledTimestampOfThisAttempt: ai.ScheduledTime.UnixNano(),
+		HeartbeatDetails:                ai.Details,
+		WorkflowType:                    msBuilder.GetWorkflowType(),
+		WorkflowNamespace:               namespaceEntry.GetInfo().Name,
+		Header:                          attributes.GetHeader(),
+	}, nil
+}
+
 func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
 	namespaceID string,
 	msBuilder mutableState,
@@ -42,9 +42,16 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/payloadstore"
 )
 
 type (
+	// eagerActivity is an activity which can be dispatched to the worker completing the decision
+	eagerActivity struct {
+		scheduleID  int64
+		payloadSize int
+	}
+
 	decisionAttrValidationFn func() error
 
 	decisionTaskHandlerImpl struct {
@@ -59,6 +66,8 @@ type (
 		continueAsNewBuilder              mutableState
 		stopProcessing                    bool // should stop processing any more decisions
 		mutableState                      mutableState
+		// activities scheduled on the workflow task list, which can be dispatched to the worker completing the decision
+		eagerActivities []eagerActivity
 
 		// validation
 		attrValidator    *decisionAttrValidator
@@ -216,7 +225,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.DecisionTypeTag(enumspb.DECISION_TYPE_SCHEDULE_ACTIVITY_TASK.String()),
-		attr.GetInput().Size(),
+		handler.historyPayloadsSize(attr.GetInput()),
 		"ScheduleActivityTaskDecisionAttributes.Input exceeds size limit.",
 	)
 	if err != nil || failWorkflow {
@@ -224,9 +233,17 @@ func (handler *decisionTaskHandlerImpl) handleDecisionScheduleActivity(
 		return err
 	}
 
-	_, _, err = handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
+	_, ai, err := handler.mutableState.AddActivityTaskScheduledEvent(handler.decisionTaskCompletedID, attr)
 	switch err.(type) {
 	case nil:
+		// activities with a label selector must go through matching to reach a poller with the requested labels
+		selector, _ := getActivityLabelSelector(attr.GetHeader())
+		if targetNamespaceID == namespaceID && ai.TaskList == executionInfo.TaskList && len(selector) == 0 {
+			handler.eagerActivities = append(handler.eagerActivities, eagerActivity{
+				scheduleID:  ai.ScheduleID,
+				payloadSize: attr.GetInput().Size() + attr.GetHeader().Size(),
+			})
+		}
 		return nil
 	case *serviceerror.InvalidArgument:
 		return handler.handlerFailDecision(
@@ -344,7 +361,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionCompleteWorkflow(
 
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.DecisionTypeTag(enumspb.DECISION_TYPE_COMPLETE_WORKFLOW_EXECUTION.String()),
-		attr.GetResult().Size(),
+		handler.historyPayloadsSize(attr.GetResult()),
 		"CompleteWorkflowExecutionDecisionAttributes.Result exceeds size limit.",
 	)
 	if err != nil || failWorkflow {
@@ -602,6 +619,11 @@ func (handler *decisionTaskHandlerImpl) handleDecisionRecordMarker(
 	attr *decisionpb.RecordMarkerDecisionAttributes,
 ) error {
 
+	// memo upsert is expressed as a marker with a reserved name, so SDKs can issue it without a new decision type
+	if attr.GetMarkerName() == common.UpsertWorkflowMemoMarkerName {
+		return handler.handleDecisionUpsertWorkflowMemo(attr)
+	}
+
 	handler.metricsClient.IncCounter(
 		metrics.HistoryRespondDecisionTaskCompletedScope,
 		metrics.DecisionTypeRecordMarkerCounter,
@@ -659,7 +681,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionContinueAsNewWorkflow(
 
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.DecisionTypeTag(enumspb.DECISION_TYPE_CONTINUE_AS_NEW_WORKFLOW_EXECUTION.String()),
-		attr.GetInput().Size(),
+		handler.historyPayloadsSize(attr.GetInput()),
 		"ContinueAsNewWorkflowExecutionDecisionAttributes. Input exceeds size limit.",
 	)
 	if err != nil || failWorkflow {
@@ -752,7 +774,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionStartChildWorkflow(
 
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.DecisionTypeTag(enumspb.DECISION_TYPE_START_CHILD_WORKFLOW_EXECUTION.String()),
-		attr.GetInput().Size(),
+		handler.historyPayloadsSize(attr.GetInput()),
 		"StartChildWorkflowExecutionDecisionAttributes.Input exceeds size limit.",
 	)
 	if err != nil || failWorkflow {
@@ -807,7 +829,7 @@ func (handler *decisionTaskHandlerImpl) handleDecisionSignalExternalWorkflow(
 
 	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
 		metrics.DecisionTypeTag(enumspb.DECISION_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION.String()),
-		attr.GetInput().Size(),
+		handler.historyPayloadsSize(attr.GetInput()),
 		"SignalExternalWorkflowExecutionDecisionAttributes.Input exceeds size limit.",
 	)
 	if err != nil || failWorkflow {
@@ -870,6 +892,53 @@ func (handler *decisionTaskHandlerImpl) handleDecisionUpsertWorkflowSearchAttrib
 	return err
 }
 
+func (handler *decisionTaskHandlerImpl) handleDecisionUpsertWorkflowMemo(
+	attr *decisionpb.RecordMarkerDecisionAttributes,
+) error {
+
+	handler.metricsClient.IncCounter(
+		metrics.HistoryRespondDecisionTaskCompletedScope,
+		metrics.DecisionTypeUpsertWorkflowMemoCounter,
+	)
+
+	if err := handler.validateDecisionAttr(
+		func() error {
+			return handler.attrValidator.validateUpsertWorkflowMemoAttributes(attr)
+		},
+		enumspb.DECISION_TASK_FAILED_CAUSE_BAD_RECORD_MARKER_ATTRIBUTES,
+	); err != nil || handler.stopProcessing {
+		return err
+	}
+
+	// the limit applies to the memo resulting from the upsert, not only to the upserted fields
+	failWorkflow, err := handler.sizeLimitChecker.failWorkflowIfPayloadSizeExceedsLimit(
+		metrics.DecisionTypeTag(common.UpsertWorkflowMemoMarkerName),
+		upsertedMemoSize(handler.mutableState.GetExecutionInfo().Memo, getUpsertWorkflowMemo(attr.GetDetails())),
+		"UpsertWorkflowMemo decision exceeds size limit.",
+	)
+	if err != nil || failWorkflow {
+		handler.stopProcessing = true
+		return err
+	}
+
+	_, err = handler.mutableState.AddUpsertWorkflowMemoEvent(
+		handler.decisionTaskCompletedID, attr,
+	)
+	return err
+}
+
+func upsertedMemoSize(current map[string]*commonpb.Payload, upsert map[string]*commonpb.Payload) int {
+	result := searchAttributesSize(upsert)
+
+	for k, v := range current {
+		if _, ok := upsert[k]; !ok {
+			result += len(k)
+			result += len(v.GetData())
+		}
+	}
+	return result
+}
+
 func searchAttributesSize(fields map[string]*commonpb.Payload) int {
 	result := 0
 
@@ -945,3 +1014,13 @@ func (handler *decisionTaskHandlerImpl) handlerFailDecision(
 	handler.stopProcessing = true
 	return nil
 }
+
+// historyPayloadsSize returns the size the payloads take in history, payloads above the offload threshold
+// are written to the payload store and only their references are checked against the blob size limit
+func (handler *decisionTaskHandlerImpl) historyPayloadsSize(
+	data *commonpb.Payloads,
+) int {
+
+	namespace := handler.namespaceEntry.GetInfo().Name
+	return payloadstore.PersistedSize(data, handler.config.PayloadOffloadThreshold(namespace))
+}
@@ -27,6 +27,7 @@
 package history
 
 import (
+	"context"
 	"time"
 
 	historypb "go.temporal.io/temporal-proto/history/v1"
@@ -37,6 +38,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -68,6 +70,7 @@ type (
 	eventsCacheImpl struct {
 		cache.Cache
 		eventsV2Mgr   persistence.HistoryManager
+		payloadStore  payloadstore.Provider
 		disabled      bool
 		logger        log.Logger
 		metricsClient metrics.Client
@@ -92,11 +95,11 @@ func newEventsCache(shardCtx ShardContext) eventsCache {
 	config := shardCtx.GetConfig()
 	shardID := convert.IntPtr(shardCtx.GetShardID())
 	return newEventsCacheWithOptions(config.EventsCacheInitialSize(), config.EventsCacheMaxSize(), config.EventsCacheTTL(),
-		shardCtx.GetHistoryManager(), false, shardCtx.GetLogger(), shardCtx.GetMetricsClient(), shardID)
+		shardCtx.GetHistoryManager(), shardCtx.GetPayloadStoreProvider(), false, shardCtx.GetLogger(), shardCtx.GetMetricsClient(), shardID)
 }
 
 func newEventsCacheWithOptions(initialSize, maxSize int, ttl time.Duration,
-	eventsV2Mgr persistence.HistoryManager, disabled bool, logger log.Logger, metrics metrics.Client, shardID *int) *eventsCacheImpl {
+	eventsV2Mgr persistence.HistoryManager, payloadStore payloadstore.Provider, disabled bool, logger log.Logger, metrics metrics.Client, shardID *int) *eventsCacheImpl {
 	opts := &cache.Options{}
 	opts.InitialCapacity = initialSize
 	opts.TTL = ttl
@@ -104,6 +107,7 @@ func newEventsCacheWithOptions(initialSize, maxSize int, ttl time.Duration,
 	return &eventsCacheImpl{
 		Cache:         cache.New(maxSize, opts),
 		eventsV2Mgr:   eventsV2Mgr,
+		payloadStore:  payloadStore,
 		disabled:      disabled,
 		logger:        logger.WithTags(tag.ComponentEventsCache),
 		metricsClient: metrics,
@@ -191,9 +195,17 @@ func (e *eventsCacheImpl) getHistoryEventFromStore(namespaceID, workflowID, runI
 	}
 
 	// find history event from batch and return back single event to caller
-	for _, e := range response.HistoryEvents {
-		if e.GetEventId() == eventID {
-			return e, nil
+	for _, event := range response.HistoryEvents {
+		if event.GetEventId() == eventID {
+			// events in cache are always inflated, offloaded payloads are fetched only once
+			ctx, cancel := context.WithTimeout(context.Background(), payloadstore.DefaultTimeout)
+			event, err = payloadstore.InflateEvent(ctx, e.payloadStore, event)
+			cancel()
+			if err != nil {
+				e.metricsClient.IncCounter(metrics.EventsCacheGetFromStoreScope, metrics.CacheFailures)
+				return nil, err
+			}
+			return event, nil
 		}
 	}
 
@@ -29,9 +29,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	historypb "go.temporal.io/temporal-proto/history/v1"
 
@@ -39,6 +41,8 @@ import (
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -47,6 +51,10 @@ type (
 		suite.Suite
 		*require.Assertions
 
+		controller              *gomock.Controller
+		mockPayloadStore        *payloadstore.MockProvider
+		mockPayloadStoreBackend *payloadstore.MockStore
+
 		logger log.Logger
 
 		mockEventsV2Mgr *mocks.HistoryV2Manager
@@ -74,17 +82,21 @@ func (s *eventsCacheSuite) SetupTest() {
 	s.logger = loggerimpl.NewDevelopmentForTest(s.Suite)
 	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
 	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.mockPayloadStore = payloadstore.NewMockProvider(s.controller)
+	s.mockPayloadStoreBackend = payloadstore.NewMockStore(s.controller)
 	s.mockEventsV2Mgr = &mocks.HistoryV2Manager{}
 	s.cache = s.newTestEventsCache()
 }
 
 func (s *eventsCacheSuite) TearDownTest() {
 	s.mockEventsV2Mgr.AssertExpectations(s.T())
+	s.controller.Finish()
 }
 
 func (s *eventsCacheSuite) newTestEventsCache() *eventsCacheImpl {
 	shardId := 10
-	return newEventsCacheWithOptions(16, 32, time.Minute, s.mockEventsV2Mgr, false, s.logger,
+	return newEventsCacheWithOptions(16, 32, time.Minute, s.mockEventsV2Mgr, s.mockPayloadStore, false, s.logger,
 		metrics.NewClient(tally.NoopScope, metrics.History), &shardId)
 }
 
@@ -161,6 +173,51 @@ func (s *eventsCacheSuite) TestEventsCacheMissMultiEventsBatchV2Success() {
 	s.Equal(event6, actualEvent)
 }
 
+func (s *eventsCacheSuite) TestEventsCacheMissInflatesOffloadedPayload() {
+	namespaceID := "events-cache-miss-inflates-offloaded-payload-namespace"
+	workflowID := "events-cache-miss-inflates-offloaded-payload-workflow-id"
+	runID := "events-cache-miss-inflates-offloaded-payload-run-id"
+	payload := payloads.EncodeString("large activity input")
+	payloadData, err := payload.GetPayloads()[0].Marshal()
+	s.NoError(err)
+	reference := &commonpb.Payload{
+		Metadata: map[string][]byte{"encoding": []byte(payloadstore.EncodingReference)},
+		Data:     []byte("file:///tmp/payloads/" + namespaceID + "/checksum"),
+	}
+	event := &historypb.HistoryEvent{
+		EventId:   21,
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+		Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+			Input: &commonpb.Payloads{Payloads: []*commonpb.Payload{reference}},
+		}},
+	}
+
+	shardId := 10
+	s.mockEventsV2Mgr.On("ReadHistoryBranch", &persistence.ReadHistoryBranchRequest{
+		BranchToken:   []byte("store_token"),
+		MinEventID:    event.GetEventId(),
+		MaxEventID:    event.GetEventId() + 1,
+		PageSize:      1,
+		NextPageToken: nil,
+		ShardID:       &shardId,
+	}).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents:    []*historypb.HistoryEvent{event},
+		NextPageToken:    nil,
+		LastFirstEventID: event.GetEventId(),
+	}, nil).Once()
+	s.mockPayloadStore.EXPECT().GetStore("file").Return(s.mockPayloadStoreBackend, nil).Times(1)
+	s.mockPayloadStoreBackend.EXPECT().Get(gomock.Any(), gomock.Any()).Return(payloadData, nil).Times(1)
+
+	for i := 0; i < 2; i++ {
+		actualEvent, err := s.cache.getEvent(namespaceID, workflowID, runID, event.GetEventId(), event.GetEventId(),
+			[]byte("store_token"))
+		s.NoError(err)
+		s.Equal(payload, actualEvent.GetActivityTaskScheduledEventAttributes().GetInput())
+	}
+	// the persisted event must not be modified
+	s.Equal(reference, event.GetActivityTaskScheduledEventAttributes().GetInput().GetPayloads()[0])
+}
+
 func (s *eventsCacheSuite) TestEventsCacheMissV2Failure() {
 	namespaceID := "events-cache-miss-failure-namespace"
 	workflowID := "events-cache-miss-failure-workflow-id"
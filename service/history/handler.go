@@ -127,6 +127,7 @@ func (h *Handler) Start() {
 
 	h.replicationTaskFetchers = NewReplicationTaskFetchers(
 		h.GetLogger(),
+		h.GetMetricsClient(),
 		h.config,
 		h.GetClusterMetadata().GetReplicationConsumerConfig(),
 		h.GetClusterMetadata(),
@@ -1502,12 +1503,18 @@ func (h *Handler) GetReplicationMessages(ctx context.Context, request *historyse
 				ctx,
 				request.GetClusterName(),
 				token.GetLastRetrievedMessageId(),
+				int(token.GetPageSize()),
 			)
 			if err != nil {
 				h.GetLogger().Warn("Failed to get replication tasks for shard", tag.Error(err))
 				return
 			}
 
+			if err := compressReplicationTasks(tasks.GetReplicationTasks(), request.GetAcceptedCompressionType()); err != nil {
+				h.GetLogger().Warn("Failed to compress replication tasks for shard", tag.Error(err))
+				return
+			}
+
 			result.Store(token.GetShardId(), tasks)
 		}(token)
 	}
@@ -1527,6 +1534,37 @@ func (h *Handler) GetReplicationMessages(ctx context.Context, request *historyse
 	return &historyservice.GetReplicationMessagesResponse{MessagesByShard: messagesByShard}, nil
 }
 
+// GetReplicationStatus returns the replication progress of the requested shards towards the requested remote clusters
+func (h *Handler) GetReplicationStatus(ctx context.Context, request *historyservice.GetReplicationStatusRequest) (_ *historyservice.GetReplicationStatusResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryGetReplicationStatusScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	response := &historyservice.GetReplicationStatusResponse{}
+	for _, shardID := range request.GetShardIds() {
+		engine, err := h.controller.getEngineForShard(int(shardID))
+		if err != nil {
+			return nil, h.error(err, scope, "", "")
+		}
+
+		status, err := engine.GetReplicationStatus(ctx, request.GetRemoteClusters(), request.GetNamespaceId(), request.GetIncludeDlqTasks())
+		if err != nil {
+			return nil, h.error(err, scope, "", "")
+		}
+		response.Shards = append(response.Shards, status)
+	}
+
+	return response, nil
+}
+
 // GetDLQReplicationMessages is called by remote peers to get replicated messages for DLQ merging
 func (h *Handler) GetDLQReplicationMessages(ctx context.Context, request *historyservice.GetDLQReplicationMessagesRequest) (_ *historyservice.GetDLQReplicationMessagesResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -1769,6 +1807,174 @@ func (h *Handler) RefreshWorkflowTasks(ctx context.Context, request *historyserv
 	return &historyservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+func (h *Handler) ReindexWorkflowExecution(ctx context.Context, request *historyservice.ReindexWorkflowExecutionRequest) (_ *historyservice.ReindexWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryReindexWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	execution := request.GetRequest().GetExecution()
+	workflowID := execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	err = engine.ReindexWorkflowExecution(
+		ctx,
+		namespaceID,
+		commonpb.WorkflowExecution{
+			WorkflowId: execution.WorkflowId,
+			RunId:      execution.RunId,
+		},
+	)
+
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	return &historyservice.ReindexWorkflowExecutionResponse{}, nil
+}
+
+func (h *Handler) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (_ *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDeleteWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	execution := request.GetRequest().GetExecution()
+	workflowID := execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	err = engine.DeleteWorkflowExecution(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	return &historyservice.DeleteWorkflowExecutionResponse{}, nil
+}
+
+func (h *Handler) PauseWorkflowExecution(ctx context.Context, request *historyservice.PauseWorkflowExecutionRequest) (_ *historyservice.PauseWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryPauseWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	execution := request.GetRequest().GetExecution()
+	workflowID := execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	err = engine.PauseWorkflowExecution(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	return &historyservice.PauseWorkflowExecutionResponse{}, nil
+}
+
+func (h *Handler) UnpauseWorkflowExecution(ctx context.Context, request *historyservice.UnpauseWorkflowExecutionRequest) (_ *historyservice.UnpauseWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUnpauseWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	execution := request.GetRequest().GetExecution()
+	workflowID := execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	err = engine.UnpauseWorkflowExecution(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	return &historyservice.UnpauseWorkflowExecutionResponse{}, nil
+}
+
+func (h *Handler) UpsertWorkflowExecutionAttributes(ctx context.Context, request *historyservice.UpsertWorkflowExecutionAttributesRequest) (_ *historyservice.UpsertWorkflowExecutionAttributesResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUpsertWorkflowExecutionAttributesScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	execution := request.GetRequest().GetExecution()
+	workflowID := execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	err = engine.UpsertWorkflowExecutionAttributes(ctx, request)
+	if err != nil {
+		err = h.error(err, scope, namespaceID, workflowID)
+		return nil, err
+	}
+
+	return &historyservice.UpsertWorkflowExecutionAttributesResponse{}, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -60,6 +60,7 @@ import (
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/elasticsearch/validator"
 	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -112,14 +113,20 @@ type (
 		ReplicateEventsV2(ctx context.Context, request *historyservice.ReplicateEventsV2Request) error
 		SyncShardStatus(ctx context.Context, request *historyservice.SyncShardStatusRequest) error
 		SyncActivity(ctx context.Context, request *historyservice.SyncActivityRequest) error
-		GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64) (*replicationgenpb.ReplicationMessages, error)
+		GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64, pageSize int) (*replicationgenpb.ReplicationMessages, error)
 		GetDLQReplicationMessages(ctx context.Context, taskInfos []*replicationgenpb.ReplicationTaskInfo) ([]*replicationgenpb.ReplicationTask, error)
+		GetReplicationStatus(ctx context.Context, remoteClusters []string, namespaceID string, includeDLQTasks bool) (*replicationgenpb.ShardReplicationStatus, error)
 		QueryWorkflow(ctx context.Context, request *historyservice.QueryWorkflowRequest) (*historyservice.QueryWorkflowResponse, error)
 		ReapplyEvents(ctx context.Context, namespaceUUID string, workflowID string, runID string, events []*historypb.HistoryEvent) error
 		ReadDLQMessages(ctx context.Context, messagesRequest *historyservice.ReadDLQMessagesRequest) (*historyservice.ReadDLQMessagesResponse, error)
 		PurgeDLQMessages(ctx context.Context, messagesRequest *historyservice.PurgeDLQMessagesRequest) error
 		MergeDLQMessages(ctx context.Context, messagesRequest *historyservice.MergeDLQMessagesRequest) (*historyservice.MergeDLQMessagesResponse, error)
 		RefreshWorkflowTasks(ctx context.Context, namespaceUUID string, execution commonpb.WorkflowExecution) error
+		ReindexWorkflowExecution(ctx context.Context, namespaceUUID string, execution commonpb.WorkflowExecution) error
+		DeleteWorkflowExecution(ctx context.Context, deleteRequest *historyservice.DeleteWorkflowExecutionRequest) error
+		PauseWorkflowExecution(ctx context.Context, pauseRequest *historyservice.PauseWorkflowExecutionRequest) error
+		UnpauseWorkflowExecution(ctx context.Context, unpauseRequest *historyservice.UnpauseWorkflowExecutionRequest) error
+		UpsertWorkflowExecutionAttributes(ctx context.Context, upsertRequest *historyservice.UpsertWorkflowExecutionAttributesRequest) error
 
 		NotifyNewHistoryEvent(event *historyEventNotification)
 		NotifyNewTransferTasks(tasks []persistence.Task)
@@ -160,6 +167,7 @@ type (
 		rawMatchingClient         matching.Client
 		versionChecker            headers.VersionChecker
 		replicationDLQHandler     replicationDLQHandler
+		taskDLQHandler            taskDLQHandler
 	}
 )
 
@@ -182,10 +190,18 @@ var (
 	ErrActivityTaskNotFound = serviceerror.NewNotFound("invalid activityID or activity already timed out or invoking workflow is completed")
 	// ErrWorkflowCompleted is the error to indicate workflow execution already completed
 	ErrWorkflowCompleted = serviceerror.NewNotFound("workflow execution already completed")
+	// ErrWorkflowPaused is the error to indicate tasks of a paused workflow execution are not dispatched
+	ErrWorkflowPaused = serviceerror.NewNotFound("workflow execution is paused")
+	// ErrWorkflowAlreadyPaused is the error to indicate workflow execution is already paused
+	ErrWorkflowAlreadyPaused = serviceerror.NewFailedPrecondition("workflow execution is already paused")
+	// ErrWorkflowNotPaused is the error to indicate workflow execution is not paused
+	ErrWorkflowNotPaused = serviceerror.NewFailedPrecondition("workflow execution is not paused")
 	// ErrWorkflowParent is the error to parent execution is given and mismatch
 	ErrWorkflowParent = serviceerror.NewNotFound("workflow parent does not match")
 	// ErrDeserializingToken is the error to indicate task token is invalid
 	ErrDeserializingToken = serviceerror.NewInvalidArgument("error deserializing task token")
+	// ErrSignalNameReserved is the error to indicate signal name is reserved for signals recorded by the server
+	ErrSignalNameReserved = serviceerror.NewInvalidArgument("signal name is reserved by the server")
 	// ErrSignalOverSize is the error to indicate signal input size is > 256K
 	ErrSignalOverSize = serviceerror.NewInvalidArgument("signal input size is over 256K")
 	// ErrCancellationAlreadyRequested is the error indicating cancellation for target workflow is already requested
@@ -202,6 +218,10 @@ var (
 	ErrConsistentQueryNotEnabled = serviceerror.NewInvalidArgument("cluster or namespace does not enable strongly consistent query but strongly consistent query was requested")
 	// ErrConsistentQueryBufferExceeded is error indicating that too many consistent queries have been buffered and until buffered queries are finished new consistent queries cannot be buffered
 	ErrConsistentQueryBufferExceeded = serviceerror.NewInternal("consistent query buffer is full, cannot accept new consistent queries")
+	// ErrNamespaceHandover is error indicating that the namespace rejects writes while a graceful failover is draining replication
+	ErrNamespaceHandover = serviceerror.NewUnavailable("namespace is handing over to another cluster, retry later")
+
+	errReplicationNotEnabled = serviceerror.NewInternal("replication is not enabled on this shard")
 
 	// FailedWorkflowStatuses is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
@@ -265,6 +285,7 @@ func NewEngineWithShardContext(
 
 	historyEngImpl.txProcessor = newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, matching, historyClient, queueTaskProcessor, logger)
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(shard, historyEngImpl, matching, queueTaskProcessor, logger)
+	historyEngImpl.taskDLQHandler = newTaskDLQHandler(shard, historyEngImpl.txProcessor, historyEngImpl.timerProcessor)
 	historyEngImpl.eventsReapplier = newNDCEventsReapplier(shard.GetMetricsClient(), logger)
 
 	// Only start the replicator processor if valid publisher is passed in
@@ -1388,6 +1409,10 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(
 			if !mutableState.IsWorkflowExecutionRunning() {
 				return ErrWorkflowCompleted
 			}
+			if mutableState.IsWorkflowExecutionPaused() {
+				// activity task is regenerated when the workflow is unpaused
+				return ErrWorkflowPaused
+			}
 
 			scheduleID := request.GetScheduleId()
 			requestID := request.GetRequestId()
@@ -1866,6 +1891,9 @@ func (e *historyEngineImpl) SignalWorkflowExecution(
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	request := signalRequest.SignalRequest
+	if common.IsReservedSignalName(request.GetSignalName()) {
+		return ErrSignalNameReserved
+	}
 	parentExecution := signalRequest.ExternalWorkflowExecution
 	childWorkflowOnly := signalRequest.GetChildWorkflowOnly()
 	execution := commonpb.WorkflowExecution{
@@ -1941,6 +1969,9 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	sRequest := signalWithStartRequest.SignalWithStartRequest
+	if common.IsReservedSignalName(sRequest.GetSignalName()) {
+		return nil, ErrSignalNameReserved
+	}
 	execution := commonpb.WorkflowExecution{
 		WorkflowId: sRequest.WorkflowId,
 	}
@@ -2618,7 +2649,7 @@ func (e *historyEngineImpl) NotifyNewReplicationTasks(
 ) {
 
 	if len(tasks) > 0 {
-		e.replicatorProcessor.notifyNewTask()
+		e.replicatorProcessor.notifyNewReplicationTasks(tasks)
 	}
 }
 
@@ -2761,6 +2792,9 @@ func getActiveNamespaceEntryFromShard(
 	if err = namespaceEntry.GetNamespaceNotActiveErr(); err != nil {
 		return nil, err
 	}
+	if namespaceEntry.IsNamespaceHandover() {
+		return nil, ErrNamespaceHandover
+	}
 	return namespaceEntry, nil
 }
 
@@ -2901,6 +2935,7 @@ func (e *historyEngineImpl) GetReplicationMessages(
 	ctx context.Context,
 	pollingCluster string,
 	lastReadMessageID int64,
+	pageSize int,
 ) (*replicationgenpb.ReplicationMessages, error) {
 
 	scope := metrics.HistoryGetReplicationMessagesScope
@@ -2911,6 +2946,7 @@ func (e *historyEngineImpl) GetReplicationMessages(
 		ctx,
 		pollingCluster,
 		lastReadMessageID,
+		pageSize,
 	)
 	if err != nil {
 		e.logger.Error("Failed to retrieve replication messages.", tag.Error(err))
@@ -2925,6 +2961,19 @@ func (e *historyEngineImpl) GetReplicationMessages(
 	return replicationMessages, nil
 }
 
+func (e *historyEngineImpl) GetReplicationStatus(
+	ctx context.Context,
+	remoteClusters []string,
+	namespaceID string,
+	includeDLQTasks bool,
+) (*replicationgenpb.ShardReplicationStatus, error) {
+
+	if e.replicatorProcessor == nil {
+		return nil, errReplicationNotEnabled
+	}
+	return e.replicatorProcessor.getReplicationStatus(remoteClusters, namespaceID, includeDLQTasks)
+}
+
 func (e *historyEngineImpl) GetDLQReplicationMessages(
 	ctx context.Context,
 	taskInfos []*replicationgenpb.ReplicationTaskInfo,
@@ -3084,6 +3133,24 @@ func (e *historyEngineImpl) ReadDLQMessages(
 	request *historyservice.ReadDLQMessagesRequest,
 ) (*historyservice.ReadDLQMessagesResponse, error) {
 
+	switch request.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		messages, token, err := e.taskDLQHandler.readMessages(
+			request.GetType(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &historyservice.ReadDLQMessagesResponse{
+			Type:          request.GetType(),
+			TaskMessages:  messages,
+			NextPageToken: token,
+		}, nil
+	}
+
 	tasks, token, err := e.replicationDLQHandler.readMessages(
 		ctx,
 		request.GetSourceCluster(),
@@ -3106,6 +3173,14 @@ func (e *historyEngineImpl) PurgeDLQMessages(
 	request *historyservice.PurgeDLQMessagesRequest,
 ) error {
 
+	switch request.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		return e.taskDLQHandler.purgeMessages(
+			request.GetType(),
+			request.GetInclusiveEndMessageId(),
+		)
+	}
+
 	return e.replicationDLQHandler.purgeMessages(
 		request.GetSourceCluster(),
 		request.GetInclusiveEndMessageId(),
@@ -3117,13 +3192,26 @@ func (e *historyEngineImpl) MergeDLQMessages(
 	request *historyservice.MergeDLQMessagesRequest,
 ) (*historyservice.MergeDLQMessagesResponse, error) {
 
-	token, err := e.replicationDLQHandler.mergeMessages(
-		ctx,
-		request.GetSourceCluster(),
-		request.GetInclusiveEndMessageId(),
-		int(request.GetMaximumPageSize()),
-		request.GetNextPageToken(),
-	)
+	var token []byte
+	var err error
+	switch request.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		token, err = e.taskDLQHandler.mergeMessages(
+			ctx,
+			request.GetType(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+	default:
+		token, err = e.replicationDLQHandler.mergeMessages(
+			ctx,
+			request.GetSourceCluster(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3180,6 +3268,352 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 	return nil
 }
 
+// DeleteWorkflowExecution terminates the workflow if it is still running and adds a delete execution transfer task,
+// the task removes mutable state, history and visibility records once prior tasks of the workflow are processed
+func (e *historyEngineImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	deleteRequest *historyservice.DeleteWorkflowExecutionRequest,
+) (retError error) {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(deleteRequest.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+
+	request := deleteRequest.GetRequest()
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetExecution().GetWorkflowId(),
+		RunId:      request.GetExecution().GetRunId(),
+	}
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return err
+	}
+
+	updateMode := persistence.UpdateWorkflowModeUpdateCurrent
+	if mutableState.IsWorkflowExecutionRunning() {
+		if err := terminateWorkflow(
+			mutableState,
+			mutableState.GetNextEventID(),
+			request.GetReason(),
+			nil,
+			request.GetIdentity(),
+		); err != nil {
+			return err
+		}
+	} else {
+		// closed run may no longer be the current run of the workflow
+		resp, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+			NamespaceID: namespaceID,
+			WorkflowID:  execution.GetWorkflowId(),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.RunID != mutableState.GetExecutionInfo().RunID {
+			updateMode = persistence.UpdateWorkflowModeBypassCurrent
+		}
+	}
+
+	// task version is verified against last write version when the task is processed
+	lastWriteVersion, err := mutableState.GetLastWriteVersion()
+	if err != nil {
+		return err
+	}
+	now := e.shard.GetTimeSource().Now()
+	mutableState.AddTransferTasks(&persistence.DeleteExecutionTask{
+		// TaskID is set by shard
+		VisibilityTimestamp: now,
+		Version:             lastWriteVersion,
+	})
+
+	return context.updateWorkflowExecutionWithNew(
+		now,
+		updateMode,
+		nil,
+		nil,
+		transactionPolicyActive,
+		nil,
+	)
+}
+
+// PauseWorkflowExecution records the pause in history and mutable state, decision and activity tasks
+// of the workflow are not dispatched until it is unpaused
+func (e *historyEngineImpl) PauseWorkflowExecution(
+	ctx context.Context,
+	pauseRequest *historyservice.PauseWorkflowExecutionRequest,
+) error {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(pauseRequest.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+
+	request := pauseRequest.GetRequest()
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetExecution().GetWorkflowId(),
+		RunId:      request.GetExecution().GetRunId(),
+	}
+
+	return e.updateWorkflow(
+		ctx,
+		namespaceID,
+		execution,
+		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+			if mutableState.IsWorkflowExecutionPaused() {
+				return nil, ErrWorkflowAlreadyPaused
+			}
+
+			if _, err := mutableState.AddWorkflowExecutionPausedEvent(
+				request.GetReason(),
+				request.GetIdentity(),
+			); err != nil {
+				return nil, serviceerror.NewInternal("Unable to pause workflow execution.")
+			}
+			return &updateWorkflowAction{}, nil
+		})
+}
+
+// UnpauseWorkflowExecution records the unpause in history and mutable state, then regenerates
+// the tasks which were dropped while the workflow was paused
+func (e *historyEngineImpl) UnpauseWorkflowExecution(
+	ctx context.Context,
+	unpauseRequest *historyservice.UnpauseWorkflowExecutionRequest,
+) error {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(unpauseRequest.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+
+	request := unpauseRequest.GetRequest()
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetExecution().GetWorkflowId(),
+		RunId:      request.GetExecution().GetRunId(),
+	}
+
+	mutableStateTaskRefresher := newMutableStateTaskRefresher(
+		e.shard.GetConfig(),
+		e.shard.GetNamespaceCache(),
+		e.shard.GetEventsCache(),
+		e.shard.GetLogger(),
+	)
+
+	return e.updateWorkflow(
+		ctx,
+		namespaceID,
+		execution,
+		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+			if !mutableState.IsWorkflowExecutionPaused() {
+				return nil, ErrWorkflowNotPaused
+			}
+
+			if _, err := mutableState.AddWorkflowExecutionUnpausedEvent(
+				request.GetReason(),
+				request.GetIdentity(),
+			); err != nil {
+				return nil, serviceerror.NewInternal("Unable to unpause workflow execution.")
+			}
+
+			// decision and activity tasks processed during the pause were dropped
+			if err := mutableStateTaskRefresher.refreshTasks(
+				e.shard.GetTimeSource().Now(),
+				mutableState,
+			); err != nil {
+				return nil, err
+			}
+			return &updateWorkflowAction{}, nil
+		})
+}
+
+// UpsertWorkflowExecutionAttributes merges memo and search attributes into a running workflow,
+// the change is recorded in history so it is replicated and survives mutable state rebuilds
+func (e *historyEngineImpl) UpsertWorkflowExecutionAttributes(
+	ctx context.Context,
+	upsertRequest *historyservice.UpsertWorkflowExecutionAttributesRequest,
+) error {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(upsertRequest.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+	namespace := namespaceEntry.GetInfo().Name
+
+	request := upsertRequest.GetRequest()
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetExecution().GetWorkflowId(),
+		RunId:      request.GetExecution().GetRunId(),
+	}
+
+	return e.updateWorkflow(
+		ctx,
+		namespaceID,
+		execution,
+		func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+			if !mutableState.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			// the limits apply to the attributes resulting from the upsert, not only to the upserted fields
+			if err := e.validateUpsertedWorkflowExecutionAttributes(
+				namespace,
+				mutableState.GetExecutionInfo(),
+				request.GetMemo(),
+				request.GetSearchAttributes(),
+			); err != nil {
+				return nil, err
+			}
+
+			if _, err := mutableState.AddWorkflowExecutionAttributesUpsertedEvent(
+				request.GetMemo(),
+				request.GetSearchAttributes(),
+				request.GetIdentity(),
+			); err != nil {
+				return nil, serviceerror.NewInternal("Unable to upsert workflow execution attributes.")
+			}
+			return &updateWorkflowAction{}, nil
+		})
+}
+
+func (e *historyEngineImpl) validateUpsertedWorkflowExecutionAttributes(
+	namespace string,
+	executionInfo *persistence.WorkflowExecutionInfo,
+	memo *commonpb.Memo,
+	searchAttributes *commonpb.SearchAttributes,
+) error {
+
+	if searchAttributes != nil {
+		saValidator := validator.NewSearchAttributesValidator(
+			e.logger,
+			e.config.ValidSearchAttributes,
+			e.config.SearchAttributesNumberOfKeysLimit,
+			e.config.SearchAttributesSizeOfValueLimit,
+			e.config.SearchAttributesTotalSizeLimit,
+		)
+		merged := &commonpb.SearchAttributes{
+			IndexedFields: mergeMapOfPayload(
+				mergeMapOfPayload(nil, executionInfo.SearchAttributes),
+				searchAttributes.GetIndexedFields(),
+			),
+		}
+		if err := saValidator.ValidateSearchAttributes(merged, namespace); err != nil {
+			return err
+		}
+	}
+
+	if upsertedMemoSize(executionInfo.Memo, memo.GetFields()) > e.config.BlobSizeLimitError(namespace) {
+		return common.ErrBlobSizeExceedsLimit
+	}
+	return nil
+}
+
+// ReindexWorkflowExecution re-sends the visibility record built from mutable state,
+// it is used to repair visibility documents which were rejected by ElasticSearch
+func (e *historyEngineImpl) ReindexWorkflowExecution(
+	ctx context.Context,
+	namespaceUUID string,
+	execution commonpb.WorkflowExecution,
+) (retError error) {
+
+	// visibility is maintained by every cluster, so standby namespaces can be reindexed as well
+	namespaceEntry, err := e.shard.GetNamespaceCache().GetNamespaceByID(namespaceUUID)
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+	namespace := namespaceEntry.GetInfo().Name
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return err
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	startEvent, err := mutableState.GetStartEvent()
+	if err != nil {
+		return err
+	}
+	startTimestamp := startEvent.GetTimestamp()
+	executionTimestamp := getWorkflowExecutionTimestamp(mutableState, startEvent).UnixNano()
+	visibilityMemo := getWorkflowMemo(executionInfo.Memo)
+	searchAttr := copySearchAttributes(executionInfo.SearchAttributes)
+	workflowExecution := commonpb.WorkflowExecution{
+		WorkflowId: executionInfo.WorkflowID,
+		RunId:      executionInfo.RunID,
+	}
+
+	// task ID is used as document version by ElasticSearch,
+	// a new one makes sure the reindexed record supersedes the existing document
+	taskID, err := e.shard.GenerateTransferTaskID()
+	if err != nil {
+		return err
+	}
+
+	if mutableState.IsWorkflowExecutionRunning() {
+		request := &persistence.UpsertWorkflowExecutionRequest{
+			NamespaceID:        namespaceID,
+			Namespace:          namespace,
+			Execution:          workflowExecution,
+			WorkflowTypeName:   executionInfo.WorkflowTypeName,
+			StartTimestamp:     startTimestamp,
+			ExecutionTimestamp: executionTimestamp,
+			WorkflowTimeout:    int64(executionInfo.WorkflowRunTimeout),
+			TaskID:             taskID,
+			Memo:               visibilityMemo,
+			TaskList:           executionInfo.TaskList,
+			SearchAttributes:   searchAttr,
+		}
+		release(nil)
+		return e.visibilityMgr.UpsertWorkflowExecution(request)
+	}
+
+	completionEvent, err := mutableState.GetCompletionEvent()
+	if err != nil {
+		return err
+	}
+	request := &persistence.RecordWorkflowExecutionClosedRequest{
+		NamespaceID:        namespaceID,
+		Namespace:          namespace,
+		Execution:          workflowExecution,
+		WorkflowTypeName:   executionInfo.WorkflowTypeName,
+		StartTimestamp:     startTimestamp,
+		ExecutionTimestamp: executionTimestamp,
+		CloseTimestamp:     completionEvent.GetTimestamp(),
+		Status:             executionInfo.Status,
+		HistoryLength:      mutableState.GetNextEventID() - 1,
+		RetentionSeconds:   int64(namespaceEntry.GetRetentionDays(executionInfo.WorkflowID)) * int64(secondsInDay),
+		TaskID:             taskID,
+		Memo:               visibilityMemo,
+		TaskList:           executionInfo.TaskList,
+		SearchAttributes:   searchAttr,
+	}
+	release(nil)
+	return e.visibilityMgr.RecordWorkflowExecutionClosed(request)
+}
+
 func (e *historyEngineImpl) loadWorkflowOnce(
 	ctx context.Context,
 	namespaceID string,
@@ -56,11 +56,18 @@ type (
 			ctx context.Context,
 			pollingCluster string,
 			lastReadTaskID int64,
+			pageSize int,
 		) (*replicationgenpb.ReplicationMessages, error)
 		getTask(
 			ctx context.Context,
 			taskInfo *replicationgenpb.ReplicationTaskInfo,
 		) (*replicationgenpb.ReplicationTask, error)
+		getReplicationStatus(
+			remoteClusters []string,
+			namespaceID string,
+			includeDLQTasks bool,
+		) (*replicationgenpb.ShardReplicationStatus, error)
+		notifyNewReplicationTasks(tasks []persistence.Task)
 	}
 
 	queueAckMgr interface {
@@ -70,6 +77,8 @@ type (
 		getQueueAckLevel() int64
 		getQueueReadLevel() int64
 		updateQueueAckLevel() error
+		isNamespaceSplit(namespaceID string) bool
+		hasPendingTasksBefore(namespaceID string, workflowID string, runID string, taskID int64) bool
 	}
 
 	queueTaskInfo interface {
@@ -127,6 +136,7 @@ type (
 		getAckLevel() timerKey
 		getReadLevel() timerKey
 		updateAckLevel() error
+		isNamespaceSplit(namespaceID string) bool
 	}
 
 	historyEventNotifier interface {
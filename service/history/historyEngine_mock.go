@@ -477,18 +477,33 @@ func (mr *MockEngineMockRecorder) SyncActivity(ctx, request interface{}) *gomock
 }
 
 // GetReplicationMessages mocks base method
-func (m *MockEngine) GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64) (*repication.ReplicationMessages, error) {
+func (m *MockEngine) GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64, pageSize int) (*repication.ReplicationMessages, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReplicationMessages", ctx, pollingCluster, lastReadMessageID)
+	ret := m.ctrl.Call(m, "GetReplicationMessages", ctx, pollingCluster, lastReadMessageID, pageSize)
 	ret0, _ := ret[0].(*repication.ReplicationMessages)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetReplicationMessages indicates an expected call of GetReplicationMessages
-func (mr *MockEngineMockRecorder) GetReplicationMessages(ctx, pollingCluster, lastReadMessageID interface{}) *gomock.Call {
+func (mr *MockEngineMockRecorder) GetReplicationMessages(ctx, pollingCluster, lastReadMessageID, pageSize interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationMessages", reflect.TypeOf((*MockEngine)(nil).GetReplicationMessages), ctx, pollingCluster, lastReadMessageID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationMessages", reflect.TypeOf((*MockEngine)(nil).GetReplicationMessages), ctx, pollingCluster, lastReadMessageID, pageSize)
+}
+
+// GetReplicationStatus mocks base method
+func (m *MockEngine) GetReplicationStatus(ctx context.Context, remoteClusters []string, namespaceID string, includeDLQTasks bool) (*repication.ShardReplicationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplicationStatus", ctx, remoteClusters, namespaceID, includeDLQTasks)
+	ret0, _ := ret[0].(*repication.ShardReplicationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplicationStatus indicates an expected call of GetReplicationStatus
+func (mr *MockEngineMockRecorder) GetReplicationStatus(ctx, remoteClusters, namespaceID, includeDLQTasks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationStatus", reflect.TypeOf((*MockEngine)(nil).GetReplicationStatus), ctx, remoteClusters, namespaceID, includeDLQTasks)
 }
 
 // GetDLQReplicationMessages mocks base method
@@ -593,6 +608,76 @@ func (mr *MockEngineMockRecorder) RefreshWorkflowTasks(ctx, namespaceUUID, execu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWorkflowTasks", reflect.TypeOf((*MockEngine)(nil).RefreshWorkflowTasks), ctx, namespaceUUID, execution)
 }
 
+// ReindexWorkflowExecution mocks base method
+func (m *MockEngine) ReindexWorkflowExecution(ctx context.Context, namespaceUUID string, execution common.WorkflowExecution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReindexWorkflowExecution", ctx, namespaceUUID, execution)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReindexWorkflowExecution indicates an expected call of ReindexWorkflowExecution
+func (mr *MockEngineMockRecorder) ReindexWorkflowExecution(ctx, namespaceUUID, execution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReindexWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).ReindexWorkflowExecution), ctx, namespaceUUID, execution)
+}
+
+// DeleteWorkflowExecution mocks base method
+func (m *MockEngine) DeleteWorkflowExecution(ctx context.Context, deleteRequest *historyservice.DeleteWorkflowExecutionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkflowExecution", ctx, deleteRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkflowExecution indicates an expected call of DeleteWorkflowExecution
+func (mr *MockEngineMockRecorder) DeleteWorkflowExecution(ctx, deleteRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).DeleteWorkflowExecution), ctx, deleteRequest)
+}
+
+// PauseWorkflowExecution mocks base method
+func (m *MockEngine) PauseWorkflowExecution(ctx context.Context, pauseRequest *historyservice.PauseWorkflowExecutionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseWorkflowExecution", ctx, pauseRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseWorkflowExecution indicates an expected call of PauseWorkflowExecution
+func (mr *MockEngineMockRecorder) PauseWorkflowExecution(ctx, pauseRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).PauseWorkflowExecution), ctx, pauseRequest)
+}
+
+// UnpauseWorkflowExecution mocks base method
+func (m *MockEngine) UnpauseWorkflowExecution(ctx context.Context, unpauseRequest *historyservice.UnpauseWorkflowExecutionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpauseWorkflowExecution", ctx, unpauseRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpauseWorkflowExecution indicates an expected call of UnpauseWorkflowExecution
+func (mr *MockEngineMockRecorder) UnpauseWorkflowExecution(ctx, unpauseRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpauseWorkflowExecution", reflect.TypeOf((*MockEngine)(nil).UnpauseWorkflowExecution), ctx, unpauseRequest)
+}
+
+// UpsertWorkflowExecutionAttributes mocks base method
+func (m *MockEngine) UpsertWorkflowExecutionAttributes(ctx context.Context, upsertRequest *historyservice.UpsertWorkflowExecutionAttributesRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertWorkflowExecutionAttributes", ctx, upsertRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertWorkflowExecutionAttributes indicates an expected call of UpsertWorkflowExecutionAttributes
+func (mr *MockEngineMockRecorder) UpsertWorkflowExecutionAttributes(ctx, upsertRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkflowExecutionAttributes", reflect.TypeOf((*MockEngine)(nil).UpsertWorkflowExecutionAttributes), ctx, upsertRequest)
+}
+
 // NotifyNewHistoryEvent mocks base method
 func (m *MockEngine) NotifyNewHistoryEvent(event *historyEventNotification) {
 	m.ctrl.T.Helper()
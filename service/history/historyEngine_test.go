@@ -49,6 +49,7 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
@@ -68,6 +69,7 @@ import (
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/primitives"
@@ -212,7 +214,7 @@ func (s *engineSuite) SetupTest() {
 	s.mockReplicationProcessor = NewMockReplicatorQueueProcessor(s.controller)
 	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
 	s.mockTxProcessor.EXPECT().NotifyNewTask(gomock.Any(), gomock.Any()).AnyTimes()
-	s.mockReplicationProcessor.EXPECT().notifyNewTask().AnyTimes()
+	s.mockReplicationProcessor.EXPECT().notifyNewReplicationTasks(gomock.Any()).AnyTimes()
 	s.mockTimerProcessor.EXPECT().NotifyNewTimers(gomock.Any(), gomock.Any()).AnyTimes()
 
 	s.mockShard = newTestShardContext(
@@ -1761,6 +1763,150 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDec
 	s.Equal(int32(5), activity1Attributes.HeartbeatTimeoutSeconds)
 }
 
+func (s *engineSuite) TestRespondDecisionTaskCompleted_EagerActivityDispatch() {
+
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	tt := &tokengenpb.Task{
+		WorkflowId: "wId",
+		RunId:      we.GetRunId(),
+		ScheduleId: 2,
+	}
+	taskToken, _ := tt.Marshal()
+	identity := "testIdentity"
+	input := payloads.EncodeString("input")
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, payloads.EncodeString("input"), 100, 90, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	var decisions []*decisionpb.Decision
+	for _, activityID := range []string{"activity1", "activity2"} {
+		decisions = append(decisions, &decisionpb.Decision{
+			DecisionType: enumspb.DECISION_TYPE_SCHEDULE_ACTIVITY_TASK,
+			Attributes: &decisionpb.Decision_ScheduleActivityTaskDecisionAttributes{ScheduleActivityTaskDecisionAttributes: &decisionpb.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    activityID,
+				ActivityType:                  &commonpb.ActivityType{Name: "activity_type1"},
+				TaskList:                      &tasklistpb.TaskList{Name: tl},
+				Input:                         input,
+				ScheduleToCloseTimeoutSeconds: 100,
+				ScheduleToStartTimeoutSeconds: 10,
+				StartToCloseTimeoutSeconds:    50,
+				HeartbeatTimeoutSeconds:       5,
+			}},
+		})
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	s.mockHistoryEngine.config.EnableEagerActivityDispatch = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &historyservice.RespondDecisionTaskCompletedRequest{
+		NamespaceId: testNamespaceID,
+		CompleteRequest: &workflowservice.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  identity,
+		},
+		EagerActivityTaskSlots: 1,
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	s.Len(resp.ActivityTasks, 1)
+	activityTask := resp.ActivityTasks[0]
+	s.Equal("activity1", activityTask.ActivityId)
+	s.Equal(input, activityTask.Input)
+	s.Equal(testNamespace, activityTask.WorkflowNamespace)
+	s.Equal(int32(50), activityTask.StartToCloseTimeoutSeconds)
+	activityToken, err := s.mockHistoryEngine.tokenSerializer.Deserialize(activityTask.TaskToken)
+	s.NoError(err)
+	s.Equal(int64(5), activityToken.GetScheduleId())
+	s.Equal("activity1", activityToken.GetActivityId())
+
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	activity1, ok := executionBuilder.GetActivityInfo(5)
+	s.True(ok)
+	s.NotEqual(common.EmptyEventID, activity1.StartedID)
+	s.Equal(identity, activity1.StartedIdentity)
+	// no more slots, the second activity is dispatched through matching
+	activity2, ok := executionBuilder.GetActivityInfo(6)
+	s.True(ok)
+	s.Equal(common.EmptyEventID, activity2.StartedID)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompleted_EagerActivityDispatch_PayloadSizeLimit() {
+
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tl := "testTaskList"
+	tt := &tokengenpb.Task{
+		WorkflowId: "wId",
+		RunId:      we.GetRunId(),
+		ScheduleId: 2,
+	}
+	taskToken, _ := tt.Marshal()
+	identity := "testIdentity"
+	largeInput := payloads.EncodeBytes(make([]byte, 2048))
+	smallInput := payloads.EncodeString("input")
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, payloads.EncodeString("input"), 100, 90, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	var decisions []*decisionpb.Decision
+	for activityID, input := range map[string]*commonpb.Payloads{"activity1": largeInput, "activity2": smallInput} {
+		decisions = append(decisions, &decisionpb.Decision{
+			DecisionType: enumspb.DECISION_TYPE_SCHEDULE_ACTIVITY_TASK,
+			Attributes: &decisionpb.Decision_ScheduleActivityTaskDecisionAttributes{ScheduleActivityTaskDecisionAttributes: &decisionpb.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    activityID,
+				ActivityType:                  &commonpb.ActivityType{Name: "activity_type1"},
+				TaskList:                      &tasklistpb.TaskList{Name: tl},
+				Input:                         input,
+				ScheduleToCloseTimeoutSeconds: 100,
+				ScheduleToStartTimeoutSeconds: 10,
+				StartToCloseTimeoutSeconds:    50,
+				HeartbeatTimeoutSeconds:       5,
+			}},
+		})
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	s.mockHistoryEngine.config.EnableEagerActivityDispatch = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true)
+	s.mockHistoryEngine.config.EagerActivityMaxPayloadSize = dynamicconfig.GetIntPropertyFilteredByNamespace(1024)
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &historyservice.RespondDecisionTaskCompletedRequest{
+		NamespaceId: testNamespaceID,
+		CompleteRequest: &workflowservice.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  identity,
+		},
+		EagerActivityTaskSlots: 2,
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	// the large activity is dispatched through matching
+	s.Len(resp.ActivityTasks, 1)
+	s.Equal("activity2", resp.ActivityTasks[0].ActivityId)
+	s.Equal(smallInput, resp.ActivityTasks[0].Input)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompleted_DecisionHeartbeatTimeout() {
 
 	we := commonpb.WorkflowExecution{
@@ -4743,6 +4889,48 @@ func (s *engineSuite) TestSignalWorkflowExecution_Failed() {
 	s.EqualError(err, "workflow execution already completed")
 }
 
+func (s *engineSuite) TestSignalWorkflowExecution_ReservedSignalName() {
+	signalRequest := &historyservice.SignalWorkflowExecutionRequest{
+		NamespaceId: testNamespaceID,
+		SignalRequest: &workflowservice.SignalWorkflowExecutionRequest{
+			Namespace: testNamespaceID,
+			WorkflowExecution: &commonpb.WorkflowExecution{
+				WorkflowId: "wId",
+				RunId:      testRunID,
+			},
+			Identity:   "testIdentity",
+			SignalName: common.WorkflowPausedSignalName,
+		},
+	}
+
+	err := s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), signalRequest)
+	s.Equal(ErrSignalNameReserved, err)
+}
+
+func (s *engineSuite) TestValidateUpsertedWorkflowExecutionAttributes() {
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		Memo: map[string]*commonpb.Payload{
+			"existing": {Data: make([]byte, 3*1024*1024/2)},
+		},
+	}
+
+	err := s.mockHistoryEngine.validateUpsertedWorkflowExecutionAttributes(testNamespace, executionInfo, &commonpb.Memo{
+		Fields: map[string]*commonpb.Payload{"upserted": {Data: make([]byte, 1024)}},
+	}, nil)
+	s.NoError(err)
+
+	// each upsert is under the limit, the merged memo is not
+	err = s.mockHistoryEngine.validateUpsertedWorkflowExecutionAttributes(testNamespace, executionInfo, &commonpb.Memo{
+		Fields: map[string]*commonpb.Payload{"upserted": {Data: make([]byte, 1024*1024)}},
+	}, nil)
+	s.Equal(common.ErrBlobSizeExceedsLimit, err)
+
+	err = s.mockHistoryEngine.validateUpsertedWorkflowExecutionAttributes(testNamespace, executionInfo, nil, &commonpb.SearchAttributes{
+		IndexedFields: map[string]*commonpb.Payload{"UnknownKey": payload.EncodeString("value")},
+	})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
 func (s *engineSuite) TestRemoveSignalMutableState() {
 	removeRequest := &historyservice.RemoveSignalMutableStateRequest{}
 	err := s.mockHistoryEngine.RemoveSignalMutableState(context.Background(), removeRequest)
@@ -4776,6 +4964,114 @@ func (s *engineSuite) TestRemoveSignalMutableState() {
 	s.Nil(err)
 }
 
+func (s *engineSuite) TestPauseWorkflowExecution() {
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+	pauseRequest := &historyservice.PauseWorkflowExecutionRequest{
+		NamespaceId: testNamespaceID,
+		Request: &adminservice.PauseWorkflowExecutionRequest{
+			Namespace: testNamespaceID,
+			Execution: &we,
+			Reason:    "some random reason",
+			Identity:  identity,
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tasklist, payloads.EncodeString("input"), 100, 50, 200, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.NamespaceID = testNamespaceID
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(input *persistence.UpdateWorkflowExecutionRequest) bool {
+		s.True(input.UpdateWorkflowMutation.ExecutionInfo.Paused)
+		return true
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	err := s.mockHistoryEngine.PauseWorkflowExecution(context.Background(), pauseRequest)
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(testNamespaceID, we)
+	s.True(executionBuilder.IsWorkflowExecutionPaused())
+	s.Equal(identity, executionBuilder.GetExecutionInfo().PauseIdentity)
+	s.Equal("some random reason", executionBuilder.GetExecutionInfo().PauseReason)
+
+	err = s.mockHistoryEngine.PauseWorkflowExecution(context.Background(), pauseRequest)
+	s.Equal(ErrWorkflowAlreadyPaused, err)
+}
+
+func (s *engineSuite) TestUnpauseWorkflowExecution_NotPaused() {
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tasklist, payloads.EncodeString("input"), 100, 50, 200, identity)
+	addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.NamespaceID = testNamespaceID
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	err := s.mockHistoryEngine.UnpauseWorkflowExecution(context.Background(), &historyservice.UnpauseWorkflowExecutionRequest{
+		NamespaceId: testNamespaceID,
+		Request: &adminservice.UnpauseWorkflowExecutionRequest{
+			Namespace: testNamespaceID,
+			Execution: &we,
+			Identity:  identity,
+		},
+	})
+	s.Equal(ErrWorkflowNotPaused, err)
+}
+
+func (s *engineSuite) TestRecordDecisionTaskStarted_Paused() {
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), we.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tasklist, payloads.EncodeString("input"), 100, 50, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.NamespaceID = testNamespaceID
+	ms.ExecutionInfo.Paused = true
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+
+	_, err := s.mockHistoryEngine.RecordDecisionTaskStarted(context.Background(), &historyservice.RecordDecisionTaskStartedRequest{
+		NamespaceId:       testNamespaceID,
+		WorkflowExecution: &we,
+		ScheduleId:        di.ScheduleID,
+		TaskId:            100,
+		RequestId:         "reqId",
+		PollRequest: &workflowservice.PollForDecisionTaskRequest{
+			TaskList: &tasklistpb.TaskList{
+				Name: tasklist,
+			},
+			Identity: identity,
+		},
+	})
+	s.Equal(ErrWorkflowPaused, err)
+}
+
 func (s *engineSuite) getBuilder(testNamespaceID string, we commonpb.WorkflowExecution) mutableState {
 	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecutionForBackground(testNamespaceID, we)
 	if err != nil {
@@ -5174,6 +5470,9 @@ func copyWorkflowExecutionInfo(sourceInfo *persistence.WorkflowExecutionInfo) *p
 		AutoResetPoints:                    sourceInfo.AutoResetPoints,
 		Memo:                               sourceInfo.Memo,
 		SearchAttributes:                   sourceInfo.SearchAttributes,
+		Paused:                             sourceInfo.Paused,
+		PauseIdentity:                      sourceInfo.PauseIdentity,
+		PauseReason:                        sourceInfo.PauseReason,
 		Attempt:                            sourceInfo.Attempt,
 		HasRetryPolicy:                     sourceInfo.HasRetryPolicy,
 		InitialInterval:                    sourceInfo.InitialInterval,
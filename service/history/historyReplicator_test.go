@@ -108,7 +108,7 @@ func (s *historyReplicatorSuite) SetupTest() {
 	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
 	s.mockStateBuilder = NewMockstateBuilder(s.controller)
 	s.mockTxProcessor.EXPECT().NotifyNewTask(gomock.Any(), gomock.Any()).AnyTimes()
-	s.mockReplicationProcessor.EXPECT().notifyNewTask().AnyTimes()
+	s.mockReplicationProcessor.EXPECT().notifyNewReplicationTasks(gomock.Any()).AnyTimes()
 	s.mockTimerProcessor.EXPECT().NotifyNewTimers(gomock.Any(), gomock.Any()).AnyTimes()
 
 	s.mockShard = newTestShardContext(
@@ -57,6 +57,7 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/payload"
+	"github.com/temporalio/temporal/common/payloads"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -1628,6 +1629,10 @@ func (e *mutableStateBuilder) IsCancelRequested() (bool, string) {
 	return false, ""
 }
 
+func (e *mutableStateBuilder) IsWorkflowExecutionPaused() bool {
+	return e.executionInfo.Paused
+}
+
 func (e *mutableStateBuilder) IsSignalRequested(
 	requestID string,
 ) bool {
@@ -1735,6 +1740,9 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 	}
 
 	event := e.hBuilder.AddWorkflowExecutionStartedEvent(req, previousExecutionInfo, firstRunID, execution.GetRunId())
+	if err := e.recordReplicationFilter(event); err != nil {
+		return nil, err
+	}
 	if err := e.ReplicateWorkflowExecutionStartedEvent(
 		parentNamespaceID,
 		execution,
@@ -1791,6 +1799,9 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEvent(
 	}
 
 	event := e.hBuilder.AddWorkflowExecutionStartedEvent(startRequest, nil, execution.GetRunId(), execution.GetRunId())
+	if err := e.recordReplicationFilter(event); err != nil {
+		return nil, err
+	}
 
 	var parentNamespaceID string
 	if startRequest.ParentExecutionInfo != nil {
@@ -1892,11 +1903,61 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(
 	if event.SearchAttributes != nil {
 		e.executionInfo.SearchAttributes = event.SearchAttributes.GetIndexedFields()
 	}
+	// the replication filter is evaluated once when the run starts, see recordReplicationFilter
+	e.executionInfo.Unreplicated = isUnreplicated(event.GetSearchAttributes())
 
 	e.writeEventToCache(startEvent)
 	return nil
 }
 
+// recordReplicationFilter excludes the new run from replication if it does not match the replication filter of the
+// namespace. The result is recorded in the search attributes of the started event, so rebuilding mutable state from
+// history does not depend on the filter configured at that time.
+func (e *mutableStateBuilder) recordReplicationFilter(
+	startEvent *historypb.HistoryEvent,
+) error {
+
+	attributes := startEvent.GetWorkflowExecutionStartedEventAttributes()
+	if _, ok := attributes.GetSearchAttributes().GetIndexedFields()[definition.TemporalUnreplicated]; ok {
+		// the flag is owned by the server, the value given by the caller is dropped
+		indexedFields := mergeMapOfPayload(nil, attributes.SearchAttributes.GetIndexedFields())
+		delete(indexedFields, definition.TemporalUnreplicated)
+		attributes.SearchAttributes = &commonpb.SearchAttributes{IndexedFields: indexedFields}
+	}
+
+	if e.namespaceEntry.GetReplicationPolicy() != cache.ReplicationPolicyMultiCluster ||
+		e.namespaceEntry.ShouldReplicateWorkflow(attributes.GetWorkflowType().GetName(), attributes.GetSearchAttributes().GetIndexedFields()) {
+		return nil
+	}
+
+	unreplicatedPayload, err := payload.Encode(true)
+	if err != nil {
+		return err
+	}
+	attributes.SearchAttributes = &commonpb.SearchAttributes{
+		IndexedFields: mergeMapOfPayload(
+			mergeMapOfPayload(nil, attributes.GetSearchAttributes().GetIndexedFields()),
+			map[string]*commonpb.Payload{definition.TemporalUnreplicated: unreplicatedPayload},
+		),
+	}
+	return nil
+}
+
+func isUnreplicated(
+	searchAttributes *commonpb.SearchAttributes,
+) bool {
+
+	unreplicatedPayload, ok := searchAttributes.GetIndexedFields()[definition.TemporalUnreplicated]
+	if !ok {
+		return false
+	}
+	var unreplicated bool
+	if err := payload.Decode(unreplicatedPayload, &unreplicated); err != nil {
+		return false
+	}
+	return unreplicated
+}
+
 func (e *mutableStateBuilder) AddFirstDecisionTaskScheduled(
 	startEvent *historypb.HistoryEvent,
 ) error {
@@ -2974,6 +3035,60 @@ func (e *mutableStateBuilder) ReplicateUpsertWorkflowSearchAttributesEvent(
 	e.executionInfo.SearchAttributes = mergeMapOfPayload(currentSearchAttr, upsertSearchAttr)
 }
 
+func (e *mutableStateBuilder) AddUpsertWorkflowMemoEvent(
+	decisionCompletedEventID int64,
+	attributes *decisionpb.RecordMarkerDecisionAttributes,
+) (*historypb.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionUpsertWorkflowMemo
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	event := e.hBuilder.AddMarkerRecordedEvent(decisionCompletedEventID, attributes)
+	e.ReplicateUpsertWorkflowMemoEvent(event)
+	// memo is part of every visibility record, so the record is upserted regardless of the visibility store
+	if err := e.taskGenerator.generateWorkflowSearchAttrTasks(
+		e.unixNanoToTime(event.GetTimestamp()),
+	); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *mutableStateBuilder) ReplicateUpsertWorkflowMemoEvent(
+	event *historypb.HistoryEvent,
+) {
+
+	upsertMemo := getUpsertWorkflowMemo(event.GetMarkerRecordedEventAttributes().GetDetails())
+	e.executionInfo.Memo = mergeMapOfPayload(e.executionInfo.Memo, upsertMemo)
+}
+
+func isUpsertWorkflowMemoEvent(
+	event *historypb.HistoryEvent,
+) bool {
+
+	if event.GetEventType() != enumspb.EVENT_TYPE_MARKER_RECORDED {
+		return false
+	}
+	return event.GetMarkerRecordedEventAttributes().GetMarkerName() == common.UpsertWorkflowMemoMarkerName
+}
+
+// getUpsertWorkflowMemo converts the details of an upsert memo marker to memo fields
+func getUpsertWorkflowMemo(
+	details map[string]*commonpb.Payloads,
+) map[string]*commonpb.Payload {
+
+	memo := make(map[string]*commonpb.Payload, len(details))
+	for key, fieldPayloads := range details {
+		if len(fieldPayloads.GetPayloads()) == 0 {
+			continue
+		}
+		memo[key] = fieldPayloads.GetPayloads()[0]
+	}
+	return memo
+}
+
 func mergeMapOfPayload(
 	current map[string]*commonpb.Payload,
 	upsert map[string]*commonpb.Payload,
@@ -3307,11 +3422,174 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionSignaled(
 	event *historypb.HistoryEvent,
 ) error {
 
+	attributes := event.GetWorkflowExecutionSignaledEventAttributes()
+	switch attributes.GetSignalName() {
+	case common.WorkflowPausedSignalName:
+		return e.replicateWorkflowExecutionPauseState(true, attributes)
+	case common.WorkflowUnpausedSignalName:
+		return e.replicateWorkflowExecutionPauseState(false, attributes)
+	case common.WorkflowAttributesUpsertedSignalName:
+		return e.replicateWorkflowExecutionAttributesUpserted(attributes)
+	}
+
 	// Increment signal count in mutable state for this workflow execution
 	e.executionInfo.SignalCount++
 	return nil
 }
 
+func (e *mutableStateBuilder) AddWorkflowExecutionPausedEvent(
+	reason string,
+	identity string,
+) (*historypb.HistoryEvent, error) {
+
+	return e.addReservedSignalEvent(common.WorkflowPausedSignalName, payloads.EncodeString(reason), identity)
+}
+
+func (e *mutableStateBuilder) AddWorkflowExecutionUnpausedEvent(
+	reason string,
+	identity string,
+) (*historypb.HistoryEvent, error) {
+
+	return e.addReservedSignalEvent(common.WorkflowUnpausedSignalName, payloads.EncodeString(reason), identity)
+}
+
+func (e *mutableStateBuilder) AddWorkflowExecutionAttributesUpsertedEvent(
+	memo *commonpb.Memo,
+	searchAttributes *commonpb.SearchAttributes,
+	identity string,
+) (*historypb.HistoryEvent, error) {
+
+	input, err := encodeWorkflowExecutionAttributes(memo, searchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	return e.addReservedSignalEvent(common.WorkflowAttributesUpsertedSignalName, input, identity)
+}
+
+// server initiated state changes (pause state, memo and search attributes upsert) are recorded as
+// signals with reserved names, so they are replicated and rebuilt like any other signal without a new event type.
+// A marker would not do, SDKs match markers against the decisions of the workflow when replaying history.
+func (e *mutableStateBuilder) addReservedSignalEvent(
+	signalName string,
+	input *commonpb.Payloads,
+	identity string,
+) (*historypb.HistoryEvent, error) {
+
+	opTag := tag.WorkflowActionWorkflowSignaled
+	if err := e.checkMutability(opTag); err != nil {
+		return nil, err
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionSignaledEvent(signalName, input, identity)
+	if err := e.ReplicateWorkflowExecutionSignaled(event); err != nil {
+		return nil, err
+	}
+	if e.shard.GetConfig().AdvancedVisibilityWritingMode() != common.AdvancedVisibilityWritingModeOff {
+		if err := e.taskGenerator.generateWorkflowSearchAttrTasks(
+			e.unixNanoToTime(event.GetTimestamp()),
+		); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+func isReservedSignal(
+	event *historypb.HistoryEvent,
+) bool {
+
+	if event.GetEventType() != enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED {
+		return false
+	}
+	return common.IsReservedSignalName(event.GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+}
+
+func (e *mutableStateBuilder) replicateWorkflowExecutionPauseState(
+	paused bool,
+	attributes *historypb.WorkflowExecutionSignaledEventAttributes,
+) error {
+
+	var reason string
+	if attributes.GetInput() != nil {
+		if err := payloads.Decode(attributes.GetInput(), &reason); err != nil {
+			return err
+		}
+	}
+
+	e.executionInfo.Paused = paused
+	e.executionInfo.PauseIdentity = ""
+	e.executionInfo.PauseReason = ""
+	if paused {
+		e.executionInfo.PauseIdentity = attributes.GetIdentity()
+		e.executionInfo.PauseReason = reason
+	}
+
+	if e.executionInfo.SearchAttributes == nil {
+		e.executionInfo.SearchAttributes = make(map[string]*commonpb.Payload)
+	}
+	pausedPayload, err := payload.Encode(paused)
+	if err != nil {
+		return err
+	}
+	e.executionInfo.SearchAttributes[definition.TemporalPaused] = pausedPayload
+	return nil
+}
+
+func (e *mutableStateBuilder) replicateWorkflowExecutionAttributesUpserted(
+	attributes *historypb.WorkflowExecutionSignaledEventAttributes,
+) error {
+
+	memo, searchAttributes, err := decodeWorkflowExecutionAttributes(attributes.GetInput())
+	if err != nil {
+		return err
+	}
+	e.executionInfo.Memo = mergeMapOfPayload(e.executionInfo.Memo, memo.GetFields())
+	e.executionInfo.SearchAttributes = mergeMapOfPayload(e.executionInfo.SearchAttributes, searchAttributes.GetIndexedFields())
+	return nil
+}
+
+// memo and search attributes are carried as raw encoded proto blobs in the input of the reserved signal
+func encodeWorkflowExecutionAttributes(
+	memo *commonpb.Memo,
+	searchAttributes *commonpb.SearchAttributes,
+) (*commonpb.Payloads, error) {
+
+	if memo == nil {
+		memo = &commonpb.Memo{}
+	}
+	if searchAttributes == nil {
+		searchAttributes = &commonpb.SearchAttributes{}
+	}
+	memoBlob, err := memo.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	searchAttributesBlob, err := searchAttributes.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return payloads.Encode(memoBlob, searchAttributesBlob)
+}
+
+func decodeWorkflowExecutionAttributes(
+	input *commonpb.Payloads,
+) (*commonpb.Memo, *commonpb.SearchAttributes, error) {
+
+	var memoBlob, searchAttributesBlob []byte
+	if err := payloads.Decode(input, &memoBlob, &searchAttributesBlob); err != nil {
+		return nil, nil, err
+	}
+	memo := &commonpb.Memo{}
+	if err := memo.Unmarshal(memoBlob); err != nil {
+		return nil, nil, err
+	}
+	searchAttributes := &commonpb.SearchAttributes{}
+	if err := searchAttributes.Unmarshal(searchAttributesBlob); err != nil {
+		return nil, nil, err
+	}
+	return memo, searchAttributes, nil
+}
+
 func (e *mutableStateBuilder) AddContinueAsNewEvent(
 	firstEventID int64,
 	decisionCompletedEventID int64,
@@ -4344,7 +4622,8 @@ func (e *mutableStateBuilder) updateWithLastFirstEvent(
 
 func (e *mutableStateBuilder) canReplicateEvents() bool {
 	return (e.GetReplicationState() != nil || e.GetVersionHistories() != nil) &&
-		e.namespaceEntry.GetReplicationPolicy() == cache.ReplicationPolicyMultiCluster
+		e.namespaceEntry.GetReplicationPolicy() == cache.ReplicationPolicyMultiCluster &&
+		!e.executionInfo.Unreplicated
 }
 
 // validateNoEventsAfterWorkflowFinish perform check on history event batch
@@ -45,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/checksum"
+	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/failure"
 	"github.com/temporalio/temporal/common/log"
@@ -545,6 +546,216 @@ func (s *mutableStateSuite) TestMergeMapOfPayload() {
 	s.Equal(2, len(resultMap))
 }
 
+func (s *mutableStateSuite) TestReplicateWorkflowExecutionStartedEvent_ReplicationFilter() {
+	namespaceEntry := cache.NewGlobalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{
+			Id:   testNamespaceID,
+			Name: testNamespace,
+			Data: map[string]string{cache.ReplicatedWorkflowTypesKey: "some random critical workflow type"},
+		},
+		&persistenceblobs.NamespaceConfig{RetentionDays: 1},
+		&persistenceblobs.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []string{
+				cluster.TestCurrentClusterName,
+				cluster.TestAlternativeClusterName,
+			},
+		},
+		testVersion,
+		nil,
+	)
+	s.mockEventsCache.EXPECT().putEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	newStartedEvent := func(workflowType string, searchAttributes *commonpb.SearchAttributes) *historypb.HistoryEvent {
+		return &historypb.HistoryEvent{
+			Version:   testVersion,
+			EventId:   common.FirstEventID,
+			Timestamp: time.Now().UnixNano(),
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+			Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{
+				WorkflowType:     &commonpb.WorkflowType{Name: workflowType},
+				TaskList:         &tasklistpb.TaskList{Name: "some random task list"},
+				SearchAttributes: searchAttributes,
+			}},
+		}
+	}
+	replicateStartedEvent := func(startEvent *historypb.HistoryEvent) *mutableStateBuilder {
+		msBuilder := newMutableStateBuilderWithVersionHistories(s.mockShard, s.mockEventsCache, s.logger, namespaceEntry)
+		err := msBuilder.ReplicateWorkflowExecutionStartedEvent(
+			"",
+			commonpb.WorkflowExecution{WorkflowId: "some random workflow ID", RunId: uuid.New()},
+			uuid.New(),
+			startEvent,
+		)
+		s.NoError(err)
+		return msBuilder
+	}
+	unreplicatedPayload, err := payload.Encode(true)
+	s.NoError(err)
+	activeBuilder := newMutableStateBuilderWithVersionHistories(s.mockShard, s.mockEventsCache, s.logger, namespaceEntry)
+
+	startEvent := newStartedEvent("some random critical workflow type", nil)
+	s.NoError(activeBuilder.recordReplicationFilter(startEvent))
+	s.Nil(startEvent.GetWorkflowExecutionStartedEventAttributes().GetSearchAttributes())
+	msBuilder := replicateStartedEvent(startEvent)
+	s.False(msBuilder.GetExecutionInfo().Unreplicated)
+	s.True(msBuilder.canReplicateEvents())
+
+	// the flag given by the caller is dropped
+	startEvent = newStartedEvent("some random critical workflow type", &commonpb.SearchAttributes{
+		IndexedFields: map[string]*commonpb.Payload{definition.TemporalUnreplicated: unreplicatedPayload},
+	})
+	s.NoError(activeBuilder.recordReplicationFilter(startEvent))
+	s.NotContains(startEvent.GetWorkflowExecutionStartedEventAttributes().GetSearchAttributes().GetIndexedFields(), definition.TemporalUnreplicated)
+
+	startEvent = newStartedEvent("some random workflow type", nil)
+	s.NoError(activeBuilder.recordReplicationFilter(startEvent))
+	s.Contains(startEvent.GetWorkflowExecutionStartedEventAttributes().GetSearchAttributes().GetIndexedFields(), definition.TemporalUnreplicated)
+	msBuilder = replicateStartedEvent(startEvent)
+	s.True(msBuilder.GetExecutionInfo().Unreplicated)
+	s.False(msBuilder.canReplicateEvents())
+
+	// rebuilding from history does not evaluate the filter again
+	msBuilder = replicateStartedEvent(newStartedEvent("some random workflow type", nil))
+	s.False(msBuilder.GetExecutionInfo().Unreplicated)
+	s.True(msBuilder.canReplicateEvents())
+}
+
+func (s *mutableStateSuite) TestReplicateWorkflowExecutionSignaled_PauseState() {
+	s.False(s.msBuilder.IsWorkflowExecutionPaused())
+
+	err := s.msBuilder.ReplicateWorkflowExecutionSignaled(&historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: common.WorkflowPausedSignalName,
+			Input:      payloads.EncodeString("some random reason"),
+			Identity:   "some random identity",
+		}},
+	})
+	s.NoError(err)
+	s.True(s.msBuilder.IsWorkflowExecutionPaused())
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	s.Equal("some random reason", executionInfo.PauseReason)
+	s.Equal("some random identity", executionInfo.PauseIdentity)
+	s.Equal(int32(0), executionInfo.SignalCount)
+	var paused bool
+	s.NoError(payload.Decode(executionInfo.SearchAttributes[definition.TemporalPaused], &paused))
+	s.True(paused)
+
+	err = s.msBuilder.ReplicateWorkflowExecutionSignaled(&historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: common.WorkflowUnpausedSignalName,
+			Identity:   "some random identity",
+		}},
+	})
+	s.NoError(err)
+	s.False(s.msBuilder.IsWorkflowExecutionPaused())
+	s.Empty(executionInfo.PauseReason)
+	s.Empty(executionInfo.PauseIdentity)
+	s.NoError(payload.Decode(executionInfo.SearchAttributes[definition.TemporalPaused], &paused))
+	s.False(paused)
+
+	err = s.msBuilder.ReplicateWorkflowExecutionSignaled(&historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "some random signal",
+		}},
+	})
+	s.NoError(err)
+	s.Equal(int32(1), executionInfo.SignalCount)
+}
+
+func (s *mutableStateSuite) TestReplicateWorkflowExecutionSignaled_AttributesUpserted() {
+	existingPayload, err := payload.Encode("some existing value")
+	s.NoError(err)
+	upsertedPayload, err := payload.Encode("some upserted value")
+	s.NoError(err)
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	executionInfo.Memo = map[string]*commonpb.Payload{
+		"existing key": existingPayload,
+		"updated key":  existingPayload,
+	}
+
+	input, err := encodeWorkflowExecutionAttributes(
+		&commonpb.Memo{Fields: map[string]*commonpb.Payload{
+			"updated key": upsertedPayload,
+		}},
+		&commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			definition.CustomKeywordField: upsertedPayload,
+		}},
+	)
+	s.NoError(err)
+	err = s.msBuilder.ReplicateWorkflowExecutionSignaled(&historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: common.WorkflowAttributesUpsertedSignalName,
+			Input:      input,
+			Identity:   "some random identity",
+		}},
+	})
+	s.NoError(err)
+	s.Equal(int32(0), executionInfo.SignalCount)
+	s.Equal(existingPayload, executionInfo.Memo["existing key"])
+	s.Equal(upsertedPayload, executionInfo.Memo["updated key"])
+	s.Equal(upsertedPayload, executionInfo.SearchAttributes[definition.CustomKeywordField])
+}
+
+func (s *mutableStateSuite) TestAddWorkflowExecutionAttributesUpsertedEvent() {
+	upsertedPayload, err := payload.Encode("some upserted value")
+	s.NoError(err)
+
+	event, err := s.msBuilder.AddWorkflowExecutionAttributesUpsertedEvent(
+		&commonpb.Memo{Fields: map[string]*commonpb.Payload{
+			"upserted key": upsertedPayload,
+		}},
+		nil,
+		"some random identity",
+	)
+	s.NoError(err)
+	// recorded as a signal with a reserved name, which is not a decision event for SDKs replaying history
+	s.Equal(enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED, event.GetEventType())
+	s.Equal(common.WorkflowAttributesUpsertedSignalName, event.GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+	s.Equal("some random identity", event.GetWorkflowExecutionSignaledEventAttributes().GetIdentity())
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	s.Equal(int32(0), executionInfo.SignalCount)
+	s.Equal(upsertedPayload, executionInfo.Memo["upserted key"])
+	s.False(s.msBuilder.HasPendingDecision())
+}
+
+func (s *mutableStateSuite) TestReplicateUpsertWorkflowMemoEvent() {
+	existingPayload, err := payload.Encode("some existing value")
+	s.NoError(err)
+	upsertedPayload, err := payload.Encode("some upserted value")
+	s.NoError(err)
+	executionInfo := s.msBuilder.GetExecutionInfo()
+	executionInfo.Memo = map[string]*commonpb.Payload{
+		"existing key": existingPayload,
+		"updated key":  existingPayload,
+	}
+
+	event := &historypb.HistoryEvent{
+		EventType: enumspb.EVENT_TYPE_MARKER_RECORDED,
+		Attributes: &historypb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &historypb.MarkerRecordedEventAttributes{
+			MarkerName: common.UpsertWorkflowMemoMarkerName,
+			Details: map[string]*commonpb.Payloads{
+				"updated key": {Payloads: []*commonpb.Payload{upsertedPayload}},
+				"new key":     {Payloads: []*commonpb.Payload{upsertedPayload}},
+			},
+		}},
+	}
+	s.True(isUpsertWorkflowMemoEvent(event))
+	s.msBuilder.ReplicateUpsertWorkflowMemoEvent(event)
+	s.Equal(map[string]*commonpb.Payload{
+		"existing key": existingPayload,
+		"updated key":  upsertedPayload,
+		"new key":      upsertedPayload,
+	}, executionInfo.Memo)
+
+	event.GetMarkerRecordedEventAttributes().MarkerName = "some random marker"
+	s.False(isUpsertWorkflowMemoEvent(event))
+}
+
 func (s *mutableStateSuite) TestEventReapplied() {
 	runID := uuid.New()
 	eventID := int64(1)
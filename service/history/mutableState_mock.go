@@ -673,6 +673,36 @@ func (mr *MockmutableStateMockRecorder) AddUpsertWorkflowSearchAttributesEvent(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpsertWorkflowSearchAttributesEvent", reflect.TypeOf((*MockmutableState)(nil).AddUpsertWorkflowSearchAttributesEvent), arg0, arg1)
 }
 
+// AddUpsertWorkflowMemoEvent mocks base method
+func (m *MockmutableState) AddUpsertWorkflowMemoEvent(arg0 int64, arg1 *decision.RecordMarkerDecisionAttributes) (*history.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUpsertWorkflowMemoEvent", arg0, arg1)
+	ret0, _ := ret[0].(*history.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUpsertWorkflowMemoEvent indicates an expected call of AddUpsertWorkflowMemoEvent
+func (mr *MockmutableStateMockRecorder) AddUpsertWorkflowMemoEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpsertWorkflowMemoEvent", reflect.TypeOf((*MockmutableState)(nil).AddUpsertWorkflowMemoEvent), arg0, arg1)
+}
+
+// AddWorkflowExecutionAttributesUpsertedEvent mocks base method
+func (m *MockmutableState) AddWorkflowExecutionAttributesUpsertedEvent(memo *common.Memo, searchAttributes *common.SearchAttributes, identity string) (*history.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkflowExecutionAttributesUpsertedEvent", memo, searchAttributes, identity)
+	ret0, _ := ret[0].(*history.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkflowExecutionAttributesUpsertedEvent indicates an expected call of AddWorkflowExecutionAttributesUpsertedEvent
+func (mr *MockmutableStateMockRecorder) AddWorkflowExecutionAttributesUpsertedEvent(memo, searchAttributes, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionAttributesUpsertedEvent", reflect.TypeOf((*MockmutableState)(nil).AddWorkflowExecutionAttributesUpsertedEvent), memo, searchAttributes, identity)
+}
+
 // AddWorkflowExecutionCancelRequestedEvent mocks base method
 func (m *MockmutableState) AddWorkflowExecutionCancelRequestedEvent(arg0 string, arg1 *historyservice.RequestCancelWorkflowExecutionRequest) (*history.HistoryEvent, error) {
 	m.ctrl.T.Helper()
@@ -703,6 +733,21 @@ func (mr *MockmutableStateMockRecorder) AddWorkflowExecutionCanceledEvent(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionCanceledEvent", reflect.TypeOf((*MockmutableState)(nil).AddWorkflowExecutionCanceledEvent), arg0, arg1)
 }
 
+// AddWorkflowExecutionPausedEvent mocks base method
+func (m *MockmutableState) AddWorkflowExecutionPausedEvent(reason, identity string) (*history.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkflowExecutionPausedEvent", reason, identity)
+	ret0, _ := ret[0].(*history.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkflowExecutionPausedEvent indicates an expected call of AddWorkflowExecutionPausedEvent
+func (mr *MockmutableStateMockRecorder) AddWorkflowExecutionPausedEvent(reason, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionPausedEvent", reflect.TypeOf((*MockmutableState)(nil).AddWorkflowExecutionPausedEvent), reason, identity)
+}
+
 // AddWorkflowExecutionSignaled mocks base method
 func (m *MockmutableState) AddWorkflowExecutionSignaled(signalName string, input *common.Payloads, identity string) (*history.HistoryEvent, error) {
 	m.ctrl.T.Helper()
@@ -748,6 +793,21 @@ func (mr *MockmutableStateMockRecorder) AddWorkflowExecutionTerminatedEvent(firs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionTerminatedEvent", reflect.TypeOf((*MockmutableState)(nil).AddWorkflowExecutionTerminatedEvent), firstEventID, reason, details, identity)
 }
 
+// AddWorkflowExecutionUnpausedEvent mocks base method
+func (m *MockmutableState) AddWorkflowExecutionUnpausedEvent(reason, identity string) (*history.HistoryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkflowExecutionUnpausedEvent", reason, identity)
+	ret0, _ := ret[0].(*history.HistoryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkflowExecutionUnpausedEvent indicates an expected call of AddWorkflowExecutionUnpausedEvent
+func (mr *MockmutableStateMockRecorder) AddWorkflowExecutionUnpausedEvent(reason, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflowExecutionUnpausedEvent", reflect.TypeOf((*MockmutableState)(nil).AddWorkflowExecutionUnpausedEvent), reason, identity)
+}
+
 // ClearStickyness mocks base method
 func (m *MockmutableState) ClearStickyness() {
 	m.ctrl.T.Helper()
@@ -1561,6 +1621,20 @@ func (mr *MockmutableStateMockRecorder) IsWorkflowExecutionRunning() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWorkflowExecutionRunning", reflect.TypeOf((*MockmutableState)(nil).IsWorkflowExecutionRunning))
 }
 
+// IsWorkflowExecutionPaused mocks base method
+func (m *MockmutableState) IsWorkflowExecutionPaused() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWorkflowExecutionPaused")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsWorkflowExecutionPaused indicates an expected call of IsWorkflowExecutionPaused
+func (mr *MockmutableStateMockRecorder) IsWorkflowExecutionPaused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWorkflowExecutionPaused", reflect.TypeOf((*MockmutableState)(nil).IsWorkflowExecutionPaused))
+}
+
 // IsResourceDuplicated mocks base method
 func (m *MockmutableState) IsResourceDuplicated(resourceDedupKey definition.DeduplicationID) bool {
 	m.ctrl.T.Helper()
@@ -2053,6 +2127,18 @@ func (mr *MockmutableStateMockRecorder) ReplicateUpsertWorkflowSearchAttributesE
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateUpsertWorkflowSearchAttributesEvent", reflect.TypeOf((*MockmutableState)(nil).ReplicateUpsertWorkflowSearchAttributesEvent), arg0)
 }
 
+// ReplicateUpsertWorkflowMemoEvent mocks base method
+func (m *MockmutableState) ReplicateUpsertWorkflowMemoEvent(arg0 *history.HistoryEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReplicateUpsertWorkflowMemoEvent", arg0)
+}
+
+// ReplicateUpsertWorkflowMemoEvent indicates an expected call of ReplicateUpsertWorkflowMemoEvent
+func (mr *MockmutableStateMockRecorder) ReplicateUpsertWorkflowMemoEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicateUpsertWorkflowMemoEvent", reflect.TypeOf((*MockmutableState)(nil).ReplicateUpsertWorkflowMemoEvent), arg0)
+}
+
 // ReplicateWorkflowExecutionCancelRequestedEvent mocks base method
 func (m *MockmutableState) ReplicateWorkflowExecutionCancelRequestedEvent(arg0 *history.HistoryEvent) error {
 	m.ctrl.T.Helper()
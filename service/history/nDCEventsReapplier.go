@@ -76,6 +76,10 @@ func (r *nDCEventsReapplierImpl) reapplyEvents(
 	for _, event := range historyEvents {
 		switch event.GetEventType() {
 		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+			if isReservedSignal(event) {
+				// signals recorded by the server are not user input and are not reapplied
+				continue
+			}
 			dedupResource := definition.NewEventReappliedID(runID, event.GetEventId(), event.GetVersion())
 			if msBuilder.IsResourceDuplicated(dedupResource) {
 				// skip already applied event
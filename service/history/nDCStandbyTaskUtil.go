@@ -118,6 +118,7 @@ type (
 
 	pushActivityToMatchingInfo struct {
 		activityScheduleToStartTimeout int32
+		labelSelector                  map[string]string
 	}
 
 	pushDecisionToMatchingInfo struct {
@@ -149,10 +150,12 @@ func newHistoryResendInfoFor2DC(
 
 func newPushActivityToMatchingInfo(
 	activityScheduleToStartTimeout int32,
+	labelSelector map[string]string,
 ) *pushActivityToMatchingInfo {
 
 	return &pushActivityToMatchingInfo{
 		activityScheduleToStartTimeout: activityScheduleToStartTimeout,
+		labelSelector:                  labelSelector,
 	}
 }
 
@@ -301,6 +301,14 @@ func (h *NilCheckHandler) GetReplicationMessages(ctx context.Context, request *h
 	return resp, err
 }
 
+func (h *NilCheckHandler) GetReplicationStatus(ctx context.Context, request *historyservice.GetReplicationStatusRequest) (_ *historyservice.GetReplicationStatusResponse, retError error) {
+	resp, err := h.parentHandler.GetReplicationStatus(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.GetReplicationStatusResponse{}
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) GetDLQReplicationMessages(ctx context.Context, request *historyservice.GetDLQReplicationMessagesRequest) (_ *historyservice.GetDLQReplicationMessagesResponse, retError error) {
 	resp, err := h.parentHandler.GetDLQReplicationMessages(ctx, request)
 	if resp == nil && err == nil {
@@ -356,3 +364,43 @@ func (h *NilCheckHandler) RefreshWorkflowTasks(ctx context.Context, request *his
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) ReindexWorkflowExecution(ctx context.Context, request *historyservice.ReindexWorkflowExecutionRequest) (*historyservice.ReindexWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.ReindexWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.ReindexWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.DeleteWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.DeleteWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) PauseWorkflowExecution(ctx context.Context, request *historyservice.PauseWorkflowExecutionRequest) (*historyservice.PauseWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.PauseWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.PauseWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UnpauseWorkflowExecution(ctx context.Context, request *historyservice.UnpauseWorkflowExecutionRequest) (*historyservice.UnpauseWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.UnpauseWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.UnpauseWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpsertWorkflowExecutionAttributes(ctx context.Context, request *historyservice.UpsertWorkflowExecutionAttributesRequest) (*historyservice.UpsertWorkflowExecutionAttributesResponse, error) {
+	resp, err := h.parentHandler.UpsertWorkflowExecutionAttributes(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.UpsertWorkflowExecutionAttributesResponse{}
+	}
+	return resp, err
+}
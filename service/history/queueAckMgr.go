@@ -41,6 +41,8 @@ type (
 	// It keeps track of read level when dispatching tasks to processor and maintains a map of outstanding tasks.
 	// Outstanding tasks map uses the task id sequencer as the key, which is used by updateAckLevel to move the ack level
 	// for the shard when all preceding tasks are acknowledged.
+	// Namespaces with too many or too old pending tasks are split out of the shared ack level, each keeping its
+	// own ack level until its pending tasks catch up, after which it is merged back.
 	queueAckMgrImpl struct {
 		isFailover    bool
 		shard         ShardContext
@@ -49,12 +51,20 @@ type (
 		logger        log.Logger
 		metricsClient metrics.Client
 		finishedChan  chan struct{}
+		// updateAckLevels persists the shared ack level along with the ack levels of split namespaces
+		// in one shard update, namespaces are never split if it is nil
+		updateAckLevels func(ackLevel int64, namespaceAckLevels map[string]int64) error
 
 		sync.RWMutex
-		outstandingTasks map[int64]bool
-		readLevel        int64
-		ackLevel         int64
-		isReadFinished   bool
+		outstandingTasks    map[int64]bool
+		outstandingTaskMeta map[int64]queueTaskMeta
+		namespaceAckLevels  map[string]int64
+		readLevel           int64
+		ackLevel            int64
+		isReadFinished      bool
+		// levels of the last successful ack level update, the update is skipped if no level moved since
+		persistedAckLevel           int64
+		persistedNamespaceAckLevels map[string]int64
 	}
 )
 
@@ -62,35 +72,62 @@ const (
 	warnPendingTasks = 2000
 )
 
-func newQueueAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, logger log.Logger) *queueAckMgrImpl {
+func newQueueAckMgr(
+	shard ShardContext,
+	options *QueueProcessorOptions,
+	processor processor,
+	ackLevel int64,
+	namespaceAckLevels map[string]int64,
+	updateAckLevels func(ackLevel int64, namespaceAckLevels map[string]int64) error,
+	logger log.Logger,
+) *queueAckMgrImpl {
+
+	// split namespaces may be behind the shared ack level,
+	// so reading starts from the lowest of all ack levels
+	readLevel := ackLevel
+	splitNamespaceAckLevels := make(map[string]int64, len(namespaceAckLevels))
+	for namespaceID, namespaceAckLevel := range namespaceAckLevels {
+		splitNamespaceAckLevels[namespaceID] = namespaceAckLevel
+		if namespaceAckLevel < readLevel {
+			readLevel = namespaceAckLevel
+		}
+	}
 
 	return &queueAckMgrImpl{
-		isFailover:       false,
-		shard:            shard,
-		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     nil,
+		isFailover:                  false,
+		shard:                       shard,
+		options:                     options,
+		processor:                   processor,
+		updateAckLevels:             updateAckLevels,
+		outstandingTasks:            make(map[int64]bool),
+		outstandingTaskMeta:         make(map[int64]queueTaskMeta),
+		namespaceAckLevels:          splitNamespaceAckLevels,
+		readLevel:                   readLevel,
+		ackLevel:                    ackLevel,
+		persistedAckLevel:           ackLevel,
+		persistedNamespaceAckLevels: namespaceAckLevels,
+		logger:                      logger,
+		metricsClient:               shard.GetMetricsClient(),
+		finishedChan:                nil,
 	}
 }
 
 func newQueueFailoverAckMgr(shard ShardContext, options *QueueProcessorOptions, processor processor, ackLevel int64, logger log.Logger) *queueAckMgrImpl {
 
 	return &queueAckMgrImpl{
-		isFailover:       true,
-		shard:            shard,
-		options:          options,
-		processor:        processor,
-		outstandingTasks: make(map[int64]bool),
-		readLevel:        ackLevel,
-		ackLevel:         ackLevel,
-		logger:           logger,
-		metricsClient:    shard.GetMetricsClient(),
-		finishedChan:     make(chan struct{}, 1),
+		isFailover:          true,
+		shard:               shard,
+		options:             options,
+		processor:           processor,
+		outstandingTasks:    make(map[int64]bool),
+		outstandingTaskMeta: make(map[int64]queueTaskMeta),
+		namespaceAckLevels:  make(map[string]int64),
+		readLevel:           ackLevel,
+		ackLevel:            ackLevel,
+		persistedAckLevel:   ackLevel,
+		logger:              logger,
+		metricsClient:       shard.GetMetricsClient(),
+		finishedChan:        make(chan struct{}, 1),
 	}
 }
 
@@ -118,12 +155,16 @@ func (a *queueAckMgrImpl) readQueueTasks() ([]queueTaskInfo, bool, error) {
 		a.isReadFinished = true
 	}
 
+	now := a.shard.GetTimeSource().Now()
+	filteredTasks := make([]queueTaskInfo, 0, len(tasks))
+
 TaskFilterLoop:
 	for _, task := range tasks {
 		_, isLoaded := a.outstandingTasks[task.GetTaskId()]
 		if isLoaded {
 			// task already loaded
 			a.logger.Debug("Skipping transfer task", tag.Task(task))
+			filteredTasks = append(filteredTasks, task)
 			continue TaskFilterLoop
 		}
 
@@ -134,10 +175,26 @@ TaskFilterLoop:
 		}
 		a.logger.Debug("Moving read level", tag.TaskID(task.GetTaskId()))
 		a.readLevel = task.GetTaskId()
+
+		if task.GetTaskId() <= a.ackLevel {
+			// task is only read because a split namespace is behind the shared ack level
+			namespaceAckLevel, isSplit := a.namespaceAckLevels[task.GetNamespaceId()]
+			if !isSplit || task.GetTaskId() <= namespaceAckLevel {
+				continue TaskFilterLoop
+			}
+		}
+
 		a.outstandingTasks[task.GetTaskId()] = false
+		a.outstandingTaskMeta[task.GetTaskId()] = queueTaskMeta{
+			namespaceID: task.GetNamespaceId(),
+			workflowID:  task.GetWorkflowId(),
+			runID:       task.GetRunId(),
+			loadTime:    now,
+		}
+		filteredTasks = append(filteredTasks, task)
 	}
 
-	return tasks, morePage, nil
+	return filteredTasks, morePage, nil
 }
 
 func (a *queueAckMgrImpl) completeQueueTask(taskID int64) {
@@ -148,10 +205,18 @@ func (a *queueAckMgrImpl) completeQueueTask(taskID int64) {
 	a.Unlock()
 }
 
+// getQueueAckLevel returns the level up to which tasks of all namespaces, including split ones, are acknowledged
 func (a *queueAckMgrImpl) getQueueAckLevel() int64 {
 	a.Lock()
 	defer a.Unlock()
-	return a.ackLevel
+
+	ackLevel := a.ackLevel
+	for _, namespaceAckLevel := range a.namespaceAckLevels {
+		if namespaceAckLevel < ackLevel {
+			ackLevel = namespaceAckLevel
+		}
+	}
+	return ackLevel
 }
 
 func (a *queueAckMgrImpl) getQueueReadLevel() int64 {
@@ -164,6 +229,30 @@ func (a *queueAckMgrImpl) getFinishedChan() <-chan struct{} {
 	return a.finishedChan
 }
 
+// hasPendingTasksBefore returns whether any task of the workflow execution read before the given task is not yet acknowledged
+func (a *queueAckMgrImpl) hasPendingTasksBefore(namespaceID string, workflowID string, runID string, taskID int64) bool {
+	a.RLock()
+	defer a.RUnlock()
+
+	for current, acked := range a.outstandingTasks {
+		if acked || current >= taskID {
+			continue
+		}
+		meta := a.outstandingTaskMeta[current]
+		if meta.namespaceID == namespaceID && meta.workflowID == workflowID && meta.runID == runID {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *queueAckMgrImpl) isNamespaceSplit(namespaceID string) bool {
+	a.RLock()
+	defer a.RUnlock()
+	_, ok := a.namespaceAckLevels[namespaceID]
+	return ok
+}
+
 func (a *queueAckMgrImpl) updateQueueAckLevel() error {
 	a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateCounter)
 
@@ -193,18 +282,28 @@ func (a *queueAckMgrImpl) updateQueueAckLevel() error {
 		a.metricsClient.RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTransferStandbyPendingTasksTimer, time.Duration(pendingTasks))
 	}
 
+	a.splitNamespacesLocked(taskIDs)
+
 MoveAckLevelLoop:
 	for _, current := range taskIDs {
 		acked := a.outstandingTasks[current]
 		if acked {
-			ackLevel = current
+			// acked tasks of split namespaces can be behind the shared ack level
+			if current > ackLevel {
+				ackLevel = current
+			}
 			delete(a.outstandingTasks, current)
+			delete(a.outstandingTaskMeta, current)
 			a.logger.Debug("Moving timer ack level to", tag.AckLevel(ackLevel))
+		} else if _, isSplit := a.namespaceAckLevels[a.outstandingTaskMeta[current].namespaceID]; isSplit {
+			// pending tasks of split namespaces are tracked by their own ack level
+			continue MoveAckLevelLoop
 		} else {
 			break MoveAckLevelLoop
 		}
 	}
 	a.ackLevel = ackLevel
+	namespaceAckLevels := a.updateNamespaceAckLevelsLocked(taskIDs)
 
 	if a.isFailover && a.isReadFinished && len(a.outstandingTasks) == 0 {
 		a.Unlock()
@@ -219,11 +318,109 @@ MoveAckLevelLoop:
 		return nil
 	}
 
+	if ackLevel == a.persistedAckLevel && namespaceAckLevelsEqual(namespaceAckLevels, a.persistedNamespaceAckLevels) {
+		a.Unlock()
+		return nil
+	}
 	a.Unlock()
-	if err := a.processor.updateAckLevel(ackLevel); err != nil {
+
+	var err error
+	if a.updateAckLevels != nil {
+		// the shared ack level is persisted along with the namespace ack levels,
+		// so it never moves past pending tasks of split namespaces in shard info
+		err = a.updateAckLevels(ackLevel, namespaceAckLevels)
+	} else {
+		err = a.processor.updateAckLevel(ackLevel)
+	}
+	if err != nil {
 		a.metricsClient.IncCounter(a.options.MetricScope, metrics.AckLevelUpdateFailedCounter)
 		a.logger.Error("Error updating ack level for shard", tag.Error(err), tag.OperationFailed)
 		return err
 	}
+
+	a.Lock()
+	a.persistedAckLevel = ackLevel
+	a.persistedNamespaceAckLevels = namespaceAckLevels
+	a.Unlock()
 	return nil
 }
+
+// splitNamespacesLocked splits namespaces with too many or too old pending tasks out of the shared ack level
+func (a *queueAckMgrImpl) splitNamespacesLocked(
+	taskIDs []int64,
+) {
+
+	if a.isFailover || a.updateAckLevels == nil ||
+		a.options.EnableNamespaceSplit == nil || !a.options.EnableNamespaceSplit() {
+		return
+	}
+
+	var pendingTasks []queueTaskMeta
+	for _, taskID := range taskIDs {
+		if !a.outstandingTasks[taskID] {
+			pendingTasks = append(pendingTasks, a.outstandingTaskMeta[taskID])
+		}
+	}
+
+	namespaceIDs := getNamespacesToSplit(
+		pendingTasks,
+		a.options.SplitMaxPendingTasks(),
+		a.options.SplitMaxPendingDuration(),
+		a.shard.GetTimeSource().Now(),
+	)
+	for namespaceID := range namespaceIDs {
+		if _, isSplit := a.namespaceAckLevels[namespaceID]; isSplit {
+			continue
+		}
+		a.logger.Info("Splitting namespace out of queue.", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(a.ackLevel))
+		a.metricsClient.IncCounter(a.options.MetricScope, metrics.NamespaceSplitCounter)
+		a.namespaceAckLevels[namespaceID] = a.ackLevel
+	}
+}
+
+// updateNamespaceAckLevelsLocked moves the ack level of each split namespace to just before its first pending task,
+// merging the namespace back once it catches up with the shared ack level. The returned ack levels are nil if no
+// namespace is or was split, in which case there is nothing to persist.
+func (a *queueAckMgrImpl) updateNamespaceAckLevelsLocked(
+	taskIDs []int64,
+) map[string]int64 {
+
+	if len(a.namespaceAckLevels) == 0 {
+		return nil
+	}
+
+	namespaceAckLevels := make(map[string]int64)
+	for _, taskID := range taskIDs {
+		acked, ok := a.outstandingTasks[taskID]
+		if !ok || acked {
+			continue
+		}
+		namespaceID := a.outstandingTaskMeta[taskID].namespaceID
+		if _, isSplit := a.namespaceAckLevels[namespaceID]; !isSplit {
+			continue
+		}
+		if _, ok := namespaceAckLevels[namespaceID]; !ok && taskID-1 < a.ackLevel {
+			namespaceAckLevels[namespaceID] = taskID - 1
+		}
+	}
+
+	for namespaceID, ackLevel := range a.namespaceAckLevels {
+		if _, ok := namespaceAckLevels[namespaceID]; !ok {
+			if a.readLevel < a.ackLevel {
+				// tasks of the namespace behind the shared ack level are not fully read yet
+				namespaceAckLevels[namespaceID] = ackLevel
+				continue
+			}
+			a.logger.Info("Merging namespace back into queue.", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(a.ackLevel))
+			a.metricsClient.IncCounter(a.options.MetricScope, metrics.NamespaceMergeCounter)
+		}
+	}
+	a.namespaceAckLevels = namespaceAckLevels
+	a.metricsClient.UpdateGauge(a.options.MetricScope, metrics.SplitNamespaceCountGauge, float64(len(namespaceAckLevels)))
+
+	result := make(map[string]int64, len(namespaceAckLevels))
+	for namespaceID, ackLevel := range namespaceAckLevels {
+		result[namespaceID] = ackLevel
+	}
+	return result
+}
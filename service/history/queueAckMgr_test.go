@@ -117,7 +117,7 @@ func (s *queueAckMgrSuite) SetupTest() {
 
 	s.queueAckMgr = newQueueAckMgr(s.mockShard, &QueueProcessorOptions{
 		MetricScope: metrics.ReplicatorQueueProcessorScope,
-	}, s.mockProcessor, 0, s.logger)
+	}, s.mockProcessor, 0, nil, nil, s.logger)
 }
 
 func (s *queueAckMgrSuite) TearDownTest() {
@@ -259,7 +259,7 @@ func (s *queueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.queueAckMgr.updateQueueAckLevel()
 	s.Equal(taskID1, s.queueAckMgr.getQueueAckLevel())
 
-	s.mockProcessor.On("updateAckLevel", taskID1).Return(nil).Once()
+	// ack level does not move, so it is not updated again
 	s.queueAckMgr.completeQueueTask(taskID3)
 	s.queueAckMgr.updateQueueAckLevel()
 	s.Equal(taskID1, s.queueAckMgr.getQueueAckLevel())
@@ -270,6 +270,183 @@ func (s *queueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(taskID3, s.queueAckMgr.getQueueAckLevel())
 }
 
+func (s *queueAckMgrSuite) TestUpdateQueueAckLevel_SplitNamespace() {
+	var sharedAckLevel int64
+	var namespaceAckLevels map[string]int64
+	updateCount := 0
+	queueAckMgr := newQueueAckMgr(s.mockShard, &QueueProcessorOptions{
+		EnableNamespaceSplit:    dynamicconfig.GetBoolPropertyFn(true),
+		SplitMaxPendingTasks:    dynamicconfig.GetIntPropertyFn(1),
+		SplitMaxPendingDuration: dynamicconfig.GetDurationPropertyFn(time.Hour),
+		MetricScope:             metrics.TransferActiveQueueProcessorScope,
+	}, s.mockProcessor, 0, nil, func(ackLevel int64, ackLevels map[string]int64) error {
+		sharedAckLevel = ackLevel
+		namespaceAckLevels = ackLevels
+		updateCount++
+		return nil
+	}, s.logger)
+
+	stuckNamespaceID := uuid.New()
+	taskID1 := int64(59)
+	taskID2 := int64(60)
+	taskID3 := int64(61)
+	tasksInput := []queueTaskInfo{
+		&persistenceblobs.TransferTaskInfo{
+			NamespaceId: stuckNamespaceID,
+			WorkflowId:  "some random workflow ID",
+			RunId:       uuid.New(),
+			TaskId:      taskID1,
+			TaskList:    "some random tasklist",
+			TaskType:    1,
+			ScheduleId:  28,
+		},
+		&persistenceblobs.TransferTaskInfo{
+			NamespaceId: stuckNamespaceID,
+			WorkflowId:  "some random workflow ID",
+			RunId:       uuid.New(),
+			TaskId:      taskID2,
+			TaskList:    "some random tasklist",
+			TaskType:    1,
+			ScheduleId:  28,
+		},
+		&persistenceblobs.TransferTaskInfo{
+			NamespaceId: TestNamespaceId,
+			WorkflowId:  "some random workflow ID",
+			RunId:       uuid.New(),
+			TaskId:      taskID3,
+			TaskList:    "some random tasklist",
+			TaskType:    1,
+			ScheduleId:  28,
+		},
+	}
+
+	s.mockProcessor.On("readTasks", int64(0)).Return(tasksInput, false, nil).Once()
+	_, _, err := queueAckMgr.readQueueTasks()
+	s.NoError(err)
+
+	// pending tasks of the stuck namespace exceed the threshold, so the namespace is split
+	// and the shared ack level moves past its pending tasks
+	queueAckMgr.completeQueueTask(taskID3)
+	s.NoError(queueAckMgr.updateQueueAckLevel())
+	s.True(queueAckMgr.isNamespaceSplit(stuckNamespaceID))
+	s.False(queueAckMgr.isNamespaceSplit(TestNamespaceId))
+	s.Equal(taskID3, queueAckMgr.ackLevel)
+	s.Equal(taskID3, sharedAckLevel)
+	s.Equal(map[string]int64{stuckNamespaceID: taskID1 - 1}, namespaceAckLevels)
+	s.Equal(taskID1-1, queueAckMgr.getQueueAckLevel())
+	s.Equal(1, updateCount)
+
+	// no level moved, so the shard is not updated
+	s.NoError(queueAckMgr.updateQueueAckLevel())
+	s.Equal(1, updateCount)
+
+	// once the pending tasks are done, the namespace is merged back
+	queueAckMgr.completeQueueTask(taskID1)
+	queueAckMgr.completeQueueTask(taskID2)
+	s.NoError(queueAckMgr.updateQueueAckLevel())
+	s.False(queueAckMgr.isNamespaceSplit(stuckNamespaceID))
+	s.Equal(taskID3, sharedAckLevel)
+	s.Empty(namespaceAckLevels)
+	s.Equal(taskID3, queueAckMgr.getQueueAckLevel())
+	s.Equal(2, updateCount)
+}
+
+func (s *queueAckMgrSuite) TestReadQueueTasks_SplitNamespaceBehindAckLevel() {
+	splitNamespaceID := uuid.New()
+	queueAckMgr := newQueueAckMgr(s.mockShard, &QueueProcessorOptions{
+		MetricScope: metrics.TransferActiveQueueProcessorScope,
+	}, s.mockProcessor, 61, map[string]int64{splitNamespaceID: 58}, func(int64, map[string]int64) error {
+		return nil
+	}, s.logger)
+	s.Equal(int64(58), queueAckMgr.getQueueReadLevel())
+	s.Equal(int64(58), queueAckMgr.getQueueAckLevel())
+
+	splitTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: splitNamespaceID,
+		WorkflowId:  "some random workflow ID",
+		RunId:       uuid.New(),
+		TaskId:      59,
+		TaskList:    "some random tasklist",
+		TaskType:    1,
+		ScheduleId:  28,
+	}
+	ackedTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: TestNamespaceId,
+		WorkflowId:  "some random workflow ID",
+		RunId:       uuid.New(),
+		TaskId:      60,
+		TaskList:    "some random tasklist",
+		TaskType:    1,
+		ScheduleId:  28,
+	}
+	newTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: TestNamespaceId,
+		WorkflowId:  "some random workflow ID",
+		RunId:       uuid.New(),
+		TaskId:      62,
+		TaskList:    "some random tasklist",
+		TaskType:    1,
+		ScheduleId:  28,
+	}
+
+	s.mockProcessor.On("readTasks", int64(58)).Return([]queueTaskInfo{splitTask, ackedTask, newTask}, false, nil).Once()
+	tasksOutput, _, err := queueAckMgr.readQueueTasks()
+	s.NoError(err)
+	s.Equal([]queueTaskInfo{splitTask, newTask}, tasksOutput)
+	s.Equal(map[int64]bool{59: false, 62: false}, queueAckMgr.outstandingTasks)
+	s.Equal(int64(62), queueAckMgr.getQueueReadLevel())
+}
+
+func (s *queueAckMgrSuite) TestHasPendingTasksBefore() {
+	workflowID := "some random workflow ID"
+	runID := uuid.New()
+	otherWorkflowID := "some other workflow ID"
+	otherRunID := uuid.New()
+	readLevel := s.queueAckMgr.readLevel
+	tasksInput := []queueTaskInfo{
+		&persistenceblobs.TransferTaskInfo{
+			NamespaceId: TestNamespaceId,
+			WorkflowId:  workflowID,
+			RunId:       runID,
+			TaskId:      59,
+			TaskList:    "some random tasklist",
+			TaskType:    1,
+			ScheduleId:  28,
+		},
+		&persistenceblobs.TransferTaskInfo{
+			NamespaceId: TestNamespaceId,
+			WorkflowId:  otherWorkflowID,
+			RunId:       otherRunID,
+			TaskId:      60,
+			TaskList:    "some random tasklist",
+			TaskType:    1,
+			ScheduleId:  28,
+		},
+		&persistenceblobs.TransferTaskInfo{
+			NamespaceId: TestNamespaceId,
+			WorkflowId:  workflowID,
+			RunId:       runID,
+			TaskId:      61,
+			TaskList:    "some random tasklist",
+			TaskType:    1,
+			ScheduleId:  28,
+		},
+	}
+
+	s.mockProcessor.On("readTasks", readLevel).Return(tasksInput, false, nil).Once()
+	_, _, err := s.queueAckMgr.readQueueTasks()
+	s.NoError(err)
+
+	s.False(s.queueAckMgr.hasPendingTasksBefore(TestNamespaceId, workflowID, runID, 59))
+	s.True(s.queueAckMgr.hasPendingTasksBefore(TestNamespaceId, workflowID, runID, 61))
+
+	// pending tasks of other executions in the same namespace do not count
+	s.queueAckMgr.completeQueueTask(59)
+	s.False(s.queueAckMgr.hasPendingTasksBefore(TestNamespaceId, workflowID, runID, 61))
+	s.True(s.queueAckMgr.hasPendingTasksBefore(TestNamespaceId, otherWorkflowID, otherRunID, 61))
+	s.False(s.queueAckMgr.hasPendingTasksBefore(TestNamespaceId, workflowID, uuid.New(), 61))
+}
+
 // Tests for failover ack manager
 func (s *queueFailoverAckMgrSuite) SetupSuite() {
 
@@ -406,7 +583,7 @@ func (s *queueFailoverAckMgrSuite) TestReadCompleteQueueTasks() {
 
 	s.queueFailoverAckMgr.completeQueueTask(taskID2)
 	s.Equal(map[int64]bool{taskID1: false, taskID2: true}, s.queueFailoverAckMgr.outstandingTasks)
-	s.mockProcessor.On("updateAckLevel", s.queueFailoverAckMgr.getQueueAckLevel()).Return(nil)
+	// ack level does not move, so it is not updated
 	s.queueFailoverAckMgr.updateQueueAckLevel()
 	select {
 	case <-s.queueFailoverAckMgr.getFinishedChan():
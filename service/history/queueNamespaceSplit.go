@@ -0,0 +1,221 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/collection"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/quotas"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// queueTaskMeta is kept by ack managers for every outstanding task,
+	// so that pending work can be attributed to namespaces
+	queueTaskMeta struct {
+		namespaceID string
+		workflowID  string
+		runID       string
+		loadTime    time.Time
+	}
+
+	// splitNamespaceDispatcher owns the processing queues of namespaces split out of the shared queue.
+	// Each split namespace gets its own queue and dispatch rate limit, so that a stuck or overloaded
+	// namespace does not hold back tasks of other namespaces.
+	splitNamespaceDispatcher struct {
+		namespaceCache cache.NamespaceCache
+		maxDispatchRPS dynamicconfig.IntPropertyFnWithNamespaceFilter
+		submitFn       func(queueTaskInfo) bool
+		logger         log.Logger
+		metricsScope   metrics.Scope
+		ctx            context.Context
+		cancel         context.CancelFunc
+
+		sync.Mutex
+		queues map[string]*splitNamespaceQueue
+	}
+
+	splitNamespaceQueue struct {
+		tasks       collection.Queue
+		rateLimiter quotas.Limiter
+	}
+)
+
+// getNamespacesToSplit returns the namespaces whose pending tasks exceed either the pending task count
+// or the pending duration threshold
+func getNamespacesToSplit(
+	pendingTasks []queueTaskMeta,
+	maxPendingTasks int,
+	maxPendingDuration time.Duration,
+	now time.Time,
+) map[string]struct{} {
+
+	pendingCount := make(map[string]int)
+	oldestLoadTime := make(map[string]time.Time)
+	for _, meta := range pendingTasks {
+		pendingCount[meta.namespaceID]++
+		if loadTime, ok := oldestLoadTime[meta.namespaceID]; !ok || meta.loadTime.Before(loadTime) {
+			oldestLoadTime[meta.namespaceID] = meta.loadTime
+		}
+	}
+
+	namespaceIDs := make(map[string]struct{})
+	for namespaceID, count := range pendingCount {
+		if count > maxPendingTasks || now.Sub(oldestLoadTime[namespaceID]) > maxPendingDuration {
+			namespaceIDs[namespaceID] = struct{}{}
+		}
+	}
+	return namespaceIDs
+}
+
+// namespaceAckLevelsEqual returns whether the ack levels of split namespaces are the same,
+// no split namespace is represented by either a nil or an empty map
+func namespaceAckLevelsEqual(
+	first map[string]int64,
+	second map[string]int64,
+) bool {
+
+	if len(first) != len(second) {
+		return false
+	}
+	for namespaceID, ackLevel := range first {
+		if otherAckLevel, ok := second[namespaceID]; !ok || ackLevel != otherAckLevel {
+			return false
+		}
+	}
+	return true
+}
+
+// namespaceTimerAckLevelsEqual is namespaceAckLevelsEqual for the timer ack levels of split namespaces
+func namespaceTimerAckLevelsEqual(
+	first map[string]time.Time,
+	second map[string]time.Time,
+) bool {
+
+	if len(first) != len(second) {
+		return false
+	}
+	for namespaceID, ackLevel := range first {
+		if otherAckLevel, ok := second[namespaceID]; !ok || !ackLevel.Equal(otherAckLevel) {
+			return false
+		}
+	}
+	return true
+}
+
+func newSplitNamespaceDispatcher(
+	namespaceCache cache.NamespaceCache,
+	maxDispatchRPS dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	submitFn func(queueTaskInfo) bool,
+	logger log.Logger,
+	metricsScope metrics.Scope,
+) *splitNamespaceDispatcher {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &splitNamespaceDispatcher{
+		namespaceCache: namespaceCache,
+		maxDispatchRPS: maxDispatchRPS,
+		submitFn:       submitFn,
+		logger:         logger,
+		metricsScope:   metricsScope,
+		ctx:            ctx,
+		cancel:         cancel,
+		queues:         make(map[string]*splitNamespaceQueue),
+	}
+}
+
+// dispatch adds the task to the queue of its namespace, starting the queue if it is not running
+func (d *splitNamespaceDispatcher) dispatch(
+	task queueTaskInfo,
+) {
+
+	namespaceID := task.GetNamespaceId()
+
+	d.Lock()
+	defer d.Unlock()
+
+	if queue, ok := d.queues[namespaceID]; ok {
+		queue.tasks.Add(task)
+		return
+	}
+
+	namespace, err := d.namespaceCache.GetNamespaceName(namespaceID)
+	if err != nil {
+		d.logger.Warn("Cannot find namespace name for split namespace", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+	}
+	queue := &splitNamespaceQueue{
+		tasks: collection.NewConcurrentQueue(),
+		rateLimiter: quotas.NewDynamicRateLimiter(
+			func() float64 {
+				return float64(d.maxDispatchRPS(namespace))
+			},
+		),
+	}
+	queue.tasks.Add(task)
+	d.queues[namespaceID] = queue
+	go d.dispatchLoop(namespaceID, queue)
+}
+
+func (d *splitNamespaceDispatcher) stop() {
+	d.cancel()
+}
+
+func (d *splitNamespaceDispatcher) dispatchLoop(
+	namespaceID string,
+	queue *splitNamespaceQueue,
+) {
+
+	for {
+		d.Lock()
+		if queue.tasks.IsEmpty() {
+			// queue is drained, a new one will be created on next dispatch
+			delete(d.queues, namespaceID)
+			d.Unlock()
+			return
+		}
+		task := queue.tasks.Remove().(queueTaskInfo)
+		d.Unlock()
+
+		if !queue.rateLimiter.Allow() {
+			d.metricsScope.IncCounter(metrics.SplitNamespaceTaskThrottledCounter)
+			if err := queue.rateLimiter.Wait(d.ctx); err != nil {
+				// dispatcher is stopped
+				return
+			}
+		}
+
+		if submitted := d.submitFn(task); !submitted {
+			// processor is shutting down
+			return
+		}
+	}
+}
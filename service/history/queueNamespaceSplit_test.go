@@ -0,0 +1,71 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	queueNamespaceSplitSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestQueueNamespaceSplitSuite(t *testing.T) {
+	s := new(queueNamespaceSplitSuite)
+	suite.Run(t, s)
+}
+
+func (s *queueNamespaceSplitSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *queueNamespaceSplitSuite) TestGetNamespacesToSplit() {
+	now := time.Now()
+	pendingTasks := []queueTaskMeta{
+		{namespaceID: "overloaded", loadTime: now},
+		{namespaceID: "overloaded", loadTime: now},
+		{namespaceID: "overloaded", loadTime: now},
+		{namespaceID: "stuck", loadTime: now.Add(-time.Hour)},
+		{namespaceID: "healthy", loadTime: now.Add(-time.Second)},
+		{namespaceID: "healthy", loadTime: now},
+	}
+
+	namespaceIDs := getNamespacesToSplit(pendingTasks, 2, time.Minute, now)
+	s.Equal(map[string]struct{}{
+		"overloaded": {},
+		"stuck":      {},
+	}, namespaceIDs)
+}
+
+func (s *queueNamespaceSplitSuite) TestGetNamespacesToSplit_NoPendingTasks() {
+	s.Empty(getNamespacesToSplit(nil, 2, time.Minute, time.Now()))
+}
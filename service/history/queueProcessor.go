@@ -57,6 +57,10 @@ type (
 		RedispatchIntervalJitterCoefficient dynamicconfig.FloatPropertyFn
 		MaxRedispatchQueueSize              dynamicconfig.IntPropertyFn
 		EnablePriorityTaskProcessor         dynamicconfig.BoolPropertyFn
+		EnableNamespaceSplit                dynamicconfig.BoolPropertyFn
+		SplitMaxPendingTasks                dynamicconfig.IntPropertyFn
+		SplitMaxPendingDuration             dynamicconfig.DurationPropertyFn
+		SplitNamespaceMaxDispatchRPS        dynamicconfig.IntPropertyFnWithNamespaceFilter
 		MetricScope                         int
 	}
 
@@ -76,6 +80,7 @@ type (
 		queueTaskProcessor   queueTaskProcessor
 		redispatchQueue      collection.Queue
 		queueTaskInitializer queueTaskInitializer
+		splitDispatcher      *splitNamespaceDispatcher
 
 		lastPollTime time.Time
 
@@ -139,6 +144,16 @@ func newQueueProcessorBase(
 		queueTaskInitializer: queueTaskInitializer,
 	}
 
+	if options.SplitNamespaceMaxDispatchRPS != nil {
+		p.splitDispatcher = newSplitNamespaceDispatcher(
+			shard.GetNamespaceCache(),
+			options.SplitNamespaceMaxDispatchRPS,
+			p.submitTask,
+			logger,
+			metricsScope,
+		)
+	}
+
 	return p
 }
 
@@ -167,6 +182,9 @@ func (p *queueProcessorBase) Stop() {
 	defer p.logger.Info("", tag.LifeCycleStopped, tag.ComponentTransferQueue)
 
 	close(p.shutdownCh)
+	if p.splitDispatcher != nil {
+		p.splitDispatcher.stop()
+	}
 	p.retryTasks()
 
 	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
@@ -279,6 +297,10 @@ func (p *queueProcessorBase) processBatch() {
 	}
 
 	for _, task := range tasks {
+		if p.splitDispatcher != nil && p.ackMgr.isNamespaceSplit(task.GetNamespaceId()) {
+			p.splitDispatcher.dispatch(task)
+			continue
+		}
 		if submitted := p.submitTask(task); !submitted {
 			// submitted since processor has been shutdown
 			return
@@ -279,6 +279,12 @@ func (t *queueTaskBase) HandleErr(
 	}
 
 	t.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
+
+	// t.attempt is only incremented after this function returns
+	if attempt := t.attempt + 1; attempt > t.maxRetryCount() &&
+		moveTaskToDLQ(t.shard, t.queueTaskInfo, attempt, err, t.scope, t.logger) {
+		return nil
+	}
 	return err
 }
 
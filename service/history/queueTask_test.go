@@ -30,11 +30,13 @@ import (
 	"time"
 
 	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	"go.temporal.io/temporal-proto/serviceerror"
 
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
@@ -186,6 +188,67 @@ func (s *queueTaskSuite) TestHandleErr_UnknownErr() {
 	s.Equal(err, queueTaskBase.HandleErr(err))
 }
 
+func (s *queueTaskSuite) TestHandleErr_ExceedMaxRetryCount_MovedToDLQ() {
+	s.mockShard.GetConfig().EnableTaskDLQ = dynamicconfig.GetBoolPropertyFn(true)
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: "some random namespaceID",
+		WorkflowId:  "some random workflowID",
+		RunId:       "some random runID",
+		TaskId:      12345,
+	}
+	queueTaskBase := s.newTestQueueTaskBaseWithInfo(transferTask)
+	queueTaskBase.attempt = s.maxRetryCount()
+
+	err := errors.New("some random error")
+	s.mockShard.resource.ExecutionMgr.On("PutHistoryTaskToDLQ", mock.MatchedBy(func(request *persistence.PutHistoryTaskToDLQRequest) bool {
+		message := request.Message
+		return message.GetMessageId() == transferTask.GetTaskId() &&
+			message.GetShardId() == int32(s.mockShard.GetShardID()) &&
+			message.GetType() == enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER &&
+			message.GetTransferTask() == transferTask &&
+			message.GetAttempt() == int32(s.maxRetryCount()+1) &&
+			message.GetFailureReason() == err.Error()
+	})).Return(nil).Once()
+
+	s.NoError(queueTaskBase.HandleErr(err))
+}
+
+func (s *queueTaskSuite) TestHandleErr_ExceedMaxRetryCount_TransientError() {
+	s.mockShard.GetConfig().EnableTaskDLQ = dynamicconfig.GetBoolPropertyFn(true)
+
+	for _, err := range []error{
+		&persistence.ShardOwnershipLostError{ShardID: 1},
+		serviceerror.NewResourceExhausted("some random error"),
+		serviceerror.NewDeadlineExceeded("some random error"),
+		ErrNamespaceHandover,
+	} {
+		queueTaskBase := s.newTestQueueTaskBaseWithInfo(&persistenceblobs.TransferTaskInfo{})
+		queueTaskBase.attempt = s.maxRetryCount()
+		s.Equal(err, queueTaskBase.HandleErr(err))
+	}
+	s.mockShard.resource.ExecutionMgr.AssertNotCalled(s.T(), "PutHistoryTaskToDLQ", mock.Anything)
+}
+
+func (s *queueTaskSuite) TestHandleErr_ExceedMaxRetryCount_DLQDisabled() {
+	queueTaskBase := s.newTestQueueTaskBaseWithInfo(&persistenceblobs.TransferTaskInfo{})
+	queueTaskBase.attempt = s.maxRetryCount()
+
+	err := errors.New("some random error")
+	s.Equal(err, queueTaskBase.HandleErr(err))
+	s.Equal(s.maxRetryCount()+1, queueTaskBase.attempt)
+}
+
+func (s *queueTaskSuite) TestHandleErr_ExceedMaxRetryCount_PublishFailed() {
+	s.mockShard.GetConfig().EnableTaskDLQ = dynamicconfig.GetBoolPropertyFn(true)
+	queueTaskBase := s.newTestQueueTaskBaseWithInfo(&persistenceblobs.TimerTaskInfo{})
+	queueTaskBase.attempt = s.maxRetryCount()
+
+	s.mockShard.resource.ExecutionMgr.On("PutHistoryTaskToDLQ", mock.Anything).Return(errors.New("some random error")).Once()
+
+	err := errors.New("some random error")
+	s.Equal(err, queueTaskBase.HandleErr(err))
+}
+
 func (s *queueTaskSuite) TestTaskState() {
 	queueTaskBase := s.newTestQueueTaskBase(func(task queueTaskInfo) (bool, error) {
 		return true, nil
@@ -224,3 +287,20 @@ func (s *queueTaskSuite) newTestQueueTaskBase(
 		s.maxRetryCount,
 	)
 }
+
+func (s *queueTaskSuite) newTestQueueTaskBaseWithInfo(
+	taskInfo queueTaskInfo,
+) *queueTaskBase {
+	return newQueueTaskBase(
+		s.mockShard,
+		taskInfo,
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) {
+			return true, nil
+		},
+		s.mockQueueTaskExecutor,
+		s.timeSource,
+		s.maxRetryCount,
+	)
+}
@@ -0,0 +1,134 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
+)
+
+// compressReplicationTasks compresses the history event blobs of the replication tasks in place,
+// if the remote cluster accepts the compression.
+func compressReplicationTasks(
+	replicationTasks []*replicationgenpb.ReplicationTask,
+	compressionType enumsgenpb.ReplicationCompressionType,
+) error {
+
+	if compressionType != enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP {
+		return nil
+	}
+
+	for _, replicationTask := range replicationTasks {
+		attr := replicationTask.GetHistoryTaskV2Attributes()
+		if attr == nil || attr.GetCompressionType() != enumsgenpb.REPLICATION_COMPRESSION_TYPE_UNSPECIFIED {
+			continue
+		}
+
+		events, err := gzipBlob(attr.GetEvents())
+		if err != nil {
+			return err
+		}
+		newRunEvents, err := gzipBlob(attr.GetNewRunEvents())
+		if err != nil {
+			return err
+		}
+		attr.Events = events
+		attr.NewRunEvents = newRunEvents
+		attr.CompressionType = enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP
+	}
+	return nil
+}
+
+// decompressReplicationTasks reverts compressReplicationTasks in place.
+func decompressReplicationTasks(
+	replicationTasks []*replicationgenpb.ReplicationTask,
+) error {
+
+	for _, replicationTask := range replicationTasks {
+		attr := replicationTask.GetHistoryTaskV2Attributes()
+		if attr == nil || attr.GetCompressionType() != enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP {
+			continue
+		}
+
+		events, err := gunzipBlob(attr.GetEvents())
+		if err != nil {
+			return err
+		}
+		newRunEvents, err := gunzipBlob(attr.GetNewRunEvents())
+		if err != nil {
+			return err
+		}
+		attr.Events = events
+		attr.NewRunEvents = newRunEvents
+		attr.CompressionType = enumsgenpb.REPLICATION_COMPRESSION_TYPE_UNSPECIFIED
+	}
+	return nil
+}
+
+func gzipBlob(blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	if blob == nil {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(blob.GetData()); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.GetEncodingType(),
+		Data:         buf.Bytes(),
+	}, nil
+}
+
+func gunzipBlob(blob *commonpb.DataBlob) (*commonpb.DataBlob, error) {
+	if blob == nil {
+		return nil, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(blob.GetData()))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &commonpb.DataBlob{
+		EncodingType: blob.GetEncodingType(),
+		Data:         data,
+	}, nil
+}
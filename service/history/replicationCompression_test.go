@@ -0,0 +1,119 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
+)
+
+type (
+	replicationCompressionSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestReplicationCompressionSuite(t *testing.T) {
+	s := new(replicationCompressionSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicationCompressionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *replicationCompressionSuite) TestCompressAndDecompress() {
+	events := &commonpb.DataBlob{
+		EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+		Data:         []byte("some random history events, some random history events"),
+	}
+	newRunEvents := &commonpb.DataBlob{
+		EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+		Data:         []byte("some random new run history events"),
+	}
+	syncActivityTask := &replicationgenpb.ReplicationTask{
+		TaskType: enumsgenpb.REPLICATION_TASK_TYPE_SYNC_ACTIVITY_TASK,
+		Attributes: &replicationgenpb.ReplicationTask_SyncActivityTaskAttributes{
+			SyncActivityTaskAttributes: &replicationgenpb.SyncActivityTaskAttributes{},
+		},
+	}
+	replicationTasks := []*replicationgenpb.ReplicationTask{
+		{
+			TaskType: enumsgenpb.REPLICATION_TASK_TYPE_HISTORY_V2_TASK,
+			Attributes: &replicationgenpb.ReplicationTask_HistoryTaskV2Attributes{
+				HistoryTaskV2Attributes: &replicationgenpb.HistoryTaskV2Attributes{
+					Events:       events,
+					NewRunEvents: newRunEvents,
+				},
+			},
+		},
+		syncActivityTask,
+	}
+
+	err := compressReplicationTasks(replicationTasks, enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP)
+	s.NoError(err)
+	attr := replicationTasks[0].GetHistoryTaskV2Attributes()
+	s.Equal(enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP, attr.GetCompressionType())
+	s.Equal(enumspb.ENCODING_TYPE_PROTO3, attr.GetEvents().GetEncodingType())
+	s.NotEqual(events.GetData(), attr.GetEvents().GetData())
+	s.NotEqual(newRunEvents.GetData(), attr.GetNewRunEvents().GetData())
+
+	err = decompressReplicationTasks(replicationTasks)
+	s.NoError(err)
+	attr = replicationTasks[0].GetHistoryTaskV2Attributes()
+	s.Equal(enumsgenpb.REPLICATION_COMPRESSION_TYPE_UNSPECIFIED, attr.GetCompressionType())
+	s.Equal(events, attr.GetEvents())
+	s.Equal(newRunEvents, attr.GetNewRunEvents())
+	s.Equal(syncActivityTask, replicationTasks[1])
+}
+
+func (s *replicationCompressionSuite) TestCompress_NotAccepted() {
+	events := &commonpb.DataBlob{
+		EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+		Data:         []byte("some random history events"),
+	}
+	replicationTasks := []*replicationgenpb.ReplicationTask{{
+		TaskType: enumsgenpb.REPLICATION_TASK_TYPE_HISTORY_V2_TASK,
+		Attributes: &replicationgenpb.ReplicationTask_HistoryTaskV2Attributes{
+			HistoryTaskV2Attributes: &replicationgenpb.HistoryTaskV2Attributes{
+				Events: events,
+			},
+		},
+	}}
+
+	err := compressReplicationTasks(replicationTasks, enumsgenpb.REPLICATION_COMPRESSION_TYPE_UNSPECIFIED)
+	s.NoError(err)
+	attr := replicationTasks[0].GetHistoryTaskV2Attributes()
+	s.Equal(enumsgenpb.REPLICATION_COMPRESSION_TYPE_UNSPECIFIED, attr.GetCompressionType())
+	s.Equal(events, attr.GetEvents())
+}
@@ -27,10 +27,16 @@
 package history
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.temporal.io/temporal-proto/serviceerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 	"github.com/temporalio/temporal/client"
 	"github.com/temporalio/temporal/client/admin"
@@ -39,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/rpc"
 	serviceConfig "github.com/temporalio/temporal/common/service/config"
 )
@@ -46,6 +53,9 @@ import (
 const (
 	fetchTaskRequestTimeout = time.Minute
 	requestChanBufferSize   = 1000
+	// compressionProbeInterval is how long fetcher stops asking for compressed calls after the remote cluster
+	// failed to serve one, e.g. because it does not run a version which supports them yet.
+	compressionProbeInterval = 10 * time.Minute
 )
 
 type (
@@ -56,9 +66,17 @@ type (
 		sourceCluster  string
 		config         *Config
 		logger         log.Logger
+		metricsScope   metrics.Scope
 		remotePeer     admin.Client
 		requestChan    chan *request
 		done           chan struct{}
+
+		// unix nanoseconds until which calls are not compressed
+		compressionDisabledUntil int64
+
+		pageSizeLock sync.Mutex
+		// page size to ask for in the next fetch of each shard
+		pageSizeByShard map[int32]int32
 	}
 	// ReplicationTaskFetcher is responsible for fetching replication messages from remote DC.
 	ReplicationTaskFetcher interface {
@@ -86,6 +104,7 @@ type (
 // NewReplicationTaskFetchers creates an instance of ReplicationTaskFetchers with given configs.
 func NewReplicationTaskFetchers(
 	logger log.Logger,
+	metricsClient metrics.Client,
 	config *Config,
 	consumerConfig *serviceConfig.ReplicationConsumerConfig,
 	clusterMetadata cluster.Metadata,
@@ -104,6 +123,7 @@ func NewReplicationTaskFetchers(
 				remoteFrontendClient := clientBean.GetRemoteAdminClient(clusterName)
 				fetcher := newReplicationTaskFetcher(
 					logger,
+					metricsClient,
 					clusterName,
 					currentCluster,
 					config,
@@ -153,6 +173,7 @@ func (f *ReplicationTaskFetchersImpl) GetFetchers() []ReplicationTaskFetcher {
 // newReplicationTaskFetcher creates a new fetcher.
 func newReplicationTaskFetcher(
 	logger log.Logger,
+	metricsClient metrics.Client,
 	sourceCluster string,
 	currentCluster string,
 	config *Config,
@@ -160,14 +181,16 @@ func newReplicationTaskFetcher(
 ) *ReplicationTaskFetcherImpl {
 
 	return &ReplicationTaskFetcherImpl{
-		status:         common.DaemonStatusInitialized,
-		config:         config,
-		logger:         logger.WithTags(tag.ClusterName(sourceCluster)),
-		remotePeer:     sourceFrontend,
-		currentCluster: currentCluster,
-		sourceCluster:  sourceCluster,
-		requestChan:    make(chan *request, requestChanBufferSize),
-		done:           make(chan struct{}),
+		status:          common.DaemonStatusInitialized,
+		config:          config,
+		logger:          logger.WithTags(tag.ClusterName(sourceCluster)),
+		metricsScope:    metricsClient.Scope(metrics.ReplicationTaskFetcherScope, metrics.TargetClusterTag(sourceCluster)),
+		remotePeer:      sourceFrontend,
+		currentCluster:  currentCluster,
+		sourceCluster:   sourceCluster,
+		requestChan:     make(chan *request, requestChanBufferSize),
+		done:            make(chan struct{}),
+		pageSizeByShard: make(map[int32]int32),
 	}
 }
 
@@ -266,23 +289,90 @@ func (f *ReplicationTaskFetcherImpl) getMessages(
 	requestByShard map[int32]*request,
 ) (map[int32]*replicationgenpb.ReplicationMessages, error) {
 	var tokens []*replicationgenpb.ReplicationToken
-	for _, request := range requestByShard {
+	for shardID, request := range requestByShard {
+		request.token.PageSize = f.getPageSize(shardID)
 		tokens = append(tokens, request.token)
 	}
 
 	ctx, cancel := rpc.NewContextWithTimeoutAndHeaders(fetchTaskRequestTimeout)
 	defer cancel()
+	ctx, wireSize := rpc.NewContextWithWireSize(ctx)
 
 	request := &adminservice.GetReplicationMessagesRequest{
 		Tokens:      tokens,
 		ClusterName: f.currentCluster,
 	}
-	response, err := f.remotePeer.GetReplicationMessages(ctx, request)
+	var opts []grpc.CallOption
+	compressed := f.shouldCompress()
+	if compressed {
+		request.AcceptedCompressionType = enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP
+		opts = append(opts, grpc.UseCompressor(gzip.Name))
+	}
+
+	response, err := f.remotePeer.GetReplicationMessages(ctx, request, opts...)
 	if err != nil {
+		if _, ok := err.(*serviceerror.Unimplemented); ok && compressed {
+			f.logger.Warn("Remote cluster failed to serve compressed call, fetching uncompressed.", tag.Error(err))
+			atomic.StoreInt64(&f.compressionDisabledUntil, time.Now().Add(compressionProbeInterval).UnixNano())
+		}
 		return nil, err
 	}
 
-	return response.GetMessagesByShard(), err
+	f.metricsScope.RecordTimer(metrics.ReplicationMessagesWireSize, time.Duration(wireSize.Received()))
+	f.metricsScope.RecordTimer(metrics.ReplicationMessagesSize, time.Duration(response.Size()))
+
+	messagesByShard := make(map[int32]*replicationgenpb.ReplicationMessages)
+	for shardID, messages := range response.GetMessagesByShard() {
+		request, ok := requestByShard[shardID]
+		if !ok {
+			continue
+		}
+		// The shard is asked again in the next fetch if its tasks cannot be decompressed.
+		if err := decompressReplicationTasks(messages.GetReplicationTasks()); err != nil {
+			f.logger.Error("Failed to decompress replication tasks.", tag.ShardID(int(shardID)), tag.Error(err))
+			continue
+		}
+
+		f.updatePageSize(shardID, request.token.GetPageSize(), messages.GetHasMore())
+		messagesByShard[shardID] = messages
+	}
+
+	return messagesByShard, nil
+}
+
+func (f *ReplicationTaskFetcherImpl) shouldCompress() bool {
+	return f.config.ReplicationTaskFetcherEnableCompression() &&
+		time.Now().UnixNano() >= atomic.LoadInt64(&f.compressionDisabledUntil)
+}
+
+func (f *ReplicationTaskFetcherImpl) getPageSize(shardID int32) int32 {
+	f.pageSizeLock.Lock()
+	defer f.pageSizeLock.Unlock()
+
+	if pageSize, ok := f.pageSizeByShard[shardID]; ok {
+		return pageSize
+	}
+	return int32(f.config.ReplicationTaskFetcherMinPageSize())
+}
+
+// updatePageSize doubles the page size of the shard while it has a backlog of replication tasks,
+// and resets it once the backlog is drained.
+func (f *ReplicationTaskFetcherImpl) updatePageSize(shardID int32, pageSize int32, hasMore bool) {
+	minPageSize := int32(f.config.ReplicationTaskFetcherMinPageSize())
+	maxPageSize := int32(f.config.ReplicationTaskFetcherMaxPageSize())
+
+	if hasMore {
+		pageSize = common.MinInt32(pageSize*2, maxPageSize)
+	} else {
+		pageSize = minPageSize
+	}
+	if pageSize < minPageSize {
+		pageSize = minPageSize
+	}
+
+	f.pageSizeLock.Lock()
+	defer f.pageSizeLock.Unlock()
+	f.pageSizeByShard[shardID] = pageSize
 }
 
 // GetSourceCluster returns the source cluster for the fetcher
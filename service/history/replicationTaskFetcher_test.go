@@ -30,13 +30,17 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock/v1"
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -76,6 +80,7 @@ func (s *replicationTaskFetcherSuite) SetupTest() {
 
 	s.replicationTaskFetcher = newReplicationTaskFetcher(
 		logger,
+		s.mockResource.MetricsClient,
 		"standby",
 		"active",
 		s.config,
@@ -143,3 +148,70 @@ func (s *replicationTaskFetcherSuite) TestFetchAndDistributeTasks() {
 	respToken := <-respChan
 	s.Equal(messageByShared[0], respToken)
 }
+
+func (s *replicationTaskFetcherSuite) TestGetMessages_AdaptivePageSize() {
+	s.config.ReplicationTaskFetcherMinPageSize = dynamicconfig.GetIntPropertyFn(10)
+	s.config.ReplicationTaskFetcherMaxPageSize = dynamicconfig.GetIntPropertyFn(30)
+
+	fetch := func(hasMore bool) int32 {
+		token := &replicationgenpb.ReplicationToken{
+			ShardId:                0,
+			LastProcessedMessageId: 1,
+			LastRetrievedMessageId: 2,
+		}
+		requestByShard := map[int32]*request{0: {token: token}}
+		s.frontendClient.EXPECT().GetReplicationMessages(gomock.Any(), gomock.Any()).Return(&adminservice.GetReplicationMessagesResponse{
+			MessagesByShard: map[int32]*replicationgenpb.ReplicationMessages{
+				0: {HasMore: hasMore},
+			},
+		}, nil)
+		_, err := s.replicationTaskFetcher.getMessages(requestByShard)
+		s.NoError(err)
+		return token.GetPageSize()
+	}
+
+	s.Equal(int32(10), fetch(true))
+	s.Equal(int32(20), fetch(true))
+	s.Equal(int32(30), fetch(true))
+	s.Equal(int32(30), fetch(false))
+	s.Equal(int32(10), fetch(false))
+}
+
+func (s *replicationTaskFetcherSuite) TestGetMessages_Compression() {
+	s.config.ReplicationTaskFetcherEnableCompression = dynamicconfig.GetBoolPropertyFn(true)
+
+	token := &replicationgenpb.ReplicationToken{
+		ShardId:                0,
+		LastProcessedMessageId: 1,
+		LastRetrievedMessageId: 2,
+	}
+	requestByShard := map[int32]*request{0: {token: token}}
+	events := &commonpb.DataBlob{
+		EncodingType: enumspb.ENCODING_TYPE_PROTO3,
+		Data:         []byte("some random history events"),
+	}
+	replicationTasks := []*replicationgenpb.ReplicationTask{{
+		TaskType: enumsgenpb.REPLICATION_TASK_TYPE_HISTORY_V2_TASK,
+		Attributes: &replicationgenpb.ReplicationTask_HistoryTaskV2Attributes{
+			HistoryTaskV2Attributes: &replicationgenpb.HistoryTaskV2Attributes{
+				Events: events,
+			},
+		},
+	}}
+	s.NoError(compressReplicationTasks(replicationTasks, enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP))
+
+	s.frontendClient.EXPECT().GetReplicationMessages(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ interface{}, request *adminservice.GetReplicationMessagesRequest, _ ...interface{}) (*adminservice.GetReplicationMessagesResponse, error) {
+			s.Equal(enumsgenpb.REPLICATION_COMPRESSION_TYPE_GZIP, request.GetAcceptedCompressionType())
+			return &adminservice.GetReplicationMessagesResponse{
+				MessagesByShard: map[int32]*replicationgenpb.ReplicationMessages{
+					0: {ReplicationTasks: replicationTasks},
+				},
+			}, nil
+		})
+	response, err := s.replicationTaskFetcher.getMessages(requestByShard)
+	s.NoError(err)
+	attr := response[0].GetReplicationTasks()[0].GetHistoryTaskV2Attributes()
+	s.Equal(enumsgenpb.REPLICATION_COMPRESSION_TYPE_UNSPECIFIED, attr.GetCompressionType())
+	s.Equal(events, attr.GetEvents())
+}
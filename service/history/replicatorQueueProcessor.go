@@ -27,6 +27,8 @@ package history
 import (
 	"context"
 	"errors"
+	"math"
+	"sync"
 	"time"
 
 	commonpb "go.temporal.io/temporal-proto/common/v1"
@@ -46,6 +48,7 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/persistence/serialization"
 )
@@ -69,6 +72,14 @@ type (
 		queueAckMgr
 
 		lastShardSyncTimestamp time.Time
+
+		caughtUpLock sync.Mutex
+		// last time each remote cluster polled without any replication task left to fetch
+		clusterCaughtUpTime map[string]time.Time
+
+		maxTaskIDLock sync.Mutex
+		// highest ID of the replication tasks written by the shard, emptyMessageID until it is known
+		maxTaskID int64
 	}
 )
 
@@ -76,6 +87,10 @@ var (
 	errUnknownReplicationTask = errors.New("unknown replication task")
 	errHistoryNotFoundTask    = errors.New("history not found")
 	defaultHistoryPageSize    = 1000
+	// pages of replication tasks read at most to count the pending tasks of a namespace
+	maxPendingNamespaceTaskPages = 10
+	// pages of replication tasks read at most to find the highest task ID of the shard
+	maxTaskIDScanPages = 10
 )
 
 func newReplicatorQueueProcessor(
@@ -116,6 +131,9 @@ func newReplicatorQueueProcessor(
 	retryPolicy.SetMaximumAttempts(10)
 	retryPolicy.SetBackoffCoefficient(1)
 
+	// replication tasks leave the cluster, so offloaded payloads are replicated inline
+	historyV2Mgr = payloadstore.NewInflatingHistoryManager(historyV2Mgr, shard.GetPayloadStoreProvider())
+
 	processor := &replicatorQueueProcessorImpl{
 		currentClusterName:    currentClusterName,
 		shard:                 shard,
@@ -129,9 +147,11 @@ func newReplicatorQueueProcessor(
 		logger:                logger,
 		retryPolicy:           retryPolicy,
 		fetchTasksBatchSize:   config.ReplicatorProcessorFetchTasksBatchSize(),
+		clusterCaughtUpTime:   make(map[string]time.Time),
+		maxTaskID:             emptyMessageID,
 	}
 
-	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), logger)
+	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), nil, nil, logger)
 	queueProcessorBase := newQueueProcessorBase(
 		currentClusterName,
 		shard,
@@ -151,6 +171,21 @@ func newReplicatorQueueProcessor(
 	return processor
 }
 
+// notifyNewReplicationTasks records the replication tasks written by the shard and notifies the processor
+func (p *replicatorQueueProcessorImpl) notifyNewReplicationTasks(
+	tasks []persistence.Task,
+) {
+
+	p.maxTaskIDLock.Lock()
+	for _, task := range tasks {
+		if task.GetTaskID() > p.maxTaskID {
+			p.maxTaskID = task.GetTaskID()
+		}
+	}
+	p.maxTaskIDLock.Unlock()
+	p.notifyNewTask()
+}
+
 func (p *replicatorQueueProcessorImpl) getTaskFilter() taskFilter {
 	return p.replicationTaskFilter
 }
@@ -459,16 +494,27 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 	ctx context.Context,
 	pollingCluster string,
 	lastReadTaskID int64,
+	pageSize int,
 ) (*replicationgenpb.ReplicationMessages, error) {
 
 	if lastReadTaskID == emptyMessageID {
 		lastReadTaskID = p.shard.GetClusterReplicationLevel(pollingCluster)
 	}
 
-	taskInfoList, hasMore, err := p.readTasksWithBatchSize(lastReadTaskID, p.fetchTasksBatchSize)
+	batchSize := p.fetchTasksBatchSize
+	if pageSize > 0 {
+		batchSize = common.MinInt(pageSize, p.shard.GetConfig().ReplicatorProcessorMaxFetchTasksBatchSize())
+	}
+
+	taskInfoList, hasMore, err := p.readTasksWithBatchSize(lastReadTaskID, batchSize)
 	if err != nil {
 		return nil, err
 	}
+	if len(taskInfoList) == 0 {
+		p.caughtUpLock.Lock()
+		p.clusterCaughtUpTime[pollingCluster] = p.shard.GetTimeSource().Now()
+		p.caughtUpLock.Unlock()
+	}
 
 	var replicationTasks []*replicationgenpb.ReplicationTask
 	readLevel := lastReadTaskID
@@ -544,6 +590,159 @@ func (p *replicatorQueueProcessorImpl) getTask(
 	return p.toReplicationTask(ctx, &persistence.ReplicationTaskInfoWrapper{ReplicationTaskInfo: task})
 }
 
+// getReplicationStatus reports the replication level of each remote cluster, if namespace ID is given
+// replication tasks of the namespace which are not processed by remote clusters are counted as well
+func (p *replicatorQueueProcessorImpl) getReplicationStatus(
+	remoteClusters []string,
+	namespaceID string,
+	includeDLQTasks bool,
+) (*replicationgenpb.ShardReplicationStatus, error) {
+
+	maxTaskID := int64(emptyMessageID)
+	maxTaskIDUnknown := false
+	if len(remoteClusters) > 0 {
+		minAckedTaskID := int64(math.MaxInt64)
+		for _, cluster := range remoteClusters {
+			if ackedTaskID := p.shard.GetClusterReplicationLevel(cluster); ackedTaskID < minAckedTaskID {
+				minAckedTaskID = ackedTaskID
+			}
+		}
+		var err error
+		if maxTaskID, maxTaskIDUnknown, err = p.getMaxTaskID(minAckedTaskID); err != nil {
+			return nil, err
+		}
+	}
+
+	status := &replicationgenpb.ShardReplicationStatus{
+		ShardId:                     int32(p.shard.GetShardID()),
+		MaxReplicationTaskId:        maxTaskID,
+		MaxReplicationTaskIdUnknown: maxTaskIDUnknown,
+		RemoteClusters:              make(map[string]*replicationgenpb.ShardReplicationStatusPerCluster, len(remoteClusters)),
+	}
+	for _, cluster := range remoteClusters {
+		ackedTaskID := p.shard.GetClusterReplicationLevel(cluster)
+		pendingTasks := 0
+		pendingTasksIncomplete := false
+		if namespaceID != "" {
+			var err error
+			if pendingTasks, pendingTasksIncomplete, err = p.countPendingNamespaceTasks(ackedTaskID, namespaceID); err != nil {
+				return nil, err
+			}
+		}
+		dlqTasks := 0
+		if includeDLQTasks {
+			var err error
+			if dlqTasks, err = p.countDLQTasks(cluster); err != nil {
+				return nil, err
+			}
+		}
+		var caughtUpTime int64
+		p.caughtUpLock.Lock()
+		if t, ok := p.clusterCaughtUpTime[cluster]; ok {
+			caughtUpTime = t.UnixNano()
+		}
+		p.caughtUpLock.Unlock()
+		status.RemoteClusters[cluster] = &replicationgenpb.ShardReplicationStatusPerCluster{
+			AckedTaskId:                     ackedTaskID,
+			PendingNamespaceTasks:           int32(pendingTasks),
+			PendingNamespaceTasksIncomplete: pendingTasksIncomplete,
+			LastCaughtUpTime:                caughtUpTime,
+			DlqTasks:                        int32(dlqTasks),
+		}
+	}
+	return status, nil
+}
+
+// getMaxTaskID returns the highest ID of the replication tasks of the shard, when it is not yet known since the
+// shard is loaded the tasks after the given read level are read, a read level with no task after it is returned as is.
+// Reading stops after a bounded number of pages, the highest ID is then reported unknown until a task is written
+func (p *replicatorQueueProcessorImpl) getMaxTaskID(
+	readLevel int64,
+) (int64, bool, error) {
+
+	p.maxTaskIDLock.Lock()
+	maxTaskID := p.maxTaskID
+	p.maxTaskIDLock.Unlock()
+	if maxTaskID != emptyMessageID {
+		return maxTaskID, false, nil
+	}
+
+	maxTaskID = readLevel
+	hasMore := true
+	for page := 0; hasMore && page < maxTaskIDScanPages; page++ {
+		var taskInfoList []queueTaskInfo
+		var err error
+		taskInfoList, hasMore, err = p.readTasksWithBatchSize(maxTaskID, p.fetchTasksBatchSize)
+		if err != nil {
+			return 0, false, err
+		}
+		if len(taskInfoList) > 0 {
+			maxTaskID = taskInfoList[len(taskInfoList)-1].GetTaskId()
+		}
+	}
+
+	p.maxTaskIDLock.Lock()
+	defer p.maxTaskIDLock.Unlock()
+	// tasks written during the read are recorded already
+	if p.maxTaskID != emptyMessageID {
+		return p.maxTaskID, false, nil
+	}
+	if hasMore {
+		return emptyMessageID, true, nil
+	}
+	p.maxTaskID = maxTaskID
+	return p.maxTaskID, false, nil
+}
+
+func (p *replicatorQueueProcessorImpl) countDLQTasks(
+	sourceCluster string,
+) (int, error) {
+
+	dlqTasks := 0
+	var pageToken []byte
+	for hasMore := true; hasMore && dlqTasks < p.fetchTasksBatchSize; hasMore = len(pageToken) != 0 {
+		resp, err := p.executionMgr.GetReplicationTasksFromDLQ(persistence.NewGetReplicationTasksFromDLQRequest(
+			sourceCluster,
+			p.shard.GetReplicatorDLQAckLevel(sourceCluster),
+			math.MaxInt64,
+			p.fetchTasksBatchSize,
+			pageToken,
+		))
+		if err != nil {
+			return 0, err
+		}
+		dlqTasks += len(resp.Tasks)
+		pageToken = resp.NextPageToken
+	}
+	return dlqTasks, nil
+}
+
+// countPendingNamespaceTasks counts the replication tasks of the namespace after the given read level, counting
+// stops after a bounded number of pages so the returned count is only a lower bound when it is reported incomplete
+func (p *replicatorQueueProcessorImpl) countPendingNamespaceTasks(
+	readLevel int64,
+	namespaceID string,
+) (int, bool, error) {
+
+	pendingTasks := 0
+	hasMore := true
+	for page := 0; hasMore && page < maxPendingNamespaceTaskPages && pendingTasks < p.fetchTasksBatchSize; page++ {
+		var taskInfoList []queueTaskInfo
+		var err error
+		taskInfoList, hasMore, err = p.readTasksWithBatchSize(readLevel, p.fetchTasksBatchSize)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, taskInfo := range taskInfoList {
+			if taskInfo.GetNamespaceId() == namespaceID {
+				pendingTasks++
+			}
+			readLevel = taskInfo.GetTaskId()
+		}
+	}
+	return pendingTasks, hasMore, nil
+}
+
 func (p *replicatorQueueProcessorImpl) readTasksWithBatchSize(readLevel int64, batchSize int) ([]queueTaskInfo, bool, error) {
 	response, err := p.executionMgr.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
 		ReadLevel:    readLevel,
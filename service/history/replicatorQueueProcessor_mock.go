@@ -35,6 +35,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
+	persistence "github.com/temporalio/temporal/common/persistence"
 )
 
 // MockReplicatorQueueProcessor is a mock of ReplicatorQueueProcessor interface
@@ -85,9 +86,9 @@ func (mr *MockReplicatorQueueProcessorMockRecorder) Stop() *gomock.Call {
 }
 
 // getTasks mocks base method
-func (m *MockReplicatorQueueProcessor) getTasks(arg0 context.Context, arg1 string, arg2 int64) (*replicationgenpb.ReplicationMessages, error) {
+func (m *MockReplicatorQueueProcessor) getTasks(arg0 context.Context, arg1 string, arg2 int64, arg3 int) (*replicationgenpb.ReplicationMessages, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "getTasks", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "getTasks", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*replicationgenpb.ReplicationMessages)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
@@ -103,9 +104,36 @@ func (m *MockReplicatorQueueProcessor) getTask(arg0 context.Context, arg1 *repli
 }
 
 // getTasks indicates an expected call of getTasks
-func (mr *MockReplicatorQueueProcessorMockRecorder) getTasks(arg0 interface{}, arg1 interface{}, arg2 interface{}) *gomock.Call {
+func (mr *MockReplicatorQueueProcessorMockRecorder) getTasks(arg0 interface{}, arg1 interface{}, arg2 interface{}, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTasks", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getTasks), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTasks", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getTasks), arg0, arg1, arg2, arg3)
+}
+
+// getReplicationStatus mocks base method
+func (m *MockReplicatorQueueProcessor) getReplicationStatus(arg0 []string, arg1 string, arg2 bool) (*replicationgenpb.ShardReplicationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getReplicationStatus", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*replicationgenpb.ShardReplicationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// getReplicationStatus indicates an expected call of getReplicationStatus
+func (mr *MockReplicatorQueueProcessorMockRecorder) getReplicationStatus(arg0 interface{}, arg1 interface{}, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReplicationStatus", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getReplicationStatus), arg0, arg1, arg2)
+}
+
+// notifyNewReplicationTasks mocks base method
+func (m *MockReplicatorQueueProcessor) notifyNewReplicationTasks(arg0 []persistence.Task) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "notifyNewReplicationTasks", arg0)
+}
+
+// notifyNewReplicationTasks indicates an expected call of notifyNewReplicationTasks
+func (mr *MockReplicatorQueueProcessorMockRecorder) notifyNewReplicationTasks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "notifyNewReplicationTasks", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).notifyNewReplicationTasks), arg0)
 }
 
 // notifyNewTask mocks base method
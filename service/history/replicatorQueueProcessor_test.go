@@ -25,11 +25,13 @@
 package history
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
@@ -481,6 +483,113 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 	s.Nil(err)
 }
 
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus() {
+	namespaceID := uuid.New()
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 4}
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    4,
+		MaxReadLevel: s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:    s.replicatorQueueProcessor.fetchTasksBatchSize,
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistenceblobs.ReplicationTaskInfo{
+			{NamespaceId: namespaceID, TaskId: 5},
+			{NamespaceId: uuid.New(), TaskId: 6},
+			{NamespaceId: namespaceID, TaskId: 7},
+		},
+	}, nil).Twice()
+
+	status, err := s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, namespaceID, false)
+	s.NoError(err)
+	s.Equal(int32(0), status.GetShardId())
+	s.Equal(int64(7), status.GetMaxReplicationTaskId())
+	s.Equal(int64(4), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetAckedTaskId())
+	s.Equal(int32(2), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetPendingNamespaceTasks())
+	s.Equal(int64(0), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetLastCaughtUpTime())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_PendingNamespaceTasksIncomplete() {
+	namespaceID := uuid.New()
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 4}
+	s.replicatorQueueProcessor.maxTaskID = 100
+	s.mockExecutionMgr.On("GetReplicationTasks", mock.Anything).Return(&persistence.GetReplicationTasksResponse{
+		Tasks:         []*persistenceblobs.ReplicationTaskInfo{{NamespaceId: uuid.New(), TaskId: 5}},
+		NextPageToken: []byte{1},
+	}, nil).Times(maxPendingNamespaceTaskPages)
+
+	status, err := s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, namespaceID, false)
+	s.NoError(err)
+	s.Equal(int32(0), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetPendingNamespaceTasks())
+	s.True(status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetPendingNamespaceTasksIncomplete())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_CaughtUpTimeAndDLQTasks() {
+	s.mockExecutionMgr.On("GetReplicationTasksFromDLQ", persistence.NewGetReplicationTasksFromDLQRequest(
+		cluster.TestAlternativeClusterName,
+		s.mockShard.GetReplicatorDLQAckLevel(cluster.TestAlternativeClusterName),
+		math.MaxInt64,
+		s.replicatorQueueProcessor.fetchTasksBatchSize,
+		nil,
+	)).Return(&persistence.GetReplicationTasksFromDLQResponse{
+		Tasks: []*persistenceblobs.ReplicationTaskInfo{{TaskId: 1}, {TaskId: 2}},
+	}, nil).Once()
+
+	caughtUpTime := time.Now()
+	s.replicatorQueueProcessor.clusterCaughtUpTime[cluster.TestAlternativeClusterName] = caughtUpTime
+	s.replicatorQueueProcessor.maxTaskID = 10
+
+	status, err := s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, "", true)
+	s.NoError(err)
+	s.Equal(caughtUpTime.UnixNano(), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetLastCaughtUpTime())
+	s.Equal(int32(2), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetDlqTasks())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_NoNamespace() {
+	s.replicatorQueueProcessor.maxTaskID = 10
+	status, err := s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, "", false)
+	s.NoError(err)
+	s.Equal(int64(-1), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetAckedTaskId())
+	s.Equal(int32(0), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetPendingNamespaceTasks())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_MaxTaskID() {
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 4}
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    4,
+		MaxReadLevel: s.mockShard.GetTransferMaxReadLevel(),
+		BatchSize:    s.replicatorQueueProcessor.fetchTasksBatchSize,
+	}).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+
+	// no task after the acked level, the remote cluster is caught up
+	status, err := s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, "", false)
+	s.NoError(err)
+	s.Equal(int64(4), status.GetMaxReplicationTaskId())
+
+	// tasks written afterwards are tracked without reading them
+	s.replicatorQueueProcessor.notifyNewReplicationTasks([]persistence.Task{
+		&persistence.HistoryReplicationTask{TaskID: 8},
+		&persistence.HistoryReplicationTask{TaskID: 9},
+	})
+	status, err = s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, "", false)
+	s.NoError(err)
+	s.Equal(int64(9), status.GetMaxReplicationTaskId())
+	s.Equal(int64(4), status.GetRemoteClusters()[cluster.TestAlternativeClusterName].GetAckedTaskId())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_MaxTaskIDUnknown() {
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 4}
+	s.mockExecutionMgr.On("GetReplicationTasks", mock.Anything).Return(&persistence.GetReplicationTasksResponse{
+		Tasks:         []*persistenceblobs.ReplicationTaskInfo{{TaskId: 5}},
+		NextPageToken: []byte{1},
+	}, nil).Times(maxTaskIDScanPages)
+
+	// the backlog is longer than the pages read
+	status, err := s.replicatorQueueProcessor.getReplicationStatus([]string{cluster.TestAlternativeClusterName}, "", false)
+	s.NoError(err)
+	s.True(status.GetMaxReplicationTaskIdUnknown())
+	s.Equal(int64(emptyMessageID), status.GetMaxReplicationTaskId())
+	s.Equal(int64(emptyMessageID), s.replicatorQueueProcessor.maxTaskID)
+}
+
 func (s *replicatorQueueProcessorSuite) TestPaginateHistoryWithShardID() {
 	firstEventID := int64(133)
 	nextEventID := int64(134)
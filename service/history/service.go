@@ -97,6 +97,14 @@ type Config struct {
 	TaskSchedulerQueueSize         dynamicconfig.IntPropertyFn
 	TaskSchedulerRoundRobinWeights dynamicconfig.MapPropertyFn
 
+	// Namespace split settings, shared by transfer and timer queue processors
+	QueueProcessorEnableNamespaceSplit         dynamicconfig.BoolPropertyFn
+	QueueProcessorSplitMaxPendingTasks         dynamicconfig.IntPropertyFn
+	QueueProcessorSplitMaxPendingDuration      dynamicconfig.DurationPropertyFn
+	QueueProcessorSplitNamespaceMaxDispatchRPS dynamicconfig.IntPropertyFnWithNamespaceFilter
+
+	EnableTaskDLQ dynamicconfig.BoolPropertyFn
+
 	// TimerQueueProcessor settings
 	TimerTaskBatchSize                                dynamicconfig.IntPropertyFn
 	TimerTaskWorkerCount                              dynamicconfig.IntPropertyFn
@@ -150,6 +158,7 @@ type Config struct {
 	ReplicatorProcessorMaxRedispatchQueueSize              dynamicconfig.IntPropertyFn
 	ReplicatorProcessorEnablePriorityTaskProcessor         dynamicconfig.BoolPropertyFn
 	ReplicatorProcessorFetchTasksBatchSize                 dynamicconfig.IntPropertyFn
+	ReplicatorProcessorMaxFetchTasksBatchSize              dynamicconfig.IntPropertyFn
 
 	// Persistence settings
 	ExecutionMgrNumConns dynamicconfig.IntPropertyFn
@@ -224,6 +233,9 @@ type Config struct {
 	ReplicationTaskFetcherAggregationInterval        dynamicconfig.DurationPropertyFn
 	ReplicationTaskFetcherTimerJitterCoefficient     dynamicconfig.FloatPropertyFn
 	ReplicationTaskFetcherErrorRetryWait             dynamicconfig.DurationPropertyFn
+	ReplicationTaskFetcherMinPageSize                dynamicconfig.IntPropertyFn
+	ReplicationTaskFetcherMaxPageSize                dynamicconfig.IntPropertyFn
+	ReplicationTaskFetcherEnableCompression          dynamicconfig.BoolPropertyFn
 	ReplicationTaskProcessorErrorRetryWait           dynamicconfig.DurationPropertyFn
 	ReplicationTaskProcessorErrorRetryMaxAttempts    dynamicconfig.IntPropertyFn
 	ReplicationTaskProcessorNoTaskRetryWait          dynamicconfig.DurationPropertyFn
@@ -242,6 +254,13 @@ type Config struct {
 
 	//Crocess DC Replication configuration
 	ReplicationEventsFromCurrentCluster dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+	// Payload offloading settings
+	PayloadOffloadThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter
+	PayloadOffloadURI       dynamicconfig.StringPropertyFnWithNamespaceFilter
+
+	EnableEagerActivityDispatch dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	EagerActivityMaxPayloadSize dynamicconfig.IntPropertyFnWithNamespaceFilter
 }
 
 const (
@@ -287,6 +306,13 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		TaskSchedulerQueueSize:         dc.GetIntProperty(dynamicconfig.TaskSchedulerQueueSize, 2000),
 		TaskSchedulerRoundRobinWeights: dc.GetMapProperty(dynamicconfig.TaskSchedulerRoundRobinWeights, convertWeightsToDynamicConfigValue(defaultTaskPriorityWeight)),
 
+		QueueProcessorEnableNamespaceSplit:         dc.GetBoolProperty(dynamicconfig.QueueProcessorEnableNamespaceSplit, false),
+		QueueProcessorSplitMaxPendingTasks:         dc.GetIntProperty(dynamicconfig.QueueProcessorSplitMaxPendingTasks, 1000),
+		QueueProcessorSplitMaxPendingDuration:      dc.GetDurationProperty(dynamicconfig.QueueProcessorSplitMaxPendingDuration, 5*time.Minute),
+		QueueProcessorSplitNamespaceMaxDispatchRPS: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.QueueProcessorSplitNamespaceMaxDispatchRPS, 100),
+
+		EnableTaskDLQ: dc.GetBoolProperty(dynamicconfig.EnableTaskDLQ, false),
+
 		TimerTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TimerTaskBatchSize, 100),
 		TimerTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TimerTaskWorkerCount, 10),
 		TimerTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TimerTaskMaxRetryCount, 100),
@@ -337,6 +363,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		ReplicatorProcessorMaxRedispatchQueueSize:              dc.GetIntProperty(dynamicconfig.ReplicatorProcessorMaxRedispatchQueueSize, 10000),
 		ReplicatorProcessorEnablePriorityTaskProcessor:         dc.GetBoolProperty(dynamicconfig.ReplicatorProcessorEnablePriorityTaskProcessor, false),
 		ReplicatorProcessorFetchTasksBatchSize:                 dc.GetIntProperty(dynamicconfig.ReplicatorTaskBatchSize, 25),
+		ReplicatorProcessorMaxFetchTasksBatchSize:              dc.GetIntProperty(dynamicconfig.ReplicatorTaskMaxBatchSize, 500),
 
 		ExecutionMgrNumConns:            dc.GetIntProperty(dynamicconfig.ExecutionMgrNumConns, 50),
 		HistoryMgrNumConns:              dc.GetIntProperty(dynamicconfig.HistoryMgrNumConns, 50),
@@ -382,6 +409,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		ReplicationTaskFetcherAggregationInterval:        dc.GetDurationProperty(dynamicconfig.ReplicationTaskFetcherAggregationInterval, 2*time.Second),
 		ReplicationTaskFetcherTimerJitterCoefficient:     dc.GetFloat64Property(dynamicconfig.ReplicationTaskFetcherTimerJitterCoefficient, 0.15),
 		ReplicationTaskFetcherErrorRetryWait:             dc.GetDurationProperty(dynamicconfig.ReplicationTaskFetcherErrorRetryWait, time.Second),
+		ReplicationTaskFetcherMinPageSize:                dc.GetIntProperty(dynamicconfig.ReplicationTaskFetcherMinPageSize, 25),
+		ReplicationTaskFetcherMaxPageSize:                dc.GetIntProperty(dynamicconfig.ReplicationTaskFetcherMaxPageSize, 500),
+		ReplicationTaskFetcherEnableCompression:          dc.GetBoolProperty(dynamicconfig.ReplicationTaskFetcherEnableCompression, false),
 		ReplicationTaskProcessorErrorRetryWait:           dc.GetDurationProperty(dynamicconfig.ReplicationTaskProcessorErrorRetryWait, time.Second),
 		ReplicationTaskProcessorErrorRetryMaxAttempts:    dc.GetIntProperty(dynamicconfig.ReplicationTaskProcessorErrorRetryMaxAttempts, 20),
 		ReplicationTaskProcessorNoTaskRetryWait:          dc.GetDurationProperty(dynamicconfig.ReplicationTaskProcessorNoTaskInitialWait, 2*time.Second),
@@ -396,6 +426,12 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		MutableStateChecksumInvalidateBefore:  dc.GetFloat64Property(dynamicconfig.MutableStateChecksumInvalidateBefore, 0),
 
 		ReplicationEventsFromCurrentCluster: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.ReplicationEventsFromCurrentCluster, false),
+
+		PayloadOffloadThreshold: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.PayloadOffloadThreshold, 0),
+		PayloadOffloadURI:       dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.PayloadOffloadURI, ""),
+
+		EnableEagerActivityDispatch: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableEagerActivityDispatch, false),
+		EagerActivityMaxPayloadSize: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.EagerActivityMaxPayloadSize, 64*1024),
 	}
 
 	return cfg
@@ -447,7 +483,7 @@ func NewService(
 			if err != nil {
 				logger.Fatal("Creating visibility producer failed", tag.Error(err))
 			}
-			visibilityFromES = espersistence.NewESVisibilityManager("", nil, nil, visibilityProducer,
+			visibilityFromES = espersistence.NewESVisibilityManager("", nil, nil, nil, visibilityProducer,
 				params.MetricsClient, logger)
 		}
 		return persistence.NewVisibilityManagerWrapper(
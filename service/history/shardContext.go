@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"sync"
@@ -45,6 +46,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
 )
@@ -84,6 +86,8 @@ type (
 		UpdateTransferAckLevel(ackLevel int64) error
 		GetTransferClusterAckLevel(cluster string) int64
 		UpdateTransferClusterAckLevel(cluster string, ackLevel int64) error
+		GetTransferClusterNamespaceAckLevels(cluster string) map[string]int64
+		UpdateTransferClusterAckLevels(cluster string, ackLevel int64, namespaceAckLevels map[string]int64) error
 
 		GetReplicatorAckLevel() int64
 		UpdateReplicatorAckLevel(ackLevel int64) error
@@ -97,6 +101,8 @@ type (
 		UpdateTimerAckLevel(ackLevel time.Time) error
 		GetTimerClusterAckLevel(cluster string) time.Time
 		UpdateTimerClusterAckLevel(cluster string, ackLevel time.Time) error
+		GetTimerClusterNamespaceAckLevels(cluster string) map[string]time.Time
+		UpdateTimerClusterAckLevels(cluster string, ackLevel time.Time, namespaceAckLevels map[string]time.Time) error
 
 		UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error
 		DeleteTransferFailoverLevel(failoverID string) error
@@ -124,6 +130,7 @@ type (
 		rangeID          int64
 		executionManager persistence.ExecutionManager
 		eventsCache      eventsCache
+		payloadOffloader payloadstore.Offloader
 		closeCallback    func(int, *historyShardsItem)
 		closed           int32
 		config           *Config
@@ -244,6 +251,38 @@ func (s *shardContextImpl) UpdateTransferClusterAckLevel(cluster string, ackLeve
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetTransferClusterNamespaceAckLevels(cluster string) map[string]int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	ackLevels := make(map[string]int64)
+	if namespaceAckLevels, ok := s.shardInfo.ClusterTransferNamespaceAckLevel[cluster]; ok {
+		for namespaceID, ackLevel := range namespaceAckLevels.GetNamespaceAckLevel() {
+			ackLevels[namespaceID] = ackLevel
+		}
+	}
+	return ackLevels
+}
+
+func (s *shardContextImpl) UpdateTransferClusterAckLevels(cluster string, ackLevel int64, namespaceAckLevels map[string]int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	namespaceAckLevel := make(map[string]int64, len(namespaceAckLevels))
+	for namespaceID, level := range namespaceAckLevels {
+		namespaceAckLevel[namespaceID] = level
+	}
+	s.shardInfo.ClusterTransferAckLevel[cluster] = ackLevel
+	if s.shardInfo.ClusterTransferNamespaceAckLevel == nil {
+		s.shardInfo.ClusterTransferNamespaceAckLevel = make(map[string]*persistenceblobs.TransferNamespaceAckLevels)
+	}
+	s.shardInfo.ClusterTransferNamespaceAckLevel[cluster] = &persistenceblobs.TransferNamespaceAckLevels{
+		NamespaceAckLevel: namespaceAckLevel,
+	}
+	s.shardInfo.StolenSinceRenew = 0
+	return s.updateShardInfoLocked()
+}
+
 func (s *shardContextImpl) GetReplicatorAckLevel() int64 {
 	s.RLock()
 	defer s.RUnlock()
@@ -369,6 +408,47 @@ func (s *shardContextImpl) UpdateTimerClusterAckLevel(cluster string, ackLevel t
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetTimerClusterNamespaceAckLevels(cluster string) map[string]time.Time {
+	s.RLock()
+	defer s.RUnlock()
+
+	ackLevels := make(map[string]time.Time)
+	if namespaceAckLevels, ok := s.shardInfo.ClusterTimerNamespaceAckLevel[cluster]; ok {
+		for namespaceID, ackLevel := range namespaceAckLevels.GetNamespaceAckLevel() {
+			goTime, _ := types.TimestampFromProto(ackLevel)
+			ackLevels[namespaceID] = goTime
+		}
+	}
+	return ackLevels
+}
+
+func (s *shardContextImpl) UpdateTimerClusterAckLevels(cluster string, ackLevel time.Time, namespaceAckLevels map[string]time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	pAckLevel, err := types.TimestampProto(ackLevel)
+	if err != nil {
+		return err
+	}
+	namespaceAckLevel := make(map[string]*types.Timestamp, len(namespaceAckLevels))
+	for namespaceID, level := range namespaceAckLevels {
+		pTime, err := types.TimestampProto(level)
+		if err != nil {
+			return err
+		}
+		namespaceAckLevel[namespaceID] = pTime
+	}
+	s.shardInfo.ClusterTimerAckLevel[cluster] = pAckLevel
+	if s.shardInfo.ClusterTimerNamespaceAckLevel == nil {
+		s.shardInfo.ClusterTimerNamespaceAckLevel = make(map[string]*persistenceblobs.TimerNamespaceAckLevels)
+	}
+	s.shardInfo.ClusterTimerNamespaceAckLevel[cluster] = &persistenceblobs.TimerNamespaceAckLevels{
+		NamespaceAckLevel: namespaceAckLevel,
+	}
+	s.shardInfo.StolenSinceRenew = 0
+	return s.updateShardInfoLocked()
+}
+
 func (s *shardContextImpl) UpdateTransferFailoverLevel(failoverID string, level persistence.TransferFailoverLevel) error {
 	s.Lock()
 	defer s.Unlock()
@@ -836,6 +916,19 @@ func (s *shardContextImpl) AppendHistoryV2Events(
 		return 0, err
 	}
 
+	// payloads above the namespace threshold are persisted as references to the payload store
+	ctx, cancel := context.WithTimeout(context.Background(), payloadstore.DefaultTimeout)
+	defer cancel()
+	request.Events, err = s.payloadOffloader.OffloadEvents(
+		ctx,
+		namespaceID,
+		namespaceEntry.GetInfo().Name,
+		request.Events,
+	)
+	if err != nil {
+		return 0, err
+	}
+
 	request.Encoding = s.getDefaultEncoding(namespaceEntry)
 	request.ShardID = convert.IntPtr(s.shardID)
 	request.TransactionID = transactionID
@@ -1271,6 +1364,11 @@ func acquireShard(
 		previousShardOwnerWasDifferent: ownershipChanged,
 	}
 	shardContext.eventsCache = newEventsCache(shardContext)
+	shardContext.payloadOffloader = payloadstore.NewOffloader(
+		shardItem.GetPayloadStoreProvider(),
+		shardItem.config.PayloadOffloadThreshold,
+		shardItem.config.PayloadOffloadURI,
+	)
 
 	err1 := shardContext.renewRangeLocked(true)
 	if err1 != nil {
@@ -1301,20 +1399,30 @@ func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.Sh
 	for k, v := range shardInfo.ClusterReplicationLevel {
 		clusterReplicationLevel[k] = v
 	}
+	clusterTransferNamespaceAckLevel := make(map[string]*persistenceblobs.TransferNamespaceAckLevels)
+	for k, v := range shardInfo.ClusterTransferNamespaceAckLevel {
+		clusterTransferNamespaceAckLevel[k] = v
+	}
+	clusterTimerNamespaceAckLevel := make(map[string]*persistenceblobs.TimerNamespaceAckLevels)
+	for k, v := range shardInfo.ClusterTimerNamespaceAckLevel {
+		clusterTimerNamespaceAckLevel[k] = v
+	}
 	shardInfoCopy := &persistence.ShardInfoWithFailover{
 		ShardInfo: &persistenceblobs.ShardInfo{
-			ShardId:                      shardInfo.GetShardId(),
-			Owner:                        shardInfo.Owner,
-			RangeId:                      shardInfo.GetRangeId(),
-			StolenSinceRenew:             shardInfo.StolenSinceRenew,
-			ReplicationAckLevel:          shardInfo.ReplicationAckLevel,
-			TransferAckLevel:             shardInfo.TransferAckLevel,
-			TimerAckLevel:                shardInfo.TimerAckLevel,
-			ClusterTransferAckLevel:      clusterTransferAckLevel,
-			ClusterTimerAckLevel:         clusterTimerAckLevel,
-			NamespaceNotificationVersion: shardInfo.NamespaceNotificationVersion,
-			ClusterReplicationLevel:      clusterReplicationLevel,
-			UpdatedAt:                    shardInfo.UpdatedAt,
+			ShardId:                          shardInfo.GetShardId(),
+			Owner:                            shardInfo.Owner,
+			RangeId:                          shardInfo.GetRangeId(),
+			StolenSinceRenew:                 shardInfo.StolenSinceRenew,
+			ReplicationAckLevel:              shardInfo.ReplicationAckLevel,
+			TransferAckLevel:                 shardInfo.TransferAckLevel,
+			TimerAckLevel:                    shardInfo.TimerAckLevel,
+			ClusterTransferAckLevel:          clusterTransferAckLevel,
+			ClusterTimerAckLevel:             clusterTimerAckLevel,
+			NamespaceNotificationVersion:     shardInfo.NamespaceNotificationVersion,
+			ClusterReplicationLevel:          clusterReplicationLevel,
+			UpdatedAt:                        shardInfo.UpdatedAt,
+			ClusterTransferNamespaceAckLevel: clusterTransferNamespaceAckLevel,
+			ClusterTimerNamespaceAckLevel:    clusterTimerNamespaceAckLevel,
 		},
 		TransferFailoverLevels: transferFailoverLevels,
 		TimerFailoverLevels:    timerFailoverLevels,
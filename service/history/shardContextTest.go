@@ -31,6 +31,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
 )
@@ -66,6 +67,11 @@ func newTestShardContext(
 		timerMaxReadLevelMap:      make(map[string]time.Time),
 		remoteClusterCurrentTime:  make(map[string]time.Time),
 		eventsCache:               eventsCache,
+		payloadOffloader: payloadstore.NewOffloader(
+			resource.PayloadStoreProvider,
+			config.PayloadOffloadThreshold,
+			config.PayloadOffloadURI,
+		),
 	}
 	return &shardContextTest{
 		shardContextImpl: shard,
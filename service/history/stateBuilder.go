@@ -511,7 +511,15 @@ func (b *stateBuilderImpl) applyEvents(
 			}
 
 		case enumspb.EVENT_TYPE_MARKER_RECORDED:
-			// No mutable state action is needed
+			// only the upsert memo marker changes mutable state
+			if isUpsertWorkflowMemoEvent(event) {
+				b.mutableState.ReplicateUpsertWorkflowMemoEvent(event)
+				if err := taskGenerator.generateWorkflowSearchAttrTasks(
+					b.unixNanoToTime(event.GetTimestamp()),
+				); err != nil {
+					return nil, err
+				}
+			}
 
 		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
 			if err := b.mutableState.ReplicateWorkflowExecutionSignaled(
@@ -520,6 +528,15 @@ func (b *stateBuilderImpl) applyEvents(
 				return nil, err
 			}
 
+			// reserved signals update search attributes or memo
+			if isReservedSignal(event) {
+				if err := taskGenerator.generateWorkflowSearchAttrTasks(
+					b.unixNanoToTime(event.GetTimestamp()),
+				); err != nil {
+					return nil, err
+				}
+			}
+
 		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCEL_REQUESTED:
 			if err := b.mutableState.ReplicateWorkflowExecutionCancelRequestedEvent(
 				event,
@@ -672,6 +672,39 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeMarkerRecorded() {
 	s.Nil(err)
 }
 
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeMarkerRecorded_UpsertWorkflowMemo() {
+	version := int64(1)
+	requestID := uuid.New()
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      testRunID,
+	}
+
+	now := time.Now()
+	evenType := enumspb.EVENT_TYPE_MARKER_RECORDED
+	event := &historypb.HistoryEvent{
+		Version:   version,
+		EventId:   130,
+		Timestamp: now.UnixNano(),
+		EventType: evenType,
+		Attributes: &historypb.HistoryEvent_MarkerRecordedEventAttributes{MarkerRecordedEventAttributes: &historypb.MarkerRecordedEventAttributes{
+			MarkerName: common.UpsertWorkflowMemoMarkerName,
+			Details:    map[string]*commonpb.Payloads{"key": payloads.EncodeString("value")},
+		}},
+	}
+	s.mockMutableState.EXPECT().ReplicateUpsertWorkflowMemoEvent(event).Return().Times(1)
+	s.mockUpdateVersion(event)
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).AnyTimes()
+	s.mockTaskGenerator.EXPECT().generateWorkflowSearchAttrTasks(
+		s.stateBuilder.unixNanoToTime(event.GetTimestamp()),
+	).Return(nil).Times(1)
+	s.mockMutableState.EXPECT().ClearStickyness().Times(1)
+
+	_, err := s.stateBuilder.applyEvents(testNamespaceID, requestID, execution, s.toHistory(event), nil, false)
+	s.Nil(err)
+}
+
 // decision operations
 
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskScheduled() {
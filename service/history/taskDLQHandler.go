@@ -0,0 +1,267 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/primitives/timestamp"
+)
+
+const (
+	// taskDLQMinMessageID is below every message ID since messages are keyed by task ID
+	taskDLQMinMessageID int64 = 0
+)
+
+var (
+	errTaskDLQRetryNotActive = serviceerror.NewFailedPrecondition("Task can only be retried in the active cluster of its namespace.")
+	errTaskDLQUnknownType    = serviceerror.NewInvalidArgument("Unknown task DLQ type.")
+)
+
+type (
+	// taskDLQHandler is the interface handles transfer and timer task DLQ messages of a shard
+	taskDLQHandler interface {
+		readMessages(
+			dlqType enumsgenpb.DeadLetterQueueType,
+			lastMessageID int64,
+			pageSize int,
+			pageToken []byte,
+		) ([]*persistenceblobs.HistoryTaskDLQMessage, []byte, error)
+		purgeMessages(
+			dlqType enumsgenpb.DeadLetterQueueType,
+			lastMessageID int64,
+		) error
+		mergeMessages(
+			ctx context.Context,
+			dlqType enumsgenpb.DeadLetterQueueType,
+			lastMessageID int64,
+			pageSize int,
+			pageToken []byte,
+		) ([]byte, error)
+	}
+
+	taskDLQHandlerImpl struct {
+		shard          ShardContext
+		txProcessor    transferQueueProcessor
+		timerProcessor timerQueueProcessor
+		logger         log.Logger
+	}
+)
+
+func newTaskDLQHandler(
+	shard ShardContext,
+	txProcessor transferQueueProcessor,
+	timerProcessor timerQueueProcessor,
+) taskDLQHandler {
+
+	return &taskDLQHandlerImpl{
+		shard:          shard,
+		txProcessor:    txProcessor,
+		timerProcessor: timerProcessor,
+		logger:         shard.GetLogger(),
+	}
+}
+
+// moveTaskToDLQ publishes a transfer or timer task which exceeded its max retry count to the task DLQ
+// of its shard, it returns true if the task is in the DLQ and can be acked
+func moveTaskToDLQ(
+	shard ShardContext,
+	taskInfo queueTaskInfo,
+	attempt int,
+	taskErr error,
+	scope metrics.Scope,
+	logger log.Logger,
+) bool {
+
+	if !shard.GetConfig().EnableTaskDLQ() || isTransientTaskError(taskErr) {
+		return false
+	}
+
+	message := &persistenceblobs.HistoryTaskDLQMessage{
+		MessageId:     taskInfo.GetTaskId(),
+		ShardId:       int32(shard.GetShardID()),
+		Attempt:       int32(attempt),
+		FailureReason: taskErr.Error(),
+		FailedTime:    timestamp.TimestampNow().ToProto(),
+	}
+	switch task := taskInfo.(type) {
+	case *persistenceblobs.TransferTaskInfo:
+		message.Type = enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER
+		message.TransferTask = task
+	case *persistenceblobs.TimerTaskInfo:
+		message.Type = enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER
+		message.TimerTask = task
+	default:
+		return false
+	}
+
+	if err := shard.GetExecutionManager().PutHistoryTaskToDLQ(&persistence.PutHistoryTaskToDLQRequest{
+		Message: message,
+	}); err != nil {
+		scope.IncCounter(metrics.TaskDLQWriteFailures)
+		logger.Error("Fail to move task to DLQ, retrying.", tag.Error(err))
+		return false
+	}
+
+	scope.IncCounter(metrics.TaskDLQWrites)
+	logger.Warn("Task exceeded max retry count and was moved to DLQ.",
+		tag.Error(taskErr), tag.Attempt(int32(attempt)), tag.TaskType(taskInfo.GetTaskType()))
+	return true
+}
+
+// isTransientTaskError returns true if the task failed because of the shard or the cluster
+// rather than the task itself, such a task is retried no matter how many attempts it took
+func isTransientTaskError(
+	err error,
+) bool {
+
+	switch err.(type) {
+	case *persistence.ShardOwnershipLostError,
+		*serviceerror.ShardOwnershipLost,
+		*serviceerror.Unavailable,
+		*serviceerror.ResourceExhausted,
+		*serviceerror.DeadlineExceeded,
+		*serviceerror.Canceled:
+		return true
+	}
+	return err == ErrTaskRetry || err == context.DeadlineExceeded || err == context.Canceled
+}
+
+func (t *taskDLQHandlerImpl) readMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]*persistenceblobs.HistoryTaskDLQMessage, []byte, error) {
+
+	response, err := t.shard.GetExecutionManager().GetHistoryTasksFromDLQ(&persistence.GetHistoryTasksFromDLQRequest{
+		Category:      dlqType,
+		ReadLevel:     taskDLQMinMessageID,
+		MaxReadLevel:  lastMessageID,
+		BatchSize:     pageSize,
+		NextPageToken: pageToken,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.Messages, response.NextPageToken, nil
+}
+
+func (t *taskDLQHandlerImpl) purgeMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	lastMessageID int64,
+) error {
+
+	return t.shard.GetExecutionManager().RangeDeleteHistoryTaskFromDLQ(&persistence.RangeDeleteHistoryTaskFromDLQRequest{
+		Category:                dlqType,
+		ExclusiveBeginMessageID: taskDLQMinMessageID,
+		InclusiveEndMessageID:   lastMessageID,
+	})
+}
+
+func (t *taskDLQHandlerImpl) mergeMessages(
+	ctx context.Context,
+	dlqType enumsgenpb.DeadLetterQueueType,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]byte, error) {
+
+	messages, token, err := t.readMessages(dlqType, lastMessageID, pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	mergedMessageID := taskDLQMinMessageID
+	for _, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return nil, t.deleteMergedMessages(dlqType, mergedMessageID, err)
+		}
+
+		if err := t.retryTask(message); err != nil {
+			t.logger.Error("Failed to retry task from DLQ.",
+				tag.Task(message), tag.Error(err))
+			return nil, t.deleteMergedMessages(dlqType, mergedMessageID, err)
+		}
+		mergedMessageID = message.GetMessageId()
+	}
+
+	if err := t.deleteMergedMessages(dlqType, mergedMessageID, nil); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// deleteMergedMessages deletes messages up to and including the last merged one,
+// it returns mergeErr unless the delete itself failed
+func (t *taskDLQHandlerImpl) deleteMergedMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	mergedMessageID int64,
+	mergeErr error,
+) error {
+
+	if mergedMessageID == taskDLQMinMessageID {
+		return mergeErr
+	}
+	if err := t.purgeMessages(dlqType, mergedMessageID); err != nil {
+		t.logger.Error("Failed to delete retried tasks from DLQ.",
+			tag.TaskID(mergedMessageID), tag.Error(err))
+		return err
+	}
+	return mergeErr
+}
+
+func (t *taskDLQHandlerImpl) retryTask(
+	message *persistenceblobs.HistoryTaskDLQMessage,
+) error {
+
+	var err error
+	switch message.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER:
+		err = t.txProcessor.RetryTask(message.GetTransferTask())
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		err = t.timerProcessor.RetryTask(message.GetTimerTask())
+	default:
+		return errTaskDLQUnknownType
+	}
+
+	switch err.(type) {
+	case nil, *serviceerror.NotFound:
+		return nil
+	default:
+		if err == ErrTaskDiscarded {
+			return nil
+		}
+		return err
+	}
+}
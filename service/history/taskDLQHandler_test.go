@@ -0,0 +1,183 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	taskDLQHandlerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockShard          *shardContextTest
+		mockExecutionMgr   *mocks.ExecutionManager
+		mockTxProcessor    *MocktransferQueueProcessor
+		mockTimerProcessor *MocktimerQueueProcessor
+
+		handler *taskDLQHandlerImpl
+	}
+)
+
+func TestTaskDLQHandlerSuite(t *testing.T) {
+	s := new(taskDLQHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskDLQHandlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockShard = newTestShardContext(
+		s.controller,
+		&persistence.ShardInfoWithFailover{
+			ShardInfo: &persistenceblobs.ShardInfo{
+				ShardId: 10,
+				RangeId: 1,
+			}},
+		NewDynamicConfigForTest(),
+	)
+	s.mockExecutionMgr = s.mockShard.resource.ExecutionMgr
+	s.mockTxProcessor = NewMocktransferQueueProcessor(s.controller)
+	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
+
+	s.handler = newTaskDLQHandler(
+		s.mockShard,
+		s.mockTxProcessor,
+		s.mockTimerProcessor,
+	).(*taskDLQHandlerImpl)
+}
+
+func (s *taskDLQHandlerSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *taskDLQHandlerSuite) TestReadMessages() {
+	transferMessage := s.newTransferMessage(1, 10)
+	token := []byte("some random token")
+
+	s.mockExecutionMgr.On("GetHistoryTasksFromDLQ", &persistence.GetHistoryTasksFromDLQRequest{
+		Category:      enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		ReadLevel:     taskDLQMinMessageID,
+		MaxReadLevel:  100,
+		BatchSize:     10,
+		NextPageToken: nil,
+	}).Return(&persistence.GetHistoryTasksFromDLQResponse{
+		Messages:      []*persistenceblobs.HistoryTaskDLQMessage{transferMessage},
+		NextPageToken: token,
+	}, nil).Once()
+
+	messages, nextToken, err := s.handler.readMessages(enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, 100, 10, nil)
+	s.NoError(err)
+	s.Equal([]*persistenceblobs.HistoryTaskDLQMessage{transferMessage}, messages)
+	s.Equal(token, nextToken)
+}
+
+func (s *taskDLQHandlerSuite) TestPurgeMessages() {
+	s.mockExecutionMgr.On("RangeDeleteHistoryTaskFromDLQ", &persistence.RangeDeleteHistoryTaskFromDLQRequest{
+		Category:                enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER,
+		ExclusiveBeginMessageID: taskDLQMinMessageID,
+		InclusiveEndMessageID:   100,
+	}).Return(nil).Once()
+
+	s.NoError(s.handler.purgeMessages(enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER, 100))
+}
+
+func (s *taskDLQHandlerSuite) TestMergeMessages_Success() {
+	message := s.newTransferMessage(1, 10)
+	s.mockExecutionMgr.On("GetHistoryTasksFromDLQ", mock.Anything).Return(&persistence.GetHistoryTasksFromDLQResponse{
+		Messages: []*persistenceblobs.HistoryTaskDLQMessage{message},
+	}, nil).Once()
+	s.mockTxProcessor.EXPECT().RetryTask(message.GetTransferTask()).Return(nil).Times(1)
+	s.mockExecutionMgr.On("RangeDeleteHistoryTaskFromDLQ", &persistence.RangeDeleteHistoryTaskFromDLQRequest{
+		Category:                enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		ExclusiveBeginMessageID: taskDLQMinMessageID,
+		InclusiveEndMessageID:   1,
+	}).Return(nil).Once()
+
+	token, err := s.handler.mergeMessages(context.Background(), enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, 100, 10, nil)
+	s.NoError(err)
+	s.Empty(token)
+}
+
+func (s *taskDLQHandlerSuite) TestMergeMessages_RetryFailed() {
+	mergedMessage := s.newTimerMessage(1, 10)
+	failedMessage := s.newTimerMessage(2, 10)
+	retryErr := errors.New("some random error")
+	s.mockExecutionMgr.On("GetHistoryTasksFromDLQ", mock.Anything).Return(&persistence.GetHistoryTasksFromDLQResponse{
+		Messages: []*persistenceblobs.HistoryTaskDLQMessage{mergedMessage, failedMessage, s.newTimerMessage(3, 10)},
+	}, nil).Once()
+	s.mockTimerProcessor.EXPECT().RetryTask(mergedMessage.GetTimerTask()).Return(nil).Times(1)
+	s.mockTimerProcessor.EXPECT().RetryTask(failedMessage.GetTimerTask()).Return(retryErr).Times(1)
+	s.mockExecutionMgr.On("RangeDeleteHistoryTaskFromDLQ", &persistence.RangeDeleteHistoryTaskFromDLQRequest{
+		Category:                enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER,
+		ExclusiveBeginMessageID: taskDLQMinMessageID,
+		InclusiveEndMessageID:   1,
+	}).Return(nil).Once()
+
+	_, err := s.handler.mergeMessages(context.Background(), enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER, 100, 10, nil)
+	s.Equal(retryErr, err)
+}
+
+func (s *taskDLQHandlerSuite) newTransferMessage(
+	messageID int64,
+	shardID int32,
+) *persistenceblobs.HistoryTaskDLQMessage {
+
+	return &persistenceblobs.HistoryTaskDLQMessage{
+		MessageId:    messageID,
+		ShardId:      shardID,
+		Type:         enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		TransferTask: &persistenceblobs.TransferTaskInfo{TaskId: messageID},
+	}
+}
+
+func (s *taskDLQHandlerSuite) newTimerMessage(
+	messageID int64,
+	shardID int32,
+) *persistenceblobs.HistoryTaskDLQMessage {
+
+	return &persistenceblobs.HistoryTaskDLQMessage{
+		MessageId: messageID,
+		ShardId:   shardID,
+		Type:      enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER,
+		TimerTask: &persistenceblobs.TimerTaskInfo{TaskId: messageID},
+	}
+}
@@ -308,6 +308,12 @@ func (t *taskProcessor) handleTaskError(
 	}
 
 	task.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
+
+	// task.attempt is only incremented after this function returns
+	if attempt := task.attempt + 1; attempt > t.config.TimerTaskMaxRetryCount() &&
+		moveTaskToDLQ(t.shard, task.task, attempt, err, scope, task.logger) {
+		return nil
+	}
 	return err
 }
 
@@ -65,6 +65,9 @@ type (
 		timeNow             timeNow
 		updateTimerAckLevel updateTimerAckLevel
 		timerQueueShutdown  timerQueueShutdown
+		// updateAckLevels persists the shared ack level along with the ack levels of split namespaces
+		// in one shard update, namespaces are never split if it is nil
+		updateAckLevels func(ackLevel timerKey, namespaceAckLevels map[string]time.Time) error
 		// isReadFinished indicate timer queue ack manager
 		// have no more task to send out
 		isReadFinished bool
@@ -76,8 +79,15 @@ type (
 		sync.Mutex
 		// outstanding timer task -> finished (true)
 		outstandingTasks map[timerKey]bool
+		// outstanding timer task -> namespace and load time
+		outstandingTaskMeta map[timerKey]queueTaskMeta
 		// timer task ack level
 		ackLevel timerKey
+		// split namespace -> ack level of the namespace, always behind the shared ack level
+		namespaceAckLevels map[string]timerKey
+		// levels of the last successful ack level update, the update is skipped if no level moved since
+		persistedAckLevel           time.Time
+		persistedNamespaceAckLevels map[string]time.Time
 		// timer task read level, used by failover
 		readLevel timerKey
 		// mutable timer level
@@ -138,31 +148,49 @@ func newTimerQueueAckMgr(
 	minLevel time.Time,
 	timeNow timeNow,
 	updateTimerAckLevel updateTimerAckLevel,
+	namespaceAckLevels map[string]time.Time,
+	updateAckLevels func(ackLevel timerKey, namespaceAckLevels map[string]time.Time) error,
 	logger log.Logger,
 	clusterName string,
 ) *timerQueueAckMgrImpl {
 	ackLevel := timerKey{VisibilityTimestamp: minLevel}
 
+	// split namespaces may be behind the shared ack level,
+	// so reading starts from the lowest of all ack levels
+	readLevel := ackLevel
+	namespaceTimerKeys := make(map[string]timerKey)
+	for namespaceID, namespaceAckLevel := range namespaceAckLevels {
+		namespaceTimerKeys[namespaceID] = timerKey{VisibilityTimestamp: namespaceAckLevel}
+		if namespaceAckLevel.Before(readLevel.VisibilityTimestamp) {
+			readLevel = timerKey{VisibilityTimestamp: namespaceAckLevel}
+		}
+	}
+
 	timerQueueAckMgrImpl := &timerQueueAckMgrImpl{
-		scope:               scope,
-		isFailover:          false,
-		shard:               shard,
-		executionMgr:        shard.GetExecutionManager(),
-		metricsClient:       metricsClient,
-		logger:              logger,
-		config:              shard.GetConfig(),
-		timeNow:             timeNow,
-		updateTimerAckLevel: updateTimerAckLevel,
-		timerQueueShutdown:  func() error { return nil },
-		outstandingTasks:    make(map[timerKey]bool),
-		ackLevel:            ackLevel,
-		readLevel:           ackLevel,
-		minQueryLevel:       ackLevel.VisibilityTimestamp,
-		pageToken:           nil,
-		maxQueryLevel:       ackLevel.VisibilityTimestamp,
-		isReadFinished:      false,
-		finishedChan:        nil,
-		clusterName:         clusterName,
+		scope:                       scope,
+		isFailover:                  false,
+		shard:                       shard,
+		executionMgr:                shard.GetExecutionManager(),
+		metricsClient:               metricsClient,
+		logger:                      logger,
+		config:                      shard.GetConfig(),
+		timeNow:                     timeNow,
+		updateTimerAckLevel:         updateTimerAckLevel,
+		timerQueueShutdown:          func() error { return nil },
+		updateAckLevels:             updateAckLevels,
+		outstandingTasks:            make(map[timerKey]bool),
+		outstandingTaskMeta:         make(map[timerKey]queueTaskMeta),
+		ackLevel:                    ackLevel,
+		namespaceAckLevels:          namespaceTimerKeys,
+		persistedAckLevel:           minLevel,
+		persistedNamespaceAckLevels: namespaceAckLevels,
+		readLevel:                   readLevel,
+		minQueryLevel:               readLevel.VisibilityTimestamp,
+		pageToken:                   nil,
+		maxQueryLevel:               readLevel.VisibilityTimestamp,
+		isReadFinished:              false,
+		finishedChan:                nil,
+		clusterName:                 clusterName,
 	}
 
 	return timerQueueAckMgrImpl
@@ -193,7 +221,10 @@ func newTimerQueueFailoverAckMgr(
 		updateTimerAckLevel: updateTimerAckLevel,
 		timerQueueShutdown:  timerQueueShutdown,
 		outstandingTasks:    make(map[timerKey]bool),
+		outstandingTaskMeta: make(map[timerKey]queueTaskMeta),
 		ackLevel:            ackLevel,
+		namespaceAckLevels:  make(map[string]timerKey),
+		persistedAckLevel:   minLevel,
 		readLevel:           ackLevel,
 		minQueryLevel:       ackLevel.VisibilityTimestamp,
 		pageToken:           nil,
@@ -263,7 +294,21 @@ TaskFilterLoop:
 		t.logger.Debug("Moving timer read level", tag.Task(timerKey))
 		t.readLevel = *timerKey
 
+		if len(t.namespaceAckLevels) != 0 && !compareTimerIDLess(&t.ackLevel, timerKey) {
+			// timer is only read because a split namespace is behind the shared ack level
+			namespaceAckLevel, isSplit := t.namespaceAckLevels[task.GetNamespaceId()]
+			if !isSplit || compareTimerIDLess(timerKey, &namespaceAckLevel) {
+				continue TaskFilterLoop
+			}
+		}
+
 		t.outstandingTasks[*timerKey] = false
+		t.outstandingTaskMeta[*timerKey] = queueTaskMeta{
+			namespaceID: task.GetNamespaceId(),
+			workflowID:  task.GetWorkflowId(),
+			runID:       task.GetRunId(),
+			loadTime:    t.timeNow(),
+		}
 		filteredTasks = append(filteredTasks, task)
 	}
 
@@ -321,16 +366,32 @@ func (t *timerQueueAckMgrImpl) completeTimerTask(timerTask *persistenceblobs.Tim
 	t.outstandingTasks[*timerKey] = true
 }
 
+func (t *timerQueueAckMgrImpl) isNamespaceSplit(namespaceID string) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	_, ok := t.namespaceAckLevels[namespaceID]
+	return ok
+}
+
 func (t *timerQueueAckMgrImpl) getReadLevel() timerKey {
 	t.Lock()
 	defer t.Unlock()
 	return t.readLevel
 }
 
+// getAckLevel returns the level up to which timers of all namespaces, including split ones, are acknowledged
 func (t *timerQueueAckMgrImpl) getAckLevel() timerKey {
 	t.Lock()
 	defer t.Unlock()
-	return t.ackLevel
+
+	ackLevel := t.ackLevel
+	for _, namespaceAckLevel := range t.namespaceAckLevels {
+		if compareTimerIDLess(&namespaceAckLevel, &ackLevel) {
+			ackLevel = namespaceAckLevel
+		}
+	}
+	return ackLevel
 }
 
 func (t *timerQueueAckMgrImpl) updateAckLevel() error {
@@ -361,18 +422,28 @@ func (t *timerQueueAckMgrImpl) updateAckLevel() error {
 		t.metricsClient.RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTimerStandbyPendingTasksTimer, time.Duration(pendingTasks))
 	}
 
+	t.splitNamespacesLocked(sequenceIDs)
+
 MoveAckLevelLoop:
 	for _, current := range sequenceIDs {
 		acked := outstandingTasks[current]
 		if acked {
-			ackLevel = current
+			// acked timers of split namespaces can be behind the shared ack level
+			if compareTimerIDLess(&ackLevel, &current) {
+				ackLevel = current
+			}
 			delete(outstandingTasks, current)
+			delete(t.outstandingTaskMeta, current)
 			t.logger.Debug("Moving timer ack level", tag.AckLevel(ackLevel))
+		} else if _, isSplit := t.namespaceAckLevels[t.outstandingTaskMeta[current].namespaceID]; isSplit {
+			// pending timers of split namespaces are tracked by their own ack level
+			continue MoveAckLevelLoop
 		} else {
 			break MoveAckLevelLoop
 		}
 	}
 	t.ackLevel = ackLevel
+	namespaceAckLevels := t.updateNamespaceAckLevelsLocked(sequenceIDs)
 
 	if t.isFailover && t.isReadFinished && len(outstandingTasks) == 0 {
 		t.Unlock()
@@ -387,12 +458,32 @@ MoveAckLevelLoop:
 		return err
 	}
 
+	// ack level is persisted as time only
+	if ackLevel.VisibilityTimestamp.Equal(t.persistedAckLevel) &&
+		namespaceTimerAckLevelsEqual(namespaceAckLevels, t.persistedNamespaceAckLevels) {
+		t.Unlock()
+		return nil
+	}
 	t.Unlock()
-	if err := t.updateTimerAckLevel(ackLevel); err != nil {
+
+	var err error
+	if t.updateAckLevels != nil {
+		// the shared ack level is persisted along with the namespace ack levels,
+		// so it never moves past pending timers of split namespaces in shard info
+		err = t.updateAckLevels(ackLevel, namespaceAckLevels)
+	} else {
+		err = t.updateTimerAckLevel(ackLevel)
+	}
+	if err != nil {
 		t.metricsClient.IncCounter(t.scope, metrics.AckLevelUpdateFailedCounter)
 		t.logger.Error("Error updating timer ack level for shard", tag.Error(err))
 		return err
 	}
+
+	t.Lock()
+	t.persistedAckLevel = ackLevel.VisibilityTimestamp
+	t.persistedNamespaceAckLevels = namespaceAckLevels
+	t.Unlock()
 	return nil
 }
 
@@ -424,3 +515,84 @@ func (t *timerQueueAckMgrImpl) isProcessNow(expiryTime time.Time) bool {
 	}
 	return expiryTime.UnixNano() <= t.timeNow().UnixNano()
 }
+
+// splitNamespacesLocked splits namespaces with too many or too old pending timers out of the shared ack level
+func (t *timerQueueAckMgrImpl) splitNamespacesLocked(
+	sequenceIDs timerKeys,
+) {
+
+	if t.isFailover || t.updateAckLevels == nil || !t.config.QueueProcessorEnableNamespaceSplit() {
+		return
+	}
+
+	var pendingTasks []queueTaskMeta
+	for _, sequenceID := range sequenceIDs {
+		if !t.outstandingTasks[sequenceID] {
+			pendingTasks = append(pendingTasks, t.outstandingTaskMeta[sequenceID])
+		}
+	}
+
+	namespaceIDs := getNamespacesToSplit(
+		pendingTasks,
+		t.config.QueueProcessorSplitMaxPendingTasks(),
+		t.config.QueueProcessorSplitMaxPendingDuration(),
+		t.timeNow(),
+	)
+	for namespaceID := range namespaceIDs {
+		if _, isSplit := t.namespaceAckLevels[namespaceID]; isSplit {
+			continue
+		}
+		t.logger.Info("Splitting namespace out of timer queue.", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(t.ackLevel))
+		t.metricsClient.IncCounter(t.scope, metrics.NamespaceSplitCounter)
+		t.namespaceAckLevels[namespaceID] = t.ackLevel
+	}
+}
+
+// updateNamespaceAckLevelsLocked moves the ack level of each split namespace to the time of its first pending timer,
+// merging the namespace back once it catches up with the shared ack level. The returned ack levels are nil if no
+// namespace is or was split, in which case there is nothing to persist.
+func (t *timerQueueAckMgrImpl) updateNamespaceAckLevelsLocked(
+	sequenceIDs timerKeys,
+) map[string]time.Time {
+
+	if len(t.namespaceAckLevels) == 0 {
+		return nil
+	}
+
+	namespaceAckLevels := make(map[string]timerKey)
+	for _, sequenceID := range sequenceIDs {
+		acked, ok := t.outstandingTasks[sequenceID]
+		if !ok || acked {
+			continue
+		}
+		namespaceID := t.outstandingTaskMeta[sequenceID].namespaceID
+		if _, isSplit := t.namespaceAckLevels[namespaceID]; !isSplit {
+			continue
+		}
+		// ack level is persisted as time only, timers at that time are read again after shard reload
+		ackLevel := timerKey{VisibilityTimestamp: sequenceID.VisibilityTimestamp}
+		if _, ok := namespaceAckLevels[namespaceID]; !ok && compareTimerIDLess(&ackLevel, &t.ackLevel) {
+			namespaceAckLevels[namespaceID] = ackLevel
+		}
+	}
+
+	for namespaceID, ackLevel := range t.namespaceAckLevels {
+		if _, ok := namespaceAckLevels[namespaceID]; !ok {
+			if !t.minQueryLevel.After(t.ackLevel.VisibilityTimestamp) {
+				// timers of the namespace behind the shared ack level are not fully read yet
+				namespaceAckLevels[namespaceID] = ackLevel
+				continue
+			}
+			t.logger.Info("Merging namespace back into timer queue.", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(t.ackLevel))
+			t.metricsClient.IncCounter(t.scope, metrics.NamespaceMergeCounter)
+		}
+	}
+	t.namespaceAckLevels = namespaceAckLevels
+	t.metricsClient.UpdateGauge(t.scope, metrics.SplitNamespaceCountGauge, float64(len(namespaceAckLevels)))
+
+	result := make(map[string]time.Time, len(namespaceAckLevels))
+	for namespaceID, ackLevel := range namespaceAckLevels {
+		result[namespaceID] = ackLevel.VisibilityTimestamp
+	}
+	return result
+}
@@ -145,6 +145,10 @@ func (s *timerQueueAckMgrSuite) SetupTest() {
 		func(ackLevel timerKey) error {
 			return s.mockShard.UpdateTimerClusterAckLevel(s.clusterName, ackLevel.VisibilityTimestamp)
 		},
+		s.mockShard.GetTimerClusterNamespaceAckLevels(s.clusterName),
+		func(ackLevel timerKey, namespaceAckLevels map[string]time.Time) error {
+			return s.mockShard.UpdateTimerClusterAckLevels(s.clusterName, ackLevel.VisibilityTimestamp, namespaceAckLevels)
+		},
 		s.logger,
 		s.clusterName,
 	)
@@ -481,7 +485,7 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.timerQueueAckMgr.updateAckLevel()
 	s.Equal(protoToNanos(timer1.VisibilityTimestamp), s.mockShard.GetTimerClusterAckLevel(s.clusterName).UnixNano())
 
-	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
+	// ack level does not move, so the shard is not updated
 	timerSequenceID3 := timerKeyFromGogoTime(timer3.VisibilityTimestamp, timer3.GetTaskId())
 	s.timerQueueAckMgr.completeTimerTask(timer3)
 	s.True(s.timerQueueAckMgr.outstandingTasks[*timerSequenceID3])
@@ -498,6 +502,77 @@ func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	s.Equal(protoToNanos(timer3.VisibilityTimestamp), s.mockShard.GetTimerClusterAckLevel(s.clusterName).UnixNano())
 }
 
+func (s *timerQueueAckMgrSuite) TestReadCompleteUpdateTimerTasks_SplitNamespace() {
+	s.timerQueueAckMgr.config.QueueProcessorEnableNamespaceSplit = dynamicconfig.GetBoolPropertyFn(true)
+	s.timerQueueAckMgr.config.QueueProcessorSplitMaxPendingTasks = dynamicconfig.GetIntPropertyFn(1)
+
+	stuckNamespaceID := uuid.New()
+	timer1 := &persistenceblobs.TimerTaskInfo{
+		NamespaceId:         stuckNamespaceID,
+		WorkflowId:          "some random workflow ID",
+		RunId:               uuid.New(),
+		VisibilityTimestamp: gogoProtoTimestampNowAddDuration(-5),
+		TaskId:              int64(59),
+		TaskType:            1,
+		TimeoutType:         2,
+		EventId:             int64(28),
+		ScheduleAttempt:     0,
+	}
+	timer2 := &persistenceblobs.TimerTaskInfo{
+		NamespaceId:         stuckNamespaceID,
+		WorkflowId:          "some random workflow ID",
+		RunId:               uuid.New(),
+		VisibilityTimestamp: timer1.VisibilityTimestamp,
+		TaskId:              timer1.GetTaskId() + 1,
+		TaskType:            1,
+		TimeoutType:         2,
+		EventId:             int64(29),
+		ScheduleAttempt:     0,
+	}
+	timer3 := &persistenceblobs.TimerTaskInfo{
+		NamespaceId:         TestNamespaceId,
+		WorkflowId:          "some random workflow ID",
+		RunId:               uuid.New(),
+		VisibilityTimestamp: &types.Timestamp{Seconds: timer1.VisibilityTimestamp.Seconds + 1, Nanos: timer1.VisibilityTimestamp.Nanos},
+		TaskId:              timer2.GetTaskId() + 1,
+		TaskType:            1,
+		TimeoutType:         2,
+		EventId:             int64(30),
+		ScheduleAttempt:     0,
+	}
+	response := &persistence.GetTimerIndexTasksResponse{
+		Timers:        []*persistenceblobs.TimerTaskInfo{timer1, timer2, timer3},
+		NextPageToken: nil,
+	}
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(response, nil).Once()
+	s.mockExecutionMgr.On("GetTimerIndexTasks", mock.Anything).Return(&persistence.GetTimerIndexTasksResponse{}, nil).Once()
+	_, _, _, err := s.timerQueueAckMgr.readTimerTasks()
+	s.Nil(err)
+
+	// pending timers of the stuck namespace exceed the threshold, so the namespace is split
+	// and the shared ack level moves past its pending timers
+	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
+	s.timerQueueAckMgr.completeTimerTask(timer3)
+	s.NoError(s.timerQueueAckMgr.updateAckLevel())
+	s.True(s.timerQueueAckMgr.isNamespaceSplit(stuckNamespaceID))
+	s.False(s.timerQueueAckMgr.isNamespaceSplit(TestNamespaceId))
+	s.Equal(protoToNanos(timer3.VisibilityTimestamp), s.mockShard.GetTimerClusterAckLevel(s.clusterName).UnixNano())
+	namespaceAckLevels := s.mockShard.GetTimerClusterNamespaceAckLevels(s.clusterName)
+	s.Len(namespaceAckLevels, 1)
+	s.Equal(protoToNanos(timer1.VisibilityTimestamp), namespaceAckLevels[stuckNamespaceID].UnixNano())
+	s.Equal(protoToNanos(timer1.VisibilityTimestamp), s.timerQueueAckMgr.getAckLevel().VisibilityTimestamp.UnixNano())
+
+	// once the pending timers are done, the namespace is merged back
+	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
+	s.timerQueueAckMgr.completeTimerTask(timer1)
+	s.timerQueueAckMgr.completeTimerTask(timer2)
+	s.NoError(s.timerQueueAckMgr.updateAckLevel())
+	s.False(s.timerQueueAckMgr.isNamespaceSplit(stuckNamespaceID))
+	s.Empty(s.mockShard.GetTimerClusterNamespaceAckLevels(s.clusterName))
+	s.Equal(protoToNanos(timer3.VisibilityTimestamp), s.timerQueueAckMgr.getAckLevel().VisibilityTimestamp.UnixNano())
+}
+
 func (s *timerQueueAckMgrSuite) TestReadLookAheadTask() {
 	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(s.clusterName).AnyTimes()
 	level := s.mockShard.UpdateTimerMaxReadLevel(s.clusterName)
@@ -794,7 +869,7 @@ func (s *timerQueueFailoverAckMgrSuite) TestReadCompleteUpdateTimerTasks() {
 	timerSequenceID3 := timerKeyFromGogoTime(timer3.VisibilityTimestamp, timer3.GetTaskId())
 	s.timerQueueFailoverAckMgr.completeTimerTask(timer3)
 	s.True(s.timerQueueFailoverAckMgr.outstandingTasks[*timerSequenceID3])
-	s.mockShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
+	// ack level does not move, so the shard is not updated
 	s.timerQueueFailoverAckMgr.updateAckLevel()
 	select {
 	case <-s.timerQueueFailoverAckMgr.getFinishedChan():
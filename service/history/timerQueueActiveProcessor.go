@@ -84,6 +84,10 @@ func newTimerQueueActiveProcessor(
 		shard.GetTimerClusterAckLevel(currentClusterName),
 		timeNow,
 		updateShardAckLevel,
+		shard.GetTimerClusterNamespaceAckLevels(currentClusterName),
+		func(ackLevel timerKey, namespaceAckLevels map[string]time.Time) error {
+			return shard.UpdateTimerClusterAckLevels(currentClusterName, ackLevel.VisibilityTimestamp, namespaceAckLevels)
+		},
 		logger,
 		currentClusterName,
 	)
@@ -411,6 +411,11 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 		return err
 	}
 
+	if mutableState.IsWorkflowExecutionPaused() {
+		// activity task is regenerated when the workflow is unpaused
+		return nil
+	}
+
 	namespaceID := task.GetNamespaceId()
 	targetNamespaceID := namespaceID
 	if activityInfo.NamespaceID != "" {
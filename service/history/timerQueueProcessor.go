@@ -54,6 +54,7 @@ type (
 		NotifyNewTimers(clusterName string, timerTask []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		RetryTask(task queueTaskInfo) error
 	}
 
 	timeNow                 func() time.Time
@@ -262,6 +263,21 @@ func (t *timerQueueProcessorImpl) UnlockTaskProcessing() {
 	t.taskAllocator.unlock()
 }
 
+// RetryTask executes a timer task taken out of the task DLQ, the task must belong to an active namespace
+func (t *timerQueueProcessorImpl) RetryTask(
+	task queueTaskInfo,
+) error {
+
+	shouldProcessTask, err := t.activeTimerProcessor.timerTaskFilter(task)
+	if err != nil {
+		return err
+	}
+	if !shouldProcessTask {
+		return errTaskDLQRetryNotActive
+	}
+	return t.activeTimerProcessor.taskExecutor.execute(task, true)
+}
+
 func (t *timerQueueProcessorImpl) completeTimersLoop() {
 	timer := time.NewTimer(t.config.TimerProcessorCompleteTimerInterval())
 	defer timer.Stop()
@@ -80,6 +80,7 @@ type (
 		queueTaskProcessor   queueTaskProcessor
 		redispatchQueue      collection.Queue
 		queueTaskInitializer queueTaskInitializer
+		splitDispatcher      *splitNamespaceDispatcher
 
 		// timer notification
 		newTimerCh  chan struct{}
@@ -144,6 +145,13 @@ func newTimerQueueProcessorBase(
 		),
 		retryPolicy: common.CreatePersistanceRetryPolicy(),
 	}
+	base.splitDispatcher = newSplitNamespaceDispatcher(
+		shard.GetNamespaceCache(),
+		config.QueueProcessorSplitNamespaceMaxDispatchRPS,
+		base.submitTask,
+		logger,
+		metricsScope,
+	)
 
 	return base
 }
@@ -171,6 +179,7 @@ func (t *timerQueueProcessorBase) Stop() {
 
 	t.timerGate.Close()
 	close(t.shutdownCh)
+	t.splitDispatcher.stop()
 	t.retryTasks()
 
 	if success := common.AwaitWaitGroup(&t.shutdownWG, time.Minute); !success {
@@ -365,6 +374,10 @@ func (t *timerQueueProcessorBase) readAndFanoutTimerTasks() (*persistenceblobs.T
 	}
 
 	for _, task := range timerTasks {
+		if t.timerQueueAckMgr.isNamespaceSplit(task.GetNamespaceId()) {
+			t.splitDispatcher.dispatch(task)
+			continue
+		}
 		if submitted := t.submitTask(task); !submitted {
 			return nil, nil
 		}
@@ -128,3 +128,17 @@ func (mr *MocktimerQueueProcessorMockRecorder) UnlockTaskProcessing() *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskProcessing", reflect.TypeOf((*MocktimerQueueProcessor)(nil).UnlockTaskProcessing))
 }
+
+// RetryTask mocks base method
+func (m *MocktimerQueueProcessor) RetryTask(task queueTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryTask indicates an expected call of RetryTask
+func (mr *MocktimerQueueProcessorMockRecorder) RetryTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTask", reflect.TypeOf((*MocktimerQueueProcessor)(nil).RetryTask), task)
+}
@@ -87,6 +87,10 @@ func newTimerQueueStandbyProcessor(
 		shard.GetTimerClusterAckLevel(clusterName),
 		timeNow,
 		updateShardAckLevel,
+		shard.GetTimerClusterNamespaceAckLevels(clusterName),
+		func(ackLevel timerKey, namespaceAckLevels map[string]time.Time) error {
+			return shard.UpdateTimerClusterAckLevels(clusterName, ackLevel.VisibilityTimestamp, namespaceAckLevels)
+		},
 		logger,
 		clusterName,
 	)
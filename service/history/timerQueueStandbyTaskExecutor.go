@@ -423,6 +423,12 @@ func (t *timerQueueStandbyTaskExecutor) processTimer(
 		return nil
 	}
 
+	if mutableState.GetExecutionInfo().Unreplicated && mutableState.IsWorkflowExecutionRunning() {
+		// workflow is not replicated, the active cluster will never make progress on it,
+		// retrying the task would hold back the standby ack level forever
+		return ErrTaskDiscarded
+	}
+
 	if !mutableState.IsWorkflowExecutionRunning() {
 		// workflow already finished, no need to process the timer
 		return nil
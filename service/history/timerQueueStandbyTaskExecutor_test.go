@@ -114,7 +114,7 @@ func (s *timerQueueStandbyTaskExecutorSuite) SetupTest() {
 	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
 	s.mockNDCHistoryResender = xdc.NewMockNDCHistoryResender(s.controller)
 	s.mockTxProcessor.EXPECT().NotifyNewTask(gomock.Any(), gomock.Any()).AnyTimes()
-	s.mockReplicationProcessor.EXPECT().notifyNewTask().AnyTimes()
+	s.mockReplicationProcessor.EXPECT().notifyNewReplicationTasks(gomock.Any()).AnyTimes()
 	s.mockTimerProcessor.EXPECT().NotifyNewTimers(gomock.Any(), gomock.Any()).AnyTimes()
 
 	s.mockHistoryRereplicator = &xdc.MockHistoryRereplicator{}
@@ -260,6 +260,75 @@ func (s *timerQueueStandbyTaskExecutorSuite) TestProcessUserTimerTimeout_Pending
 	s.Equal(ErrTaskDiscarded, err)
 }
 
+func (s *timerQueueStandbyTaskExecutorSuite) TestProcessUserTimerTimeout_Unreplicated() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(
+		s.mockShard,
+		s.mockShard.GetEventsCache(),
+		s.logger,
+		s.version,
+		execution.GetRunId(),
+	)
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                        &tasklistpb.TaskList{Name: taskListName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+	mutableState.GetExecutionInfo().Unreplicated = true
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+
+	timerID := "timer"
+	timerTimeout := 2 * time.Second
+	event, _ = addTimerStartedEvent(mutableState, event.GetEventId(), timerID, int64(timerTimeout.Seconds()))
+
+	timerSequence := newTimerSequence(s.timeSource, mutableState)
+	mutableState.insertTimerTasks = nil
+	modified, err := timerSequence.createNextUserTimer()
+	s.NoError(err)
+	s.True(modified)
+	task := mutableState.insertTimerTasks[0]
+	protoTaskTime, err := types.TimestampProto(task.(*persistence.UserTimerTask).GetVisibilityTimestamp())
+	s.NoError(err)
+	timerTask := &persistenceblobs.TimerTaskInfo{
+		Version:             s.version,
+		NamespaceId:         s.namespaceID,
+		WorkflowId:          execution.GetWorkflowId(),
+		RunId:               execution.GetRunId(),
+		TaskId:              int64(100),
+		TaskType:            enumsgenpb.TASK_TYPE_USER_TIMER,
+		TimeoutType:         int32(enumspb.TIMEOUT_TYPE_START_TO_CLOSE),
+		VisibilityTimestamp: protoTaskTime,
+		EventId:             event.EventId,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	// the task is discarded without waiting for the discard duration or resending history
+	s.mockShard.SetCurrentTime(s.clusterName, s.now)
+	err = s.timerQueueStandbyTaskExecutor.execute(timerTask, true)
+	s.Equal(ErrTaskDiscarded, err)
+}
+
 func (s *timerQueueStandbyTaskExecutorSuite) TestProcessUserTimerTimeout_Success() {
 
 	execution := commonpb.WorkflowExecution{
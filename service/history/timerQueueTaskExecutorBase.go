@@ -115,7 +115,7 @@ func (t *timerQueueTaskExecutorBase) executeDeleteHistoryEventTask(
 }
 
 func (t *timerQueueTaskExecutorBase) deleteWorkflow(
-	task *persistenceblobs.TimerTaskInfo,
+	task queueTaskInfo,
 	context workflowExecutionContext,
 	msBuilder mutableState,
 ) error {
@@ -132,7 +132,7 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflow(
 		return err
 	}
 
-	if err := t.deleteWorkflowVisibility(task); err != nil {
+	if err := t.deleteWorkflowVisibility(task, msBuilder); err != nil {
 		return err
 	}
 	// calling clear here to force accesses of mutable state to read database
@@ -142,7 +142,7 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflow(
 }
 
 func (t *timerQueueTaskExecutorBase) archiveWorkflow(
-	task *persistenceblobs.TimerTaskInfo,
+	task queueTaskInfo,
 	workflowContext workflowExecutionContext,
 	msBuilder mutableState,
 	namespaceCacheEntry *cache.NamespaceCacheEntry,
@@ -199,7 +199,7 @@ func (t *timerQueueTaskExecutorBase) archiveWorkflow(
 	}
 	// delete visibility record here regardless if it's been archived inline or not
 	// since the entire record is included as part of the archive request.
-	if err := t.deleteWorkflowVisibility(task); err != nil {
+	if err := t.deleteWorkflowVisibility(task, msBuilder); err != nil {
 		return err
 	}
 	// calling clear here to force accesses of mutable state to read database
@@ -209,7 +209,7 @@ func (t *timerQueueTaskExecutorBase) archiveWorkflow(
 }
 
 func (t *timerQueueTaskExecutorBase) deleteWorkflowExecution(
-	task *persistenceblobs.TimerTaskInfo,
+	task queueTaskInfo,
 ) error {
 
 	op := func() error {
@@ -223,7 +223,7 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflowExecution(
 }
 
 func (t *timerQueueTaskExecutorBase) deleteCurrentWorkflowExecution(
-	task *persistenceblobs.TimerTaskInfo,
+	task queueTaskInfo,
 ) error {
 
 	op := func() error {
@@ -237,7 +237,7 @@ func (t *timerQueueTaskExecutorBase) deleteCurrentWorkflowExecution(
 }
 
 func (t *timerQueueTaskExecutorBase) deleteWorkflowHistory(
-	task *persistenceblobs.TimerTaskInfo,
+	task queueTaskInfo,
 	msBuilder mutableState,
 ) error {
 
@@ -256,15 +256,17 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflowHistory(
 }
 
 func (t *timerQueueTaskExecutorBase) deleteWorkflowVisibility(
-	task *persistenceblobs.TimerTaskInfo,
+	task queueTaskInfo,
+	msBuilder mutableState,
 ) error {
 
 	op := func() error {
 		request := &persistence.VisibilityDeleteWorkflowExecutionRequest{
-			NamespaceID: task.GetNamespaceId(),
-			WorkflowID:  task.GetWorkflowId(),
-			RunID:       task.GetRunId(),
-			TaskID:      task.GetTaskId(),
+			NamespaceID:    task.GetNamespaceId(),
+			WorkflowID:     task.GetWorkflowId(),
+			RunID:          task.GetRunId(),
+			TaskID:         task.GetTaskId(),
+			StartTimestamp: msBuilder.GetExecutionInfo().StartTimestamp.UnixNano(),
 		}
 		// TODO: expose GetVisibilityManager method on shardContext interface
 		return t.shard.GetService().GetVisibilityManager().DeleteWorkflowExecution(request) // delete from db
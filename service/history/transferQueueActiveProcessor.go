@@ -79,6 +79,10 @@ func newTransferQueueActiveProcessor(
 		RedispatchIntervalJitterCoefficient: config.TransferProcessorRedispatchIntervalJitterCoefficient,
 		MaxRedispatchQueueSize:              config.TransferProcessorMaxRedispatchQueueSize,
 		EnablePriorityTaskProcessor:         config.TransferProcessorEnablePriorityTaskProcessor,
+		EnableNamespaceSplit:                config.QueueProcessorEnableNamespaceSplit,
+		SplitMaxPendingTasks:                config.QueueProcessorSplitMaxPendingTasks,
+		SplitMaxPendingDuration:             config.QueueProcessorSplitMaxPendingDuration,
+		SplitNamespaceMaxDispatchRPS:        config.QueueProcessorSplitNamespaceMaxDispatchRPS,
 		MetricScope:                         metrics.TransferActiveQueueProcessorScope,
 	}
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
@@ -107,13 +111,6 @@ func newTransferQueueActiveProcessor(
 		logger:             logger,
 		metricsClient:      historyService.metricsClient,
 		transferTaskFilter: transferTaskFilter,
-		taskExecutor: newTransferQueueActiveTaskExecutor(
-			shard,
-			historyService,
-			logger,
-			historyService.metricsClient,
-			config,
-		),
 		transferQueueProcessorBase: newTransferQueueProcessorBase(
 			shard,
 			options,
@@ -129,8 +126,20 @@ func newTransferQueueActiveProcessor(
 		options,
 		processor,
 		shard.GetTransferClusterAckLevel(currentClusterName),
+		shard.GetTransferClusterNamespaceAckLevels(currentClusterName),
+		func(ackLevel int64, namespaceAckLevels map[string]int64) error {
+			return shard.UpdateTransferClusterAckLevels(currentClusterName, ackLevel, namespaceAckLevels)
+		},
 		logger,
 	)
+	processor.taskExecutor = newTransferQueueActiveTaskExecutor(
+		shard,
+		historyService,
+		logger,
+		historyService.metricsClient,
+		config,
+		queueAckMgr,
+	)
 
 	redispatchQueue := collection.NewConcurrentQueue()
 
@@ -242,13 +251,6 @@ func newTransferQueueFailoverProcessor(
 		logger:             logger,
 		metricsClient:      historyService.metricsClient,
 		transferTaskFilter: transferTaskFilter,
-		taskExecutor: newTransferQueueActiveTaskExecutor(
-			shard,
-			historyService,
-			logger,
-			historyService.metricsClient,
-			config,
-		),
 		transferQueueProcessorBase: newTransferQueueProcessorBase(
 			shard,
 			options,
@@ -266,6 +268,14 @@ func newTransferQueueFailoverProcessor(
 		minLevel,
 		logger,
 	)
+	processor.taskExecutor = newTransferQueueActiveTaskExecutor(
+		shard,
+		historyService,
+		logger,
+		historyService.metricsClient,
+		config,
+		queueAckMgr,
+	)
 
 	redispatchQueue := collection.NewConcurrentQueue()
 
@@ -58,6 +58,8 @@ type (
 
 		historyClient           history.Client
 		parentClosePolicyClient parentclosepolicy.Client
+		workflowDeleter         *timerQueueTaskExecutorBase
+		queueAckMgr             queueAckMgr
 	}
 )
 
@@ -67,6 +69,7 @@ func newTransferQueueActiveTaskExecutor(
 	logger log.Logger,
 	metricsClient metrics.Client,
 	config *Config,
+	queueAckMgr queueAckMgr,
 ) queueTaskExecutor {
 	return &transferQueueActiveTaskExecutor{
 		transferQueueTaskExecutorBase: newTransferQueueTaskExecutorBase(
@@ -83,6 +86,15 @@ func newTransferQueueActiveTaskExecutor(
 			historyService.publicClient,
 			config.NumParentClosePolicySystemWorkflows(),
 		),
+		// deletion shares archival and cleanup logic with retention timer
+		workflowDeleter: newTimerQueueTaskExecutorBase(
+			shard,
+			historyService,
+			logger,
+			metricsClient,
+			config,
+		),
+		queueAckMgr: queueAckMgr,
 	}
 }
 
@@ -119,6 +131,8 @@ func (t *transferQueueActiveTaskExecutor) execute(
 		return t.processResetWorkflow(task)
 	case enumsgenpb.TASK_TYPE_TRANSFER_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES:
 		return t.processUpsertWorkflowSearchAttributes(task)
+	case enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION:
+		return t.processDeleteExecution(task)
 	default:
 		return errUnknownTransferTask
 	}
@@ -154,11 +168,30 @@ func (t *transferQueueActiveTaskExecutor) processActivityTask(
 		return err
 	}
 
+	if mutableState.IsWorkflowExecutionPaused() {
+		// task is regenerated when the workflow is unpaused
+		return nil
+	}
+
+	if ai.StartedID != common.EmptyEventID {
+		// activity is already dispatched to the worker which completed the decision
+		return nil
+	}
+
+	scheduledEvent, err := mutableState.GetActivityScheduledEvent(task.GetScheduleId())
+	if err != nil {
+		return err
+	}
+	labelSelector, err := getActivityLabelSelector(scheduledEvent.GetActivityTaskScheduledEventAttributes().GetHeader())
+	if err != nil {
+		return err
+	}
+
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushActivity(task, timeout)
+	return t.pushActivity(task, timeout, labelSelector)
 }
 
 func (t *transferQueueActiveTaskExecutor) processDecisionTask(
@@ -191,6 +224,11 @@ func (t *transferQueueActiveTaskExecutor) processDecisionTask(
 		return err
 	}
 
+	if mutableState.IsWorkflowExecutionPaused() {
+		// task is regenerated when the workflow is unpaused
+		return nil
+	}
+
 	executionInfo := mutableState.GetExecutionInfo()
 	runTimeout := executionInfo.WorkflowRunTimeout
 	taskTimeout := common.MinInt32(runTimeout, common.MaxTaskTimeout)
@@ -638,6 +676,54 @@ func (t *transferQueueActiveTaskExecutor) processUpsertWorkflowSearchAttributes(
 	return t.processRecordWorkflowStartedOrUpsertHelper(task, false)
 }
 
+func (t *transferQueueActiveTaskExecutor) processDeleteExecution(
+	task *persistenceblobs.TransferTaskInfo,
+) (retError error) {
+
+	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
+		t.getNamespaceIDAndWorkflowExecution(task),
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadMutableStateForTransferTask(weContext, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil || mutableState.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	lastWriteVersion, err := mutableState.GetLastWriteVersion()
+	if err != nil {
+		return err
+	}
+	ok, err := verifyTaskVersion(t.shard, t.logger, task.GetNamespaceId(), lastWriteVersion, task.Version, task)
+	if err != nil || !ok {
+		return err
+	}
+
+	// the close execution and other transfer tasks of the workflow are created before the delete task,
+	// so the workflow is only deleted once they are processed, otherwise they would find no mutable state
+	if t.queueAckMgr.hasPendingTasksBefore(task.GetNamespaceId(), task.GetWorkflowId(), task.GetRunId(), task.GetTaskId()) {
+		return ErrTaskRetry
+	}
+
+	namespaceCacheEntry, err := t.shard.GetNamespaceCache().GetNamespaceByID(task.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	clusterConfiguredForHistoryArchival := t.shard.GetService().GetArchivalMetadata().GetHistoryConfig().ClusterConfiguredForArchival()
+	namespaceConfiguredForHistoryArchival := namespaceCacheEntry.GetConfig().HistoryArchivalStatus == enumspb.ARCHIVAL_STATUS_ENABLED
+
+	if clusterConfiguredForHistoryArchival && namespaceConfiguredForHistoryArchival {
+		return t.workflowDeleter.archiveWorkflow(task, weContext, mutableState, namespaceCacheEntry)
+	}
+	return t.workflowDeleter.deleteWorkflow(task, weContext, mutableState)
+}
+
 func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHelper(
 	task *persistenceblobs.TransferTaskInfo,
 	recordStart bool,
@@ -144,7 +144,7 @@ func (s *transferQueueActiveTaskExecutorSuite) SetupTest() {
 	s.mockReplicationProcessor = NewMockReplicatorQueueProcessor(s.controller)
 	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
 	s.mockTxProcessor.EXPECT().NotifyNewTask(gomock.Any(), gomock.Any()).AnyTimes()
-	s.mockReplicationProcessor.EXPECT().notifyNewTask().AnyTimes()
+	s.mockReplicationProcessor.EXPECT().notifyNewReplicationTasks(gomock.Any()).AnyTimes()
 	s.mockTimerProcessor.EXPECT().NotifyNewTimers(gomock.Any(), gomock.Any()).AnyTimes()
 
 	config := NewDynamicConfigForTest()
@@ -213,6 +213,7 @@ func (s *transferQueueActiveTaskExecutorSuite) SetupTest() {
 		s.logger,
 		s.mockShard.GetMetricsClient(),
 		config,
+		s.mockQueueAckMgr,
 	).(*transferQueueActiveTaskExecutor)
 	s.transferQueueActiveTaskExecutor.parentClosePolicyClient = s.mockParentClosePolicyClient
 }
@@ -381,6 +382,52 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessDecisionTask_FirstDeci
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessDecisionTask_Paused() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                        &tasklistpb.TaskList{Name: taskListName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	taskID := int64(59)
+	di := addDecisionTaskScheduledEvent(mutableState)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.namespaceID,
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       execution.GetRunId(),
+		TaskId:      taskID,
+		TaskList:    taskListName,
+		TaskType:    enumsgenpb.TASK_TYPE_TRANSFER_DECISION_TASK,
+		ScheduleId:  di.ScheduleID,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, di.ScheduleID, di.Version)
+	persistenceMutableState.ExecutionInfo.Paused = true
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestProcessDecisionTask_NonFirstDecision() {
 
 	execution := commonpb.WorkflowExecution{
@@ -1846,6 +1893,150 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessUpsertWorkflowSearchAt
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessDeleteExecution() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                        &tasklistpb.TaskList{Name: taskListName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+	event = addCompleteWorkflowEvent(mutableState, event.GetEventId(), nil)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.namespaceID,
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       execution.GetRunId(),
+		TaskId:      int64(59),
+		TaskType:    enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockQueueAckMgr.On("hasPendingTasksBefore", s.namespaceID, transferTask.GetWorkflowId(), transferTask.GetRunId(), transferTask.GetTaskId()).Return(false).Once()
+	s.mockArchivalMetadata.On("GetHistoryConfig").Return(archiver.NewArchivalConfig("disabled", dc.GetStringPropertyFn("disabled"), dc.GetBoolPropertyFn(false), "disabled", "random URI"))
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockHistoryV2Mgr.On("DeleteHistoryBranch", mock.Anything).Return(nil).Once()
+	s.mockVisibilityMgr.On("DeleteWorkflowExecution", mock.MatchedBy(func(request *persistence.VisibilityDeleteWorkflowExecutionRequest) bool {
+		return request.WorkflowID == execution.GetWorkflowId() && request.RunID == execution.GetRunId()
+	})).Return(nil).Once()
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessDeleteExecution_PendingTasks() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                        &tasklistpb.TaskList{Name: taskListName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+	event = addCompleteWorkflowEvent(mutableState, event.GetEventId(), nil)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.namespaceID,
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       execution.GetRunId(),
+		TaskId:      int64(59),
+		TaskType:    enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockQueueAckMgr.On("hasPendingTasksBefore", s.namespaceID, transferTask.GetWorkflowId(), transferTask.GetRunId(), transferTask.GetTaskId()).Return(true).Once()
+
+	// workflow is not deleted before its preceding transfer tasks are processed
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Equal(ErrTaskRetry, err)
+}
+
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessDeleteExecution_Running() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                        &tasklistpb.TaskList{Name: taskListName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+	di := addDecisionTaskScheduledEvent(mutableState)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.namespaceID,
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       execution.GetRunId(),
+		TaskId:      int64(59),
+		TaskType:    enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION,
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, di.ScheduleID, di.Version)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	// running workflow is never deleted
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestCopySearchAttributes() {
 	var input map[string]*commonpb.Payload
 	s.Nil(copySearchAttributes(input))
@@ -55,6 +55,7 @@ type (
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskPrrocessing()
+		RetryTask(task queueTaskInfo) error
 	}
 
 	taskFilter func(task queueTaskInfo) (bool, error)
@@ -277,6 +278,21 @@ func (t *transferQueueProcessorImpl) UnlockTaskPrrocessing() {
 	t.taskAllocator.unlock()
 }
 
+// RetryTask executes a transfer task taken out of the task DLQ, the task must belong to an active namespace
+func (t *transferQueueProcessorImpl) RetryTask(
+	task queueTaskInfo,
+) error {
+
+	shouldProcessTask, err := t.activeTaskProcessor.transferTaskFilter(task)
+	if err != nil {
+		return err
+	}
+	if !shouldProcessTask {
+		return errTaskDLQRetryNotActive
+	}
+	return t.activeTaskProcessor.taskExecutor.execute(task, true)
+}
+
 func (t *transferQueueProcessorImpl) completeTransferLoop() {
 	timer := time.NewTimer(t.config.TransferProcessorCompleteTransferInterval())
 	defer timer.Stop()
@@ -159,6 +159,11 @@ func getTransferTaskMetricsScope(
 			return metrics.TransferActiveTaskUpsertWorkflowSearchAttributesScope
 		}
 		return metrics.TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	case enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION:
+		if isActive {
+			return metrics.TransferActiveTaskDeleteExecutionScope
+		}
+		return metrics.TransferStandbyTaskDeleteExecutionScope
 	default:
 		if isActive {
 			return metrics.TransferActiveQueueProcessorScope
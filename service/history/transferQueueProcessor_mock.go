@@ -128,3 +128,17 @@ func (mr *MocktransferQueueProcessorMockRecorder) UnlockTaskPrrocessing() *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskPrrocessing", reflect.TypeOf((*MocktransferQueueProcessor)(nil).UnlockTaskPrrocessing))
 }
+
+// RetryTask mocks base method
+func (m *MocktransferQueueProcessor) RetryTask(task queueTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryTask indicates an expected call of RetryTask
+func (mr *MocktransferQueueProcessorMockRecorder) RetryTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTask", reflect.TypeOf((*MocktransferQueueProcessor)(nil).RetryTask), task)
+}
@@ -78,6 +78,10 @@ func newTransferQueueStandbyProcessor(
 		RedispatchIntervalJitterCoefficient: config.TransferProcessorRedispatchIntervalJitterCoefficient,
 		MaxRedispatchQueueSize:              config.TransferProcessorMaxRedispatchQueueSize,
 		EnablePriorityTaskProcessor:         config.TransferProcessorEnablePriorityTaskProcessor,
+		EnableNamespaceSplit:                config.QueueProcessorEnableNamespaceSplit,
+		SplitMaxPendingTasks:                config.QueueProcessorSplitMaxPendingTasks,
+		SplitMaxPendingDuration:             config.QueueProcessorSplitMaxPendingDuration,
+		SplitNamespaceMaxDispatchRPS:        config.QueueProcessorSplitNamespaceMaxDispatchRPS,
 		MetricScope:                         metrics.TransferStandbyQueueProcessorScope,
 	}
 	logger = logger.WithTags(tag.ClusterName(clusterName))
@@ -131,6 +135,10 @@ func newTransferQueueStandbyProcessor(
 		options,
 		processor,
 		shard.GetTransferClusterAckLevel(clusterName),
+		shard.GetTransferClusterNamespaceAckLevels(clusterName),
+		func(ackLevel int64, namespaceAckLevels map[string]int64) error {
+			return shard.UpdateTransferClusterAckLevels(clusterName, ackLevel, namespaceAckLevels)
+		},
 		logger,
 	)
 
@@ -110,6 +110,9 @@ func (t *transferQueueStandbyTaskExecutor) execute(
 		return nil
 	case enumsgenpb.TASK_TYPE_TRANSFER_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES:
 		return t.processUpsertWorkflowSearchAttributes(transferTask)
+	case enumsgenpb.TASK_TYPE_TRANSFER_DELETE_EXECUTION:
+		// deletion is requested on active cluster only, standby relies on its own retention
+		return nil
 	default:
 		return errUnknownTransferTask
 	}
@@ -133,8 +136,17 @@ func (t *transferQueueStandbyTaskExecutor) processActivityTask(
 		}
 
 		if activityInfo.StartedID == common.EmptyEventID {
+			scheduledEvent, err := mutableState.GetActivityScheduledEvent(activityInfo.ScheduleID)
+			if err != nil {
+				return nil, err
+			}
+			labelSelector, err := getActivityLabelSelector(scheduledEvent.GetActivityTaskScheduledEventAttributes().GetHeader())
+			if err != nil {
+				return nil, err
+			}
 			return newPushActivityToMatchingInfo(
 				activityInfo.ScheduleToStartTimeout,
+				labelSelector,
 			), nil
 		}
 
@@ -498,6 +510,12 @@ func (t *transferQueueStandbyTaskExecutor) processTransfer(
 		return err
 	}
 
+	if mutableState.GetExecutionInfo().Unreplicated && mutableState.IsWorkflowExecutionRunning() {
+		// workflow is not replicated, the active cluster will never make progress on it,
+		// retrying the task would hold back the standby ack level forever
+		return ErrTaskDiscarded
+	}
+
 	if !mutableState.IsWorkflowExecutionRunning() && !processTaskIfClosed {
 		// workflow already finished, no need to process the timer
 		return nil
@@ -527,6 +545,7 @@ func (t *transferQueueStandbyTaskExecutor) pushActivity(
 	return t.transferQueueTaskExecutorBase.pushActivity(
 		task.(*persistenceblobs.TransferTaskInfo),
 		timeout,
+		pushActivityInfo.labelSelector,
 	)
 }
 
@@ -234,6 +234,63 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessActivityTask_Pending(
 	s.Equal(ErrTaskRetry, err)
 }
 
+func (s *transferQueueStandbyTaskExecutorSuite) TestProcessActivityTask_Unreplicated() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskList:                        &tasklistpb.TaskList{Name: taskListName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+			},
+		},
+	)
+	s.Nil(err)
+	mutableState.GetExecutionInfo().Unreplicated = true
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+
+	taskID := int64(59)
+	activityID := "activity-1"
+	activityType := "some random activity type"
+	event, _ = addActivityTaskScheduledEvent(mutableState, event.GetEventId(), activityID, activityType, taskListName, &commonpb.Payloads{}, 1, 1, 1, 1)
+
+	now := types.TimestampNow()
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:             s.version,
+		NamespaceId:         s.namespaceID,
+		WorkflowId:          execution.GetWorkflowId(),
+		RunId:               execution.GetRunId(),
+		VisibilityTimestamp: now,
+		TaskId:              taskID,
+		TaskList:            taskListName,
+		TaskType:            enumsgenpb.TASK_TYPE_TRANSFER_ACTIVITY_TASK,
+		ScheduleId:          event.GetEventId(),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+
+	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
+	// the task is discarded instead of retried until the discard duration
+	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	s.Equal(ErrTaskDiscarded, err)
+}
+
 func (s *transferQueueStandbyTaskExecutorSuite) TestProcessActivityTask_Pending_PushToMatching() {
 
 	execution := commonpb.WorkflowExecution{
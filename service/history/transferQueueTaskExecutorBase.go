@@ -97,6 +97,7 @@ func (t *transferQueueTaskExecutorBase) getNamespaceIDAndWorkflowExecution(
 func (t *transferQueueTaskExecutorBase) pushActivity(
 	task *persistenceblobs.TransferTaskInfo,
 	activityScheduleToStartTimeout int32,
+	labelSelector map[string]string,
 ) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
@@ -116,6 +117,7 @@ func (t *transferQueueTaskExecutorBase) pushActivity(
 		TaskList:                      &tasklistpb.TaskList{Name: task.TaskList},
 		ScheduleId:                    task.GetScheduleId(),
 		ScheduleToStartTimeoutSeconds: activityScheduleToStartTimeout,
+		LabelSelector:                 labelSelector,
 	})
 
 	return err
@@ -561,6 +561,10 @@ func (r *workflowResetterImpl) reapplyEvents(
 	for _, event := range events {
 		switch event.GetEventType() {
 		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+			if isReservedSignal(event) {
+				// signals recorded by the server are not user input and are not reapplied
+				continue
+			}
 			attr := event.GetWorkflowExecutionSignaledEventAttributes()
 			if _, err := mutableState.AddWorkflowExecutionSignaled(
 				attr.GetSignalName(),
@@ -54,6 +54,15 @@ type (
 		ForwarderMaxRatePerSecond    dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 		ForwarderMaxChildrenPerNode  dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 
+		// partition auto scaling configuration
+		EnablePartitionAutoScaling   dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+		PartitionAutoScalingInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+		PartitionTargetRatePerSecond dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+		MaxTasklistPartitions        dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+
+		// Time an activity task with a label selector waits for a poller with matching labels
+		LabelSelectorTimeout dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
+
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 		MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskListInfoFilters
@@ -90,6 +99,12 @@ type (
 		MaxTaskBatchSize                func() int
 		NumWritePartitions              func() int
 		NumReadPartitions               func() int
+		// partition auto scaling configuration
+		EnablePartitionAutoScaling   func() bool
+		PartitionAutoScalingInterval func() time.Duration
+		PartitionTargetRatePerSecond func() int
+		MaxPartitions                func() int
+		LabelSelectorTimeout         func() time.Duration
 	}
 )
 
@@ -118,6 +133,11 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		ForwarderMaxRatePerSecond:       dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
 		ForwarderMaxChildrenPerNode:     dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
 		ShutdownDrainDuration:           dc.GetDurationProperty(dynamicconfig.MatchingShutdownDrainDuration, 0),
+		EnablePartitionAutoScaling:      dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnablePartitionAutoScaling, false),
+		PartitionAutoScalingInterval:    dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPartitionAutoScalingInterval, time.Minute),
+		PartitionTargetRatePerSecond:    dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPartitionTargetRatePerSecond, 500),
+		MaxTasklistPartitions:           dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTasklistPartitions, 32),
+		LabelSelectorTimeout:            dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingLabelSelectorTimeout, 10*time.Second),
 	}
 }
 
@@ -168,6 +188,21 @@ func newTaskListConfig(id *taskListID, config *Config, namespaceCache cache.Name
 		NumReadPartitions: func() int {
 			return common.MaxInt(1, config.NumTasklistReadPartitions(namespace, taskListName, taskType))
 		},
+		EnablePartitionAutoScaling: func() bool {
+			return config.EnablePartitionAutoScaling(namespace, taskListName, taskType)
+		},
+		PartitionAutoScalingInterval: func() time.Duration {
+			return config.PartitionAutoScalingInterval(namespace, taskListName, taskType)
+		},
+		PartitionTargetRatePerSecond: func() int {
+			return common.MaxInt(1, config.PartitionTargetRatePerSecond(namespace, taskListName, taskType))
+		},
+		MaxPartitions: func() int {
+			return common.MaxInt(1, config.MaxTasklistPartitions(namespace, taskListName, taskType))
+		},
+		LabelSelectorTimeout: func() time.Duration {
+			return config.LabelSelectorTimeout(namespace, taskListName, taskType)
+		},
 		forwarderConfig: forwarderConfig{
 			ForwarderMaxOutstandingPolls: func() int {
 				return config.ForwarderMaxOutstandingPolls(namespace, taskListName, taskType)
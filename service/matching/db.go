@@ -31,6 +31,7 @@ import (
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/persistence"
@@ -45,12 +46,20 @@ type (
 		taskType     enumspb.TaskListType
 		rangeID      int64
 		ackLevel     int64
-		store        persistence.TaskManager
-		logger       log.Logger
+		// partitionConfig is the persisted partition count of the task list,
+		// only ever set on the root partition when auto scaling is enabled
+		partitionConfig *persistenceblobs.TaskListPartitionConfig
+		// approximateBacklogCount is the backlog count as of the last state update
+		approximateBacklogCount int64
+		// pauseInfo is set while dispatch of the task list is paused
+		pauseInfo *tasklistgenpb.TaskListPauseInfo
+		store     persistence.TaskManager
+		logger    log.Logger
 	}
 	taskListState struct {
-		rangeID  int64
-		ackLevel int64
+		rangeID                 int64
+		ackLevel                int64
+		approximateBacklogCount int64
 	}
 )
 
@@ -60,10 +69,10 @@ type (
 //
 // This class will serialize writes to persistence that do condition updates. There are
 // two reasons for doing this:
-// - To work around known Cassandra issue where concurrent LWT to the same partition cause timeout errors
-// - To provide the guarantee that there is only writer who updates taskList in persistence at any given point in time
-//   This guarantee makes some of the other code simpler and there is no impact to perf because updates to tasklist are
-//   spread out and happen in background routines
+//   - To work around known Cassandra issue where concurrent LWT to the same partition cause timeout errors
+//   - To provide the guarantee that there is only writer who updates taskList in persistence at any given point in time
+//     This guarantee makes some of the other code simpler and there is no impact to perf because updates to tasklist are
+//     spread out and happen in background routines
 func newTaskListDB(store persistence.TaskManager, namespaceID string, name string, taskType enumspb.TaskListType, kind enumspb.TaskListKind, logger log.Logger) *taskListDB {
 	return &taskListDB{
 		namespaceID:  namespaceID,
@@ -99,25 +108,96 @@ func (db *taskListDB) RenewLease() (taskListState, error) {
 	}
 	db.ackLevel = resp.TaskListInfo.Data.AckLevel
 	db.rangeID = resp.TaskListInfo.RangeID
-	return taskListState{rangeID: db.rangeID, ackLevel: db.ackLevel}, nil
+	db.partitionConfig = resp.TaskListInfo.Data.PartitionConfig
+	db.approximateBacklogCount = resp.TaskListInfo.Data.ApproximateBacklogCount
+	db.pauseInfo = resp.TaskListInfo.Data.PauseInfo
+	return taskListState{
+		rangeID:                 db.rangeID,
+		ackLevel:                db.ackLevel,
+		approximateBacklogCount: db.approximateBacklogCount,
+	}, nil
 }
 
-// UpdateState updates the taskList state with the given value
-func (db *taskListDB) UpdateState(ackLevel int64) error {
+// UpdateState updates the taskList state with the given values
+func (db *taskListDB) UpdateState(ackLevel int64, approximateBacklogCount int64) error {
 	db.Lock()
 	defer db.Unlock()
 	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
 		TaskListInfo: &persistenceblobs.TaskListInfo{
-			NamespaceId: db.namespaceID,
-			Name:        db.taskListName,
-			TaskType:    db.taskType,
-			AckLevel:    ackLevel,
-			Kind:        db.taskListKind,
+			NamespaceId:             db.namespaceID,
+			Name:                    db.taskListName,
+			TaskType:                db.taskType,
+			AckLevel:                ackLevel,
+			Kind:                    db.taskListKind,
+			PartitionConfig:         db.partitionConfig,
+			ApproximateBacklogCount: approximateBacklogCount,
+			PauseInfo:               db.pauseInfo,
 		},
 		RangeID: db.rangeID,
 	})
 	if err == nil {
 		db.ackLevel = ackLevel
+		db.approximateBacklogCount = approximateBacklogCount
+	}
+	return err
+}
+
+// PartitionConfig returns the persisted partition config of the taskList, nil if none
+func (db *taskListDB) PartitionConfig() *persistenceblobs.TaskListPartitionConfig {
+	db.Lock()
+	defer db.Unlock()
+	return db.partitionConfig
+}
+
+// UpdatePartitionConfig persists the given partition config along with the current taskList state
+func (db *taskListDB) UpdatePartitionConfig(partitionConfig *persistenceblobs.TaskListPartitionConfig) error {
+	db.Lock()
+	defer db.Unlock()
+	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistenceblobs.TaskListInfo{
+			NamespaceId:             db.namespaceID,
+			Name:                    db.taskListName,
+			TaskType:                db.taskType,
+			AckLevel:                db.ackLevel,
+			Kind:                    db.taskListKind,
+			PartitionConfig:         partitionConfig,
+			ApproximateBacklogCount: db.approximateBacklogCount,
+			PauseInfo:               db.pauseInfo,
+		},
+		RangeID: db.rangeID,
+	})
+	if err == nil {
+		db.partitionConfig = partitionConfig
+	}
+	return err
+}
+
+// PauseInfo returns the persisted pause state of the taskList, nil if dispatch is not paused
+func (db *taskListDB) PauseInfo() *tasklistgenpb.TaskListPauseInfo {
+	db.Lock()
+	defer db.Unlock()
+	return db.pauseInfo
+}
+
+// UpdatePauseInfo persists the given pause state along with the current taskList state
+func (db *taskListDB) UpdatePauseInfo(pauseInfo *tasklistgenpb.TaskListPauseInfo) error {
+	db.Lock()
+	defer db.Unlock()
+	_, err := db.store.UpdateTaskList(&persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistenceblobs.TaskListInfo{
+			NamespaceId:             db.namespaceID,
+			Name:                    db.taskListName,
+			TaskType:                db.taskType,
+			AckLevel:                db.ackLevel,
+			Kind:                    db.taskListKind,
+			PartitionConfig:         db.partitionConfig,
+			ApproximateBacklogCount: db.approximateBacklogCount,
+			PauseInfo:               pauseInfo,
+		},
+		RangeID: db.rangeID,
+	})
+	if err == nil {
+		db.pauseInfo = pauseInfo
 	}
 	return err
 }
@@ -130,11 +210,14 @@ func (db *taskListDB) CreateTasks(tasks []*persistenceblobs.AllocatedTaskInfo) (
 		&persistence.CreateTasksRequest{
 			TaskListInfo: &persistence.PersistedTaskListInfo{
 				Data: &persistenceblobs.TaskListInfo{
-					NamespaceId: db.namespaceID,
-					Name:        db.taskListName,
-					TaskType:    db.taskType,
-					AckLevel:    db.ackLevel,
-					Kind:        db.taskListKind,
+					NamespaceId:             db.namespaceID,
+					Name:                    db.taskListName,
+					TaskType:                db.taskType,
+					AckLevel:                db.ackLevel,
+					Kind:                    db.taskListKind,
+					PartitionConfig:         db.partitionConfig,
+					ApproximateBacklogCount: db.approximateBacklogCount,
+					PauseInfo:               db.pauseInfo,
 				},
 				RangeID: db.rangeID,
 			},
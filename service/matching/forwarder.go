@@ -224,6 +224,7 @@ func (fwdr *Forwarder) ForwardPoll(ctx context.Context) (*internalTask, error) {
 
 	pollerID, _ := ctx.Value(pollerIDKey).(string)
 	identity, _ := ctx.Value(identityKey).(string)
+	labels, _ := ctx.Value(pollerLabelsKey).(map[string]string)
 
 	switch fwdr.taskListID.taskType {
 	case enumspb.TASK_LIST_TYPE_DECISION:
@@ -255,6 +256,7 @@ func (fwdr *Forwarder) ForwardPoll(ctx context.Context) (*internalTask, error) {
 				Identity: identity,
 			},
 			ForwardedFrom: fwdr.taskListID.name,
+			PollerLabels:  labels,
 		})
 		if err != nil {
 			return nil, fwdr.handleErr(err)
@@ -388,6 +388,54 @@ func (h *Handler) ListTaskListPartitions(
 	return response, hCtx.handleErr(err)
 }
 
+// GetTaskListPartitionConfig returns the number of read and write partitions of a taskList
+func (h *Handler) GetTaskListPartitionConfig(
+	ctx context.Context,
+	request *matchingservice.GetTaskListPartitionConfigRequest,
+) (_ *matchingservice.GetTaskListPartitionConfigResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		request.GetTaskList(),
+		metrics.MatchingGetTaskListPartitionConfigScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.GetTaskListPartitionConfig(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
+// UpdateTaskListPauseState pauses or resumes dispatch of tasks from a taskList to pollers
+func (h *Handler) UpdateTaskListPauseState(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskListPauseStateRequest,
+) (_ *matchingservice.UpdateTaskListPauseStateResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		request.GetTaskList(),
+		metrics.MatchingUpdateTaskListPauseStateScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.UpdateTaskListPauseState(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
 func (h *Handler) namespaceName(id string) string {
 	entry, err := h.GetNamespaceCache().GetNamespaceByID(id)
 	if err != nil {
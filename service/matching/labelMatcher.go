@@ -0,0 +1,147 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// labelMatcher matches tasks carrying a label selector with pollers advertising labels which
+	// satisfy the selector. Tasks wait in a list until a poller claims them, waiting pollers are
+	// signaled when a task they are able to claim is added. Pulling tasks instead of pushing them
+	// to pollers guarantees that a poller which meanwhile picked up another task never ends up
+	// holding two tasks.
+	labelMatcher struct {
+		sync.Mutex
+		tasks   []*labeledTask
+		pollers map[*labeledPoller]struct{}
+	}
+
+	labeledTask struct {
+		task     *internalTask
+		claimedC chan struct{} // closed once a poller claimed the task
+	}
+
+	labeledPoller struct {
+		labels  map[string]string
+		notifyC chan struct{} // signaled when a task matching the labels is added
+	}
+)
+
+func newLabelMatcher() *labelMatcher {
+	return &labelMatcher{
+		pollers: make(map[*labeledPoller]struct{}),
+	}
+}
+
+// offer makes the task available to pollers with matching labels and blocks until the task
+// is claimed or the context is done. Returns true if the task was claimed by a poller
+func (lm *labelMatcher) offer(ctx context.Context, task *internalTask) bool {
+	t := &labeledTask{task: task, claimedC: make(chan struct{})}
+
+	lm.Lock()
+	lm.tasks = append(lm.tasks, t)
+	for p := range lm.pollers {
+		if labelsMatch(task.labelSelector, p.labels) {
+			select {
+			case p.notifyC <- struct{}{}:
+			default: // poller already signaled
+			}
+		}
+	}
+	lm.Unlock()
+
+	select {
+	case <-t.claimedC:
+		return true
+	case <-ctx.Done():
+	}
+
+	lm.Lock()
+	defer lm.Unlock()
+	for i, waiting := range lm.tasks {
+		if waiting == t {
+			lm.tasks = append(lm.tasks[:i], lm.tasks[i+1:]...)
+			return false
+		}
+	}
+	// claimed concurrently with the context being done
+	return true
+}
+
+// claim removes and returns the oldest waiting task whose label selector
+// is satisfied by the given labels, nil if there is no such task
+func (lm *labelMatcher) claim(labels map[string]string) *internalTask {
+	lm.Lock()
+	defer lm.Unlock()
+	for i, t := range lm.tasks {
+		if labelsMatch(t.task.labelSelector, labels) {
+			lm.tasks = append(lm.tasks[:i], lm.tasks[i+1:]...)
+			close(t.claimedC)
+			return t.task
+		}
+	}
+	return nil
+}
+
+// hasPoller returns true if a waiting poller has labels which satisfy the selector
+func (lm *labelMatcher) hasPoller(selector map[string]string) bool {
+	lm.Lock()
+	defer lm.Unlock()
+	for p := range lm.pollers {
+		if labelsMatch(selector, p.labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// register adds a waiting poller, which is signaled on its notifyC whenever
+// a task it can claim is offered. Pollers must unregister once done waiting
+func (lm *labelMatcher) register(labels map[string]string) *labeledPoller {
+	p := &labeledPoller{labels: labels, notifyC: make(chan struct{}, 1)}
+	lm.Lock()
+	lm.pollers[p] = struct{}{}
+	lm.Unlock()
+	return p
+}
+
+func (lm *labelMatcher) unregister(p *labeledPoller) {
+	lm.Lock()
+	delete(lm.pollers, p)
+	lm.Unlock()
+}
+
+// labelsMatch returns true if the labels contain every key of the selector with the same value
+func labelsMatch(selector map[string]string, labels map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
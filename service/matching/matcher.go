@@ -50,6 +50,8 @@ type TaskMatcher struct {
 	queryTaskC chan *internalTask
 	// ratelimiter that limits the rate at which tasks can be dispatched to consumers
 	limiter *quotas.RateLimiter
+	// matches tasks with a label selector with pollers advertising matching labels
+	labelMatcher *labelMatcher
 
 	fwdr          *Forwarder
 	scope         func() metrics.Scope // namespace metric scope
@@ -75,6 +77,7 @@ func newTaskMatcher(config *taskListConfig, fwdr *Forwarder, scopeFunc func() me
 		fwdr:          fwdr,
 		taskC:         make(chan *internalTask),
 		queryTaskC:    make(chan *internalTask),
+		labelMatcher:  newLabelMatcher(),
 		numPartitions: config.NumReadPartitions,
 	}
 }
@@ -104,6 +107,10 @@ func newTaskMatcher(config *taskListConfig, fwdr *Forwarder, scopeFunc func() me
 // trying to match with a poller. The caller is expected to set the
 // correct context timeout.
 //
+// Tasks with a label selector:
+// A task with a label selector is only matched with local pollers whose
+// labels satisfy the selector, and is never forwarded.
+//
 // returns error when:
 //  - ratelimit is exceeded (does not apply to query task)
 //  - context deadline is exceeded
@@ -119,6 +126,21 @@ func (tm *TaskMatcher) Offer(ctx context.Context, task *internalTask) (bool, err
 		}
 	}
 
+	if len(task.labelSelector) > 0 {
+		if !tm.labelMatcher.hasPoller(task.labelSelector) || !tm.labelMatcher.offer(ctx, task) {
+			if rsv != nil {
+				rsv.Cancel()
+			}
+			return false, nil
+		}
+		tm.scope().IncCounter(metrics.LabelMatchPerTaskListCounter)
+		if task.responseC != nil {
+			err = <-task.responseC
+			return true, err
+		}
+		return false, nil
+	}
+
 	select {
 	case tm.taskC <- task: // poller picked up the task
 		if task.responseC != nil {
@@ -215,11 +237,27 @@ func (tm *TaskMatcher) OfferQuery(ctx context.Context, task *internalTask) (*mat
 // MustOffer blocks until a consumer is found to handle this task
 // Returns error only when context is canceled or the ratelimit is set to zero (allow nothing)
 // The passed in context MUST NOT have a deadline associated with it
+// A task with a label selector is only handed to local pollers with matching labels
+// until the selector expires, after which it is handed to any poller
 func (tm *TaskMatcher) MustOffer(ctx context.Context, task *internalTask) error {
 	if _, err := tm.ratelimit(ctx); err != nil {
 		return err
 	}
 
+	if len(task.labelSelector) > 0 {
+		selectorCtx, cancel := context.WithDeadline(ctx, task.labelSelectorExpiry)
+		claimed := tm.labelMatcher.offer(selectorCtx, task)
+		cancel()
+		if claimed {
+			tm.scope().IncCounter(metrics.LabelMatchPerTaskListCounter)
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		tm.scope().IncCounter(metrics.LabelSelectorFallbackPerTaskListCounter)
+	}
+
 	// attempt a match with local poller first. When that
 	// doesn't succeed, try both local match and remote match
 	select {
@@ -272,6 +310,9 @@ forLoop:
 // On success, the returned task could be a query task or a regular task
 // Returns ErrNoTasks when context deadline is exceeded
 func (tm *TaskMatcher) Poll(ctx context.Context) (*internalTask, error) {
+	if labels, _ := ctx.Value(pollerLabelsKey).(map[string]string); len(labels) > 0 {
+		return tm.pollWithLabels(ctx, labels)
+	}
 	// try local match first without blocking until context timeout
 	if task, err := tm.pollNonBlocking(ctx, tm.taskC, tm.queryTaskC); err == nil {
 		return task, nil
@@ -343,6 +384,50 @@ func (tm *TaskMatcher) pollOrForward(
 	}
 }
 
+// pollWithLabels blocks until a task is found or context deadline is exceeded. Besides the
+// tasks available to every poller, the poller is handed tasks whose label selector is satisfied
+// by its labels. Like pollOrForward, the poll is forwarded to the parent partition at most once
+func (tm *TaskMatcher) pollWithLabels(ctx context.Context, labels map[string]string) (*internalTask, error) {
+	poller := tm.labelMatcher.register(labels)
+	defer tm.labelMatcher.unregister(poller)
+
+	fwdrTokenC := tm.fwdrPollReqTokenC()
+	for {
+		// claim after registering, so that no task offered in between is missed
+		if task := tm.labelMatcher.claim(labels); task != nil {
+			if task.responseC != nil {
+				tm.scope().IncCounter(metrics.PollSuccessWithSyncPerTaskListCounter)
+			}
+			tm.scope().IncCounter(metrics.PollSuccessPerTaskListCounter)
+			return task, nil
+		}
+
+		select {
+		case <-poller.notifyC:
+		case task := <-tm.taskC:
+			if task.responseC != nil {
+				tm.scope().IncCounter(metrics.PollSuccessWithSyncPerTaskListCounter)
+			}
+			tm.scope().IncCounter(metrics.PollSuccessPerTaskListCounter)
+			return task, nil
+		case task := <-tm.queryTaskC:
+			tm.scope().IncCounter(metrics.PollSuccessWithSyncPerTaskListCounter)
+			tm.scope().IncCounter(metrics.PollSuccessPerTaskListCounter)
+			return task, nil
+		case <-ctx.Done():
+			tm.scope().IncCounter(metrics.PollTimeoutPerTaskListCounter)
+			return nil, ErrNoTasks
+		case token := <-fwdrTokenC:
+			task, err := tm.fwdr.ForwardPoll(ctx)
+			token.release()
+			if err == nil {
+				return task, nil
+			}
+			fwdrTokenC = noopForwarderTokenC
+		}
+	}
+}
+
 func (tm *TaskMatcher) poll(
 	ctx context.Context,
 	taskC <-chan *internalTask,
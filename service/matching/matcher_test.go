@@ -359,6 +359,87 @@ func (t *MatcherTestSuite) TestMustOfferLocalMatch() {
 	t.NoError(err)
 }
 
+func (t *MatcherTestSuite) TestLabelSelectorLocalSyncMatch() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	selector := map[string]string{"gpu": "true"}
+	task := newInternalTask(randomTaskInfo(), nil, enumsgenpb.TASK_SOURCE_HISTORY, "", true)
+	task.labelSelector = selector
+
+	// no poller with matching labels, task is not offered to pollers without labels
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		_, _ = t.matcher.Poll(ctx)
+		cancel()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err := t.matcher.Offer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.False(syncMatch)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		ctx = context.WithValue(ctx, pollerLabelsKey, map[string]string{"gpu": "true", "zone": "a"})
+		task, err := t.matcher.Poll(ctx)
+		cancel()
+		if err == nil {
+			task.finish(nil)
+		}
+	}()
+	t.Eventually(func() bool { return t.matcher.labelMatcher.hasPoller(selector) }, time.Second, time.Millisecond)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err = t.matcher.Offer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.True(syncMatch)
+}
+
+func (t *MatcherTestSuite) TestMustOfferLabelSelectorFallback() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	taskInfo := randomTaskInfo()
+	task := newInternalTask(taskInfo, nil, enumsgenpb.TASK_SOURCE_DB_BACKLOG, "", false)
+	task.labelSelector = map[string]string{"gpu": "true"}
+	task.labelSelectorExpiry = time.Now().Add(50 * time.Millisecond)
+
+	polledC := make(chan *internalTask, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ctx = context.WithValue(ctx, pollerLabelsKey, map[string]string{"gpu": "false"})
+		task, err := t.matcher.Poll(ctx)
+		if err == nil {
+			polledC <- task
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	err := t.matcher.MustOffer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.True(time.Now().After(task.labelSelectorExpiry))
+
+	polled := <-polledC
+	t.Equal(taskInfo.GetTaskId(), polled.event.GetTaskId())
+}
+
+func (t *MatcherTestSuite) TestLabelsMatch() {
+	labels := map[string]string{"gpu": "true", "zone": "a"}
+	t.True(labelsMatch(nil, labels))
+	t.True(labelsMatch(map[string]string{"gpu": "true"}, labels))
+	t.True(labelsMatch(map[string]string{"gpu": "true", "zone": "a"}, labels))
+	t.False(labelsMatch(map[string]string{"gpu": "false"}, labels))
+	t.False(labelsMatch(map[string]string{"region": "us"}, labels))
+	t.False(labelsMatch(map[string]string{"gpu": "true"}, nil))
+}
+
 func (t *MatcherTestSuite) TestMustOfferRemoteMatch() {
 	pollSigC := make(chan struct{})
 
@@ -43,6 +43,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
@@ -61,8 +62,9 @@ import (
 // TODO: Switch implementation from lock/channel based to a partitioned agent
 // to simplify code and reduce possibility of synchronization errors.
 type (
-	pollerIDCtxKey string
-	identityCtxKey string
+	pollerIDCtxKey     string
+	identityCtxKey     string
+	pollerLabelsCtxKey string
 
 	// lockableQueryTaskMap maps query TaskID (which is a UUID generated in QueryWorkflow() call) to a channel
 	// that QueryWorkflow() will block on. The channel is unblocked either by worker sending response through
@@ -102,8 +104,9 @@ var (
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
 
-	pollerIDKey pollerIDCtxKey = "pollerID"
-	identityKey identityCtxKey = "identity"
+	pollerIDKey     pollerIDCtxKey     = "pollerID"
+	identityKey     identityCtxKey     = "identity"
+	pollerLabelsKey pollerLabelsCtxKey = "pollerLabels"
 )
 
 var _ Engine = (*matchingEngineImpl)(nil) // Asserts that interface is indeed implemented
@@ -297,12 +300,13 @@ func (e *matchingEngineImpl) AddActivityTask(
 	expiry := types.TimestampNow()
 	expiry.Seconds += int64(addRequest.GetScheduleToStartTimeoutSeconds())
 	taskInfo := &persistenceblobs.TaskInfo{
-		NamespaceId: sourceNamespaceID,
-		RunId:       runID,
-		WorkflowId:  addRequest.Execution.GetWorkflowId(),
-		ScheduleId:  addRequest.GetScheduleId(),
-		CreatedTime: now,
-		Expiry:      expiry,
+		NamespaceId:   sourceNamespaceID,
+		RunId:         runID,
+		WorkflowId:    addRequest.Execution.GetWorkflowId(),
+		ScheduleId:    addRequest.GetScheduleId(),
+		CreatedTime:   now,
+		Expiry:        expiry,
+		LabelSelector: addRequest.GetLabelSelector(),
 	}
 
 	return tlMgr.AddTask(hCtx.Context, addTaskParams{
@@ -435,6 +439,7 @@ pollLoop:
 		// long-poll when frontend calls CancelOutstandingPoll API
 		pollerCtx := context.WithValue(hCtx.Context, pollerIDKey, pollerID)
 		pollerCtx = context.WithValue(pollerCtx, identityKey, request.GetIdentity())
+		pollerCtx = context.WithValue(pollerCtx, pollerLabelsKey, req.GetPollerLabels())
 		taskListKind := request.TaskList.GetKind()
 		task, err := e.getTask(pollerCtx, taskList, maxDispatch, taskListKind)
 		if err != nil {
@@ -587,7 +592,45 @@ func (e *matchingEngineImpl) DescribeTaskList(
 		return nil, err
 	}
 
-	return tlMgr.DescribeTaskList(request.DescRequest.GetIncludeTaskListStatus()), nil
+	response := tlMgr.DescribeTaskList(request.DescRequest.GetIncludeTaskListStatus())
+	if request.DescRequest.GetIncludeTaskListStatus() && taskList.IsRoot() && taskListKind != enumspb.TASK_LIST_KIND_STICKY {
+		response.BacklogStats = e.aggregateBacklogStats(hCtx, taskList, tlMgr, response.BacklogStats)
+	}
+	return response, nil
+}
+
+// aggregateBacklogStats merges the backlog stats of the root partition with
+// the backlog stats of all other partitions of the task list
+func (e *matchingEngineImpl) aggregateBacklogStats(
+	hCtx *handlerContext,
+	rootTaskList *taskListID,
+	rootMgr taskListManager,
+	rootStats *tasklistgenpb.TaskListBacklogStats,
+) *tasklistgenpb.TaskListBacklogStats {
+	stats := []*tasklistgenpb.TaskListBacklogStats{rootStats}
+	numPartitions := int(rootMgr.GetPartitionConfig().GetNumReadPartitions())
+	for p := 1; p < numPartitions; p++ {
+		resp, err := e.matchingClient.DescribeTaskList(hCtx.Context, &matchingservice.DescribeTaskListRequest{
+			NamespaceId: rootTaskList.namespaceID,
+			DescRequest: &workflowservice.DescribeTaskListRequest{
+				TaskList: &tasklistpb.TaskList{
+					Name: rootTaskList.mkName(p),
+					Kind: enumspb.TASK_LIST_KIND_NORMAL,
+				},
+				TaskListType:          rootTaskList.taskType,
+				IncludeTaskListStatus: true,
+			},
+		})
+		if err != nil {
+			// stats are approximate, a partition failing to respond should not fail the call
+			e.logger.Warn("Failed to describe task list partition",
+				tag.WorkflowTaskListName(rootTaskList.mkName(p)),
+				tag.Error(err))
+			continue
+		}
+		stats = append(stats, resp.GetBacklogStats())
+	}
+	return mergeBacklogStats(stats...)
 }
 
 func (e *matchingEngineImpl) ListTaskListPartitions(
@@ -618,13 +661,9 @@ func (e *matchingEngineImpl) listTaskListPartitions(request *matchingservice.Lis
 	if err != nil {
 		return nil, err
 	}
-	partitionHostInfo := make([]*tasklistpb.TaskListPartitionMetadata, len(partitions))
-
-	if err != nil {
-		return nil, err
-	}
+	partitionHostInfo := make([]*tasklistpb.TaskListPartitionMetadata, 0, len(partitions))
 	for _, partition := range partitions {
-		if host, err := e.getHostInfo(partition); err != nil {
+		if host, err := e.getHostInfo(partition); err == nil {
 			partitionHostInfo = append(partitionHostInfo,
 				&tasklistpb.TaskListPartitionMetadata{
 					Key:           partition,
@@ -653,24 +692,115 @@ func (e *matchingEngineImpl) getAllPartitions(
 	if err != nil {
 		return partitionKeys, err
 	}
-	taskListID, err := newTaskListID(namespaceID, taskList.GetName(), enumspb.TASK_LIST_TYPE_DECISION)
-	rootPartition := taskListID.GetRoot()
+	taskListID, err := newTaskListID(namespaceID, taskList.GetName(), taskListType)
+	if err != nil {
+		return partitionKeys, err
+	}
+	rootTaskListID, err := newTaskListID(namespaceID, taskListID.GetRoot(), taskListType)
+	if err != nil {
+		return partitionKeys, err
+	}
+	rootPartition := rootTaskListID.GetRoot()
 
 	partitionKeys = append(partitionKeys, rootPartition)
 
-	nWritePartitions := e.config.GetTasksBatchSize
-	n := nWritePartitions(namespace, rootPartition, taskListType)
-	if n <= 0 {
-		return partitionKeys, nil
+	// read partitions are a superset of write partitions, a partition is only
+	// dropped from the read partitions once its backlog is drained
+	n := common.MaxInt(1, e.config.NumTasklistReadPartitions(namespace, rootPartition, taskListType))
+	if e.config.EnablePartitionAutoScaling(namespace, rootPartition, taskListType) {
+		rootMgr, err := e.getTaskListManager(rootTaskListID, enumspb.TASK_LIST_KIND_NORMAL)
+		if err != nil {
+			return partitionKeys, err
+		}
+		n = int(rootMgr.GetPartitionConfig().GetNumReadPartitions())
 	}
 
 	for i := 1; i < n; i++ {
-		partitionKeys = append(partitionKeys, fmt.Sprintf("%v%v/%v", taskListPartitionPrefix, rootPartition, i))
+		partitionKeys = append(partitionKeys, rootTaskListID.mkName(i))
 	}
 
 	return partitionKeys, nil
 }
 
+func (e *matchingEngineImpl) GetTaskListPartitionConfig(
+	hCtx *handlerContext,
+	request *matchingservice.GetTaskListPartitionConfigRequest,
+) (*matchingservice.GetTaskListPartitionConfigResponse, error) {
+	taskListID, err := newTaskListID(request.GetNamespaceId(), request.TaskList.GetName(), request.GetTaskListType())
+	if err != nil {
+		return nil, err
+	}
+	if !taskListID.IsRoot() {
+		return nil, serviceerror.NewInvalidArgument("Partition config is only available on the root partition of a task list.")
+	}
+	tlMgr, err := e.getTaskListManager(taskListID, enumspb.TASK_LIST_KIND_NORMAL)
+	if err != nil {
+		return nil, err
+	}
+
+	partitionConfig := tlMgr.GetPartitionConfig()
+	return &matchingservice.GetTaskListPartitionConfigResponse{
+		NumReadPartitions:  partitionConfig.GetNumReadPartitions(),
+		NumWritePartitions: partitionConfig.GetNumWritePartitions(),
+	}, nil
+}
+
+// UpdateTaskListPauseState pauses or resumes dispatch of a task list. The pause state is persisted on
+// the root partition which then notifies all other partitions to refresh it. Partitions which miss the
+// notification pick up the new state on their next periodic refresh
+func (e *matchingEngineImpl) UpdateTaskListPauseState(
+	hCtx *handlerContext,
+	request *matchingservice.UpdateTaskListPauseStateRequest,
+) (*matchingservice.UpdateTaskListPauseStateResponse, error) {
+	taskListID, err := newTaskListID(request.GetNamespaceId(), request.TaskList.GetName(), request.GetTaskListType())
+	if err != nil {
+		return nil, err
+	}
+	tlMgr, err := e.getTaskListManager(taskListID, enumspb.TASK_LIST_KIND_NORMAL)
+	if err != nil {
+		return nil, err
+	}
+	if !taskListID.IsRoot() {
+		if err := tlMgr.RefreshPauseInfo(hCtx.Context); err != nil {
+			return nil, err
+		}
+		return &matchingservice.UpdateTaskListPauseStateResponse{}, nil
+	}
+
+	var pauseInfo *tasklistgenpb.TaskListPauseInfo
+	if request.GetPaused() {
+		pauseInfo = &tasklistgenpb.TaskListPauseInfo{
+			Reason:    request.GetReason(),
+			Identity:  request.GetIdentity(),
+			PauseTime: types.TimestampNow(),
+		}
+	}
+	if err := tlMgr.UpdatePauseInfo(pauseInfo); err != nil {
+		return nil, err
+	}
+
+	numPartitions := int(tlMgr.GetPartitionConfig().GetNumReadPartitions())
+	for p := 1; p < numPartitions; p++ {
+		partitionRequest := *request
+		partitionRequest.TaskList = &tasklistpb.TaskList{
+			Name: taskListID.mkName(p),
+			Kind: enumspb.TASK_LIST_KIND_NORMAL,
+		}
+		if _, err := e.matchingClient.UpdateTaskListPauseState(hCtx.Context, &partitionRequest); err != nil {
+			e.logger.Warn("Failed to notify task list partition of pause state update",
+				tag.WorkflowTaskListName(partitionRequest.TaskList.GetName()),
+				tag.Error(err))
+		}
+	}
+
+	e.logger.Info("Updated task list pause state",
+		tag.WorkflowNamespaceID(taskListID.namespaceID),
+		tag.WorkflowTaskListName(taskListID.name),
+		tag.WorkflowTaskListType(taskListID.taskType),
+		tag.Value(request.GetPaused()))
+	return &matchingservice.UpdateTaskListPauseStateResponse{}, nil
+}
+
 // Loads a task from persistence and wraps it in a task context
 func (e *matchingEngineImpl) getTask(
 	ctx context.Context, taskList *taskListID, maxDispatchPerSecond *float64, taskListKind enumspb.TaskListKind,
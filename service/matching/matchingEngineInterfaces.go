@@ -41,5 +41,7 @@ type (
 		CancelOutstandingPoll(hCtx *handlerContext, request *matchingservice.CancelOutstandingPollRequest) error
 		DescribeTaskList(hCtx *handlerContext, request *matchingservice.DescribeTaskListRequest) (*matchingservice.DescribeTaskListResponse, error)
 		ListTaskListPartitions(hCtx *handlerContext, request *matchingservice.ListTaskListPartitionsRequest) (*matchingservice.ListTaskListPartitionsResponse, error)
+		GetTaskListPartitionConfig(hCtx *handlerContext, request *matchingservice.GetTaskListPartitionConfigRequest) (*matchingservice.GetTaskListPartitionConfigResponse, error)
+		UpdateTaskListPauseState(hCtx *handlerContext, request *matchingservice.UpdateTaskListPauseStateRequest) (*matchingservice.UpdateTaskListPauseStateResponse, error)
 	}
 )
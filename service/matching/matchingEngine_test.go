@@ -52,7 +52,9 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/matchingservicemock/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/common"
@@ -1645,6 +1647,190 @@ func (s *matchingEngineSuite) TestTaskExpiryAndCompletion() {
 	}
 }
 
+func (s *matchingEngineSuite) TestGetTaskListPartitionConfig() {
+	namespaceID := uuid.NewRandom().String()
+	tl := "partition-config-tl0"
+	tlID := newTestTaskListID(namespaceID, tl, enumspb.TASK_LIST_TYPE_ACTIVITY)
+	request := &matchingservice.GetTaskListPartitionConfigRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: tl},
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+	}
+
+	s.matchingEngine.config.NumTasklistReadPartitions = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(3)
+	s.matchingEngine.config.NumTasklistWritePartitions = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(2)
+
+	// partition counts come from dynamic config until auto scaling is enabled
+	resp, err := s.matchingEngine.GetTaskListPartitionConfig(s.handlerContext, request)
+	s.NoError(err)
+	s.EqualValues(3, resp.GetNumReadPartitions())
+	s.EqualValues(2, resp.GetNumWritePartitions())
+
+	tlMgr, ok := s.matchingEngine.taskLists[*tlID].(*taskListManagerImpl)
+	s.True(ok, "failed to load task list")
+	s.NoError(tlMgr.db.UpdatePartitionConfig(&persistenceblobs.TaskListPartitionConfig{
+		NumReadPartitions:  8,
+		NumWritePartitions: 6,
+	}))
+
+	resp, err = s.matchingEngine.GetTaskListPartitionConfig(s.handlerContext, request)
+	s.NoError(err)
+	s.EqualValues(3, resp.GetNumReadPartitions())
+	s.EqualValues(2, resp.GetNumWritePartitions())
+
+	s.matchingEngine.config.EnablePartitionAutoScaling = dynamicconfig.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	resp, err = s.matchingEngine.GetTaskListPartitionConfig(s.handlerContext, request)
+	s.NoError(err)
+	s.EqualValues(8, resp.GetNumReadPartitions())
+	s.EqualValues(6, resp.GetNumWritePartitions())
+
+	// persisted partition config survives task list reload
+	s.matchingEngine.unloadTaskList(tlID)
+	resp, err = s.matchingEngine.GetTaskListPartitionConfig(s.handlerContext, request)
+	s.NoError(err)
+	s.EqualValues(8, resp.GetNumReadPartitions())
+	s.EqualValues(6, resp.GetNumWritePartitions())
+
+	partitionRequest := *request
+	partitionRequest.TaskList = &tasklistpb.TaskList{Name: tlID.mkName(1)}
+	_, err = s.matchingEngine.GetTaskListPartitionConfig(s.handlerContext, &partitionRequest)
+	s.Error(err)
+}
+
+func (s *matchingEngineSuite) TestUpdateTaskListPauseState() {
+	namespaceID := uuid.NewRandom().String()
+	tl := "pause-tl0"
+	tlID := newTestTaskListID(namespaceID, tl, enumspb.TASK_LIST_TYPE_ACTIVITY)
+	taskList := &tasklistpb.TaskList{Name: tl}
+	s.matchingEngine.config.RangeSize = 10
+
+	_, err := s.matchingEngine.UpdateTaskListPauseState(s.handlerContext, &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     taskList,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Paused:       true,
+		Reason:       "bad deployment",
+		Identity:     "operator",
+	})
+	s.NoError(err)
+
+	// tasks are still accepted and persisted while paused
+	workflowExecution := &commonpb.WorkflowExecution{RunId: uuid.NewRandom().String(), WorkflowId: "workflow1"}
+	_, err = s.matchingEngine.AddActivityTask(s.handlerContext, &matchingservice.AddActivityTaskRequest{
+		SourceNamespaceId:             namespaceID,
+		NamespaceId:                   namespaceID,
+		Execution:                     workflowExecution,
+		ScheduleId:                    1,
+		TaskList:                      taskList,
+		ScheduleToStartTimeoutSeconds: 100,
+	})
+	s.NoError(err)
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+
+	// pollers do not receive tasks while paused
+	pollResp, err := s.matchingEngine.PollForActivityTask(s.handlerContext, &matchingservice.PollForActivityTaskRequest{
+		NamespaceId: namespaceID,
+		PollerId:    "pollerID",
+		PollRequest: &workflowservice.PollForActivityTaskRequest{
+			TaskList: taskList,
+			Identity: "identity",
+		},
+	})
+	s.NoError(err)
+	s.Equal(emptyPollForActivityTaskResponse, pollResp)
+
+	// pause state survives task list reload and is reported by DescribeTaskList
+	s.matchingEngine.unloadTaskList(tlID)
+	descResp, err := s.matchingEngine.DescribeTaskList(s.handlerContext, &matchingservice.DescribeTaskListRequest{
+		NamespaceId: namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			TaskList:     taskList,
+			TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		},
+	})
+	s.NoError(err)
+	s.Equal("bad deployment", descResp.GetPauseInfo().GetReason())
+	s.Equal("operator", descResp.GetPauseInfo().GetIdentity())
+	tlMgr, ok := s.matchingEngine.taskLists[*tlID].(*taskListManagerImpl)
+	s.True(ok, "failed to load task list")
+	s.True(tlMgr.isPaused())
+
+	_, err = s.matchingEngine.UpdateTaskListPauseState(s.handlerContext, &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     taskList,
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Paused:       false,
+	})
+	s.NoError(err)
+	s.False(tlMgr.isPaused())
+	s.Nil(s.taskManager.getTaskListManager(tlID).pauseInfo)
+}
+
+func (s *matchingEngineSuite) TestUpdateTaskListPauseState_Partitions() {
+	namespaceID := uuid.NewRandom().String()
+	tl := "pause-tl1"
+	rootID := newTestTaskListID(namespaceID, tl, enumspb.TASK_LIST_TYPE_ACTIVITY)
+	partitionID := newTestTaskListID(namespaceID, rootID.mkName(1), enumspb.TASK_LIST_TYPE_ACTIVITY)
+	s.matchingEngine.config.NumTasklistReadPartitions = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(2)
+	mockMatchingClient := matchingservicemock.NewMockMatchingServiceClient(s.controller)
+	s.matchingEngine.matchingClient = mockMatchingClient
+
+	// partitions refresh the pause state from the root
+	mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(&matchingservice.DescribeTaskListResponse{
+		PauseInfo: &tasklistgenpb.TaskListPauseInfo{Reason: "bad deployment"},
+	}, nil).AnyTimes()
+	// a partition failing to be notified does not fail the update, it refreshes the pause state later
+	mockMatchingClient.EXPECT().UpdateTaskListPauseState(gomock.Any(), gomock.Any()).
+		Return(nil, serviceerror.NewUnavailable("partition unavailable")).Times(1)
+
+	_, err := s.matchingEngine.UpdateTaskListPauseState(s.handlerContext, &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: tl},
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+		Paused:       true,
+		Reason:       "bad deployment",
+	})
+	s.NoError(err)
+	s.NotNil(s.taskManager.getTaskListManager(rootID).pauseInfo)
+
+	// a partition loaded after the update follows the pause state of the root
+	_, err = s.matchingEngine.UpdateTaskListPauseState(s.handlerContext, &matchingservice.UpdateTaskListPauseStateRequest{
+		NamespaceId:  namespaceID,
+		TaskList:     &tasklistpb.TaskList{Name: partitionID.name},
+		TaskListType: enumspb.TASK_LIST_TYPE_ACTIVITY,
+	})
+	s.NoError(err)
+	tlMgr, ok := s.matchingEngine.taskLists[*partitionID].(*taskListManagerImpl)
+	s.True(ok, "failed to load task list")
+	s.True(tlMgr.isPaused())
+	s.Equal("bad deployment", tlMgr.PauseInfo().GetReason())
+	// the pause state is only persisted on the root
+	s.Nil(s.taskManager.getTaskListManager(partitionID).pauseInfo)
+}
+
+func (s *matchingEngineSuite) TestPartitionPauseState_HeldUntilRefreshed() {
+	namespaceID := uuid.NewRandom().String()
+	rootID := newTestTaskListID(namespaceID, "pause-tl2", enumspb.TASK_LIST_TYPE_ACTIVITY)
+	partitionID := newTestTaskListID(namespaceID, rootID.mkName(1), enumspb.TASK_LIST_TYPE_ACTIVITY)
+	mockMatchingClient := matchingservicemock.NewMockMatchingServiceClient(s.controller)
+	s.matchingEngine.matchingClient = mockMatchingClient
+
+	// the root partition is not reachable when the partition is loaded
+	mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).
+		Return(nil, serviceerror.NewUnavailable("root partition unavailable")).Times(1)
+	mgr, err := s.matchingEngine.getTaskListManager(partitionID, enumspb.TASK_LIST_KIND_NORMAL)
+	s.NoError(err)
+	tlMgr, ok := mgr.(*taskListManagerImpl)
+	s.True(ok, "failed to load task list")
+	s.True(tlMgr.isPaused())
+	s.Nil(tlMgr.PauseInfo())
+
+	mockMatchingClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).
+		Return(&matchingservice.DescribeTaskListResponse{}, nil).Times(1)
+	s.NoError(tlMgr.RefreshPauseInfo(context.Background()))
+	s.False(tlMgr.isPaused())
+}
+
 func (s *matchingEngineSuite) setupRecordActivityTaskStartedMock(tlName string) {
 	activityTypeName := "activity1"
 	activityID := "activityId1"
@@ -1749,6 +1935,9 @@ type testTaskListManager struct {
 	ackLevel        int64
 	createTaskCount int
 	tasks           *treemap.Map
+	partitionConfig *persistenceblobs.TaskListPartitionConfig
+	backlogCount    int64
+	pauseInfo       *tasklistgenpb.TaskListPauseInfo
 }
 
 func Int64Comparator(a, b interface{}) int {
@@ -1787,11 +1976,14 @@ func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListReques
 	return &persistence.LeaseTaskListResponse{
 		TaskListInfo: &persistence.PersistedTaskListInfo{
 			Data: &persistenceblobs.TaskListInfo{
-				AckLevel:    tlm.ackLevel,
-				NamespaceId: request.NamespaceID,
-				Name:        request.TaskList,
-				TaskType:    request.TaskType,
-				Kind:        request.TaskListKind,
+				AckLevel:                tlm.ackLevel,
+				NamespaceId:             request.NamespaceID,
+				Name:                    request.TaskList,
+				TaskType:                request.TaskType,
+				Kind:                    request.TaskListKind,
+				PartitionConfig:         tlm.partitionConfig,
+				ApproximateBacklogCount: tlm.backlogCount,
+				PauseInfo:               tlm.pauseInfo,
 			},
 			RangeID: tlm.rangeID,
 		},
@@ -1813,6 +2005,9 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.partitionConfig = tli.PartitionConfig
+	tlm.backlogCount = tli.ApproximateBacklogCount
+	tlm.pauseInfo = tli.PauseInfo
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
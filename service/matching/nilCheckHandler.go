@@ -124,3 +124,19 @@ func (h *NilCheckHandler) ListTaskListPartitions(ctx context.Context, request *m
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) GetTaskListPartitionConfig(ctx context.Context, request *matchingservice.GetTaskListPartitionConfigRequest) (*matchingservice.GetTaskListPartitionConfigResponse, error) {
+	resp, err := h.parentHandler.GetTaskListPartitionConfig(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.GetTaskListPartitionConfigResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpdateTaskListPauseState(ctx context.Context, request *matchingservice.UpdateTaskListPauseStateRequest) (*matchingservice.UpdateTaskListPauseStateResponse, error) {
+	resp, err := h.parentHandler.UpdateTaskListPauseState(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.UpdateTaskListPauseStateResponse{}
+	}
+	return resp, err
+}
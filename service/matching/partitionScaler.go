@@ -0,0 +1,240 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+const (
+	// partitionDrainGracePeriod is the minimum time to wait after the number of write partitions
+	// is reduced before the dropped partitions are considered for removal. It has to be longer
+	// than the refresh interval of the partition config cache of matching clients, so that no
+	// new tasks are written to a partition once it is found drained
+	partitionDrainGracePeriod = time.Minute
+	// partitionDescribeTimeout is the timeout for fetching the backlog of a single partition
+	partitionDescribeTimeout = 5 * time.Second
+)
+
+type (
+	// partitionScaler periodically re-evaluates the number of partitions of a task list
+	// based on the observed task rate and poller count. It only runs on the root partition
+	// which is the owner of the persisted partition config.
+	//
+	// Scaling up changes read and write partitions at once. Scaling down first reduces the
+	// write partitions by one and only reduces the read partitions after the backlog of the
+	// dropped partitions is drained, so that no task is stranded in a partition which is
+	// no longer polled.
+	partitionScaler struct {
+		tlMgr *taskListManagerImpl
+	}
+)
+
+func newPartitionScaler(tlMgr *taskListManagerImpl) *partitionScaler {
+	return &partitionScaler{
+		tlMgr: tlMgr,
+	}
+}
+
+func (s *partitionScaler) Start() {
+	go s.scaleLoop()
+}
+
+func (s *partitionScaler) scaleLoop() {
+	timer := time.NewTimer(s.tlMgr.config.PartitionAutoScalingInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.tlMgr.shutdownCh:
+			return
+		case <-timer.C:
+			// the task rates of a paused task list do not reflect its load
+			if s.tlMgr.config.EnablePartitionAutoScaling() && !s.tlMgr.isPaused() {
+				s.scale()
+			}
+			timer.Reset(s.tlMgr.config.PartitionAutoScalingInterval())
+		}
+	}
+}
+
+func (s *partitionScaler) scale() {
+	now := time.Now()
+	current := s.tlMgr.GetPartitionConfig()
+	rate := math.Max(s.tlMgr.stats.addRate.rate(), s.tlMgr.stats.dispatchRate.rate())
+	pollerCount := len(s.tlMgr.GetAllPollerInfo()) * int(current.GetNumReadPartitions())
+
+	desired := desiredPartitionCount(
+		rate*float64(current.GetNumWritePartitions()),
+		s.tlMgr.config.PartitionTargetRatePerSecond(),
+		s.tlMgr.config.MaxPartitions(),
+		pollerCount,
+	)
+
+	drained := false
+	if current.GetNumReadPartitions() > current.GetNumWritePartitions() {
+		drained = s.isDrainable(current, now) &&
+			s.partitionsDrained(int(current.GetNumWritePartitions()), int(current.GetNumReadPartitions()))
+	}
+
+	scope := s.tlMgr.metricScope()
+	next := nextPartitionConfig(current, desired, drained)
+	if next == nil {
+		s.emitPartitionGauges(scope, current)
+		return
+	}
+
+	next.LastUpdated, _ = types.TimestampProto(now)
+	_, err := s.tlMgr.executeWithRetry(func() (interface{}, error) {
+		return nil, s.tlMgr.db.UpdatePartitionConfig(next)
+	})
+	if err != nil {
+		s.tlMgr.logger.Error("Failed to update task list partition config", tag.Error(err))
+		s.emitPartitionGauges(scope, current)
+		return
+	}
+
+	if next.GetNumWritePartitions() > current.GetNumWritePartitions() {
+		scope.IncCounter(metrics.PartitionScaleUpPerTaskListCounter)
+	} else {
+		scope.IncCounter(metrics.PartitionScaleDownPerTaskListCounter)
+	}
+	s.tlMgr.logger.Info("Task list partition config updated",
+		tag.TaskListReadPartitions(next.GetNumReadPartitions()),
+		tag.TaskListWritePartitions(next.GetNumWritePartitions()))
+	s.emitPartitionGauges(scope, next)
+}
+
+// isDrainable returns true when enough time passed since write partitions were reduced
+// for all clients to stop writing to the dropped partitions
+func (s *partitionScaler) isDrainable(current *persistenceblobs.TaskListPartitionConfig, now time.Time) bool {
+	if current.GetLastUpdated() == nil {
+		return true
+	}
+	lastUpdated, err := types.TimestampFromProto(current.GetLastUpdated())
+	if err != nil {
+		return true
+	}
+	return now.Sub(lastUpdated) >= partitionDrainGracePeriod
+}
+
+// partitionsDrained returns true if all partitions in the range [from, to) have no backlog. A partition
+// is only drained once its reader reached the max read level, the backlog count alone does not account
+// for tasks which were written but not read yet
+func (s *partitionScaler) partitionsDrained(from int, to int) bool {
+	id := s.tlMgr.taskListID
+	for p := from; p < to; p++ {
+		ctx, cancel := context.WithTimeout(context.Background(), partitionDescribeTimeout)
+		resp, err := s.tlMgr.engine.matchingClient.DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+			NamespaceId: id.namespaceID,
+			DescRequest: &workflowservice.DescribeTaskListRequest{
+				TaskList: &tasklistpb.TaskList{
+					Name: id.mkName(p),
+					Kind: enumspb.TASK_LIST_KIND_NORMAL,
+				},
+				TaskListType:          id.taskType,
+				IncludeTaskListStatus: true,
+			},
+		})
+		cancel()
+		if err != nil {
+			s.tlMgr.logger.Warn("Failed to describe task list partition", tag.Error(err))
+			return false
+		}
+		if !partitionDrained(resp) {
+			return false
+		}
+	}
+	return true
+}
+
+func partitionDrained(resp *matchingservice.DescribeTaskListResponse) bool {
+	return resp.GetBacklogStats().GetApproximateBacklogCount() == 0 &&
+		resp.GetTaskListStatus().GetReadLevel() >= resp.GetMaxReadLevel()
+}
+
+func (s *partitionScaler) emitPartitionGauges(scope metrics.Scope, cfg *persistenceblobs.TaskListPartitionConfig) {
+	scope.UpdateGauge(metrics.ReadPartitionsPerTaskListGauge, float64(cfg.GetNumReadPartitions()))
+	scope.UpdateGauge(metrics.WritePartitionsPerTaskListGauge, float64(cfg.GetNumWritePartitions()))
+}
+
+// desiredPartitionCount returns the number of partitions needed to serve the given rate,
+// bounded by the max number of partitions and the number of pollers
+func desiredPartitionCount(ratePerSecond float64, targetRatePerSecond int, maxPartitions int, pollerCount int) int {
+	desired := int(math.Ceil(ratePerSecond / float64(common.MaxInt(1, targetRatePerSecond))))
+	desired = common.MinInt(desired, maxPartitions)
+	// partitions without pollers only add dispatch latency
+	desired = common.MinInt(desired, common.MaxInt(1, pollerCount))
+	return common.MaxInt(1, desired)
+}
+
+// nextPartitionConfig returns the partition config to transition to given the current config,
+// the desired number of partitions and whether the partitions which are no longer written
+// to are drained. Returns nil when no change is needed.
+func nextPartitionConfig(
+	current *persistenceblobs.TaskListPartitionConfig,
+	desired int,
+	drained bool,
+) *persistenceblobs.TaskListPartitionConfig {
+	numRead := int(current.GetNumReadPartitions())
+	numWrite := int(current.GetNumWritePartitions())
+
+	switch {
+	case desired > numWrite:
+		return &persistenceblobs.TaskListPartitionConfig{
+			NumReadPartitions:  int32(common.MaxInt(numRead, desired)),
+			NumWritePartitions: int32(desired),
+		}
+	case numRead > numWrite:
+		if !drained {
+			return nil
+		}
+		return &persistenceblobs.TaskListPartitionConfig{
+			NumReadPartitions:  int32(numWrite),
+			NumWritePartitions: int32(numWrite),
+		}
+	case desired < numWrite:
+		// scale down one partition at a time so the backlog is drained gradually
+		return &persistenceblobs.TaskListPartitionConfig{
+			NumReadPartitions:  int32(numRead),
+			NumWritePartitions: int32(numWrite - 1),
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,125 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
+)
+
+func TestDesiredPartitionCount(t *testing.T) {
+	testCases := []struct {
+		name        string
+		rate        float64
+		target      int
+		max         int
+		pollerCount int
+		output      int
+	}{
+		{"idle", 0, 500, 32, 10, 1},
+		{"below target", 499, 500, 32, 10, 1},
+		{"above target", 501, 500, 32, 10, 2},
+		{"capped by max", 100000, 500, 32, 100, 32},
+		{"capped by pollers", 5000, 500, 32, 4, 4},
+		{"no pollers", 5000, 500, 32, 0, 1},
+		{"invalid target", 10, 0, 32, 100, 10},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.output, desiredPartitionCount(tc.rate, tc.target, tc.max, tc.pollerCount))
+		})
+	}
+}
+
+func TestNextPartitionConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		read          int32
+		write         int32
+		desired       int
+		drained       bool
+		noChange      bool
+		expectedRead  int32
+		expectedWrite int32
+	}{
+		{name: "unchanged", read: 4, write: 4, desired: 4, noChange: true},
+		{name: "scale up", read: 4, write: 4, desired: 8, expectedRead: 8, expectedWrite: 8},
+		{name: "scale up while draining", read: 4, write: 2, desired: 3, expectedRead: 4, expectedWrite: 3},
+		{name: "scale up beyond draining", read: 4, write: 2, desired: 6, expectedRead: 6, expectedWrite: 6},
+		{name: "scale down write", read: 4, write: 4, desired: 1, expectedRead: 4, expectedWrite: 3},
+		{name: "wait for drain", read: 4, write: 3, desired: 1, drained: false, noChange: true},
+		{name: "drained", read: 4, write: 3, desired: 1, drained: true, expectedRead: 3, expectedWrite: 3},
+		{name: "min partitions", read: 1, write: 1, desired: 1, noChange: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			current := &persistenceblobs.TaskListPartitionConfig{
+				NumReadPartitions:  tc.read,
+				NumWritePartitions: tc.write,
+			}
+			next := nextPartitionConfig(current, tc.desired, tc.drained)
+			if tc.noChange {
+				require.Nil(t, next)
+				return
+			}
+			require.NotNil(t, next)
+			require.Equal(t, tc.expectedRead, next.GetNumReadPartitions())
+			require.Equal(t, tc.expectedWrite, next.GetNumWritePartitions())
+		})
+	}
+}
+
+func TestPartitionDrained(t *testing.T) {
+	testCases := []struct {
+		name         string
+		backlogCount int64
+		readLevel    int64
+		maxReadLevel int64
+		output       bool
+	}{
+		{"drained", 0, 10, 10, true},
+		{"backlog", 1, 10, 10, false},
+		{"unread tasks", 0, 5, 10, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &matchingservice.DescribeTaskListResponse{
+				TaskListStatus: &tasklistpb.TaskListStatus{ReadLevel: tc.readLevel},
+				BacklogStats:   &tasklistgenpb.TaskListBacklogStats{ApproximateBacklogCount: tc.backlogCount},
+				MaxReadLevel:   tc.maxReadLevel,
+			}
+			require.Equal(t, tc.output, partitionDrained(resp))
+		})
+	}
+}
@@ -43,6 +43,8 @@ type (
 
 	pollerInfo struct {
 		ratePerSecond float64
+		// labels advertised by the poller, only set for activity pollers
+		labels map[string]string
 	}
 )
 
@@ -64,12 +66,24 @@ func newPollerHistory() *pollerHistory {
 	}
 }
 
-func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, ratePerSecond *float64) {
+func (pollers *pollerHistory) updatePollerInfo(id pollerIdentity, ratePerSecond *float64, labels map[string]string) {
 	rps := _defaultTaskDispatchRPS
 	if ratePerSecond != nil {
 		rps = *ratePerSecond
 	}
-	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps})
+	pollers.history.Put(id, &pollerInfo{ratePerSecond: rps, labels: labels})
+}
+
+// hasPoller returns true if any poller seen in the last few minutes has labels which satisfy the selector
+func (pollers *pollerHistory) hasPoller(selector map[string]string) bool {
+	ite := pollers.history.Iterator()
+	defer ite.Close()
+	for ite.HasNext() {
+		if labelsMatch(selector, ite.Next().Value().(*pollerInfo).labels) {
+			return true
+		}
+	}
+	return false
 }
 
 func (pollers *pollerHistory) getAllPollerInfo() []*tasklistpb.PollerInfo {
@@ -25,6 +25,8 @@
 package matching
 
 import (
+	"time"
+
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
@@ -61,6 +63,10 @@ type (
 		forwardedFrom    string     // name of the child partition this task is forwarded from (empty if not forwarded)
 		responseC        chan error // non-nil only where there is a caller waiting for response (sync-match)
 		backlogCountHint int64
+		// labelSelector is only set while the task may only be handed to pollers with matching
+		// labels, when labelSelectorExpiry passes the task is handed to any poller
+		labelSelector       map[string]string
+		labelSelectorExpiry time.Time
 	}
 )
 
@@ -33,17 +33,22 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
 
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
@@ -57,6 +62,14 @@ const (
 
 	// Fake Task ID to wrap a task for syncmatch
 	syncMatchTaskId = -137
+
+	// pauseInfoRefreshInterval is the interval at which partitions other than the root
+	// refresh the pause state owned by the root partition
+	pauseInfoRefreshInterval = time.Minute
+	// pauseInfoRefreshTimeout is the timeout for fetching the pause state from the root partition
+	pauseInfoRefreshTimeout = 5 * time.Second
+	// pauseInfoRefreshRetryInterval is the initial backoff of retries of failed pause state refreshes
+	pauseInfoRefreshRetryInterval = time.Second
 )
 
 type (
@@ -88,6 +101,13 @@ type (
 		GetAllPollerInfo() []*tasklistpb.PollerInfo
 		// DescribeTaskList returns information about the target task list
 		DescribeTaskList(includeTaskListStatus bool) *matchingservice.DescribeTaskListResponse
+		// GetPartitionConfig returns the number of read and write partitions of the task list
+		GetPartitionConfig() *persistenceblobs.TaskListPartitionConfig
+		// UpdatePauseInfo persists the pause state of the task list on the root partition. While paused,
+		// tasks are still accepted and persisted but not dispatched to pollers. A nil pauseInfo resumes dispatch
+		UpdatePauseInfo(pauseInfo *tasklistgenpb.TaskListPauseInfo) error
+		// RefreshPauseInfo fetches the pause state from the root partition, used by all other partitions
+		RefreshPauseInfo(ctx context.Context) error
 		String() string
 	}
 
@@ -117,6 +137,15 @@ type (
 		// prevent tasks being dispatched to zombie pollers.
 		outstandingPollsLock sync.Mutex
 		outstandingPollsMap  map[string]context.CancelFunc
+		// stats tracks the approximate backlog and task rates of this partition
+		stats *taskListStats
+		// pauseInfo and resumeC are set while dispatch of the task list is paused, resumeC is
+		// closed when it is resumed. Both are guarded by pauseLock. The pause state is owned by
+		// the root partition, all other partitions follow it and hold dispatch back as if paused,
+		// with resumeC set but no pauseInfo, until they fetched it once
+		pauseLock sync.Mutex
+		pauseInfo *tasklistgenpb.TaskListPauseInfo
+		resumeC   chan struct{}
 
 		shutdownCh chan struct{}  // Delivers stop to the pump that populates taskBuffer
 		startWG    sync.WaitGroup // ensures that background processes do not start until setup is ready
@@ -162,6 +191,7 @@ func newTaskListManager(
 		config:              taskListConfig,
 		pollerHistory:       newPollerHistory(),
 		outstandingPollsMap: make(map[string]context.CancelFunc),
+		stats:               newTaskListStats(clock.NewRealTimeSource()),
 	}
 
 	tlMgr.namespaceValue.Store("")
@@ -200,8 +230,25 @@ func (c *taskListManagerImpl) Start() error {
 	}
 
 	c.taskAckManager.setAckLevel(state.ackLevel)
+	c.stats.resetBacklog(state.approximateBacklogCount)
+	if c.taskListID.IsRoot() {
+		c.setPauseInfo(c.db.PauseInfo())
+	} else {
+		// do not dispatch tasks of a paused task list before the pause state is known
+		c.holdDispatch()
+		ctx, cancel := context.WithTimeout(context.Background(), pauseInfoRefreshTimeout)
+		err := c.refreshPauseInfo(ctx)
+		if err != nil {
+			c.logger.Warn("Failed to refresh task list pause state, dispatch is held until it is refreshed", tag.Error(err))
+		}
+		cancel()
+		go c.refreshPauseInfoLoop(err == nil)
+	}
 	c.taskWriter.Start(c.rangeIDToTaskIDBlock(state.rangeID))
 	c.taskReader.Start()
+	if c.taskListID.IsRoot() && c.taskListKind == enumspb.TASK_LIST_KIND_NORMAL {
+		newPartitionScaler(c).Start()
+	}
 
 	return nil
 }
@@ -239,6 +286,15 @@ func (c *taskListManagerImpl) AddTask(ctx context.Context, params addTaskParams)
 			return r, err
 		}
 
+		if c.isPaused() {
+			syncMatch = false
+			if params.forwardedFrom != "" {
+				// child partition will persist the task
+				return &persistence.CreateTasksResponse{}, errRemoteSyncMatchFailed
+			}
+			return c.taskWriter.appendTask(params.execution, td)
+		}
+
 		syncMatch, err = c.trySyncMatch(ctx, params)
 		if syncMatch {
 			return &persistence.CreateTasksResponse{}, err
@@ -254,6 +310,9 @@ func (c *taskListManagerImpl) AddTask(ctx context.Context, params addTaskParams)
 	})
 	if err == nil {
 		c.taskReader.Signal()
+		if params.forwardedFrom == "" {
+			c.stats.recordAdd()
+		}
 	}
 	return syncMatch, err
 }
@@ -291,6 +350,10 @@ func (c *taskListManagerImpl) GetTask(
 	}
 	task.namespace = c.namespace()
 	task.backlogCountHint = c.taskAckManager.getBacklogCountHint()
+	// started tasks were dispatched by a parent partition and are accounted for there
+	if !task.isQuery() && !task.isStarted() {
+		c.stats.recordDispatch()
+	}
 	return task, nil
 }
 
@@ -318,7 +381,8 @@ func (c *taskListManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 
 	identity, ok := ctx.Value(identityKey).(string)
 	if ok && identity != "" {
-		c.pollerHistory.updatePollerInfo(pollerIdentity(identity), maxDispatchPerSecond)
+		labels, _ := ctx.Value(pollerLabelsKey).(map[string]string)
+		c.pollerHistory.updatePollerInfo(pollerIdentity(identity), maxDispatchPerSecond, labels)
 	}
 
 	namespaceEntry, err := c.namespaceCache.GetNamespaceByID(c.taskListID.namespaceID)
@@ -333,7 +397,7 @@ func (c *taskListManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 	// value. Last poller wins if different pollers provide different values
 	c.matcher.UpdateRatelimit(maxDispatchPerSecond)
 
-	if namespaceEntry.GetNamespaceNotActiveErr() != nil {
+	if namespaceEntry.GetNamespaceNotActiveErr() != nil || c.isPaused() {
 		return c.matcher.PollForQuery(childCtx)
 	}
 
@@ -356,10 +420,13 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 }
 
 // DescribeTaskList returns information about the target tasklist, right now this API returns the
-// pollers which polled this tasklist in last few minutes and status of tasklist's ackManager
-// (readLevel, ackLevel, backlogCountHint and taskIDBlock).
+// pollers which polled this tasklist in last few minutes, its pause state, status of tasklist's
+// ackManager (readLevel, ackLevel, backlogCountHint and taskIDBlock) and the approximate backlog stats.
 func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *matchingservice.DescribeTaskListResponse {
-	response := &matchingservice.DescribeTaskListResponse{Pollers: c.GetAllPollerInfo()}
+	response := &matchingservice.DescribeTaskListResponse{
+		Pollers:   c.GetAllPollerInfo(),
+		PauseInfo: c.PauseInfo(),
+	}
 	if !includeTaskListStatus {
 		return response
 	}
@@ -375,10 +442,114 @@ func (c *taskListManagerImpl) DescribeTaskList(includeTaskListStatus bool) *matc
 			EndId:   taskIDBlock.end,
 		},
 	}
+	response.BacklogStats = c.stats.toProto()
+	response.MaxReadLevel = c.taskWriter.GetMaxReadLevel()
 
 	return response
 }
 
+// GetPartitionConfig returns the number of read and write partitions of the task list. The persisted
+// partition config is only used when partition auto scaling is enabled, otherwise the partition
+// counts come from dynamic config.
+func (c *taskListManagerImpl) GetPartitionConfig() *persistenceblobs.TaskListPartitionConfig {
+	if c.config.EnablePartitionAutoScaling() {
+		if partitionConfig := c.db.PartitionConfig(); partitionConfig != nil {
+			return partitionConfig
+		}
+	}
+	return &persistenceblobs.TaskListPartitionConfig{
+		NumReadPartitions:  int32(c.config.NumReadPartitions()),
+		NumWritePartitions: int32(c.config.NumWritePartitions()),
+	}
+}
+
+// UpdatePauseInfo persists the pause state of the task list on the root partition. While paused, tasks
+// are still accepted and persisted but not dispatched to pollers, only query tasks are. A nil pauseInfo
+// resumes dispatch. All other partitions follow the pause state of the root, see RefreshPauseInfo.
+func (c *taskListManagerImpl) UpdatePauseInfo(pauseInfo *tasklistgenpb.TaskListPauseInfo) error {
+	if !c.taskListID.IsRoot() {
+		return serviceerror.NewInvalidArgument("Pause state can only be updated on the root partition of a task list.")
+	}
+	c.startWG.Wait()
+	_, err := c.executeWithRetry(func() (interface{}, error) {
+		return nil, c.db.UpdatePauseInfo(pauseInfo)
+	})
+	if err != nil {
+		return err
+	}
+	c.setPauseInfo(pauseInfo)
+	return nil
+}
+
+// RefreshPauseInfo fetches the pause state from the root partition. Partitions refresh it periodically
+// and whenever the root partition notifies them of an update, so that partitions which are not loaded
+// or not reachable when the pause state is updated pick it up as well.
+func (c *taskListManagerImpl) RefreshPauseInfo(ctx context.Context) error {
+	c.startWG.Wait()
+	return c.refreshPauseInfo(ctx)
+}
+
+func (c *taskListManagerImpl) refreshPauseInfo(ctx context.Context) error {
+	if c.taskListID.IsRoot() {
+		return nil
+	}
+	resp, err := c.engine.matchingClient.DescribeTaskList(ctx, &matchingservice.DescribeTaskListRequest{
+		NamespaceId: c.taskListID.namespaceID,
+		DescRequest: &workflowservice.DescribeTaskListRequest{
+			TaskList: &tasklistpb.TaskList{
+				Name: c.taskListID.GetRoot(),
+				Kind: enumspb.TASK_LIST_KIND_NORMAL,
+			},
+			TaskListType: c.taskListID.taskType,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	c.setPauseInfo(resp.GetPauseInfo())
+	return nil
+}
+
+// refreshPauseInfoLoop refreshes the pause state periodically, failed refreshes are retried with backoff
+func (c *taskListManagerImpl) refreshPauseInfoLoop(refreshed bool) {
+	retryPolicy := backoff.NewExponentialRetryPolicy(pauseInfoRefreshRetryInterval)
+	retryPolicy.SetMaximumInterval(pauseInfoRefreshInterval)
+	retryPolicy.SetExpirationInterval(backoff.NoInterval)
+	retrier := backoff.NewRetrier(retryPolicy, backoff.SystemClock)
+
+	interval := pauseInfoRefreshInterval
+	if !refreshed {
+		interval = retrier.NextBackOff()
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.shutdownCh:
+			return
+		case <-timer.C:
+			interval = pauseInfoRefreshInterval
+			ctx, cancel := context.WithTimeout(context.Background(), pauseInfoRefreshTimeout)
+			if err := c.refreshPauseInfo(ctx); err != nil {
+				c.logger.Warn("Failed to refresh task list pause state", tag.Error(err))
+				interval = retrier.NextBackOff()
+			} else {
+				retrier.Reset()
+			}
+			cancel()
+			timer.Reset(interval)
+		}
+	}
+}
+
+// PauseInfo returns the pause state of the task list, nil if dispatch is not paused
+func (c *taskListManagerImpl) PauseInfo() *tasklistgenpb.TaskListPauseInfo {
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	return c.pauseInfo
+}
+
 func (c *taskListManagerImpl) String() string {
 	buf := new(bytes.Buffer)
 	if c.taskListID.taskType == enumspb.TASK_LIST_TYPE_ACTIVITY {
@@ -396,6 +567,62 @@ func (c *taskListManagerImpl) String() string {
 	return buf.String()
 }
 
+func (c *taskListManagerImpl) setPauseInfo(pauseInfo *tasklistgenpb.TaskListPauseInfo) {
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	c.pauseInfo = pauseInfo
+	switch {
+	case pauseInfo != nil && c.resumeC == nil:
+		c.resumeC = make(chan struct{})
+		// outstanding polls are already waiting for regular tasks, return them
+		// empty so that they poll again and only wait for query tasks
+		c.cancelOutstandingPolls()
+	case pauseInfo == nil && c.resumeC != nil:
+		close(c.resumeC)
+		c.resumeC = nil
+	}
+}
+
+// holdDispatch stops dispatch as if the task list were paused, until the pause state is set
+func (c *taskListManagerImpl) holdDispatch() {
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	if c.resumeC == nil {
+		c.resumeC = make(chan struct{})
+	}
+}
+
+func (c *taskListManagerImpl) isPaused() bool {
+	c.pauseLock.Lock()
+	defer c.pauseLock.Unlock()
+	return c.resumeC != nil
+}
+
+// waitUntilResumed blocks while dispatch of the task list is paused.
+// Returns error only when the context is canceled
+func (c *taskListManagerImpl) waitUntilResumed(ctx context.Context) error {
+	c.pauseLock.Lock()
+	resumeC := c.resumeC
+	c.pauseLock.Unlock()
+	if resumeC == nil {
+		return nil
+	}
+	select {
+	case <-resumeC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *taskListManagerImpl) cancelOutstandingPolls() {
+	c.outstandingPollsLock.Lock()
+	defer c.outstandingPollsLock.Unlock()
+	for _, cancel := range c.outstandingPollsMap {
+		cancel()
+	}
+}
+
 // completeTask marks a task as processed. Only tasks created by taskReader (i.e. backlog from db) reach
 // here. As part of completion:
 //   - task is deleted from the database when err is nil
@@ -430,6 +657,7 @@ func (c *taskListManagerImpl) completeTask(task *persistenceblobs.AllocatedTaskI
 	}
 
 	ackLevel := c.taskAckManager.completeTask(task.GetTaskId())
+	c.stats.recordRemoved()
 	c.taskGC.Run(ackLevel)
 }
 
@@ -505,11 +733,32 @@ func (c *taskListManagerImpl) trySyncMatch(ctx context.Context, params addTaskPa
 	}
 
 	task := newInternalTask(fakeTaskIdWrapper, c.completeTask, params.source, params.forwardedFrom, true)
+	c.applyLabelSelector(task)
 	matched, err := c.matcher.Offer(childCtx, task)
 	cancel()
 	return matched, err
 }
 
+// applyLabelSelector restricts the task to pollers with labels matching its label selector. The selector
+// is dropped once it timed out, or right away when no poller seen recently is able to satisfy it
+func (c *taskListManagerImpl) applyLabelSelector(task *internalTask) {
+	selector := task.event.Data.GetLabelSelector()
+	if len(selector) == 0 {
+		return
+	}
+	createdTime, err := types.TimestampFromProto(task.event.Data.GetCreatedTime())
+	if err != nil {
+		return
+	}
+	expiry := createdTime.Add(c.config.LabelSelectorTimeout())
+	if time.Now().After(expiry) || !c.pollerHistory.hasPoller(selector) {
+		c.metricScope().IncCounter(metrics.LabelSelectorFallbackPerTaskListCounter)
+		return
+	}
+	task.labelSelector = selector
+	task.labelSelectorExpiry = expiry
+}
+
 // newChildContext creates a child context with desired timeout.
 // if tailroom is non-zero, then child context timeout will be
 // the minOf(parentCtx.Deadline()-tailroom, timeout). Use this
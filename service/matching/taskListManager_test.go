@@ -92,6 +92,52 @@ func TestDeliverBufferTasks_NoPollers(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDeliverBufferTasks_LabelSelectorDoesNotBlockBacklog(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	tlm := createTestTaskListManager(controller)
+	labels := map[string]string{"gpu": "true"}
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity("labeled-poller"), nil, labels)
+	tlm.taskReader.taskBuffer <- &persistenceblobs.AllocatedTaskInfo{
+		Data: &persistenceblobs.TaskInfo{
+			CreatedTime:   timestamp.TimestampNow().ToProto(),
+			LabelSelector: labels,
+		},
+		TaskId: 1,
+	}
+	tlm.taskReader.taskBuffer <- &persistenceblobs.AllocatedTaskInfo{
+		Data: &persistenceblobs.TaskInfo{
+			CreatedTime: timestamp.TimestampNow().ToProto(),
+		},
+		TaskId: 2,
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		tlm.taskReader.dispatchBufferedTasks()
+		wg.Done()
+	}()
+
+	// the task behind the labeled task is dispatched to a poller without labels
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	task, err := tlm.matcher.Poll(ctx)
+	cancel()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), task.event.GetTaskId())
+
+	// the labeled task waits for a poller with matching labels
+	ctx, cancel = context.WithTimeout(context.WithValue(context.Background(), pollerLabelsKey, labels), time.Second)
+	task, err = tlm.matcher.Poll(ctx)
+	cancel()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), task.event.GetTaskId())
+
+	tlm.taskReader.cancelFunc()
+	close(tlm.taskReader.dispatcherShutdownC)
+	wg.Wait()
+}
+
 func TestReadLevelForAllExpiredTasksInBatch(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -206,8 +252,13 @@ func TestDescribeTaskList(t *testing.T) {
 	descResp := tlm.DescribeTaskList(includeTaskStatus)
 	require.Equal(t, 0, len(descResp.GetPollers()))
 	require.Nil(t, descResp.GetTaskListStatus())
+	require.Nil(t, descResp.GetBacklogStats())
 
 	includeTaskStatus = true
+	tlm.stats.recordPersisted(int(taskCount))
+	backlogStats := tlm.DescribeTaskList(includeTaskStatus).GetBacklogStats()
+	require.NotNil(t, backlogStats)
+	require.Equal(t, taskCount, backlogStats.GetApproximateBacklogCount())
 	taskListStatus := tlm.DescribeTaskList(includeTaskStatus).GetTaskListStatus()
 	require.NotNil(t, taskListStatus)
 	require.Zero(t, taskListStatus.GetAckLevel())
@@ -220,7 +271,7 @@ func TestDescribeTaskList(t *testing.T) {
 	require.Equal(t, tlm.config.RangeSize, taskIDBlock.GetEndId())
 
 	// Add a poller and complete all tasks
-	tlm.pollerHistory.updatePollerInfo(pollerIdentity(PollerIdentity), nil)
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity(PollerIdentity), nil, nil)
 	for i := int64(0); i < taskCount; i++ {
 		tlm.taskAckManager.completeTask(startTaskID + i)
 	}
@@ -232,7 +283,7 @@ func TestDescribeTaskList(t *testing.T) {
 	require.True(t, descResp.Pollers[0].GetRatePerSecond() > (_defaultTaskDispatchRPS-1))
 
 	rps := 5.0
-	tlm.pollerHistory.updatePollerInfo(pollerIdentity(PollerIdentity), &rps)
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity(PollerIdentity), &rps, nil)
 	descResp = tlm.DescribeTaskList(includeTaskStatus)
 	require.Equal(t, 1, len(descResp.GetPollers()))
 	require.Equal(t, PollerIdentity, descResp.Pollers[0].GetIdentity())
@@ -266,7 +317,7 @@ func TestCheckIdleTaskList(t *testing.T) {
 
 	// Active poll-er
 	tlm = createTestTaskListManagerWithConfig(controller, cfg)
-	tlm.pollerHistory.updatePollerInfo(pollerIdentity("test-poll"), nil)
+	tlm.pollerHistory.updatePollerInfo(pollerIdentity("test-poll"), nil, nil)
 	require.Equal(t, 1, len(tlm.GetAllPollerInfo()))
 	tlMgrStartWithoutNotifyEvent(tlm)
 	time.Sleep(20 * time.Millisecond)
@@ -0,0 +1,204 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+const (
+	// taskRateWindow is the window over which add and dispatch rates of a task list are computed
+	taskRateWindow = time.Minute
+)
+
+type (
+	// taskListStats tracks the approximate backlog and the task rates of a task list partition.
+	// The backlog count is incremented by the taskWriter when tasks are persisted and decremented
+	// when backlog tasks are completed or dropped by the taskReader.
+	taskListStats struct {
+		timeSource clock.TimeSource
+		// backlogCount is the approximate number of persisted tasks not yet completed
+		backlogCount int64
+		// oldestTaskCreateTime is the create time in unix nanos of the oldest task read from
+		// the backlog which is not yet dispatched, 0 if unknown
+		oldestTaskCreateTime int64
+		addRate              *windowedRate
+		dispatchRate         *windowedRate
+	}
+
+	// windowedRate approximates the rate of events over a sliding window by weighting
+	// the count of the previous fixed window with its overlap with the sliding window
+	windowedRate struct {
+		sync.Mutex
+		timeSource  clock.TimeSource
+		window      time.Duration
+		windowStart time.Time
+		current     int64
+		previous    int64
+	}
+)
+
+func newTaskListStats(timeSource clock.TimeSource) *taskListStats {
+	return &taskListStats{
+		timeSource:   timeSource,
+		addRate:      newWindowedRate(timeSource, taskRateWindow),
+		dispatchRate: newWindowedRate(timeSource, taskRateWindow),
+	}
+}
+
+func (s *taskListStats) recordAdd() {
+	s.addRate.add(1)
+}
+
+func (s *taskListStats) recordDispatch() {
+	s.dispatchRate.add(1)
+}
+
+// recordPersisted is called when tasks are written to the backlog
+func (s *taskListStats) recordPersisted(count int) {
+	atomic.AddInt64(&s.backlogCount, int64(count))
+}
+
+// recordRemoved is called when a task is removed from the backlog
+func (s *taskListStats) recordRemoved() {
+	if atomic.AddInt64(&s.backlogCount, -1) < 0 {
+		atomic.StoreInt64(&s.backlogCount, 0)
+	}
+}
+
+// recordBacklogHead is called with the task at the head of the backlog when it is dispatched
+func (s *taskListStats) recordBacklogHead(task *persistenceblobs.AllocatedTaskInfo) {
+	createTime, err := types.TimestampFromProto(task.Data.GetCreatedTime())
+	if err != nil {
+		return
+	}
+	atomic.StoreInt64(&s.oldestTaskCreateTime, createTime.UnixNano())
+}
+
+// resetBacklog sets the backlog count, used when the backlog count is loaded from persistence
+// or when the taskReader knows the exact number of tasks left in the backlog
+func (s *taskListStats) resetBacklog(count int64) {
+	atomic.StoreInt64(&s.backlogCount, count)
+	if count == 0 {
+		atomic.StoreInt64(&s.oldestTaskCreateTime, 0)
+	}
+}
+
+func (s *taskListStats) approximateBacklogCount() int64 {
+	return atomic.LoadInt64(&s.backlogCount)
+}
+
+func (s *taskListStats) approximateBacklogAge() time.Duration {
+	createTime := atomic.LoadInt64(&s.oldestTaskCreateTime)
+	if createTime == 0 || s.approximateBacklogCount() == 0 {
+		return 0
+	}
+	age := s.timeSource.Now().Sub(time.Unix(0, createTime))
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+func (s *taskListStats) toProto() *tasklistgenpb.TaskListBacklogStats {
+	return &tasklistgenpb.TaskListBacklogStats{
+		ApproximateBacklogCount:    s.approximateBacklogCount(),
+		ApproximateBacklogAgeNanos: s.approximateBacklogAge().Nanoseconds(),
+		AddTasksPerSecond:          s.addRate.rate(),
+		DispatchTasksPerSecond:     s.dispatchRate.rate(),
+	}
+}
+
+func (s *taskListStats) emit(scope metrics.Scope) {
+	scope.UpdateGauge(metrics.ApproximateBacklogCountPerTaskListGauge, float64(s.approximateBacklogCount()))
+	scope.UpdateGauge(metrics.ApproximateBacklogAgePerTaskListGauge, s.approximateBacklogAge().Seconds())
+	scope.UpdateGauge(metrics.AddTaskRatePerTaskListGauge, s.addRate.rate())
+	scope.UpdateGauge(metrics.DispatchTaskRatePerTaskListGauge, s.dispatchRate.rate())
+}
+
+// mergeBacklogStats aggregates backlog stats of multiple partitions of a task list
+func mergeBacklogStats(stats ...*tasklistgenpb.TaskListBacklogStats) *tasklistgenpb.TaskListBacklogStats {
+	result := &tasklistgenpb.TaskListBacklogStats{}
+	for _, s := range stats {
+		if s == nil {
+			continue
+		}
+		result.ApproximateBacklogCount += s.GetApproximateBacklogCount()
+		result.AddTasksPerSecond += s.GetAddTasksPerSecond()
+		result.DispatchTasksPerSecond += s.GetDispatchTasksPerSecond()
+		if s.GetApproximateBacklogAgeNanos() > result.ApproximateBacklogAgeNanos {
+			result.ApproximateBacklogAgeNanos = s.GetApproximateBacklogAgeNanos()
+		}
+	}
+	return result
+}
+
+func newWindowedRate(timeSource clock.TimeSource, window time.Duration) *windowedRate {
+	return &windowedRate{
+		timeSource:  timeSource,
+		window:      window,
+		windowStart: timeSource.Now(),
+	}
+}
+
+func (r *windowedRate) add(count int64) {
+	r.Lock()
+	defer r.Unlock()
+	r.rotate(r.timeSource.Now())
+	r.current += count
+}
+
+// rate returns the number of events per second over the last window
+func (r *windowedRate) rate() float64 {
+	r.Lock()
+	defer r.Unlock()
+	now := r.timeSource.Now()
+	r.rotate(now)
+	overlap := 1 - float64(now.Sub(r.windowStart))/float64(r.window)
+	return (float64(r.previous)*overlap + float64(r.current)) / r.window.Seconds()
+}
+
+func (r *windowedRate) rotate(now time.Time) {
+	elapsed := now.Sub(r.windowStart)
+	switch {
+	case elapsed >= 2*r.window:
+		r.previous = 0
+		r.current = 0
+		r.windowStart = now
+	case elapsed >= r.window:
+		r.previous = r.current
+		r.current = 0
+		r.windowStart = r.windowStart.Add(r.window)
+	}
+}
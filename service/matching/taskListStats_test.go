@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
+	"github.com/temporalio/temporal/common/clock"
+)
+
+func TestWindowedRate(t *testing.T) {
+	now := time.Now()
+	timeSource := clock.NewEventTimeSource().Update(now)
+	rate := newWindowedRate(timeSource, time.Minute)
+	require.Zero(t, rate.rate())
+
+	rate.add(60)
+	require.InDelta(t, 1.0, rate.rate(), 0.001)
+
+	// the previous window is weighted by its overlap with the sliding window
+	timeSource.Update(now.Add(90 * time.Second))
+	require.InDelta(t, 0.5, rate.rate(), 0.001)
+
+	rate.add(30)
+	require.InDelta(t, 1.0, rate.rate(), 0.001)
+
+	// counts older than two windows are dropped
+	timeSource.Update(now.Add(5 * time.Minute))
+	require.Zero(t, rate.rate())
+}
+
+func TestTaskListStatsBacklog(t *testing.T) {
+	now := time.Now()
+	timeSource := clock.NewEventTimeSource().Update(now)
+	stats := newTaskListStats(timeSource)
+
+	stats.resetBacklog(5)
+	stats.recordPersisted(3)
+	stats.recordRemoved()
+	require.Equal(t, int64(7), stats.approximateBacklogCount())
+	require.Zero(t, stats.approximateBacklogAge())
+
+	createTime, err := types.TimestampProto(now.Add(-time.Minute))
+	require.NoError(t, err)
+	stats.recordBacklogHead(&persistenceblobs.AllocatedTaskInfo{
+		Data: &persistenceblobs.TaskInfo{CreatedTime: createTime},
+	})
+	require.Equal(t, time.Minute, stats.approximateBacklogAge())
+
+	stats.resetBacklog(0)
+	require.Zero(t, stats.approximateBacklogAge())
+	stats.recordRemoved()
+	require.Zero(t, stats.approximateBacklogCount())
+}
+
+func TestMergeBacklogStats(t *testing.T) {
+	merged := mergeBacklogStats(
+		&tasklistgenpb.TaskListBacklogStats{
+			ApproximateBacklogCount:    10,
+			ApproximateBacklogAgeNanos: int64(time.Second),
+			AddTasksPerSecond:          1,
+			DispatchTasksPerSecond:     2,
+		},
+		nil,
+		&tasklistgenpb.TaskListBacklogStats{
+			ApproximateBacklogCount:    5,
+			ApproximateBacklogAgeNanos: int64(time.Minute),
+			AddTasksPerSecond:          3,
+			DispatchTasksPerSecond:     4,
+		},
+	)
+	require.Equal(t, int64(15), merged.GetApproximateBacklogCount())
+	require.Equal(t, int64(time.Minute), merged.GetApproximateBacklogAgeNanos())
+	require.Equal(t, 4.0, merged.GetAddTasksPerSecond())
+	require.Equal(t, 6.0, merged.GetDispatchTasksPerSecond())
+}
@@ -53,6 +53,9 @@ type (
 		// separate shutdownC needed for dispatchTasks go routine to allow
 		// getTasksPump to be stopped without stopping dispatchTasks in unit tests
 		dispatcherShutdownC chan struct{}
+		// parkedTaskTokens bounds the number of tasks with a label selector which wait for a
+		// poller with matching labels outside of the dispatch loop
+		parkedTaskTokens chan struct{}
 	}
 )
 
@@ -64,6 +67,7 @@ func newTaskReader(tlMgr *taskListManagerImpl) *taskReader {
 		cancelFunc:          cancel,
 		notifyC:             make(chan struct{}, 1),
 		dispatcherShutdownC: make(chan struct{}),
+		parkedTaskTokens:    make(chan struct{}, tlMgr.config.GetTasksBatchSize()),
 		// we always dequeue the head of the buffer and try to dispatch it to a poller
 		// so allocate one less than desired target buffer size
 		taskBuffer: make(chan *persistenceblobs.AllocatedTaskInfo, tlMgr.config.GetTasksBatchSize()-1),
@@ -97,20 +101,25 @@ dispatchLoop:
 			if !ok { // Task list getTasks pump is shutdown
 				break dispatchLoop
 			}
+			tr.tlMgr.stats.recordBacklogHead(taskInfo)
+			if err := tr.tlMgr.waitUntilResumed(tr.cancelCtx); err != nil {
+				break dispatchLoop
+			}
 			task := newInternalTask(taskInfo, tr.tlMgr.completeTask, enumsgenpb.TASK_SOURCE_DB_BACKLOG, "", false)
-			for {
-				err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
-				if err == nil {
-					break
-				}
-				if err == context.Canceled {
-					tr.tlMgr.logger.Info("Tasklist manager context is cancelled, shutting down")
+			tr.tlMgr.applyLabelSelector(task)
+			if len(task.labelSelector) > 0 {
+				// park the task until a poller with matching labels claims it or its selector
+				// expires, so that it does not hold up the tasks behind it in the backlog
+				select {
+				case tr.parkedTaskTokens <- struct{}{}:
+				case <-tr.dispatcherShutdownC:
 					break dispatchLoop
 				}
-				// this should never happen unless there is a bug - don't drop the task
-				tr.scope().IncCounter(metrics.BufferThrottlePerTaskListCounter)
-				tr.logger().Error("taskReader: unexpected error dispatching task", tag.Error(err))
-				runtime.Gosched()
+				go tr.dispatchParkedTask(task)
+				continue dispatchLoop
+			}
+			if !tr.dispatchTask(task) {
+				break dispatchLoop
 			}
 		case <-tr.dispatcherShutdownC:
 			break dispatchLoop
@@ -118,6 +127,30 @@ dispatchLoop:
 	}
 }
 
+// dispatchTask blocks until the task is dispatched, returns false if
+// the task list manager is shutting down
+func (tr *taskReader) dispatchTask(task *internalTask) bool {
+	for {
+		err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
+		if err == nil {
+			return true
+		}
+		if err == context.Canceled {
+			tr.tlMgr.logger.Info("Tasklist manager context is cancelled, shutting down")
+			return false
+		}
+		// this should never happen unless there is a bug - don't drop the task
+		tr.scope().IncCounter(metrics.BufferThrottlePerTaskListCounter)
+		tr.logger().Error("taskReader: unexpected error dispatching task", tag.Error(err))
+		runtime.Gosched()
+	}
+}
+
+func (tr *taskReader) dispatchParkedTask(task *internalTask) {
+	defer func() { <-tr.parkedTaskTokens }()
+	tr.dispatchTask(task)
+}
+
 func (tr *taskReader) getTasksPump() {
 	tr.tlMgr.startWG.Wait()
 	defer close(tr.taskBuffer)
@@ -145,6 +178,9 @@ getTasksPumpLoop:
 					tr.tlMgr.taskAckManager.setReadLevel(readLevel)
 					if !isReadBatchDone {
 						tr.Signal()
+					} else {
+						// the whole backlog is read, the outstanding tasks are all that is left
+						tr.tlMgr.stats.resetBacklog(tr.tlMgr.taskAckManager.getBacklogCountHint())
 					}
 					continue getTasksPumpLoop
 				}
@@ -169,6 +205,7 @@ getTasksPumpLoop:
 					}
 					// keep going as saving ack is not critical
 				}
+				tr.tlMgr.stats.emit(tr.scope())
 				tr.Signal() // periodically signal pump to check persistence for tasks
 				updateAckTimer = time.NewTimer(tr.tlMgr.config.UpdateAckInterval())
 			}
@@ -241,6 +278,7 @@ func (tr *taskReader) addTasksToBuffer(tasks []*persistenceblobs.AllocatedTaskIn
 			// Also increment readLevel for expired tasks otherwise it could result in
 			// looping over the same tasks if all tasks read in the batch are expired
 			tr.tlMgr.taskAckManager.setReadLevel(t.GetTaskId())
+			tr.tlMgr.stats.recordRemoved()
 			continue
 		}
 		if !tr.addSingleTaskToBuffer(t, lastWriteTime, idleTimer) {
@@ -269,7 +307,7 @@ func (tr *taskReader) addSingleTaskToBuffer(
 }
 
 func (tr *taskReader) persistAckLevel() error {
-	return tr.tlMgr.db.UpdateState(tr.tlMgr.taskAckManager.getAckLevel())
+	return tr.tlMgr.db.UpdateState(tr.tlMgr.taskAckManager.getAckLevel(), tr.tlMgr.stats.approximateBacklogCount())
 }
 
 func (tr *taskReader) isTaskAddedRecently(lastAddTime time.Time) bool {
@@ -189,6 +189,10 @@ writerLoop:
 					)
 				}
 
+				if err == nil {
+					w.tlMgr.stats.recordPersisted(len(tasks))
+				}
+
 				// Update the maxReadLevel after the writes are completed.
 				if maxReadLevel > 0 {
 					atomic.StoreInt64(&w.maxReadLevel, maxReadLevel)
@@ -0,0 +1,185 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
+
+	"github.com/temporalio/temporal/client/frontend"
+)
+
+const (
+	// ResetTypeFirstDecisionCompleted resets to the first DecisionTaskCompleted event of the run
+	ResetTypeFirstDecisionCompleted = "FirstDecisionCompleted"
+	// ResetTypeLastDecisionCompleted resets to the last DecisionTaskCompleted event of the run
+	ResetTypeLastDecisionCompleted = "LastDecisionCompleted"
+	// ResetTypeLastContinuedAsNew resets to the last DecisionTaskCompleted event of the run which continued as new into this run
+	ResetTypeLastContinuedAsNew = "LastContinuedAsNew"
+	// ResetTypeBadBinary resets to the first DecisionTaskCompleted event of the auto reset point with the bad binary checksum
+	ResetTypeBadBinary = "BadBinary"
+
+	resetHistoryPageSize = 1000
+)
+
+// AllResetTypes is the reset types supported by BatchTypeReset
+var AllResetTypes = []string{ResetTypeFirstDecisionCompleted, ResetTypeLastDecisionCompleted, ResetTypeLastContinuedAsNew, ResetTypeBadBinary}
+
+var errNoDecisionFinishEventID = serviceerror.NewInvalidArgument("no DecisionTaskCompleted event to reset to")
+
+// getResetPoint returns the base run and the DecisionTaskCompleted event ID to reset the workflow to
+func getResetPoint(
+	ctx context.Context,
+	client frontend.Client,
+	namespace string,
+	execution commonpb.WorkflowExecution,
+	params ResetParams,
+) (resetBaseRunID string, decisionFinishID int64, err error) {
+
+	switch params.ResetType {
+	case ResetTypeFirstDecisionCompleted:
+		decisionFinishID, err = findDecisionCompletedID(ctx, client, namespace, execution, true)
+		return execution.GetRunId(), decisionFinishID, err
+	case ResetTypeLastDecisionCompleted:
+		decisionFinishID, err = findDecisionCompletedID(ctx, client, namespace, execution, false)
+		return execution.GetRunId(), decisionFinishID, err
+	case ResetTypeLastContinuedAsNew:
+		resetBaseRunID, err = getContinuedExecutionRunID(ctx, client, namespace, execution)
+		if err != nil {
+			return "", 0, err
+		}
+		decisionFinishID, err = findDecisionCompletedID(ctx, client, namespace, commonpb.WorkflowExecution{
+			WorkflowId: execution.GetWorkflowId(),
+			RunId:      resetBaseRunID,
+		}, false)
+		return resetBaseRunID, decisionFinishID, err
+	case ResetTypeBadBinary:
+		decisionFinishID, err = findBadBinaryDecisionCompletedID(ctx, client, namespace, execution, params.BadBinaryChecksum)
+		return execution.GetRunId(), decisionFinishID, err
+	default:
+		return "", 0, fmt.Errorf("not supported reset type: %v", params.ResetType)
+	}
+}
+
+func findDecisionCompletedID(
+	ctx context.Context,
+	client frontend.Client,
+	namespace string,
+	execution commonpb.WorkflowExecution,
+	first bool,
+) (int64, error) {
+
+	request := &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:       namespace,
+		Execution:       &execution,
+		MaximumPageSize: resetHistoryPageSize,
+	}
+	var decisionFinishID int64
+	for {
+		resp, err := client.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return 0, err
+		}
+		for _, event := range resp.GetHistory().GetEvents() {
+			if event.GetEventType() == enumspb.EVENT_TYPE_DECISION_TASK_COMPLETED {
+				decisionFinishID = event.GetEventId()
+				if first {
+					return decisionFinishID, nil
+				}
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+
+	if decisionFinishID == 0 {
+		return 0, errNoDecisionFinishEventID
+	}
+	return decisionFinishID, nil
+}
+
+func getContinuedExecutionRunID(
+	ctx context.Context,
+	client frontend.Client,
+	namespace string,
+	execution commonpb.WorkflowExecution,
+) (string, error) {
+
+	resp, err := client.GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace:       namespace,
+		Execution:       &execution,
+		MaximumPageSize: 1,
+	})
+	if err != nil {
+		return "", err
+	}
+	events := resp.GetHistory().GetEvents()
+	if len(events) == 0 {
+		return "", serviceerror.NewInvalidArgument("workflow history is empty")
+	}
+	runID := events[0].GetWorkflowExecutionStartedEventAttributes().GetContinuedExecutionRunId()
+	if runID == "" {
+		return "", serviceerror.NewInvalidArgument("workflow is not continued from another run")
+	}
+	return runID, nil
+}
+
+func findBadBinaryDecisionCompletedID(
+	ctx context.Context,
+	client frontend.Client,
+	namespace string,
+	execution commonpb.WorkflowExecution,
+	binaryChecksum string,
+) (int64, error) {
+
+	resp, err := client.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &execution,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	nowNano := time.Now().UnixNano()
+	for _, point := range resp.GetWorkflowExecutionInfo().GetAutoResetPoints().GetPoints() {
+		if point.GetBinaryChecksum() != binaryChecksum || !point.GetResettable() {
+			continue
+		}
+		if point.GetExpiringTimeNano() > 0 && nowNano > point.GetExpiringTimeNano() {
+			// reset point has expired and the history may already be deleted
+			continue
+		}
+		return point.GetFirstDecisionCompletedId(), nil
+	}
+	return 0, errNoDecisionFinishEventID
+}
@@ -0,0 +1,151 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package batcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	historypb "go.temporal.io/temporal-proto/history/v1"
+	workflowpb "go.temporal.io/temporal-proto/workflow/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
+	"go.temporal.io/temporal-proto/workflowservicemock/v1"
+)
+
+type (
+	resetPointSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller     *gomock.Controller
+		frontendClient *workflowservicemock.MockWorkflowServiceClient
+
+		namespace string
+		execution commonpb.WorkflowExecution
+	}
+)
+
+func TestResetPointSuite(t *testing.T) {
+	s := new(resetPointSuite)
+	suite.Run(t, s)
+}
+
+func (s *resetPointSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.frontendClient = workflowservicemock.NewMockWorkflowServiceClient(s.controller)
+	s.namespace = "some random namespace"
+	s.execution = commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      "some random run ID",
+	}
+}
+
+func (s *resetPointSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *resetPointSuite) TestGetResetPoint_DecisionCompleted() {
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{Events: []*historypb.HistoryEvent{
+			{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+			{EventId: 4, EventType: enumspb.EVENT_TYPE_DECISION_TASK_COMPLETED},
+		}},
+		NextPageToken: []byte("some random page token"),
+	}, nil).Times(2)
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{Events: []*historypb.HistoryEvent{
+			{EventId: 10, EventType: enumspb.EVENT_TYPE_DECISION_TASK_COMPLETED},
+		}},
+	}, nil).Times(1)
+
+	runID, eventID, err := getResetPoint(context.Background(), s.frontendClient, s.namespace, s.execution, ResetParams{ResetType: ResetTypeFirstDecisionCompleted})
+	s.NoError(err)
+	s.Equal(s.execution.GetRunId(), runID)
+	s.Equal(int64(4), eventID)
+
+	runID, eventID, err = getResetPoint(context.Background(), s.frontendClient, s.namespace, s.execution, ResetParams{ResetType: ResetTypeLastDecisionCompleted})
+	s.NoError(err)
+	s.Equal(s.execution.GetRunId(), runID)
+	s.Equal(int64(10), eventID)
+}
+
+func (s *resetPointSuite) TestGetResetPoint_NoDecisionCompleted() {
+	s.frontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&workflowservice.GetWorkflowExecutionHistoryResponse{
+		History: &historypb.History{Events: []*historypb.HistoryEvent{
+			{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		}},
+	}, nil).Times(1)
+
+	_, _, err := getResetPoint(context.Background(), s.frontendClient, s.namespace, s.execution, ResetParams{ResetType: ResetTypeLastDecisionCompleted})
+	s.Equal(errNoDecisionFinishEventID, err)
+}
+
+func (s *resetPointSuite) TestGetResetPoint_BadBinary() {
+	s.frontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			AutoResetPoints: &workflowpb.ResetPoints{Points: []*workflowpb.ResetPointInfo{
+				{BinaryChecksum: "good binary", FirstDecisionCompletedId: 4, Resettable: true},
+				{BinaryChecksum: "bad binary", FirstDecisionCompletedId: 12, Resettable: true},
+			}},
+		},
+	}, nil).Times(1)
+
+	runID, eventID, err := getResetPoint(context.Background(), s.frontendClient, s.namespace, s.execution, ResetParams{
+		ResetType:         ResetTypeBadBinary,
+		BadBinaryChecksum: "bad binary",
+	})
+	s.NoError(err)
+	s.Equal(s.execution.GetRunId(), runID)
+	s.Equal(int64(12), eventID)
+}
+
+func (s *resetPointSuite) TestValidateParams_Reset() {
+	params := BatchParams{
+		Namespace: s.namespace,
+		Query:     "some random query",
+		Reason:    "some random reason",
+		BatchType: BatchTypeReset,
+	}
+	s.Error(validateParams(params))
+
+	params.ResetParams.ResetType = ResetTypeBadBinary
+	s.Error(validateParams(params))
+
+	params.ResetParams.BadBinaryChecksum = "bad binary"
+	s.NoError(validateParams(params))
+}
+
+func (s *resetPointSuite) TestGetResetRequestID() {
+	requestID := getResetRequestID("batch-wid", s.execution.GetWorkflowId(), s.execution.GetRunId())
+	s.Equal(requestID, getResetRequestID("batch-wid", s.execution.GetWorkflowId(), s.execution.GetRunId()))
+	s.NotEqual(requestID, getResetRequestID("other-batch-wid", s.execution.GetWorkflowId(), s.execution.GetRunId()))
+	s.NotEqual(requestID, getResetRequestID("batch-wid", s.execution.GetWorkflowId(), "other-run-id"))
+}
@@ -38,6 +38,7 @@ import (
 	"go.temporal.io/temporal/workflow"
 	"golang.org/x/time/rate"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/client/frontend"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/log"
@@ -64,6 +65,9 @@ const (
 	DefaultAttemptsOnRetryableError = 50
 	// DefaultActivityHeartBeatTimeout is the default value for ActivityHeartBeatTimeout
 	DefaultActivityHeartBeatTimeout = time.Second * 10
+
+	// maxReportedFailures is the max number of per workflow failures kept in the heartbeat details
+	maxReportedFailures = 100
 )
 
 const (
@@ -73,10 +77,16 @@ const (
 	BatchTypeCancel = "cancel"
 	// BatchTypeSignal is batch type for signaling workflows
 	BatchTypeSignal = "signal"
+	// BatchTypeReset is batch type for resetting workflows
+	BatchTypeReset = "reset"
+	// BatchTypeDelete is batch type for deleting workflows
+	BatchTypeDelete = "delete"
+	// BatchTypeUpsert is batch type for upserting memo and search attributes of workflows
+	BatchTypeUpsert = "upsert"
 )
 
 // AllBatchTypes is the batch types we supported
-var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal}
+var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeReset, BatchTypeDelete, BatchTypeUpsert}
 
 type (
 	// TerminateParams is the parameters for terminating workflow
@@ -101,6 +111,20 @@ type (
 		Input      *commonpb.Payloads
 	}
 
+	// ResetParams is the parameters for resetting workflow
+	ResetParams struct {
+		// one of AllResetTypes
+		ResetType string
+		// binary checksum to find the reset point, only for ResetTypeBadBinary
+		BadBinaryChecksum string
+	}
+
+	// UpsertParams is the parameters for upserting memo and search attributes of workflow
+	UpsertParams struct {
+		Memo             map[string]*commonpb.Payload
+		SearchAttributes map[string]*commonpb.Payload
+	}
+
 	// BatchParams is the parameters for batch operation workflow
 	BatchParams struct {
 		// Target namespace to execute batch operation
@@ -109,7 +133,7 @@ type (
 		Query string
 		// Reason for the operation
 		Reason string
-		// Supporting: reset,terminate
+		// Supporting: terminate,cancel,signal,reset,delete,upsert
 		BatchType string
 
 		// Below are all optional
@@ -119,6 +143,10 @@ type (
 		CancelParams CancelParams
 		// SignalParams is params only for BatchTypeSignal
 		SignalParams SignalParams
+		// ResetParams is params only for BatchTypeReset
+		ResetParams ResetParams
+		// UpsertParams is params only for BatchTypeUpsert
+		UpsertParams UpsertParams
 		// RPS of processing. Default to DefaultRPS
 		// TODO we will implement smarter way than this static rate limiter: https://github.com/temporalio/temporal/issues/2138
 		RPS int
@@ -144,6 +172,20 @@ type (
 		SuccessCount int
 		// Number of workflows that give up due to errors.
 		ErrorCount int
+		// Workflows that give up due to errors, capped at maxReportedFailures
+		Failures []FailureDetail
+	}
+
+	// FailureDetail is the struct for a workflow that failed to be processed
+	FailureDetail struct {
+		WorkflowID string
+		RunID      string
+		Error      string
+	}
+
+	taskResponse struct {
+		execution commonpb.WorkflowExecution
+		err       error
 	}
 
 	taskDetail struct {
@@ -195,15 +237,35 @@ func validateParams(params BatchParams) error {
 			return fmt.Errorf("must provide signal name")
 		}
 		return nil
-	case BatchTypeCancel:
-		fallthrough
-	case BatchTypeTerminate:
+	case BatchTypeReset:
+		if !isValidResetType(params.ResetParams.ResetType) {
+			return fmt.Errorf("must provide reset type, one of: %v", AllResetTypes)
+		}
+		if params.ResetParams.ResetType == ResetTypeBadBinary && params.ResetParams.BadBinaryChecksum == "" {
+			return fmt.Errorf("must provide bad binary checksum")
+		}
+		return nil
+	case BatchTypeUpsert:
+		if len(params.UpsertParams.Memo) == 0 && len(params.UpsertParams.SearchAttributes) == 0 {
+			return fmt.Errorf("must provide memo or search attributes")
+		}
+		return nil
+	case BatchTypeCancel, BatchTypeTerminate, BatchTypeDelete:
 		return nil
 	default:
 		return fmt.Errorf("not supported batch type: %v", params.BatchType)
 	}
 }
 
+func isValidResetType(resetType string) bool {
+	for _, t := range AllResetTypes {
+		if t == resetType {
+			return true
+		}
+	}
+	return false
+}
+
 func setDefaultParams(params BatchParams) BatchParams {
 	if params.RPS <= 0 {
 		params.RPS = DefaultRPS
@@ -258,7 +320,7 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	}
 	rateLimiter := rate.NewLimiter(rate.Limit(batchParams.RPS), batchParams.RPS)
 	taskCh := make(chan taskDetail, pageSize)
-	respCh := make(chan error, pageSize)
+	respCh := make(chan taskResponse, pageSize)
 	for i := 0; i < batchParams.Concurrency; i++ {
 		go startTaskProcessor(ctx, batchParams, taskCh, respCh, rateLimiter, client)
 	}
@@ -296,11 +358,18 @@ func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetai
 	Loop:
 		for {
 			select {
-			case err := <-respCh:
-				if err == nil {
+			case resp := <-respCh:
+				if resp.err == nil {
 					succCount++
 				} else {
 					errCount++
+					if len(hbd.Failures) < maxReportedFailures {
+						hbd.Failures = append(hbd.Failures, FailureDetail{
+							WorkflowID: resp.execution.GetWorkflowId(),
+							RunID:      resp.execution.GetRunId(),
+							Error:      resp.err.Error(),
+						})
+					}
 				}
 				if succCount+errCount == batchCount {
 					break Loop
@@ -328,11 +397,12 @@ func startTaskProcessor(
 	ctx context.Context,
 	batchParams BatchParams,
 	taskCh chan taskDetail,
-	respCh chan error,
+	respCh chan taskResponse,
 	limiter *rate.Limiter,
 	client frontend.Client,
 ) {
 	batcher := ctx.Value(batcherContextKey).(*Batcher)
+	adminClient := batcher.clientBean.GetRemoteAdminClient(batcher.cfg.ClusterMetadata.GetCurrentClusterName())
 	for {
 		select {
 		case <-ctx.Done():
@@ -391,14 +461,74 @@ func startTaskProcessor(
 						})
 						return err
 					})
+			case BatchTypeReset:
+				err = processTask(ctx, limiter, task, batchParams, client, convert.BoolPtr(false),
+					func(workflowID, runID string) error {
+						// a retried task has to reuse the request ID so history dedups the reset already applied by a previous attempt
+						resetRequestID := getResetRequestID(activity.GetInfo(ctx).WorkflowExecution.ID, workflowID, runID)
+						resetBaseRunID, decisionFinishID, err := getResetPoint(ctx, client, batchParams.Namespace, commonpb.WorkflowExecution{
+							WorkflowId: workflowID,
+							RunId:      runID,
+						}, batchParams.ResetParams)
+						if err != nil {
+							return err
+						}
+						_, err = client.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
+							Namespace: batchParams.Namespace,
+							WorkflowExecution: &commonpb.WorkflowExecution{
+								WorkflowId: workflowID,
+								RunId:      resetBaseRunID,
+							},
+							Reason:                batchParams.Reason,
+							DecisionFinishEventId: decisionFinishID,
+							RequestId:             resetRequestID,
+						})
+						return err
+					})
+			case BatchTypeDelete:
+				err = processTask(ctx, limiter, task, batchParams, client, convert.BoolPtr(false),
+					func(workflowID, runID string) error {
+						_, err := adminClient.DeleteWorkflowExecution(ctx, &adminservice.DeleteWorkflowExecutionRequest{
+							Namespace: batchParams.Namespace,
+							Execution: &commonpb.WorkflowExecution{
+								WorkflowId: workflowID,
+								RunId:      runID,
+							},
+							Reason:   batchParams.Reason,
+							Identity: BatchWFTypeName,
+						})
+						return err
+					})
+			case BatchTypeUpsert:
+				err = processTask(ctx, limiter, task, batchParams, client, convert.BoolPtr(false),
+					func(workflowID, runID string) error {
+						request := &adminservice.UpsertWorkflowExecutionAttributesRequest{
+							Namespace: batchParams.Namespace,
+							Execution: &commonpb.WorkflowExecution{
+								WorkflowId: workflowID,
+								RunId:      runID,
+							},
+							Identity: BatchWFTypeName,
+						}
+						if len(batchParams.UpsertParams.Memo) > 0 {
+							request.Memo = &commonpb.Memo{Fields: batchParams.UpsertParams.Memo}
+						}
+						if len(batchParams.UpsertParams.SearchAttributes) > 0 {
+							request.SearchAttributes = &commonpb.SearchAttributes{IndexedFields: batchParams.UpsertParams.SearchAttributes}
+						}
+						_, err := adminClient.UpsertWorkflowExecutionAttributes(ctx, request)
+						return err
+					})
 			}
 			if err != nil {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
 				getActivityLogger(ctx).Error("Failed to process batch operation task", tag.Error(err))
 
 				_, ok := batchParams._nonRetryableErrors[err.Error()]
-				if ok || task.attempts >= batchParams.AttemptsOnRetryableError {
-					respCh <- err
+				// invalid argument, e.g. no reset point for the workflow, fails the same way on every attempt
+				_, invalidArgument := err.(*serviceerror.InvalidArgument)
+				if ok || invalidArgument || task.attempts >= batchParams.AttemptsOnRetryableError {
+					respCh <- taskResponse{execution: task.execution, err: err}
 				} else {
 					// put back to the channel if less than attemptsOnError
 					task.attempts++
@@ -406,7 +536,7 @@ func startTaskProcessor(
 				}
 			} else {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorSuccess)
-				respCh <- nil
+				respCh <- taskResponse{execution: task.execution}
 			}
 		}
 	}
@@ -470,6 +600,11 @@ func processTask(
 	return nil
 }
 
+// getResetRequestID derives the reset request ID from the batch workflow and the workflow to reset
+func getResetRequestID(batchWorkflowID, workflowID, runID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(batchWorkflowID+"/"+workflowID+"/"+runID)).String()
+}
+
 func isDone(ctx context.Context) bool {
 	select {
 	case <-ctx.Done():
@@ -0,0 +1,290 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handover
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	replicationpb "go.temporal.io/temporal-proto/replication/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/client/frontend"
+	"github.com/temporalio/temporal/client/history"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/rpc"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// Config contains the configuration of namespace handover manager
+	Config struct {
+		CheckInterval dynamicconfig.DurationPropertyFn
+	}
+
+	// Manager completes graceful failovers started from the current cluster: a namespace in handover is
+	// promoted to the handover cluster once replication of the namespace to that cluster is drained,
+	// or once the handover expires.
+	Manager struct {
+		config          *Config
+		clusterMetadata cluster.Metadata
+		metadataMgr     persistence.MetadataManager
+		historyClient   history.Client
+		frontendClient  frontend.Client
+		serviceResolver membership.ServiceResolver
+		hostInfo        *membership.HostInfo
+		numberOfShards  int
+		logger          log.Logger
+		metricsClient   metrics.Client
+		timeSource      clock.TimeSource
+
+		status     int32
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+)
+
+const (
+	// only one worker of the cluster checks handovers, the owner of this key on the membership ring
+	handoverOwnershipKey = "namespace-handover"
+
+	listNamespacesPageSize = 100
+	operationTimeout       = time.Minute
+)
+
+// NewManager creates a new namespace handover manager
+func NewManager(
+	config *Config,
+	clusterMetadata cluster.Metadata,
+	metadataMgr persistence.MetadataManager,
+	historyClient history.Client,
+	frontendClient frontend.Client,
+	serviceResolver membership.ServiceResolver,
+	hostInfo *membership.HostInfo,
+	numberOfShards int,
+	logger log.Logger,
+	metricsClient metrics.Client,
+) *Manager {
+	return &Manager{
+		config:          config,
+		clusterMetadata: clusterMetadata,
+		metadataMgr:     metadataMgr,
+		historyClient:   historyClient,
+		frontendClient:  frontendClient,
+		serviceResolver: serviceResolver,
+		hostInfo:        hostInfo,
+		numberOfShards:  numberOfShards,
+		logger:          logger.WithTags(tag.ComponentNamespaceHandover),
+		metricsClient:   metricsClient,
+		timeSource:      clock.NewRealTimeSource(),
+		status:          common.DaemonStatusInitialized,
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start starts the handover check loop
+func (m *Manager) Start() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	m.shutdownWG.Add(1)
+	go m.checkLoop()
+	m.logger.Info("Namespace handover manager started.")
+}
+
+// Stop stops the handover check loop
+func (m *Manager) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(m.shutdownCh)
+	if success := common.AwaitWaitGroup(&m.shutdownWG, time.Minute); !success {
+		m.logger.Warn("Namespace handover manager timed out on shutdown.")
+	}
+	m.logger.Info("Namespace handover manager stopped.")
+}
+
+func (m *Manager) checkLoop() {
+	defer m.shutdownWG.Done()
+
+	timer := time.NewTimer(m.config.CheckInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-timer.C:
+			if err := m.checkHandovers(); err != nil {
+				m.logger.Error("Failed to check namespace handovers.", tag.Error(err))
+				m.metricsClient.IncCounter(metrics.NamespaceHandoverScope, metrics.NamespaceHandoverFailures)
+			}
+			timer.Reset(m.config.CheckInterval())
+		}
+	}
+}
+
+// checkHandovers promotes all namespaces in handover from the current cluster which are ready to be promoted
+func (m *Manager) checkHandovers() error {
+	// best effort to have a single worker checking handovers, promotion is idempotent enough as a namespace
+	// which is no longer in handover is skipped
+	info, err := m.serviceResolver.Lookup(handoverOwnershipKey)
+	if err != nil {
+		return err
+	}
+	if info.Identity() != m.hostInfo.Identity() {
+		return nil
+	}
+
+	namespaces, err := m.getHandoverNamespaces()
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range namespaces {
+		if err := m.checkHandover(ns); err != nil {
+			m.logger.Error("Failed to check namespace handover.",
+				tag.WorkflowNamespace(ns.Namespace.Info.Name),
+				tag.Error(err),
+			)
+			m.metricsClient.IncCounter(metrics.NamespaceHandoverScope, metrics.NamespaceHandoverFailures)
+		}
+	}
+	return nil
+}
+
+// getHandoverNamespaces returns the global namespaces which hand over from the current cluster
+func (m *Manager) getHandoverNamespaces() ([]*persistence.GetNamespaceResponse, error) {
+	var result []*persistence.GetNamespaceResponse
+	var pageToken []byte
+	for {
+		resp, err := m.metadataMgr.ListNamespaces(&persistence.ListNamespacesRequest{
+			PageSize:      listNamespacesPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.Namespaces {
+			replicationConfig := ns.Namespace.GetReplicationConfig()
+			if ns.IsGlobalNamespace &&
+				replicationConfig.GetState() == enumsgenpb.NAMESPACE_REPLICATION_STATE_HANDOVER &&
+				replicationConfig.GetActiveClusterName() == m.clusterMetadata.GetCurrentClusterName() {
+				result = append(result, ns)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return result, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (m *Manager) checkHandover(ns *persistence.GetNamespaceResponse) error {
+	info := ns.Namespace.GetInfo()
+	replicationConfig := ns.Namespace.GetReplicationConfig()
+	handoverCluster := replicationConfig.GetHandoverClusterName()
+
+	expired := !m.timeSource.Now().Before(time.Unix(0, replicationConfig.GetHandoverExpirationTimeNanos()))
+	if !expired {
+		drained, err := m.isReplicationDrained(info.GetId(), handoverCluster)
+		if err != nil || !drained {
+			return err
+		}
+	}
+
+	if err := m.promote(info.GetName(), handoverCluster); err != nil {
+		return err
+	}
+
+	if expired {
+		m.logger.Warn("Namespace handover expired before replication was drained, promoted handover cluster.",
+			tag.WorkflowNamespace(info.GetName()),
+			tag.ClusterName(handoverCluster),
+		)
+		m.metricsClient.IncCounter(metrics.NamespaceHandoverScope, metrics.NamespaceHandoverExpiredCount)
+		return nil
+	}
+	m.logger.Info("Namespace replication drained, promoted handover cluster.",
+		tag.WorkflowNamespace(info.GetName()),
+		tag.ClusterName(handoverCluster),
+	)
+	m.metricsClient.IncCounter(metrics.NamespaceHandoverScope, metrics.NamespaceHandoverCompletedCount)
+	return nil
+}
+
+// isReplicationDrained returns whether all shards have replicated the namespace tasks to the handover cluster
+func (m *Manager) isReplicationDrained(namespaceID string, handoverCluster string) (bool, error) {
+	shardIDs := make([]int32, 0, m.numberOfShards)
+	for shardID := 0; shardID < m.numberOfShards; shardID++ {
+		shardIDs = append(shardIDs, int32(shardID))
+	}
+
+	ctx, cancel := rpc.NewContextWithTimeoutAndHeaders(operationTimeout)
+	defer cancel()
+	resp, err := m.historyClient.GetReplicationStatus(ctx, &historyservice.GetReplicationStatusRequest{
+		ShardIds:       shardIDs,
+		RemoteClusters: []string{handoverCluster},
+		NamespaceId:    namespaceID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(resp.GetShards()) != len(shardIDs) {
+		return false, nil
+	}
+
+	for _, shard := range resp.GetShards() {
+		status, ok := shard.GetRemoteClusters()[handoverCluster]
+		// an incomplete count may have missed tasks of the namespace
+		if !ok || status.GetPendingNamespaceTasks() > 0 || status.GetPendingNamespaceTasksIncomplete() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *Manager) promote(namespace string, handoverCluster string) error {
+	ctx, cancel := rpc.NewContextWithTimeoutAndHeaders(operationTimeout)
+	defer cancel()
+	_, err := m.frontendClient.UpdateNamespace(ctx, &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: handoverCluster,
+		},
+	})
+	return err
+}
@@ -0,0 +1,212 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handover
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	replicationpb "go.temporal.io/temporal-proto/replication/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
+	"go.temporal.io/temporal-proto/workflowservicemock/v1"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type handoverSuite struct {
+	suite.Suite
+
+	controller          *gomock.Controller
+	mockHistoryClient   *historyservicemock.MockHistoryServiceClient
+	mockFrontendClient  *workflowservicemock.MockWorkflowServiceClient
+	mockServiceResolver *membership.MockServiceResolver
+	mockMetadataMgr     *mocks.MetadataManager
+
+	now     time.Time
+	manager *Manager
+}
+
+const (
+	testNamespace   = "test-namespace"
+	testNamespaceID = "test-namespace-id"
+	testShards      = 2
+)
+
+func TestHandoverSuite(t *testing.T) {
+	suite.Run(t, new(handoverSuite))
+}
+
+func (s *handoverSuite) SetupTest() {
+	s.controller = gomock.NewController(s.T())
+	s.mockHistoryClient = historyservicemock.NewMockHistoryServiceClient(s.controller)
+	s.mockFrontendClient = workflowservicemock.NewMockWorkflowServiceClient(s.controller)
+	s.mockServiceResolver = membership.NewMockServiceResolver(s.controller)
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+
+	hostInfo := membership.NewHostInfo("127.0.0.1:7239", nil)
+	s.mockServiceResolver.EXPECT().Lookup(handoverOwnershipKey).Return(hostInfo, nil).AnyTimes()
+
+	s.now = time.Date(2020, 6, 15, 13, 0, 0, 0, time.UTC)
+	s.manager = NewManager(
+		&Config{CheckInterval: dynamicconfig.GetDurationPropertyFn(time.Second)},
+		cluster.GetTestClusterMetadata(true, true),
+		s.mockMetadataMgr,
+		s.mockHistoryClient,
+		s.mockFrontendClient,
+		s.mockServiceResolver,
+		hostInfo,
+		testShards,
+		loggerimpl.NewNopLogger(),
+		metrics.NewClient(tally.NoopScope, metrics.Worker),
+	)
+	s.manager.timeSource = clock.NewEventTimeSource().Update(s.now)
+}
+
+func (s *handoverSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockMetadataMgr.AssertExpectations(s.T())
+}
+
+func (s *handoverSuite) TestCheckHandovers_NotOwner() {
+	resolver := membership.NewMockServiceResolver(s.controller)
+	resolver.EXPECT().Lookup(handoverOwnershipKey).Return(membership.NewHostInfo("127.0.0.2:7239", nil), nil)
+	s.manager.serviceResolver = resolver
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) TestCheckHandovers_SkipsNamespacesNotInHandover() {
+	normal := s.newTestNamespace(s.now.Add(time.Minute))
+	normal.Namespace.ReplicationConfig.State = enumsgenpb.NAMESPACE_REPLICATION_STATE_NORMAL
+	standby := s.newTestNamespace(s.now.Add(time.Minute))
+	standby.Namespace.ReplicationConfig.ActiveClusterName = cluster.TestAlternativeClusterName
+	s.expectListNamespaces(normal, standby)
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) TestCheckHandovers_NotDrained() {
+	s.expectListNamespaces(s.newTestNamespace(s.now.Add(time.Minute)))
+	s.expectReplicationStatus(false, 0, 3)
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) TestCheckHandovers_PendingTasksIncomplete() {
+	s.expectListNamespaces(s.newTestNamespace(s.now.Add(time.Minute)))
+	s.expectReplicationStatus(true, 0, 0)
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) TestCheckHandovers_Drained() {
+	s.expectListNamespaces(s.newTestNamespace(s.now.Add(time.Minute)))
+	s.expectReplicationStatus(false, 0, 0)
+	s.expectPromote()
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) TestCheckHandovers_Expired() {
+	s.expectListNamespaces(s.newTestNamespace(s.now.Add(-time.Second)))
+	s.expectPromote()
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) TestCheckHandovers_ReplicationStatusError() {
+	s.expectListNamespaces(s.newTestNamespace(s.now.Add(time.Minute)))
+	s.mockHistoryClient.EXPECT().GetReplicationStatus(gomock.Any(), gomock.Any()).Return(nil, errors.New("some random error"))
+
+	s.NoError(s.manager.checkHandovers())
+}
+
+func (s *handoverSuite) newTestNamespace(expiration time.Time) *persistence.GetNamespaceResponse {
+	return &persistence.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Info: &persistenceblobs.NamespaceInfo{
+				Id:   testNamespaceID,
+				Name: testNamespace,
+			},
+			ReplicationConfig: &persistenceblobs.NamespaceReplicationConfig{
+				ActiveClusterName:           cluster.TestCurrentClusterName,
+				Clusters:                    []string{cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName},
+				State:                       enumsgenpb.NAMESPACE_REPLICATION_STATE_HANDOVER,
+				HandoverClusterName:         cluster.TestAlternativeClusterName,
+				HandoverExpirationTimeNanos: expiration.UnixNano(),
+			},
+		},
+		IsGlobalNamespace: true,
+	}
+}
+
+func (s *handoverSuite) expectListNamespaces(namespaces ...*persistence.GetNamespaceResponse) {
+	s.mockMetadataMgr.On("ListNamespaces", mock.Anything).Return(&persistence.ListNamespacesResponse{
+		Namespaces: namespaces,
+	}, nil).Once()
+}
+
+func (s *handoverSuite) expectReplicationStatus(incomplete bool, pendingTasks ...int32) {
+	response := &historyservice.GetReplicationStatusResponse{}
+	for shardID, pending := range pendingTasks {
+		response.Shards = append(response.Shards, &replicationgenpb.ShardReplicationStatus{
+			ShardId: int32(shardID),
+			RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+				cluster.TestAlternativeClusterName: {PendingNamespaceTasks: pending, PendingNamespaceTasksIncomplete: incomplete},
+			},
+		})
+	}
+	s.mockHistoryClient.EXPECT().GetReplicationStatus(gomock.Any(), &historyservice.GetReplicationStatusRequest{
+		ShardIds:       []int32{0, 1},
+		RemoteClusters: []string{cluster.TestAlternativeClusterName},
+		NamespaceId:    testNamespaceID,
+	}).Return(response, nil)
+}
+
+func (s *handoverSuite) expectPromote() {
+	s.mockFrontendClient.EXPECT().UpdateNamespace(gomock.Any(), &workflowservice.UpdateNamespaceRequest{
+		Name: testNamespace,
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: cluster.TestAlternativeClusterName,
+		},
+	}).Return(&workflowservice.UpdateNamespaceResponse{}, nil)
+}
@@ -27,11 +27,14 @@ package indexer
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
-	"github.com/olivere/elastic"
+	"github.com/olivere/elastic/v7"
 	"github.com/uber-go/tally"
 
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/codec"
@@ -41,6 +44,7 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
 )
 
 type (
@@ -50,38 +54,33 @@ type (
 		Stop()
 		// Add request to bulk, and record kafka message in map with provided key
 		// This call will be blocked when downstream has issues
-		Add(request elastic.BulkableRequest, key string, kafkaMsg messaging.Message)
-	}
-
-	// ElasticBulkProcessor is interface for elastic.BulkProcessor
-	// (elastic package doesn't provide such interface that tests can mock)
-	ElasticBulkProcessor interface {
-		Start(ctx context.Context) error
-		Stop() error
-		Close() error
-		Stats() elastic.BulkProcessorStats
-		Add(request elastic.BulkableRequest)
-		Flush() error
+		Add(request *es.BulkableRequest, key string, kafkaMsg messaging.Message)
 	}
 
 	// esProcessorImpl implements ESProcessor, it's an agent of elastic.BulkProcessor
 	esProcessorImpl struct {
-		processor     ElasticBulkProcessor
+		processor     es.BulkProcessor
 		mapToKafkaMsg collection.ConcurrentTxMap // used to map ES request to kafka message
 		config        *Config
 		logger        log.Logger
 		metricsClient metrics.Client
 		msgEncoder    *codec.JSONPBEncoder
+		dlq           persistence.VisibilityIndexerDLQ
 	}
 
 	kafkaMessageWithMetrics struct { // value of esProcessorImpl.mapToKafkaMsg
 		message        messaging.Message
 		swFromAddToAck *tally.Stopwatch // metric from message add to process, to message ack/nack
 	}
+
+	bulkActionMetadata struct { // metadata line of ES bulk request
+		Index   string `json:"_index"`
+		ID      string `json:"_id"`
+		Version int64  `json:"version"`
+	}
 )
 
 var _ ESProcessor = (*esProcessorImpl)(nil)
-var _ ElasticBulkProcessor = (*elastic.BulkProcessor)(nil)
 
 const (
 	// retry configs for es bulk processor
@@ -91,12 +90,13 @@ const (
 
 // NewESProcessorAndStart create new ESProcessor and start
 func NewESProcessorAndStart(config *Config, client es.Client, processorName string,
-	logger log.Logger, metricsClient metrics.Client, msgEncoder *codec.JSONPBEncoder) (ESProcessor, error) {
+	dlq persistence.VisibilityIndexerDLQ, logger log.Logger, metricsClient metrics.Client, msgEncoder *codec.JSONPBEncoder) (ESProcessor, error) {
 	p := &esProcessorImpl{
 		config:        config,
 		logger:        logger.WithTags(tag.ComponentIndexerESProcessor),
 		metricsClient: metricsClient,
 		msgEncoder:    msgEncoder,
+		dlq:           dlq,
 	}
 
 	params := &es.BulkProcessorParameters{
@@ -125,7 +125,7 @@ func (p *esProcessorImpl) Stop() {
 }
 
 // Add an ES request, and an map item for kafka message
-func (p *esProcessorImpl) Add(request elastic.BulkableRequest, key string, kafkaMsg messaging.Message) {
+func (p *esProcessorImpl) Add(request *es.BulkableRequest, key string, kafkaMsg messaging.Message) {
 	actionWhenFoundDuplicates := func(key interface{}, value interface{}) error {
 		return kafkaMsg.Ack()
 	}
@@ -173,7 +173,11 @@ func (p *esProcessorImpl) bulkAfterAction(id int64, requests []elastic.BulkableR
 				p.logger.Error("ES request failed.",
 					tag.ESResponseStatus(resp.Status), tag.ESResponseError(getErrorMsgFromESResp(resp)), tag.WorkflowID(wid), tag.WorkflowRunID(rid),
 					tag.WorkflowNamespaceID(namespaceID))
-				p.nackKafkaMsg(key)
+				if p.publishToDLQ(key, requests[i], resp) {
+					p.ackKafkaMsg(key)
+				} else {
+					p.nackKafkaMsg(key)
+				}
 			default: // bulk processor will retry
 				p.logger.Info("ES request retried.", tag.ESResponseStatus(resp.Status))
 				p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorRetries)
@@ -218,17 +222,96 @@ func (p *esProcessorImpl) getKafkaMsg(key string) (kafkaMsg *kafkaMessageWithMet
 }
 
 func (p *esProcessorImpl) getMsgWithInfo(key string) (wid string, rid string, namespaceID string) {
+	msg := p.getIndexerMsg(key)
+	return msg.GetWorkflowId(), msg.GetRunId(), msg.GetNamespaceId()
+}
+
+func (p *esProcessorImpl) getIndexerMsg(key string) *indexergenpb.Message {
 	kafkaMsg, ok := p.getKafkaMsg(key)
 	if !ok {
-		return
+		return nil
 	}
 
 	var msg indexergenpb.Message
 	if err := p.msgEncoder.Decode(kafkaMsg.message.Value(), &msg); err != nil {
 		p.logger.Error("failed to deserialize kafka message.", tag.Error(err))
-		return
+		return nil
 	}
-	return msg.GetWorkflowId(), msg.GetRunId(), msg.GetNamespaceId()
+	return &msg
+}
+
+// publishToDLQ keeps request rejected by ES with non-retryable error in visibility indexer DLQ,
+// returns false if request can't be persisted and kafka message should be nacked instead
+func (p *esProcessorImpl) publishToDLQ(key string, request elastic.BulkableRequest, resp *elastic.BulkResponseItem) bool {
+	if p.dlq == nil {
+		return false
+	}
+
+	var errType string
+	if resp.Error != nil {
+		errType = resp.Error.Type
+	}
+	scope := p.metricsClient.Scope(metrics.ESProcessorScope, metrics.ESErrorTypeTag(errType))
+
+	dlqMsg, err := p.getDLQMessage(key, request)
+	if err != nil {
+		p.logger.Error("Unable to build visibility DLQ message.", tag.Error(err), tag.ESKey(key))
+		scope.IncCounter(metrics.ESProcessorDLQWriteFailures)
+		return false
+	}
+	dlqMsg.Status = int32(resp.Status)
+	dlqMsg.ErrorType = errType
+	dlqMsg.ErrorReason = getErrorMsgFromESResp(resp)
+	dlqMsg.FailedTime = time.Now().UnixNano()
+
+	if err := p.dlq.Publish(dlqMsg); err != nil {
+		p.logger.Error("Failed to publish message to visibility DLQ.", tag.Error(err), tag.ESKey(key),
+			tag.WorkflowID(dlqMsg.GetWorkflowId()), tag.WorkflowRunID(dlqMsg.GetRunId()))
+		scope.IncCounter(metrics.ESProcessorDLQWriteFailures)
+		return false
+	}
+	scope.IncCounter(metrics.ESProcessorDLQWrites)
+	return true
+}
+
+func (p *esProcessorImpl) getDLQMessage(key string, request elastic.BulkableRequest) (*indexergenpb.DLQMessage, error) {
+	req, err := request.Source()
+	if err != nil {
+		return nil, err
+	}
+	if len(req) == 0 {
+		return nil, errors.New("empty request source")
+	}
+
+	var action map[string]bulkActionMetadata
+	if err := json.Unmarshal([]byte(req[0]), &action); err != nil {
+		return nil, err
+	}
+
+	dlqMsg := &indexergenpb.DLQMessage{}
+	for op, metadata := range action {
+		switch op {
+		case "index":
+			dlqMsg.MessageType = enumsgenpb.MESSAGE_TYPE_INDEX
+		case "delete":
+			dlqMsg.MessageType = enumsgenpb.MESSAGE_TYPE_DELETE
+		default:
+			return nil, fmt.Errorf("unknown bulk request action %v", op)
+		}
+		dlqMsg.Index = metadata.Index
+		dlqMsg.DocId = metadata.ID
+		dlqMsg.Version = metadata.Version
+	}
+	if len(req) == 2 {
+		dlqMsg.Doc = []byte(req[1])
+	}
+
+	if msg := p.getIndexerMsg(key); msg != nil {
+		dlqMsg.NamespaceId = msg.GetNamespaceId()
+		dlqMsg.WorkflowId = msg.GetWorkflowId()
+		dlqMsg.RunId = msg.GetRunId()
+	}
+	return dlqMsg, nil
 }
 
 func (p *esProcessorImpl) hashFn(key interface{}) uint32 {
@@ -31,11 +31,13 @@ import (
 	"testing"
 	"time"
 
-	"github.com/olivere/elastic"
+	"github.com/golang/mock/gomock"
+	"github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
 	"github.com/temporalio/temporal/common/codec"
 	"github.com/temporalio/temporal/common/collection"
@@ -45,21 +47,21 @@ import (
 	msgMocks "github.com/temporalio/temporal/common/messaging/mocks"
 	"github.com/temporalio/temporal/common/metrics"
 	mmocks "github.com/temporalio/temporal/common/metrics/mocks"
+	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
-	"github.com/temporalio/temporal/service/worker/indexer/mocks"
 )
 
 type esProcessorSuite struct {
 	suite.Suite
 	esProcessor       *esProcessorImpl
-	mockBulkProcessor *mocks.ElasticBulkProcessor
+	mockBulkProcessor *esMocks.BulkProcessor
 	mockMetricClient  *mmocks.Client
 	mockESClient      *esMocks.Client
 }
 
 var (
 	testIndex     = "test-index"
-	testType      = esDocType
+	testType      = "_doc"
 	testID        = "test-doc-id"
 	testStopWatch = metrics.NopStopwatch()
 	testScope     = metrics.ESProcessorScope
@@ -83,7 +85,7 @@ func (s *esProcessorSuite) SetupTest() {
 		ESProcessorFlushInterval: dynamicconfig.GetDurationPropertyFn(1 * time.Minute),
 	}
 	s.mockMetricClient = &mmocks.Client{}
-	s.mockBulkProcessor = &mocks.ElasticBulkProcessor{}
+	s.mockBulkProcessor = &esMocks.BulkProcessor{}
 
 	zapLogger, err := zap.NewDevelopment()
 	s.Require().NoError(err)
@@ -126,8 +128,9 @@ func (s *esProcessorSuite) TestNewESProcessorAndStart() {
 		s.NotNil(input.Backoff)
 		s.NotNil(input.AfterFunc)
 		return true
-	})).Return(&elastic.BulkProcessor{}, nil).Once()
-	p, err := NewESProcessorAndStart(config, s.mockESClient, processorName, s.esProcessor.logger, &mmocks.Client{}, codec.NewJSONPBEncoder())
+	})).Return(s.mockBulkProcessor, nil).Once()
+	s.mockBulkProcessor.On("Stop").Return(nil).Once()
+	p, err := NewESProcessorAndStart(config, s.mockESClient, processorName, nil, s.esProcessor.logger, &mmocks.Client{}, codec.NewJSONPBEncoder())
 	s.NoError(err)
 
 	processor, ok := p.(*esProcessorImpl)
@@ -144,7 +147,7 @@ func (s *esProcessorSuite) TestStop() {
 }
 
 func (s *esProcessorSuite) TestAdd() {
-	request := elastic.NewBulkIndexRequest()
+	request := &es.BulkableRequest{}
 	mockKafkaMsg := &msgMocks.Message{}
 	key := "test-key"
 	s.Equal(0, s.esProcessor.mapToKafkaMsg.Len())
@@ -164,7 +167,7 @@ func (s *esProcessorSuite) TestAdd() {
 }
 
 func (s *esProcessorSuite) TestAdd_ConcurrentAdd() {
-	request := elastic.NewBulkIndexRequest()
+	request := &es.BulkableRequest{}
 	mockKafkaMsg := &msgMocks.Message{}
 	key := "test-key"
 
@@ -192,7 +195,7 @@ func (s *esProcessorSuite) TestBulkAfterActionX() {
 		Index(testIndex).
 		Type(testType).
 		Id(testID).
-		VersionType(versionTypeExternal).
+		VersionType("external").
 		Version(version).
 		Doc(map[string]interface{}{es.KafkaKey: testKey})
 	requests := []elastic.BulkableRequest{request}
@@ -227,7 +230,7 @@ func (s *esProcessorSuite) TestBulkAfterAction_Nack() {
 		Index(testIndex).
 		Type(testType).
 		Id(testID).
-		VersionType(versionTypeExternal).
+		VersionType("external").
 		Version(version).
 		Doc(map[string]interface{}{es.KafkaKey: testKey})
 	requests := []elastic.BulkableRequest{request}
@@ -261,13 +264,131 @@ func (s *esProcessorSuite) TestBulkAfterAction_Nack() {
 	mockKafkaMsg.AssertExpectations(s.T())
 }
 
+func (s *esProcessorSuite) TestBulkAfterAction_DLQ() {
+	controller := gomock.NewController(s.T())
+	defer controller.Finish()
+	mockDLQ := persistence.NewMockVisibilityIndexerDLQ(controller)
+	s.esProcessor.dlq = mockDLQ
+
+	version := int64(3)
+	testKey := "testKey"
+	request := elastic.NewBulkIndexRequest().
+		Index(testIndex).
+		Id(testID).
+		VersionType("external").
+		Version(version).
+		Doc(map[string]interface{}{es.KafkaKey: testKey})
+	requests := []elastic.BulkableRequest{request}
+
+	mFailed := map[string]*elastic.BulkResponseItem{
+		"index": {
+			Index:   testIndex,
+			Id:      testID,
+			Version: version,
+			Status:  400,
+			Error: &elastic.ErrorDetails{
+				Type:   "mapper_parsing_exception",
+				Reason: "failed to parse field",
+			},
+		},
+	}
+	response := &elastic.BulkResponse{
+		Took:   3,
+		Errors: true,
+		Items:  []map[string]*elastic.BulkResponseItem{mFailed},
+	}
+
+	wid := "test-workflowID"
+	rid := "test-runID"
+	namespaceID := "test-namespaceID"
+	payload := s.getEncodedMsg(wid, rid, namespaceID)
+
+	mockKafkaMsg := &msgMocks.Message{}
+	mapVal := newKafkaMessageWithMetrics(mockKafkaMsg, &testStopWatch)
+	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
+	mockKafkaMsg.On("Ack").Return(nil).Once()
+	mockKafkaMsg.On("Value").Return(payload).Twice()
+	mockScope := &mmocks.Scope{}
+	mockScope.On("IncCounter", metrics.ESProcessorDLQWrites).Once()
+	s.mockMetricClient.On("Scope", metrics.ESProcessorScope, mock.Anything).Return(mockScope).Once()
+	mockDLQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(msg *indexergenpb.DLQMessage) error {
+		s.Equal(enumsgenpb.MESSAGE_TYPE_INDEX, msg.GetMessageType())
+		s.Equal(testIndex, msg.GetIndex())
+		s.Equal(testID, msg.GetDocId())
+		s.Equal(version, msg.GetVersion())
+		s.Equal(namespaceID, msg.GetNamespaceId())
+		s.Equal(wid, msg.GetWorkflowId())
+		s.Equal(rid, msg.GetRunId())
+		s.Equal(int32(400), msg.GetStatus())
+		s.Equal("mapper_parsing_exception", msg.GetErrorType())
+		s.Equal("failed to parse field", msg.GetErrorReason())
+
+		var doc map[string]interface{}
+		s.NoError(json.Unmarshal(msg.GetDoc(), &doc))
+		s.Equal(testKey, doc[es.KafkaKey])
+		return nil
+	})
+
+	s.esProcessor.bulkAfterAction(0, requests, response, nil)
+	mockKafkaMsg.AssertExpectations(s.T())
+	mockScope.AssertExpectations(s.T())
+	s.Equal(0, s.esProcessor.mapToKafkaMsg.Len())
+}
+
+func (s *esProcessorSuite) TestBulkAfterAction_DLQFailure() {
+	controller := gomock.NewController(s.T())
+	defer controller.Finish()
+	mockDLQ := persistence.NewMockVisibilityIndexerDLQ(controller)
+	s.esProcessor.dlq = mockDLQ
+
+	testKey := "testKey"
+	request := elastic.NewBulkDeleteRequest().
+		Index(testIndex).
+		Id(testKey).
+		VersionType("external").
+		Version(3)
+	requests := []elastic.BulkableRequest{request}
+
+	mFailed := map[string]*elastic.BulkResponseItem{
+		"delete": {
+			Index:  testIndex,
+			Id:     testKey,
+			Status: 400,
+		},
+	}
+	response := &elastic.BulkResponse{
+		Took:   3,
+		Errors: true,
+		Items:  []map[string]*elastic.BulkResponseItem{mFailed},
+	}
+
+	payload := s.getEncodedMsg("test-workflowID", "test-runID", "test-namespaceID")
+	mockKafkaMsg := &msgMocks.Message{}
+	mapVal := newKafkaMessageWithMetrics(mockKafkaMsg, &testStopWatch)
+	s.esProcessor.mapToKafkaMsg.Put(testKey, mapVal)
+	mockKafkaMsg.On("Nack").Return(nil).Once()
+	mockKafkaMsg.On("Value").Return(payload).Twice()
+	mockScope := &mmocks.Scope{}
+	mockScope.On("IncCounter", metrics.ESProcessorDLQWriteFailures).Once()
+	s.mockMetricClient.On("Scope", metrics.ESProcessorScope, mock.Anything).Return(mockScope).Once()
+	mockDLQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(msg *indexergenpb.DLQMessage) error {
+		s.Equal(enumsgenpb.MESSAGE_TYPE_DELETE, msg.GetMessageType())
+		s.Empty(msg.GetDoc())
+		return errors.New("some error")
+	})
+
+	s.esProcessor.bulkAfterAction(0, requests, response, nil)
+	mockKafkaMsg.AssertExpectations(s.T())
+	mockScope.AssertExpectations(s.T())
+}
+
 func (s *esProcessorSuite) TestBulkAfterAction_Error() {
 	version := int64(3)
 	request := elastic.NewBulkIndexRequest().
 		Index(testIndex).
 		Type(testType).
 		Id(testID).
-		VersionType(versionTypeExternal).
+		VersionType("external").
 		Version(version)
 	requests := []elastic.BulkableRequest{request}
 
@@ -295,7 +416,7 @@ func (s *esProcessorSuite) TestAckKafkaMsg() {
 	// no msg in map, nothing called
 	s.esProcessor.ackKafkaMsg(key)
 
-	request := elastic.NewBulkIndexRequest()
+	request := &es.BulkableRequest{}
 	mockKafkaMsg := &msgMocks.Message{}
 	s.mockMetricClient.On("StartTimer", testScope, testMetric).Return(testStopWatch).Once()
 	s.mockBulkProcessor.On("Add", request).Return().Once()
@@ -313,7 +434,7 @@ func (s *esProcessorSuite) TestNackKafkaMsg() {
 	// no msg in map, nothing called
 	s.esProcessor.nackKafkaMsg(key)
 
-	request := elastic.NewBulkIndexRequest()
+	request := &es.BulkableRequest{}
 	mockKafkaMsg := &msgMocks.Message{}
 	s.mockBulkProcessor.On("Add", request).Return().Once()
 	s.mockMetricClient.On("StartTimer", testScope, testMetric).Return(testStopWatch).Once()
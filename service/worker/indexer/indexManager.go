@@ -0,0 +1,297 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/definition"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// indexManager maintains the rollover indices behind visibility aliases of namespaces with their own index:
+	// indices for current and next rollover period are created ahead of writes, indices of older periods are
+	// created by the index processor before it writes to them, and indices which only hold records older than
+	// namespace retention are deleted.
+	indexManager struct {
+		esClient      es.Client
+		metadataMgr   persistence.MetadataManager
+		config        *Config
+		logger        log.Logger
+		metricsClient metrics.Client
+		timeSource    clock.TimeSource
+		// knownIndices holds indices which exist along with the mapping of custom search attributes
+		knownIndices cache.Cache
+
+		status     int32
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+	}
+
+	// namespaceAlias holds the longest retention and the rollover intervals of namespaces sharing a visibility alias
+	namespaceAlias struct {
+		retention         time.Duration
+		rolloverIntervals map[time.Duration]struct{}
+	}
+)
+
+const (
+	indexManagerListNamespacesPageSize = 100
+	indexManagerOperationTimeout       = time.Minute
+	indexManagerKnownIndicesMaxSize    = 1000
+	indexManagerKnownIndicesTTL        = time.Hour
+
+	// matches records which are open, or closed after given time
+	liveRecordsQueryTemplate = `{"query":{"bool":{"should":[{"bool":{"must_not":{"exists":{"field":"%v"}}}},{"range":{"%v":{"gte":%v}}}]}}}`
+)
+
+func newIndexManager(esClient es.Client, metadataMgr persistence.MetadataManager, config *Config,
+	logger log.Logger, metricsClient metrics.Client) *indexManager {
+	return &indexManager{
+		esClient:      esClient,
+		metadataMgr:   metadataMgr,
+		config:        config,
+		logger:        logger.WithTags(tag.ComponentIndexer),
+		metricsClient: metricsClient,
+		timeSource:    clock.NewRealTimeSource(),
+		knownIndices: cache.New(indexManagerKnownIndicesMaxSize, &cache.Options{
+			TTL: indexManagerKnownIndicesTTL,
+		}),
+		status:     common.DaemonStatusInitialized,
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+func (m *indexManager) Start() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	m.shutdownWG.Add(1)
+	go m.manageLoop()
+	m.logger.Info("Index manager started.")
+}
+
+func (m *indexManager) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(m.shutdownCh)
+	if success := common.AwaitWaitGroup(&m.shutdownWG, time.Minute); !success {
+		m.logger.Warn("Index manager timed out on shutdown.")
+	}
+	m.logger.Info("Index manager stopped.")
+}
+
+func (m *indexManager) manageLoop() {
+	defer m.shutdownWG.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case <-timer.C:
+			if err := m.manageIndices(); err != nil {
+				m.logger.Error("Failed to manage namespace visibility indices.", tag.Error(err))
+				m.metricsClient.IncCounter(metrics.ESIndexManagerScope, metrics.ESIndexManagerFailures)
+			}
+			timer.Reset(m.config.ESIndexManagerInterval())
+		}
+	}
+}
+
+// manageIndices maintains indices of all visibility aliases configured on namespaces
+func (m *indexManager) manageIndices() error {
+	aliases, err := m.getAliases()
+	if err != nil {
+		return err
+	}
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), indexManagerOperationTimeout)
+	defer cancel()
+	indices, err := m.esClient.CatIndices(ctx)
+	if err != nil {
+		return err
+	}
+
+	for alias, aliasInfo := range aliases {
+		if err := m.manageAliasIndices(ctx, alias, aliasInfo, indices); err != nil {
+			m.logger.Error("Failed to manage indices of namespace visibility alias.", tag.ESIndex(alias), tag.Error(err))
+			m.metricsClient.IncCounter(metrics.ESIndexManagerScope, metrics.ESIndexManagerFailures)
+		}
+	}
+	return nil
+}
+
+// getAliases returns all visibility aliases configured on namespaces, with the longest retention and the
+// rollover intervals of namespaces sharing the alias
+func (m *indexManager) getAliases() (map[string]*namespaceAlias, error) {
+	aliases := make(map[string]*namespaceAlias)
+	var pageToken []byte
+	for {
+		resp, err := m.metadataMgr.ListNamespaces(&persistence.ListNamespacesRequest{
+			PageSize:      indexManagerListNamespacesPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range resp.Namespaces {
+			config := ns.Namespace.GetConfig()
+			alias := config.GetVisibilityIndex()
+			if len(alias) == 0 {
+				continue
+			}
+			aliasInfo, ok := aliases[alias]
+			if !ok {
+				aliasInfo = &namespaceAlias{rolloverIntervals: make(map[time.Duration]struct{})}
+				aliases[alias] = aliasInfo
+			}
+			retention := time.Duration(config.GetRetentionDays()) * 24 * time.Hour
+			if retention > aliasInfo.retention {
+				aliasInfo.retention = retention
+			}
+			aliasInfo.rolloverIntervals[es.GetRolloverInterval(config.GetVisibilityIndexRolloverIntervalSeconds())] = struct{}{}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return aliases, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (m *indexManager) manageAliasIndices(ctx context.Context, alias string, aliasInfo *namespaceAlias, indices elastic.CatIndicesResponse) error {
+	if err := m.esClient.PutTemplate(ctx, es.GetRolloverTemplateName(alias), es.BuildRolloverTemplateBody(alias)); err != nil {
+		return err
+	}
+
+	now := m.timeSource.Now()
+	// namespaces sharing the alias may have different rollover intervals pinned, the longest
+	// one bounds the start time of records in any index behind the alias
+	var maxInterval time.Duration
+	for interval := range aliasInfo.rolloverIntervals {
+		for _, periodTime := range []time.Time{now, now.Add(interval)} {
+			if err := m.ensureIndex(ctx, es.GetRolloverIndexName(alias, periodTime, interval)); err != nil {
+				return err
+			}
+		}
+		if interval > maxInterval {
+			maxInterval = interval
+		}
+	}
+	retention := aliasInfo.retention
+
+	for _, row := range indices {
+		periodStart, ok := es.ParseRolloverIndexName(alias, row.Index)
+		if !ok {
+			continue
+		}
+		// records in the index are started before period end, so they are closed before retention as well
+		if periodStart.Add(maxInterval).Add(retention).After(now) {
+			continue
+		}
+		if err := m.deleteExpiredIndex(ctx, row.Index, now.Add(-retention)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureIndex creates the index if not exists, along with mapping of custom search attributes
+// which are not part of the index template. The mapping is put on existing indices as well,
+// as they may be created by a previous attempt which failed to put the mapping.
+func (m *indexManager) ensureIndex(ctx context.Context, index string) error {
+	if m.knownIndices.Get(index) != nil {
+		return nil
+	}
+
+	exists, err := m.esClient.IndexExists(ctx, index)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := m.esClient.CreateIndex(ctx, index); err != nil {
+			return err
+		}
+		m.logger.Info("Created namespace visibility index.", tag.ESIndex(index))
+		m.metricsClient.IncCounter(metrics.ESIndexManagerScope, metrics.ESIndexManagerIndexCreatedCount)
+	}
+	for key, valueType := range m.config.ValidSearchAttributes() {
+		if definition.IsSystemIndexedKey(key) {
+			continue
+		}
+		esType := es.ConvertIndexedValueTypeToESDataType(common.ConvertIndexedValueTypeToProtoType(valueType, m.logger))
+		if len(esType) == 0 {
+			continue
+		}
+		if err := m.esClient.PutMapping(ctx, index, definition.Attr, key, esType); err != nil {
+			return err
+		}
+	}
+
+	m.knownIndices.Put(index, struct{}{})
+	return nil
+}
+
+// deleteExpiredIndex deletes the index if it has no open records nor records closed after given time.
+// Records which are still live are kept until they are deleted by retention.
+func (m *indexManager) deleteExpiredIndex(ctx context.Context, index string, closedAfter time.Time) error {
+	query := fmt.Sprintf(liveRecordsQueryTemplate, es.ExecutionStatus, es.CloseTime, closedAfter.UnixNano())
+	count, err := m.esClient.Count(ctx, index, query)
+	if err != nil {
+		return err
+	}
+	if count != 0 {
+		return nil
+	}
+
+	if err := m.esClient.DeleteIndex(ctx, index); err != nil {
+		return err
+	}
+	m.knownIndices.Delete(index)
+	m.logger.Info("Deleted expired namespace visibility index.", tag.ESIndex(index))
+	m.metricsClient.IncCounter(metrics.ESIndexManagerScope, metrics.ESIndexManagerIndexDeletedCount)
+	return nil
+}
@@ -0,0 +1,181 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/definition"
+	esMocks "github.com/temporalio/temporal/common/elasticsearch/mocks"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	mmocks "github.com/temporalio/temporal/common/metrics/mocks"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type indexManagerSuite struct {
+	suite.Suite
+	mockESClient     *esMocks.Client
+	mockMetadataMgr  *mocks.MetadataManager
+	mockMetricClient *mmocks.Client
+	indexManager     *indexManager
+}
+
+const (
+	testAlias = "temporal-visibility-ns"
+)
+
+func TestIndexManagerSuite(t *testing.T) {
+	suite.Run(t, new(indexManagerSuite))
+}
+
+func (s *indexManagerSuite) SetupTest() {
+	config := &Config{
+		ValidSearchAttributes: dynamicconfig.GetMapPropertyFn(map[string]interface{}{
+			definition.WorkflowID: enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			"CustomIntField":      enumspb.INDEXED_VALUE_TYPE_INT,
+		}),
+		ESIndexManagerInterval: dynamicconfig.GetDurationPropertyFn(time.Hour),
+	}
+	s.mockESClient = &esMocks.Client{}
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockMetricClient = &mmocks.Client{}
+	s.indexManager = newIndexManager(s.mockESClient, s.mockMetadataMgr, config, loggerimpl.NewNopLogger(), s.mockMetricClient)
+	s.indexManager.timeSource = clock.NewEventTimeSource().Update(time.Date(2020, 6, 15, 13, 0, 0, 0, time.UTC))
+}
+
+func (s *indexManagerSuite) TearDownTest() {
+	s.mockESClient.AssertExpectations(s.T())
+	s.mockMetadataMgr.AssertExpectations(s.T())
+	s.mockMetricClient.AssertExpectations(s.T())
+}
+
+func (s *indexManagerSuite) TestGetAliases() {
+	s.mockMetadataMgr.On("ListNamespaces", &persistence.ListNamespacesRequest{
+		PageSize: indexManagerListNamespacesPageSize,
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			newTestNamespace("", 7),
+			newTestNamespace(testAlias, 3),
+		},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	s.mockMetadataMgr.On("ListNamespaces", &persistence.ListNamespacesRequest{
+		PageSize:      indexManagerListNamespacesPageSize,
+		NextPageToken: []byte("token"),
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			newTestNamespaceWithRolloverInterval(testAlias, 1, time.Hour),
+			newTestNamespace("temporal-visibility-other", 0),
+		},
+	}, nil).Once()
+
+	aliases, err := s.indexManager.getAliases()
+	s.NoError(err)
+	s.Equal(map[string]*namespaceAlias{
+		testAlias: {
+			retention:         3 * 24 * time.Hour,
+			rolloverIntervals: map[time.Duration]struct{}{24 * time.Hour: {}, time.Hour: {}},
+		},
+		"temporal-visibility-other": {
+			retention:         0,
+			rolloverIntervals: map[time.Duration]struct{}{24 * time.Hour: {}},
+		},
+	}, aliases)
+}
+
+func (s *indexManagerSuite) TestManageIndices() {
+	s.mockMetadataMgr.On("ListNamespaces", mock.Anything).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{newTestNamespace(testAlias, 3)},
+	}, nil).Once()
+	s.mockESClient.On("CatIndices", mock.Anything).Return(elastic.CatIndicesResponse{
+		{Index: "temporal-visibility-dev"},
+		{Index: testAlias + "-20200601-0000"}, // expired and empty
+		{Index: testAlias + "-20200610-0000"}, // expired with live records
+		{Index: testAlias + "-20200613-0000"}, // within retention
+		{Index: testAlias + "-20200615-0000"},
+	}, nil).Once()
+
+	s.mockESClient.On("PutTemplate", mock.Anything, testAlias+"-template", mock.Anything).Return(nil).Once()
+	s.mockESClient.On("IndexExists", mock.Anything, testAlias+"-20200615-0000").Return(true, nil).Once()
+	s.mockESClient.On("PutMapping", mock.Anything, testAlias+"-20200615-0000", definition.Attr, "CustomIntField", "long").Return(nil).Once()
+	s.mockESClient.On("IndexExists", mock.Anything, testAlias+"-20200616-0000").Return(false, nil).Once()
+	s.mockESClient.On("CreateIndex", mock.Anything, testAlias+"-20200616-0000").Return(nil).Once()
+	s.mockESClient.On("PutMapping", mock.Anything, testAlias+"-20200616-0000", definition.Attr, "CustomIntField", "long").Return(nil).Once()
+	s.mockESClient.On("Count", mock.Anything, testAlias+"-20200601-0000", mock.Anything).Return(int64(0), nil).Once()
+	s.mockESClient.On("Count", mock.Anything, testAlias+"-20200610-0000", mock.Anything).Return(int64(1), nil).Once()
+	s.mockESClient.On("DeleteIndex", mock.Anything, testAlias+"-20200601-0000").Return(nil).Once()
+	s.mockMetricClient.On("IncCounter", metrics.ESIndexManagerScope, metrics.ESIndexManagerIndexCreatedCount).Once()
+	s.mockMetricClient.On("IncCounter", metrics.ESIndexManagerScope, metrics.ESIndexManagerIndexDeletedCount).Once()
+
+	s.NoError(s.indexManager.manageIndices())
+}
+
+func (s *indexManagerSuite) TestEnsureIndex_Known() {
+	s.mockESClient.On("IndexExists", mock.Anything, testAlias+"-20200101-0000").Return(false, nil).Once()
+	s.mockESClient.On("CreateIndex", mock.Anything, testAlias+"-20200101-0000").Return(nil).Once()
+	s.mockESClient.On("PutMapping", mock.Anything, testAlias+"-20200101-0000", definition.Attr, "CustomIntField", "long").Return(nil).Once()
+	s.mockMetricClient.On("IncCounter", metrics.ESIndexManagerScope, metrics.ESIndexManagerIndexCreatedCount).Once()
+
+	// index of an old rollover period is created with its mapping once
+	for i := 0; i < 2; i++ {
+		s.NoError(s.indexManager.ensureIndex(context.Background(), testAlias+"-20200101-0000"))
+	}
+}
+
+func (s *indexManagerSuite) TestManageIndices_NoNamespaceIndex() {
+	s.mockMetadataMgr.On("ListNamespaces", mock.Anything).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{newTestNamespace("", 3)},
+	}, nil).Once()
+
+	s.NoError(s.indexManager.manageIndices())
+}
+
+func newTestNamespace(visibilityIndex string, retentionDays int32) *persistence.GetNamespaceResponse {
+	return newTestNamespaceWithRolloverInterval(visibilityIndex, retentionDays, 0)
+}
+
+func newTestNamespaceWithRolloverInterval(visibilityIndex string, retentionDays int32, rolloverInterval time.Duration) *persistence.GetNamespaceResponse {
+	return &persistence.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Config: &persistenceblobs.NamespaceConfig{
+				RetentionDays:                          retentionDays,
+				VisibilityIndex:                        visibilityIndex,
+				VisibilityIndexRolloverIntervalSeconds: int64(rolloverInterval / time.Second),
+			},
+		},
+	}
+}
@@ -33,6 +33,8 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -42,9 +44,12 @@ type (
 		config              *Config
 		kafkaClient         messaging.Client
 		esClient            es.Client
+		metadataMgr         persistence.MetadataManager
+		dlq                 persistence.VisibilityIndexerDLQ
 		logger              log.Logger
 		metricsClient       metrics.Client
 		visibilityProcessor *indexProcessor
+		indexManager        *indexManager
 		visibilityIndexName string
 	}
 
@@ -56,6 +61,8 @@ type (
 		ESProcessorBulkSize      dynamicconfig.IntPropertyFn // max total size of bytes in bulk
 		ESProcessorFlushInterval dynamicconfig.DurationPropertyFn
 		ValidSearchAttributes    dynamicconfig.MapPropertyFn
+		// configs for namespaces with their own visibility index
+		ESIndexManagerInterval dynamicconfig.DurationPropertyFn
 	}
 )
 
@@ -65,13 +72,16 @@ const (
 
 // NewIndexer create a new Indexer
 func NewIndexer(config *Config, client messaging.Client, esClient es.Client, esConfig *es.Config,
-	logger log.Logger, metricsClient metrics.Client) *Indexer {
+	metadataMgr persistence.MetadataManager, dlq persistence.VisibilityIndexerDLQ, logger log.Logger,
+	metricsClient metrics.Client) *Indexer {
 	logger = logger.WithTags(tag.ComponentIndexer)
 
 	return &Indexer{
 		config:              config,
 		kafkaClient:         client,
 		esClient:            esClient,
+		metadataMgr:         metadataMgr,
+		dlq:                 dlq,
 		logger:              logger,
 		metricsClient:       metricsClient,
 		visibilityIndexName: esConfig.Indices[common.VisibilityAppName],
@@ -79,17 +89,28 @@ func NewIndexer(config *Config, client messaging.Client, esClient es.Client, esC
 }
 
 // Start indexer
-func (x Indexer) Start() error {
+func (x *Indexer) Start() error {
 	visibilityApp := common.VisibilityAppName
 	visConsumerName := getConsumerName(x.visibilityIndexName)
-	x.visibilityProcessor = newIndexProcessor(visibilityApp, visConsumerName, x.kafkaClient, x.esClient,
-		visibilityProcessorName, x.visibilityIndexName, x.config, x.logger, x.metricsClient)
-	return x.visibilityProcessor.Start()
+	indexResolver := espersistence.NewNamespaceIndexResolver(x.metadataMgr)
+	x.indexManager = newIndexManager(x.esClient, x.metadataMgr, x.config, x.logger, x.metricsClient)
+	x.visibilityProcessor = newIndexProcessor(visibilityApp, visConsumerName, x.kafkaClient, x.esClient, indexResolver,
+		x.indexManager, x.dlq, visibilityProcessorName, x.visibilityIndexName, x.config, x.logger, x.metricsClient)
+	if err := x.visibilityProcessor.Start(); err != nil {
+		return err
+	}
+	x.indexManager.Start()
+	return nil
 }
 
 // Stop indexer
-func (x Indexer) Stop() {
-	x.visibilityProcessor.Stop()
+func (x *Indexer) Stop() {
+	if x.indexManager != nil {
+		x.indexManager.Stop()
+	}
+	if x.visibilityProcessor != nil {
+		x.visibilityProcessor.Stop()
+	}
 }
 
 func getConsumerName(topic string) string {
@@ -25,13 +25,13 @@
 package indexer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/olivere/elastic"
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
@@ -44,14 +44,24 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
 )
 
+// indexEnsurer creates the rollover indices behind namespace visibility aliases
+type indexEnsurer interface {
+	ensureIndex(ctx context.Context, index string) error
+}
+
 type indexProcessor struct {
 	appName         string
 	consumerName    string
 	kafkaClient     messaging.Client
 	consumer        messaging.Consumer
 	esClient        es.Client
+	indexResolver   espersistence.NamespaceIndexResolver
+	indexEnsurer    indexEnsurer
+	dlq             persistence.VisibilityIndexerDLQ
 	esProcessor     ESProcessor
 	esProcessorName string
 	esIndexName     string
@@ -67,22 +77,24 @@ type indexProcessor struct {
 
 const (
 	esDocIDDelimiter = "~"
-	esDocType        = "_doc"
-
-	versionTypeExternal = "external"
 )
 
 var (
 	errUnknownMessageType = serviceerror.NewInvalidArgument("unknown message type")
+	errMissingStartTime   = serviceerror.NewInvalidArgument("start time is required to route message to namespace index")
 )
 
 func newIndexProcessor(appName, consumerName string, kafkaClient messaging.Client, esClient es.Client,
-	esProcessorName, esIndexName string, config *Config, logger log.Logger, metricsClient metrics.Client) *indexProcessor {
+	indexResolver espersistence.NamespaceIndexResolver, indexEnsurer indexEnsurer, dlq persistence.VisibilityIndexerDLQ, esProcessorName, esIndexName string, config *Config,
+	logger log.Logger, metricsClient metrics.Client) *indexProcessor {
 	return &indexProcessor{
 		appName:         appName,
 		consumerName:    consumerName,
 		kafkaClient:     kafkaClient,
 		esClient:        esClient,
+		indexResolver:   indexResolver,
+		indexEnsurer:    indexEnsurer,
+		dlq:             dlq,
 		esProcessorName: esProcessorName,
 		esIndexName:     esIndexName,
 		config:          config,
@@ -110,7 +122,7 @@ func (p *indexProcessor) Start() error {
 		return err
 	}
 
-	esProcessor, err := NewESProcessorAndStart(p.config, p.esClient, p.esProcessorName, p.logger, p.metricsClient, p.msgEncoder)
+	esProcessor, err := NewESProcessorAndStart(p.config, p.esClient, p.esProcessorName, p.dlq, p.logger, p.metricsClient, p.msgEncoder)
 	if err != nil {
 		p.logger.Info("", tag.LifeCycleStartFailed, tag.Error(err))
 		return err
@@ -199,27 +211,39 @@ func (p *indexProcessor) deserialize(payload []byte) (*indexergenpb.Message, err
 func (p *indexProcessor) addMessageToES(indexMsg *indexergenpb.Message, kafkaMsg messaging.Message, logger log.Logger) error {
 	docID := indexMsg.GetWorkflowId() + esDocIDDelimiter + indexMsg.GetRunId()
 
+	index, err := p.getIndex(indexMsg)
+	if err == errMissingStartTime {
+		// message can never be routed, retrying won't help
+		logger.Error("Failed to route message to namespace index.", tag.WorkflowNamespaceID(indexMsg.GetNamespaceId()), tag.Error(err))
+		p.metricsClient.IncCounter(metrics.IndexProcessorScope, metrics.IndexProcessorCorruptedData)
+		return kafkaMsg.Ack()
+	}
+	if err != nil {
+		logger.Error("Failed to resolve namespace index.", tag.WorkflowNamespaceID(indexMsg.GetNamespaceId()), tag.Error(err))
+		return err
+	}
+
 	var keyToKafkaMsg string
-	var req elastic.BulkableRequest
+	var req *es.BulkableRequest
 	switch indexMsg.GetMessageType() {
 	case enumsgenpb.MESSAGE_TYPE_INDEX:
 		keyToKafkaMsg = fmt.Sprintf("%v-%v", kafkaMsg.Partition(), kafkaMsg.Offset())
 		doc := p.generateESDoc(indexMsg, keyToKafkaMsg)
-		req = elastic.NewBulkIndexRequest().
-			Index(p.esIndexName).
-			Type(esDocType).
-			Id(docID).
-			VersionType(versionTypeExternal).
-			Version(indexMsg.GetVersion()).
-			Doc(doc)
+		req = &es.BulkableRequest{
+			RequestType: es.BulkableIndexRequest,
+			Index:       index,
+			ID:          docID,
+			Version:     indexMsg.GetVersion(),
+			Doc:         doc,
+		}
 	case enumsgenpb.MESSAGE_TYPE_DELETE:
 		keyToKafkaMsg = docID
-		req = elastic.NewBulkDeleteRequest().
-			Index(p.esIndexName).
-			Type(esDocType).
-			Id(docID).
-			VersionType(versionTypeExternal).
-			Version(indexMsg.GetVersion())
+		req = &es.BulkableRequest{
+			RequestType: es.BulkableDeleteRequest,
+			Index:       index,
+			ID:          docID,
+			Version:     indexMsg.GetVersion(),
+		}
 	default:
 		logger.Error("Unknown message type")
 		p.metricsClient.IncCounter(metrics.IndexProcessorScope, metrics.IndexProcessorCorruptedData)
@@ -230,6 +254,40 @@ func (p *indexProcessor) addMessageToES(indexMsg *indexergenpb.Message, kafkaMsg
 	return nil
 }
 
+// getIndex returns the index a message is written to. Namespaces with their own visibility index are written to
+// the rollover index behind the namespace alias that covers workflow start time, using the rollover interval pinned
+// on the namespace. Indices are only created ahead of writes for the current and next rollover period, so the index
+// is created along with its mapping here in case it would otherwise be auto created by the write.
+func (p *indexProcessor) getIndex(indexMsg *indexergenpb.Message) (string, error) {
+	if p.indexResolver == nil {
+		return p.esIndexName, nil
+	}
+	alias, err := p.indexResolver.GetNamespaceIndex(indexMsg.GetNamespaceId())
+	if err != nil {
+		return "", err
+	}
+	if len(alias) == 0 {
+		return p.esIndexName, nil
+	}
+
+	startTime := indexMsg.GetFields()[es.StartTime].GetIntData()
+	if startTime == 0 {
+		return "", errMissingStartTime
+	}
+	interval, err := p.indexResolver.GetNamespaceIndexRolloverInterval(indexMsg.GetNamespaceId())
+	if err != nil {
+		return "", err
+	}
+
+	index := es.GetRolloverIndexName(alias, time.Unix(0, startTime), interval)
+	ctx, cancel := context.WithTimeout(context.Background(), indexManagerOperationTimeout)
+	defer cancel()
+	if err := p.indexEnsurer.ensureIndex(ctx, index); err != nil {
+		return "", err
+	}
+	return index, nil
+}
+
 func (p *indexProcessor) generateESDoc(msg *indexergenpb.Message, keyToKafkaMsg string) map[string]interface{} {
 	doc := p.dumpFieldsToMap(msg.Fields)
 	fulfillDoc(doc, msg, keyToKafkaMsg)
@@ -0,0 +1,96 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package indexer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
+	es "github.com/temporalio/temporal/common/elasticsearch"
+)
+
+type (
+	indexProcessorSuite struct {
+		suite.Suite
+		processor    *indexProcessor
+		indexEnsurer *testIndexEnsurer
+	}
+
+	testIndexResolver map[string]string
+
+	testIndexEnsurer struct {
+		indices []string
+	}
+)
+
+func TestIndexProcessorSuite(t *testing.T) {
+	suite.Run(t, new(indexProcessorSuite))
+}
+
+func (s *indexProcessorSuite) SetupTest() {
+	s.indexEnsurer = &testIndexEnsurer{}
+	s.processor = &indexProcessor{
+		esIndexName:   testIndex,
+		indexResolver: testIndexResolver{"routed-namespace-id": testAlias},
+		indexEnsurer:  s.indexEnsurer,
+	}
+}
+
+func (s *indexProcessorSuite) TestGetIndex() {
+	startTime := time.Date(2020, 6, 15, 13, 0, 0, 0, time.UTC).UnixNano()
+	fields := map[string]*indexergenpb.Field{
+		es.StartTime: {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: startTime}},
+	}
+
+	index, err := s.processor.getIndex(&indexergenpb.Message{NamespaceId: "namespace-id", Fields: fields})
+	s.NoError(err)
+	s.Equal(testIndex, index)
+
+	index, err = s.processor.getIndex(&indexergenpb.Message{NamespaceId: "routed-namespace-id", Fields: fields})
+	s.NoError(err)
+	s.Equal(testAlias+"-20200615-1300", index)
+	// the index is created along with its mapping before it is written to
+	s.Equal([]string{testAlias + "-20200615-1300"}, s.indexEnsurer.indices)
+
+	_, err = s.processor.getIndex(&indexergenpb.Message{NamespaceId: "routed-namespace-id"})
+	s.Equal(errMissingStartTime, err)
+}
+
+func (r testIndexResolver) GetNamespaceIndex(namespaceID string) (string, error) {
+	return r[namespaceID], nil
+}
+
+func (r testIndexResolver) GetNamespaceIndexRolloverInterval(namespaceID string) (time.Duration, error) {
+	return time.Hour, nil
+}
+
+func (e *testIndexEnsurer) ensureIndex(ctx context.Context, index string) error {
+	e.indices = append(e.indices, index)
+	return nil
+}
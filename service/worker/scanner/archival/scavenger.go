@@ -0,0 +1,572 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archival
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	"go.temporal.io/temporal-proto/serviceerror"
+	workflowpb "go.temporal.io/temporal-proto/workflow/v1"
+	"go.temporal.io/temporal/activity"
+	"golang.org/x/time/rate"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+	carchiver "github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/convert"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payloadstore"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// ScavengerHeartbeatDetails is the heartbeat detail for ArchivalScavengerActivity
+	ScavengerHeartbeatDetails struct {
+		// NamespacePageToken is the token used to read the page of namespaces currently being scanned
+		NamespacePageToken []byte
+		// NamespaceID is the namespace currently being scanned
+		NamespaceID string
+		// ExecutionPageToken is the token of the next page of closed executions of NamespaceID
+		ExecutionPageToken []byte
+		Report             Report
+	}
+
+	// Report summarizes the result of one run of the archival scavenger
+	Report struct {
+		VerifiedCount   int
+		MissingCount    int
+		MismatchCount   int
+		RearchivedCount int
+		SkipCount       int
+		ErrorCount      int
+		// Corruptions lists the executions whose archived history was missing or did not match
+		// the history store, capped at maxReportedCorruptions entries
+		Corruptions []CorruptedExecution
+	}
+
+	// CorruptedExecution describes an execution whose archived history failed verification
+	CorruptedExecution struct {
+		NamespaceID string
+		WorkflowID  string
+		RunID       string
+		Reason      string
+		Rearchived  bool
+	}
+
+	// Scavenger is the type that holds the state for archival scavenger daemon
+	Scavenger struct {
+		metadataMgr      persistence.MetadataManager
+		visibilityMgr    persistence.VisibilityManager
+		historyMgr       persistence.HistoryManager
+		client           historyservice.HistoryServiceClient
+		archiverProvider provider.ArchiverProvider
+		numHistoryShards int
+		samplingRate     float64
+		closeGracePeriod time.Duration
+		hbd              ScavengerHeartbeatDetails
+		rps              int
+		limiter          *rate.Limiter
+		metrics          metrics.Client
+		logger           log.Logger
+		isInTest         bool
+	}
+
+	taskDetail struct {
+		namespace  *persistenceblobs.NamespaceDetail
+		workflowID string
+		runID      string
+	}
+
+	taskResult struct {
+		task       taskDetail
+		status     verifyStatus
+		reason     string
+		rearchived bool
+		err        error
+	}
+
+	verifyStatus int
+)
+
+const (
+	verifyStatusVerified verifyStatus = iota
+	verifyStatusMissing
+	verifyStatusMismatch
+	verifyStatusSkipped
+	verifyStatusError
+)
+
+const (
+	// used this to decide how many goroutines to process
+	rpsPerConcurrency      = 50
+	namespacePageSize      = 100
+	executionPageSize      = 1000
+	historyPageSize        = 100
+	maxReportedCorruptions = 1000
+)
+
+// NewScavenger returns an instance of archival scavenger daemon.
+// Each Run verifies a sample of the closed executions of namespaces with history archival enabled
+// by comparing a summary of the archived history with the history store, and re-archives the
+// executions whose archived history is missing or does not match.
+// Executions closed within closeGracePeriod are skipped as their archival may not have run yet.
+func NewScavenger(
+	metadataMgr persistence.MetadataManager,
+	visibilityMgr persistence.VisibilityManager,
+	historyMgr persistence.HistoryManager,
+	payloadStoreProvider payloadstore.Provider,
+	client historyservice.HistoryServiceClient,
+	archiverProvider provider.ArchiverProvider,
+	numHistoryShards int,
+	samplingRate float64,
+	closeGracePeriod time.Duration,
+	rps int,
+	hbd ScavengerHeartbeatDetails,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *Scavenger {
+
+	rateLimiter := rate.NewLimiter(rate.Limit(rps), rps)
+
+	// histories are archived with offloaded payloads inline, so they are summarized the same way
+	historyMgr = payloadstore.NewInflatingHistoryManager(historyMgr, payloadStoreProvider)
+
+	return &Scavenger{
+		metadataMgr:      metadataMgr,
+		visibilityMgr:    visibilityMgr,
+		historyMgr:       historyMgr,
+		client:           client,
+		archiverProvider: archiverProvider,
+		numHistoryShards: numHistoryShards,
+		samplingRate:     samplingRate,
+		closeGracePeriod: closeGracePeriod,
+		hbd:              hbd,
+		rps:              rps,
+		limiter:          rateLimiter,
+		metrics:          metricsClient,
+		logger:           logger,
+	}
+}
+
+// Run runs the scavenger
+func (s *Scavenger) Run(ctx context.Context) (Report, error) {
+	taskCh := make(chan taskDetail, executionPageSize)
+	respCh := make(chan taskResult, executionPageSize)
+	concurrency := s.rps/rpsPerConcurrency + 1
+
+	for i := 0; i < concurrency; i++ {
+		go s.startTaskProcessor(ctx, taskCh, respCh)
+	}
+
+	for {
+		resp, err := s.metadataMgr.ListNamespaces(&persistence.ListNamespacesRequest{
+			PageSize:      namespacePageSize,
+			NextPageToken: s.hbd.NamespacePageToken,
+		})
+		if err != nil {
+			return s.hbd.Report, err
+		}
+
+		for _, ns := range s.namespacesToScan(resp.Namespaces) {
+			if s.hbd.NamespaceID != ns.Namespace.Info.Id {
+				s.hbd.NamespaceID = ns.Namespace.Info.Id
+				s.hbd.ExecutionPageToken = nil
+			}
+			if err := s.scanNamespace(ctx, ns.Namespace, taskCh, respCh); err != nil {
+				return s.hbd.Report, err
+			}
+		}
+
+		s.hbd.NamespacePageToken = resp.NextPageToken
+		s.hbd.NamespaceID = ""
+		s.hbd.ExecutionPageToken = nil
+		s.recordHeartbeat(ctx)
+
+		if len(s.hbd.NamespacePageToken) == 0 {
+			break
+		}
+	}
+	return s.hbd.Report, nil
+}
+
+// namespacesToScan returns the namespaces of the current page which still need to be scanned,
+// skipping the ones already scanned before the activity was restarted from a heartbeat
+func (s *Scavenger) namespacesToScan(namespaces []*persistence.GetNamespaceResponse) []*persistence.GetNamespaceResponse {
+	if s.hbd.NamespaceID == "" {
+		return namespaces
+	}
+	for i, ns := range namespaces {
+		if ns.Namespace.Info.Id == s.hbd.NamespaceID {
+			return namespaces[i:]
+		}
+	}
+	return namespaces
+}
+
+func (s *Scavenger) scanNamespace(
+	ctx context.Context,
+	namespace *persistenceblobs.NamespaceDetail,
+	taskCh chan taskDetail,
+	respCh chan taskResult,
+) error {
+
+	if namespace.Config.HistoryArchivalStatus != enumspb.ARCHIVAL_STATUS_ENABLED || namespace.Config.HistoryArchivalURI == "" {
+		return nil
+	}
+
+	for {
+		resp, err := s.visibilityMgr.ListClosedWorkflowExecutions(&persistence.ListWorkflowExecutionsRequest{
+			NamespaceID:       namespace.Info.Id,
+			Namespace:         namespace.Info.Name,
+			EarliestStartTime: 0,
+			LatestStartTime:   time.Now().UnixNano(),
+			PageSize:          executionPageSize,
+			NextPageToken:     s.hbd.ExecutionPageToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		batchCount := 0
+		skips := 0
+		closedBefore := time.Now().Add(-s.closeGracePeriod).UnixNano()
+		for _, execution := range resp.Executions {
+			if !s.sampled(execution) || execution.GetCloseTime().GetValue() > closedBefore {
+				skips++
+				s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerSkipCount)
+				continue
+			}
+			batchCount++
+			taskCh <- taskDetail{
+				namespace:  namespace,
+				workflowID: execution.Execution.GetWorkflowId(),
+				runID:      execution.Execution.GetRunId(),
+			}
+		}
+
+		for i := 0; i < batchCount; i++ {
+			select {
+			case result := <-respCh:
+				s.recordResult(result)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		s.hbd.Report.SkipCount += skips
+		s.hbd.ExecutionPageToken = resp.NextPageToken
+		s.recordHeartbeat(ctx)
+
+		if len(s.hbd.ExecutionPageToken) == 0 {
+			return nil
+		}
+	}
+}
+
+// sampled decides if the given execution should be verified in this run.
+// Sampling is based on the run ID so that retries of the activity make the same decision.
+func (s *Scavenger) sampled(execution *workflowpb.WorkflowExecutionInfo) bool {
+	if s.samplingRate >= 1 {
+		return true
+	}
+	hash := farm.Fingerprint32([]byte(execution.Execution.GetRunId()))
+	return float64(hash)/math.MaxUint32 < s.samplingRate
+}
+
+func (s *Scavenger) recordResult(result taskResult) {
+	report := &s.hbd.Report
+	switch result.status {
+	case verifyStatusVerified:
+		report.VerifiedCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerVerifiedCount)
+	case verifyStatusMissing:
+		report.MissingCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerMissingCount)
+	case verifyStatusMismatch:
+		report.MismatchCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerMismatchCount)
+	case verifyStatusSkipped:
+		report.SkipCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerSkipCount)
+	default:
+		report.ErrorCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerErrorCount)
+	}
+
+	if result.status != verifyStatusMissing && result.status != verifyStatusMismatch {
+		return
+	}
+	if result.rearchived {
+		report.RearchivedCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerRearchivedCount)
+	} else if result.err != nil {
+		report.ErrorCount++
+		s.metrics.IncCounter(metrics.ArchivalScavengerScope, metrics.ArchivalScavengerErrorCount)
+	}
+	if len(report.Corruptions) < maxReportedCorruptions {
+		report.Corruptions = append(report.Corruptions, CorruptedExecution{
+			NamespaceID: result.task.namespace.Info.Id,
+			WorkflowID:  result.task.workflowID,
+			RunID:       result.task.runID,
+			Reason:      result.reason,
+			Rearchived:  result.rearchived,
+		})
+	}
+}
+
+func (s *Scavenger) recordHeartbeat(ctx context.Context) {
+	if !s.isInTest {
+		activity.RecordHeartbeat(ctx, s.hbd)
+	}
+}
+
+func (s *Scavenger) startTaskProcessor(
+	ctx context.Context,
+	taskCh chan taskDetail,
+	respCh chan taskResult,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-taskCh:
+			if isDone(ctx) {
+				return
+			}
+
+			s.recordHeartbeat(ctx)
+
+			if err := s.limiter.Wait(ctx); err != nil {
+				s.logger.Error("encounter error when wait for rate limiter", getTaskLoggingTags(err, task)...)
+				respCh <- taskResult{task: task, status: verifyStatusError, err: err}
+				continue
+			}
+
+			result := s.verify(ctx, task)
+			if result.err != nil {
+				s.logger.Error("encounter error when verifying archived history", getTaskLoggingTags(result.err, task)...)
+			} else if result.reason != "" {
+				s.logger.Warn("archived history failed verification",
+					append(getTaskLoggingTags(nil, task), tag.DetailInfo(result.reason))...)
+			}
+			respCh <- result
+		}
+	}
+}
+
+// verify compares the archived history of a single closed execution with the history store
+// and re-archives it from the history store if it is missing or does not match
+func (s *Scavenger) verify(ctx context.Context, task taskDetail) taskResult {
+	result := taskResult{task: task}
+
+	msResp, err := s.client.GetMutableState(ctx, &historyservice.GetMutableStateRequest{
+		NamespaceId: task.namespace.Info.Id,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: task.workflowID,
+			RunId:      task.runID,
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			// already deleted by retention, nothing left to compare with
+			result.status = verifyStatusSkipped
+			return result
+		}
+		result.status = verifyStatusError
+		result.err = err
+		return result
+	}
+	if msResp.GetWorkflowState() != enumsgenpb.WORKFLOW_EXECUTION_STATE_COMPLETED {
+		result.status = verifyStatusSkipped
+		return result
+	}
+
+	closeFailoverVersion, err := getCloseFailoverVersion(msResp)
+	if err != nil {
+		result.status = verifyStatusError
+		result.err = err
+		return result
+	}
+
+	shardID := common.WorkflowIDToHistoryShard(task.workflowID, s.numHistoryShards)
+	expected, err := s.summarizeHistory(shardID, msResp.CurrentBranchToken, msResp.GetNextEventId())
+	if err != nil {
+		result.status = verifyStatusError
+		result.err = err
+		return result
+	}
+
+	URI, err := carchiver.NewURI(task.namespace.Config.HistoryArchivalURI)
+	if err != nil {
+		result.status = verifyStatusError
+		result.err = err
+		return result
+	}
+	historyArchiver, err := s.archiverProvider.GetHistoryArchiver(URI.Scheme(), common.WorkerServiceName)
+	if err != nil {
+		result.status = verifyStatusError
+		result.err = err
+		return result
+	}
+
+	archived, err := s.summarizeArchivedHistory(ctx, historyArchiver, URI, task, closeFailoverVersion)
+	switch err.(type) {
+	case nil:
+		if result.reason = expected.diff(archived); result.reason == "" {
+			result.status = verifyStatusVerified
+			return result
+		}
+		result.status = verifyStatusMismatch
+	case *serviceerror.NotFound:
+		result.status = verifyStatusMissing
+		result.reason = carchiver.ErrHistoryNotExist.Error()
+	default:
+		result.status = verifyStatusError
+		result.err = err
+		return result
+	}
+
+	result.err = historyArchiver.Archive(ctx, URI, &carchiver.ArchiveHistoryRequest{
+		ShardID:              shardID,
+		NamespaceID:          task.namespace.Info.Id,
+		Namespace:            task.namespace.Info.Name,
+		WorkflowID:           task.workflowID,
+		RunID:                task.runID,
+		BranchToken:          msResp.CurrentBranchToken,
+		NextEventID:          msResp.GetNextEventId(),
+		CloseFailoverVersion: closeFailoverVersion,
+	})
+	result.rearchived = result.err == nil
+	return result
+}
+
+func (s *Scavenger) summarizeHistory(
+	shardID int,
+	branchToken []byte,
+	nextEventID int64,
+) (historySummary, error) {
+
+	summarizer := newHistorySummarizer()
+	var pageToken []byte
+	for {
+		events, _, nextPageToken, err := persistence.ReadFullPageV2Events(s.historyMgr, &persistence.ReadHistoryBranchRequest{
+			BranchToken:   branchToken,
+			MinEventID:    common.FirstEventID,
+			MaxEventID:    nextEventID,
+			PageSize:      historyPageSize,
+			NextPageToken: pageToken,
+			ShardID:       convert.IntPtr(shardID),
+		})
+		if err != nil {
+			return historySummary{}, err
+		}
+		summarizer.add(events...)
+		if len(nextPageToken) == 0 {
+			return summarizer.summary(), nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func (s *Scavenger) summarizeArchivedHistory(
+	ctx context.Context,
+	historyArchiver carchiver.HistoryArchiver,
+	URI carchiver.URI,
+	task taskDetail,
+	closeFailoverVersion int64,
+) (historySummary, error) {
+
+	summarizer := newHistorySummarizer()
+	request := &carchiver.GetHistoryRequest{
+		NamespaceID: task.namespace.Info.Id,
+		WorkflowID:  task.workflowID,
+		RunID:       task.runID,
+		PageSize:    historyPageSize,
+	}
+	if closeFailoverVersion != common.EmptyVersion {
+		request.CloseFailoverVersion = convert.Int64Ptr(closeFailoverVersion)
+	}
+	for {
+		resp, err := historyArchiver.Get(ctx, URI, request)
+		if err != nil {
+			return historySummary{}, err
+		}
+		for _, batch := range resp.HistoryBatches {
+			summarizer.add(batch.GetEvents()...)
+		}
+		if len(resp.NextPageToken) == 0 {
+			return summarizer.summary(), nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+// getCloseFailoverVersion returns the version archival uses to identify the archived history,
+// which is the last write version of the current version history
+func getCloseFailoverVersion(resp *historyservice.GetMutableStateResponse) (int64, error) {
+	if resp.GetVersionHistories() == nil {
+		return common.EmptyVersion, nil
+	}
+	currentVersionHistory, err := persistence.NewVersionHistoriesFromProto(resp.GetVersionHistories()).GetCurrentVersionHistory()
+	if err != nil {
+		return 0, err
+	}
+	lastItem, err := currentVersionHistory.GetLastItem()
+	if err != nil {
+		return 0, err
+	}
+	return lastItem.GetVersion(), nil
+}
+
+func getTaskLoggingTags(err error, task taskDetail) []tag.Tag {
+	tags := []tag.Tag{
+		tag.WorkflowNamespaceID(task.namespace.Info.Id),
+		tag.WorkflowID(task.workflowID),
+		tag.WorkflowRunID(task.runID),
+	}
+	if err != nil {
+		tags = append(tags, tag.Error(err))
+	}
+	return tags
+}
+
+func isDone(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,355 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archival
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	historypb "go.temporal.io/temporal-proto/history/v1"
+	"go.temporal.io/temporal-proto/serviceerror"
+	workflowpb "go.temporal.io/temporal-proto/workflow/v1"
+	"go.uber.org/zap"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+	carchiver "github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/payloadstore"
+	p "github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	ScavengerTestSuite struct {
+		suite.Suite
+		logger log.Logger
+		metric metrics.Client
+
+		controller       *gomock.Controller
+		metadataMgr      *mocks.MetadataManager
+		visibilityMgr    *mocks.VisibilityManager
+		historyMgr       *mocks.HistoryV2Manager
+		historyClient    *historyservicemock.MockHistoryServiceClient
+		archiverProvider *provider.MockArchiverProvider
+		historyArchiver  *carchiver.HistoryArchiverMock
+		payloadProvider  *payloadstore.MockProvider
+		payloadStore     *payloadstore.MockStore
+		scvgr            *Scavenger
+		// blobs written to the mock payload store keyed by URI
+		payloadBlobs map[string][]byte
+	}
+)
+
+const (
+	testNamespaceID = "deadbeef-0123-4567-890a-bcdef0123456"
+	testNamespace   = "test-namespace"
+	testArchivalURI = "test:///archival"
+	testWorkflowID  = "test-workflow-id"
+	testRunID       = "test-run-id"
+	testPayloadURI  = "file:///tmp/temporal_payloads"
+)
+
+func TestScavengerTestSuite(t *testing.T) {
+	suite.Run(t, new(ScavengerTestSuite))
+}
+
+func (s *ScavengerTestSuite) SetupTest() {
+	zapLogger, err := zap.NewDevelopment()
+	if err != nil {
+		s.Require().NoError(err)
+	}
+	s.logger = loggerimpl.NewLogger(zapLogger)
+	s.metric = metrics.NewClient(tally.NoopScope, metrics.Worker)
+
+	s.controller = gomock.NewController(s.T())
+	s.metadataMgr = &mocks.MetadataManager{}
+	s.visibilityMgr = &mocks.VisibilityManager{}
+	s.historyMgr = &mocks.HistoryV2Manager{}
+	s.historyClient = historyservicemock.NewMockHistoryServiceClient(s.controller)
+	s.archiverProvider = &provider.MockArchiverProvider{}
+	s.historyArchiver = &carchiver.HistoryArchiverMock{}
+	s.archiverProvider.On("GetHistoryArchiver", "test", common.WorkerServiceName).Return(s.historyArchiver, nil).Maybe()
+	s.payloadProvider = payloadstore.NewMockProvider(s.controller)
+	s.payloadStore = payloadstore.NewMockStore(s.controller)
+	s.payloadBlobs = make(map[string][]byte)
+	s.payloadProvider.EXPECT().GetStore("file").Return(s.payloadStore, nil).AnyTimes()
+	s.payloadStore.EXPECT().ValidateURI(gomock.Any()).Return(nil).AnyTimes()
+	s.payloadStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI carchiver.URI, data []byte) error {
+			s.payloadBlobs[URI.String()] = data
+			return nil
+		},
+	).AnyTimes()
+	s.payloadStore.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI carchiver.URI) ([]byte, error) {
+			return s.payloadBlobs[URI.String()], nil
+		},
+	).AnyTimes()
+
+	s.scvgr = NewScavenger(
+		s.metadataMgr,
+		s.visibilityMgr,
+		s.historyMgr,
+		s.payloadProvider,
+		s.historyClient,
+		s.archiverProvider,
+		4,
+		1,
+		time.Hour,
+		100,
+		ScavengerHeartbeatDetails{},
+		s.metric,
+		s.logger,
+	)
+	s.scvgr.isInTest = true
+}
+
+func (s *ScavengerTestSuite) TearDownTest() {
+	s.controller.Finish()
+	s.metadataMgr.AssertExpectations(s.T())
+	s.visibilityMgr.AssertExpectations(s.T())
+	s.historyMgr.AssertExpectations(s.T())
+	s.historyArchiver.AssertExpectations(s.T())
+}
+
+func (s *ScavengerTestSuite) TestArchivalDisabled() {
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_DISABLED)
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(Report{}, report)
+}
+
+func (s *ScavengerTestSuite) TestWorkflowAlreadyDeleted() {
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_ENABLED)
+	s.mockListClosedWorkflowExecutions(time.Now().Add(-2 * time.Hour))
+	s.historyClient.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("not found"))
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.SkipCount)
+	s.Equal(0, report.VerifiedCount)
+}
+
+func (s *ScavengerTestSuite) TestRecentlyClosedSkipped() {
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_ENABLED)
+	s.mockListClosedWorkflowExecutions(time.Now().Add(-time.Minute))
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.SkipCount)
+	s.Equal(0, report.MissingCount)
+	s.Empty(report.Corruptions)
+}
+
+func (s *ScavengerTestSuite) TestArchivedHistoryVerified() {
+	events := s.testEvents(3)
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_ENABLED)
+	s.mockListClosedWorkflowExecutions(time.Now().Add(-2 * time.Hour))
+	s.mockGetMutableState(4)
+	s.mockReadHistoryBranch(events)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&carchiver.GetHistoryResponse{
+		HistoryBatches: []*historypb.History{{Events: events[:1]}, {Events: events[1:]}},
+	}, nil).Once()
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.VerifiedCount)
+	s.Empty(report.Corruptions)
+}
+
+func (s *ScavengerTestSuite) TestArchivedHistoryVerified_OffloadedPayload() {
+	events := s.testEvents(3)
+	events[1].EventType = enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED
+	events[1].Attributes = &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+		ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+			Input: payloads.EncodeString("a payload which is offloaded to the payload store"),
+		},
+	}
+	offloader := payloadstore.NewOffloader(
+		s.payloadProvider,
+		func(namespace string) int { return 40 },
+		func(namespace string) string { return testPayloadURI },
+	)
+	// the history store keeps a reference to the payload, the archive has the payload inline
+	offloaded, err := offloader.OffloadEvents(context.Background(), testNamespaceID, testNamespace, events)
+	s.NoError(err)
+	s.NotEqual(events[1].Size(), offloaded[1].Size())
+
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_ENABLED)
+	s.mockListClosedWorkflowExecutions(time.Now().Add(-2 * time.Hour))
+	s.mockGetMutableState(4)
+	s.mockReadHistoryBranch(offloaded)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&carchiver.GetHistoryResponse{
+		HistoryBatches: []*historypb.History{{Events: events}},
+	}, nil).Once()
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.VerifiedCount)
+	s.Equal(0, report.MismatchCount)
+	s.Empty(report.Corruptions)
+}
+
+func (s *ScavengerTestSuite) TestArchivedHistoryMissing() {
+	events := s.testEvents(3)
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_ENABLED)
+	s.mockListClosedWorkflowExecutions(time.Now().Add(-2 * time.Hour))
+	s.mockGetMutableState(4)
+	s.mockReadHistoryBranch(events)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, serviceerror.NewNotFound(carchiver.ErrHistoryNotExist.Error())).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.MatchedBy(func(request *carchiver.ArchiveHistoryRequest) bool {
+		return request.NamespaceID == testNamespaceID &&
+			request.WorkflowID == testWorkflowID &&
+			request.RunID == testRunID &&
+			request.NextEventID == 4 &&
+			request.CloseFailoverVersion == 10
+	})).Return(nil).Once()
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.MissingCount)
+	s.Equal(1, report.RearchivedCount)
+	s.Len(report.Corruptions, 1)
+	s.True(report.Corruptions[0].Rearchived)
+}
+
+func (s *ScavengerTestSuite) TestArchivedHistoryMismatch() {
+	events := s.testEvents(3)
+	s.mockListNamespaces(enumspb.ARCHIVAL_STATUS_ENABLED)
+	s.mockListClosedWorkflowExecutions(time.Now().Add(-2 * time.Hour))
+	s.mockGetMutableState(4)
+	s.mockReadHistoryBranch(events)
+	s.historyArchiver.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(&carchiver.GetHistoryResponse{
+		HistoryBatches: []*historypb.History{{Events: events[:2]}},
+	}, nil).Once()
+	s.historyArchiver.On("Archive", mock.Anything, mock.Anything, mock.Anything).Return(serviceerror.NewInternal("archive failed")).Once()
+
+	report, err := s.scvgr.Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.MismatchCount)
+	s.Equal(0, report.RearchivedCount)
+	s.Equal(1, report.ErrorCount)
+	s.Len(report.Corruptions, 1)
+	s.False(report.Corruptions[0].Rearchived)
+}
+
+func (s *ScavengerTestSuite) TestHistorySummaryDiff() {
+	events := s.testEvents(3)
+	summarizer := newHistorySummarizer()
+	summarizer.add(events...)
+	expected := summarizer.summary()
+	s.Empty(expected.diff(expected))
+
+	events[1].Version = 11
+	summarizer = newHistorySummarizer()
+	summarizer.add(events...)
+	archived := summarizer.summary()
+	s.Equal(expected.EventCount, archived.EventCount)
+	s.Equal(expected.LastEventID, archived.LastEventID)
+	s.NotEmpty(expected.diff(archived))
+}
+
+func (s *ScavengerTestSuite) mockListNamespaces(status enumspb.ArchivalStatus) {
+	s.metadataMgr.On("ListNamespaces", &p.ListNamespacesRequest{
+		PageSize: namespacePageSize,
+	}).Return(&p.ListNamespacesResponse{
+		Namespaces: []*p.GetNamespaceResponse{
+			{
+				Namespace: &persistenceblobs.NamespaceDetail{
+					Info: &persistenceblobs.NamespaceInfo{
+						Id:   testNamespaceID,
+						Name: testNamespace,
+					},
+					Config: &persistenceblobs.NamespaceConfig{
+						HistoryArchivalStatus: status,
+						HistoryArchivalURI:    testArchivalURI,
+					},
+				},
+			},
+		},
+	}, nil).Once()
+}
+
+func (s *ScavengerTestSuite) mockListClosedWorkflowExecutions(closeTime time.Time) {
+	s.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{
+			{
+				Execution: &commonpb.WorkflowExecution{
+					WorkflowId: testWorkflowID,
+					RunId:      testRunID,
+				},
+				CloseTime: &types.Int64Value{Value: closeTime.UnixNano()},
+			},
+		},
+	}, nil).Once()
+}
+
+func (s *ScavengerTestSuite) mockGetMutableState(nextEventID int64) {
+	versionHistory := p.NewVersionHistory([]byte("branch-token"), []*p.VersionHistoryItem{
+		p.NewVersionHistoryItem(nextEventID-1, 10),
+	})
+	s.historyClient.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(&historyservice.GetMutableStateResponse{
+		NextEventId:        nextEventID,
+		CurrentBranchToken: []byte("branch-token"),
+		WorkflowState:      enumsgenpb.WORKFLOW_EXECUTION_STATE_COMPLETED,
+		VersionHistories:   p.NewVersionHistories(versionHistory).ToProto(),
+	}, nil)
+}
+
+func (s *ScavengerTestSuite) mockReadHistoryBranch(events []*historypb.HistoryEvent) {
+	s.historyMgr.On("ReadHistoryBranch", mock.Anything).Return(&p.ReadHistoryBranchResponse{
+		HistoryEvents: events,
+	}, nil).Once()
+}
+
+func (s *ScavengerTestSuite) testEvents(count int) []*historypb.HistoryEvent {
+	var events []*historypb.HistoryEvent
+	for i := 1; i <= count; i++ {
+		events = append(events, &historypb.HistoryEvent{
+			EventId: int64(i),
+			Version: 10,
+			TaskId:  int64(i),
+		})
+	}
+	return events
+}
@@ -0,0 +1,106 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archival
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/crc32"
+
+	historypb "go.temporal.io/temporal-proto/history/v1"
+
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	// historySummary is a compact fingerprint of a workflow history used to compare
+	// the copy in the history store against the archived copy
+	historySummary struct {
+		EventCount  int64
+		LastEventID int64
+		Checksum    uint32
+	}
+
+	// historySummarizer incrementally builds a historySummary from history events
+	historySummarizer struct {
+		eventCount  int64
+		lastEventID int64
+		crc         hash.Hash32
+		buf         []byte
+	}
+)
+
+func newHistorySummarizer() *historySummarizer {
+	return &historySummarizer{
+		lastEventID: common.EmptyEventID,
+		crc:         crc32.NewIEEE(),
+		buf:         make([]byte, 8),
+	}
+}
+
+// add folds the given events into the summary.
+// The checksum covers the identity, ordering and encoded size of each event rather than the
+// serialized bytes, since the archived copy goes through a different encoding before it is stored.
+func (s *historySummarizer) add(events ...*historypb.HistoryEvent) {
+	for _, event := range events {
+		s.eventCount++
+		s.lastEventID = event.GetEventId()
+		s.write(event.GetEventId())
+		s.write(int64(event.GetEventType()))
+		s.write(event.GetVersion())
+		s.write(event.GetTaskId())
+		s.write(event.GetTimestamp())
+		s.write(int64(event.Size()))
+	}
+}
+
+func (s *historySummarizer) write(value int64) {
+	binary.BigEndian.PutUint64(s.buf, uint64(value))
+	_, _ = s.crc.Write(s.buf)
+}
+
+func (s *historySummarizer) summary() historySummary {
+	return historySummary{
+		EventCount:  s.eventCount,
+		LastEventID: s.lastEventID,
+		Checksum:    s.crc.Sum32(),
+	}
+}
+
+// diff returns a human readable description of the first difference found
+// between the expected and the actual summary, or an empty string if they match
+func (s historySummary) diff(archived historySummary) string {
+	switch {
+	case s.EventCount != archived.EventCount:
+		return fmt.Sprintf("event count mismatch, expected: %v, archived: %v", s.EventCount, archived.EventCount)
+	case s.LastEventID != archived.LastEventID:
+		return fmt.Sprintf("last event ID mismatch, expected: %v, archived: %v", s.LastEventID, archived.LastEventID)
+	case s.Checksum != archived.Checksum:
+		return fmt.Sprintf("checksum mismatch, expected: %v, archived: %v", s.Checksum, archived.Checksum)
+	default:
+		return ""
+	}
+}
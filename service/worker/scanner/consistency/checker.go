@@ -0,0 +1,697 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package consistency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal/activity"
+	"golang.org/x/time/rate"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/client"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/xdc"
+)
+
+type (
+	// ScannerWorkflowParams are the parameters of the consistency scanner workflow
+	ScannerWorkflowParams struct {
+		// Namespace restricts the scan to a single global namespace, every global namespace is scanned if empty
+		Namespace string
+		// RemoteCluster restricts the comparison to a single remote cluster,
+		// every other cluster of the namespace is compared with if empty
+		RemoteCluster string
+		// RepairEnabled indicates if history should be resent from the remote cluster
+		// for the executions on which the local cluster is behind or diverged
+		RepairEnabled bool
+	}
+
+	// ScannerHeartbeatDetails is the heartbeat detail for ConsistencyScannerActivity
+	ScannerHeartbeatDetails struct {
+		// NamespacePageToken is the token used to read the page of namespaces currently being scanned
+		NamespacePageToken []byte
+		// NamespaceID is the namespace currently being scanned
+		NamespaceID string
+		// ScanningClosed indicates the open executions of NamespaceID are already scanned
+		ScanningClosed bool
+		// ExecutionPageToken is the token of the next page of executions of NamespaceID
+		ExecutionPageToken []byte
+		Report             Report
+	}
+
+	// Report summarizes the result of one run of the consistency scanner
+	Report struct {
+		ConsistentCount      int
+		LocalBehindCount     int
+		RemoteBehindCount    int
+		DivergedCount        int
+		StateMismatchCount   int
+		MissingRemotelyCount int
+		RepairedCount        int
+		SkipCount            int
+		ErrorCount           int
+		// Divergences lists the executions on which the clusters do not agree,
+		// capped at maxReportedDivergences entries
+		Divergences []DivergentExecution
+	}
+
+	// DivergentExecution describes an execution on which the local and the remote cluster do not agree
+	DivergentExecution struct {
+		Namespace     string
+		WorkflowID    string
+		RunID         string
+		RemoteCluster string
+		Divergence    Divergence
+		Reason        string
+		Repaired      bool
+	}
+
+	// Divergence classifies how an execution differs between the local and the remote cluster
+	Divergence string
+
+	// Checker is the type that holds the state for multi-cluster consistency scanner
+	Checker struct {
+		params          ScannerWorkflowParams
+		clusterMetadata cluster.Metadata
+		metadataMgr     persistence.MetadataManager
+		visibilityMgr   persistence.VisibilityManager
+		clientBean      client.Bean
+		resenders       map[string]xdc.NDCHistoryResender
+		hbd             ScannerHeartbeatDetails
+		rps             int
+		limiter         *rate.Limiter
+		metrics         metrics.Client
+		logger          log.Logger
+		lagRecheckDelay time.Duration
+		isInTest        bool
+	}
+
+	taskDetail struct {
+		namespace     *persistence.GetNamespaceResponse
+		remoteCluster string
+		workflowID    string
+		runID         string
+	}
+
+	taskResult struct {
+		task       taskDetail
+		status     checkStatus
+		divergence Divergence
+		reason     string
+		repaired   bool
+		err        error
+	}
+
+	// describedMutableState is the subset of the mutable state returned by
+	// the admin DescribeWorkflowExecution API which is compared between clusters
+	describedMutableState struct {
+		ExecutionInfo *struct {
+			State        enumsgenpb.WorkflowExecutionState
+			Status       enumspb.WorkflowExecutionStatus
+			NextEventID  int64
+			Unreplicated bool
+		}
+		VersionHistories *persistence.VersionHistories
+	}
+
+	checkStatus int
+)
+
+const (
+	// DivergenceLocalBehind means the remote current branch extends the local current branch
+	DivergenceLocalBehind Divergence = "LocalBehind"
+	// DivergenceRemoteBehind means the local current branch extends the remote current branch
+	DivergenceRemoteBehind Divergence = "RemoteBehind"
+	// DivergenceDiverged means neither current branch contains the last event of the other one
+	DivergenceDiverged Divergence = "Diverged"
+	// DivergenceStateMismatch means both clusters have the same events but disagree on the workflow state
+	DivergenceStateMismatch Divergence = "StateMismatch"
+	// DivergenceMissingRemotely means the execution does not exist in the remote cluster
+	DivergenceMissingRemotely Divergence = "MissingRemotely"
+)
+
+const (
+	checkStatusConsistent checkStatus = iota
+	checkStatusDivergent
+	checkStatusSkipped
+	checkStatusError
+)
+
+const (
+	// used this to decide how many goroutines to process
+	rpsPerConcurrency      = 50
+	namespacePageSize      = 100
+	executionPageSize      = 1000
+	maxReportedDivergences = 1000
+	// executions on which a cluster is behind are checked again after this delay,
+	// so that replication lag is not reported as divergence
+	lagRecheckDelay = 10 * time.Second
+)
+
+// NewChecker returns an instance of multi-cluster consistency checker.
+// Each Run compares the executions of the global namespaces selected by params with every remote cluster
+// of the namespace, using the current version history, last event ID and state described by both clusters.
+// An execution found behind on one cluster is described again after lagRecheckDelay, so replication lag is
+// not reported as divergence. When repair is enabled, history of executions on which the local cluster is
+// behind or diverged is resent from the remote cluster.
+func NewChecker(
+	params ScannerWorkflowParams,
+	clusterMetadata cluster.Metadata,
+	metadataMgr persistence.MetadataManager,
+	visibilityMgr persistence.VisibilityManager,
+	clientBean client.Bean,
+	historyClient historyservice.HistoryServiceClient,
+	namespaceCache cache.NamespaceCache,
+	serializer persistence.PayloadSerializer,
+	rps int,
+	hbd ScannerHeartbeatDetails,
+	metricsClient metrics.Client,
+	logger log.Logger,
+) *Checker {
+
+	rateLimiter := rate.NewLimiter(rate.Limit(rps), rps)
+
+	// resenders replicate history from a remote cluster into the local cluster
+	resenders := make(map[string]xdc.NDCHistoryResender)
+	for clusterName, info := range clusterMetadata.GetAllClusterInfo() {
+		if !info.Enabled || clusterName == clusterMetadata.GetCurrentClusterName() {
+			continue
+		}
+		resenders[clusterName] = xdc.NewNDCHistoryResender(
+			namespaceCache,
+			clientBean.GetRemoteAdminClient(clusterName),
+			func(ctx context.Context, request *historyservice.ReplicateEventsV2Request) error {
+				_, err := historyClient.ReplicateEventsV2(ctx, request)
+				return err
+			},
+			serializer,
+			logger,
+		)
+	}
+
+	return &Checker{
+		params:          params,
+		clusterMetadata: clusterMetadata,
+		metadataMgr:     metadataMgr,
+		visibilityMgr:   visibilityMgr,
+		clientBean:      clientBean,
+		resenders:       resenders,
+		hbd:             hbd,
+		rps:             rps,
+		limiter:         rateLimiter,
+		metrics:         metricsClient,
+		logger:          logger,
+		lagRecheckDelay: lagRecheckDelay,
+	}
+}
+
+// Run runs the checker
+func (c *Checker) Run(ctx context.Context) (Report, error) {
+	taskCh := make(chan taskDetail, executionPageSize)
+	respCh := make(chan taskResult, executionPageSize)
+	concurrency := c.rps/rpsPerConcurrency + 1
+
+	for i := 0; i < concurrency; i++ {
+		go c.startTaskProcessor(ctx, taskCh, respCh)
+	}
+
+	if c.params.Namespace != "" {
+		resp, err := c.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: c.params.Namespace})
+		if err != nil {
+			return c.hbd.Report, err
+		}
+		if !resp.IsGlobalNamespace {
+			return c.hbd.Report, serviceerror.NewInvalidArgument(fmt.Sprintf("namespace %v is not a global namespace", c.params.Namespace))
+		}
+		return c.hbd.Report, c.scanNamespace(ctx, resp, taskCh, respCh)
+	}
+
+	for {
+		resp, err := c.metadataMgr.ListNamespaces(&persistence.ListNamespacesRequest{
+			PageSize:      namespacePageSize,
+			NextPageToken: c.hbd.NamespacePageToken,
+		})
+		if err != nil {
+			return c.hbd.Report, err
+		}
+
+		for _, ns := range c.namespacesToScan(resp.Namespaces) {
+			if !ns.IsGlobalNamespace {
+				continue
+			}
+			if err := c.scanNamespace(ctx, ns, taskCh, respCh); err != nil {
+				return c.hbd.Report, err
+			}
+		}
+
+		c.hbd.NamespacePageToken = resp.NextPageToken
+		c.resetNamespaceProgress("")
+		c.recordHeartbeat(ctx)
+
+		if len(c.hbd.NamespacePageToken) == 0 {
+			break
+		}
+	}
+	return c.hbd.Report, nil
+}
+
+// namespacesToScan returns the namespaces of the current page which still need to be scanned,
+// skipping the ones already scanned before the activity was restarted from a heartbeat
+func (c *Checker) namespacesToScan(namespaces []*persistence.GetNamespaceResponse) []*persistence.GetNamespaceResponse {
+	if c.hbd.NamespaceID == "" {
+		return namespaces
+	}
+	for i, ns := range namespaces {
+		if ns.Namespace.Info.Id == c.hbd.NamespaceID {
+			return namespaces[i:]
+		}
+	}
+	return namespaces
+}
+
+func (c *Checker) resetNamespaceProgress(namespaceID string) {
+	c.hbd.NamespaceID = namespaceID
+	c.hbd.ScanningClosed = false
+	c.hbd.ExecutionPageToken = nil
+}
+
+// remoteClusters returns the enabled clusters other than the current one the namespace is replicated to
+func (c *Checker) remoteClusters(namespace *persistence.GetNamespaceResponse) []string {
+	clusterInfo := c.clusterMetadata.GetAllClusterInfo()
+	var clusters []string
+	for _, clusterName := range namespace.Namespace.ReplicationConfig.GetClusters() {
+		if clusterName == c.clusterMetadata.GetCurrentClusterName() || !clusterInfo[clusterName].Enabled {
+			continue
+		}
+		if c.params.RemoteCluster != "" && c.params.RemoteCluster != clusterName {
+			continue
+		}
+		clusters = append(clusters, clusterName)
+	}
+	return clusters
+}
+
+func (c *Checker) scanNamespace(
+	ctx context.Context,
+	namespace *persistence.GetNamespaceResponse,
+	taskCh chan taskDetail,
+	respCh chan taskResult,
+) error {
+
+	remoteClusters := c.remoteClusters(namespace)
+	if len(remoteClusters) == 0 {
+		return nil
+	}
+	if c.hbd.NamespaceID != namespace.Namespace.Info.Id {
+		c.resetNamespaceProgress(namespace.Namespace.Info.Id)
+	}
+
+	for {
+		request := &persistence.ListWorkflowExecutionsRequest{
+			NamespaceID:       namespace.Namespace.Info.Id,
+			Namespace:         namespace.Namespace.Info.Name,
+			EarliestStartTime: 0,
+			LatestStartTime:   time.Now().UnixNano(),
+			PageSize:          executionPageSize,
+			NextPageToken:     c.hbd.ExecutionPageToken,
+		}
+		var resp *persistence.ListWorkflowExecutionsResponse
+		var err error
+		if c.hbd.ScanningClosed {
+			resp, err = c.visibilityMgr.ListClosedWorkflowExecutions(request)
+		} else {
+			resp, err = c.visibilityMgr.ListOpenWorkflowExecutions(request)
+		}
+		if err != nil {
+			return err
+		}
+
+		batchCount := 0
+		for _, execution := range resp.Executions {
+			for _, remoteCluster := range remoteClusters {
+				batchCount++
+				taskCh <- taskDetail{
+					namespace:     namespace,
+					remoteCluster: remoteCluster,
+					workflowID:    execution.Execution.GetWorkflowId(),
+					runID:         execution.Execution.GetRunId(),
+				}
+			}
+		}
+
+		for i := 0; i < batchCount; i++ {
+			select {
+			case result := <-respCh:
+				c.recordResult(result)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		c.hbd.ExecutionPageToken = resp.NextPageToken
+		if len(c.hbd.ExecutionPageToken) == 0 {
+			if c.hbd.ScanningClosed {
+				c.recordHeartbeat(ctx)
+				return nil
+			}
+			c.hbd.ScanningClosed = true
+		}
+		c.recordHeartbeat(ctx)
+	}
+}
+
+func (c *Checker) recordResult(result taskResult) {
+	report := &c.hbd.Report
+	switch result.status {
+	case checkStatusConsistent:
+		report.ConsistentCount++
+		c.metrics.IncCounter(metrics.ConsistencyScannerScope, metrics.ConsistencyScannerConsistentCount)
+		return
+	case checkStatusSkipped:
+		report.SkipCount++
+		c.metrics.IncCounter(metrics.ConsistencyScannerScope, metrics.ConsistencyScannerSkipCount)
+		return
+	case checkStatusError:
+		report.ErrorCount++
+		c.metrics.IncCounter(metrics.ConsistencyScannerScope, metrics.ConsistencyScannerErrorCount)
+		return
+	}
+
+	c.metrics.IncCounter(metrics.ConsistencyScannerScope, metrics.ConsistencyScannerDivergentCount)
+	switch result.divergence {
+	case DivergenceLocalBehind:
+		report.LocalBehindCount++
+	case DivergenceRemoteBehind:
+		report.RemoteBehindCount++
+	case DivergenceDiverged:
+		report.DivergedCount++
+	case DivergenceStateMismatch:
+		report.StateMismatchCount++
+	case DivergenceMissingRemotely:
+		report.MissingRemotelyCount++
+	}
+	if result.repaired {
+		report.RepairedCount++
+		c.metrics.IncCounter(metrics.ConsistencyScannerScope, metrics.ConsistencyScannerRepairedCount)
+	} else if result.err != nil {
+		report.ErrorCount++
+		c.metrics.IncCounter(metrics.ConsistencyScannerScope, metrics.ConsistencyScannerErrorCount)
+	}
+	if len(report.Divergences) < maxReportedDivergences {
+		report.Divergences = append(report.Divergences, DivergentExecution{
+			Namespace:     result.task.namespace.Namespace.Info.Name,
+			WorkflowID:    result.task.workflowID,
+			RunID:         result.task.runID,
+			RemoteCluster: result.task.remoteCluster,
+			Divergence:    result.divergence,
+			Reason:        result.reason,
+			Repaired:      result.repaired,
+		})
+	}
+}
+
+func (c *Checker) recordHeartbeat(ctx context.Context) {
+	if !c.isInTest {
+		activity.RecordHeartbeat(ctx, c.hbd)
+	}
+}
+
+func (c *Checker) startTaskProcessor(
+	ctx context.Context,
+	taskCh chan taskDetail,
+	respCh chan taskResult,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-taskCh:
+			if isDone(ctx) {
+				return
+			}
+
+			c.recordHeartbeat(ctx)
+
+			if err := c.limiter.Wait(ctx); err != nil {
+				c.logger.Error("encounter error when wait for rate limiter", getTaskLoggingTags(err, task)...)
+				respCh <- taskResult{task: task, status: checkStatusError, err: err}
+				continue
+			}
+
+			result := c.check(ctx, task)
+			if result.err != nil {
+				c.logger.Error("encounter error when checking execution consistency", getTaskLoggingTags(result.err, task)...)
+			} else if result.status == checkStatusDivergent {
+				c.logger.Warn("execution diverged between clusters",
+					append(getTaskLoggingTags(nil, task), tag.DetailInfo(result.reason))...)
+			}
+			respCh <- result
+		}
+	}
+}
+
+// check compares a single execution between the local and the remote cluster and
+// resends history from the remote cluster if the local cluster is behind or diverged
+func (c *Checker) check(ctx context.Context, task taskDetail) taskResult {
+	result := taskResult{task: task}
+
+	local, err := c.describe(ctx, c.clusterMetadata.GetCurrentClusterName(), task)
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			// already deleted by retention after it was listed
+			result.status = checkStatusSkipped
+			return result
+		}
+		result.status = checkStatusError
+		result.err = err
+		return result
+	}
+	if local.ExecutionInfo.Unreplicated {
+		// executions started as unreplicated only exist in the local cluster
+		result.status = checkStatusSkipped
+		return result
+	}
+	remote, err := c.describe(ctx, task.remoteCluster, task)
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			result.status = checkStatusDivergent
+			result.divergence = DivergenceMissingRemotely
+			result.reason = fmt.Sprintf("execution not found in cluster %v", task.remoteCluster)
+			return result
+		}
+		result.status = checkStatusError
+		result.err = err
+		return result
+	}
+	if local.VersionHistories == nil || remote.VersionHistories == nil {
+		// executions without version histories are not replicated by NDC
+		result.status = checkStatusSkipped
+		return result
+	}
+
+	localVersionHistory, err := local.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		result.status = checkStatusError
+		result.err = err
+		return result
+	}
+	remoteVersionHistory, err := remote.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		result.status = checkStatusError
+		result.err = err
+		return result
+	}
+	localLastItem, err := localVersionHistory.GetLastItem()
+	if err != nil {
+		result.status = checkStatusError
+		result.err = err
+		return result
+	}
+	remoteLastItem, err := remoteVersionHistory.GetLastItem()
+	if err != nil {
+		result.status = checkStatusError
+		result.err = err
+		return result
+	}
+
+	var resendFrom *persistence.VersionHistoryItem
+	switch {
+	case localLastItem.Equals(remoteLastItem):
+		if local.ExecutionInfo.State == remote.ExecutionInfo.State && local.ExecutionInfo.Status == remote.ExecutionInfo.Status {
+			result.status = checkStatusConsistent
+			return result
+		}
+		result.divergence = DivergenceStateMismatch
+		result.reason = fmt.Sprintf("local state %v/%v, remote state %v/%v",
+			local.ExecutionInfo.State, local.ExecutionInfo.Status, remote.ExecutionInfo.State, remote.ExecutionInfo.Status)
+	case remoteVersionHistory.ContainsItem(localLastItem):
+		caughtUp, err := c.isCaughtUp(ctx, c.clusterMetadata.GetCurrentClusterName(), task, remoteLastItem)
+		if err != nil {
+			result.status = checkStatusError
+			result.err = err
+			return result
+		}
+		if caughtUp {
+			result.status = checkStatusConsistent
+			return result
+		}
+		result.divergence = DivergenceLocalBehind
+		resendFrom = localLastItem
+	case localVersionHistory.ContainsItem(remoteLastItem):
+		caughtUp, err := c.isCaughtUp(ctx, task.remoteCluster, task, localLastItem)
+		if err != nil {
+			result.status = checkStatusError
+			result.err = err
+			return result
+		}
+		if caughtUp {
+			result.status = checkStatusConsistent
+			return result
+		}
+		result.divergence = DivergenceRemoteBehind
+	default:
+		result.divergence = DivergenceDiverged
+		resendFrom, err = localVersionHistory.FindLCAItem(remoteVersionHistory)
+		if err != nil {
+			result.status = checkStatusError
+			result.err = err
+			return result
+		}
+	}
+	result.status = checkStatusDivergent
+	if result.reason == "" {
+		result.reason = fmt.Sprintf("local last event %v@%v, remote last event %v@%v",
+			localLastItem.EventID, localLastItem.Version, remoteLastItem.EventID, remoteLastItem.Version)
+	}
+
+	if resendFrom == nil || !c.params.RepairEnabled {
+		return result
+	}
+	result.err = c.resenders[task.remoteCluster].SendSingleWorkflowHistory(
+		task.namespace.Namespace.Info.Id,
+		task.workflowID,
+		task.runID,
+		resendFrom.EventID,
+		resendFrom.Version,
+		common.EmptyEventID,
+		common.EmptyVersion,
+	)
+	result.repaired = result.err == nil
+	return result
+}
+
+// isCaughtUp describes the execution in the cluster which is behind again after the lag recheck delay,
+// and returns whether its current branch contains the last event the other cluster had
+func (c *Checker) isCaughtUp(
+	ctx context.Context,
+	clusterName string,
+	task taskDetail,
+	item *persistence.VersionHistoryItem,
+) (bool, error) {
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-time.After(c.lagRecheckDelay):
+	}
+
+	ms, err := c.describe(ctx, clusterName, task)
+	if err != nil {
+		return false, err
+	}
+	if ms.VersionHistories == nil {
+		return false, nil
+	}
+	versionHistory, err := ms.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return false, err
+	}
+	return versionHistory.ContainsItem(item), nil
+}
+
+// describe returns the mutable state of the execution in the given cluster through its admin API
+func (c *Checker) describe(
+	ctx context.Context,
+	clusterName string,
+	task taskDetail,
+) (*describedMutableState, error) {
+
+	resp, err := c.clientBean.GetRemoteAdminClient(clusterName).DescribeWorkflowExecution(ctx, &adminservice.DescribeWorkflowExecutionRequest{
+		Namespace: task.namespace.Namespace.Info.Name,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: task.workflowID,
+			RunId:      task.runID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &describedMutableState{}
+	if err := json.Unmarshal([]byte(resp.GetMutableStateInDatabase()), ms); err != nil {
+		return nil, err
+	}
+	if ms.ExecutionInfo == nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("cluster %v returned mutable state without execution info", clusterName))
+	}
+	return ms, nil
+}
+
+func getTaskLoggingTags(err error, task taskDetail) []tag.Tag {
+	tags := []tag.Tag{
+		tag.WorkflowNamespaceID(task.namespace.Namespace.Info.Id),
+		tag.WorkflowID(task.workflowID),
+		tag.WorkflowRunID(task.runID),
+		tag.ClusterName(task.remoteCluster),
+	}
+	if err != nil {
+		tags = append(tags, tag.Error(err))
+	}
+	return tags
+}
+
+func isDone(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
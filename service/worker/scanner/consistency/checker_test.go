@@ -0,0 +1,370 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package consistency
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	"go.temporal.io/temporal-proto/serviceerror"
+	workflowpb "go.temporal.io/temporal-proto/workflow/v1"
+	"go.uber.org/zap"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/adminservicemock/v1"
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/client"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/mocks"
+	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/xdc"
+)
+
+type (
+	CheckerTestSuite struct {
+		suite.Suite
+		logger log.Logger
+		metric metrics.Client
+
+		controller        *gomock.Controller
+		metadataMgr       *mocks.MetadataManager
+		visibilityMgr     *mocks.VisibilityManager
+		clientBean        *client.MockBean
+		localAdminClient  *adminservicemock.MockAdminServiceClient
+		remoteAdminClient *adminservicemock.MockAdminServiceClient
+		resender          *xdc.MockNDCHistoryResender
+	}
+)
+
+const (
+	testNamespaceID = "deadbeef-0123-4567-890a-bcdef0123456"
+	testNamespace   = "test-namespace"
+	testWorkflowID  = "test-workflow-id"
+	testRunID       = "test-run-id"
+)
+
+func TestCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckerTestSuite))
+}
+
+func (s *CheckerTestSuite) SetupTest() {
+	zapLogger, err := zap.NewDevelopment()
+	if err != nil {
+		s.Require().NoError(err)
+	}
+	s.logger = loggerimpl.NewLogger(zapLogger)
+	s.metric = metrics.NewClient(tally.NoopScope, metrics.Worker)
+
+	s.controller = gomock.NewController(s.T())
+	s.metadataMgr = &mocks.MetadataManager{}
+	s.visibilityMgr = &mocks.VisibilityManager{}
+	s.clientBean = client.NewMockBean(s.controller)
+	s.localAdminClient = adminservicemock.NewMockAdminServiceClient(s.controller)
+	s.remoteAdminClient = adminservicemock.NewMockAdminServiceClient(s.controller)
+	s.resender = xdc.NewMockNDCHistoryResender(s.controller)
+	s.clientBean.EXPECT().GetRemoteAdminClient(cluster.TestCurrentClusterName).Return(s.localAdminClient).AnyTimes()
+	s.clientBean.EXPECT().GetRemoteAdminClient(cluster.TestAlternativeClusterName).Return(s.remoteAdminClient).AnyTimes()
+}
+
+func (s *CheckerTestSuite) TearDownTest() {
+	s.controller.Finish()
+	s.metadataMgr.AssertExpectations(s.T())
+	s.visibilityMgr.AssertExpectations(s.T())
+}
+
+func (s *CheckerTestSuite) TestLocalNamespaceSkipped() {
+	s.metadataMgr.On("ListNamespaces", &p.ListNamespacesRequest{
+		PageSize: namespacePageSize,
+	}).Return(&p.ListNamespacesResponse{
+		Namespaces: []*p.GetNamespaceResponse{s.testNamespace(false)},
+	}, nil).Once()
+
+	report, err := s.newChecker(false).Run(context.Background())
+	s.NoError(err)
+	s.Equal(Report{}, report)
+}
+
+func (s *CheckerTestSuite) TestConsistent() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.ConsistentCount)
+	s.Empty(report.Divergences)
+}
+
+func (s *CheckerTestSuite) TestStateMismatch() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_COMPLETED)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.StateMismatchCount)
+	s.Len(report.Divergences, 1)
+	s.Equal(DivergenceStateMismatch, report.Divergences[0].Divergence)
+	s.False(report.Divergences[0].Repaired)
+}
+
+func (s *CheckerTestSuite) TestLocalBehind_Repaired() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(15, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	// still behind on recheck
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.resender.EXPECT().SendSingleWorkflowHistory(
+		testNamespaceID, testWorkflowID, testRunID, int64(10), int64(1), common.EmptyEventID, common.EmptyVersion,
+	).Return(nil).Times(1)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.LocalBehindCount)
+	s.Equal(1, report.RepairedCount)
+	s.Len(report.Divergences, 1)
+	s.Equal(cluster.TestAlternativeClusterName, report.Divergences[0].RemoteCluster)
+	s.True(report.Divergences[0].Repaired)
+}
+
+func (s *CheckerTestSuite) TestLocalBehind_RepairDisabled() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(15, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	// still behind on recheck
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+
+	report, err := s.newChecker(false).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.LocalBehindCount)
+	s.Equal(0, report.RepairedCount)
+	s.Len(report.Divergences, 1)
+	s.False(report.Divergences[0].Repaired)
+}
+
+func (s *CheckerTestSuite) TestLocalBehind_CaughtUpOnRecheck() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(15, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	// replication lag, the local cluster has the remote last event on recheck
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(16, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.ConsistentCount)
+	s.Equal(0, report.LocalBehindCount)
+	s.Empty(report.Divergences)
+}
+
+func (s *CheckerTestSuite) TestRemoteBehind() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1), p.NewVersionHistoryItem(12, 2)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(8, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	// still behind on recheck
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(8, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.RemoteBehindCount)
+	s.Equal(0, report.RepairedCount)
+}
+
+func (s *CheckerTestSuite) TestDiverged_Repaired() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1), p.NewVersionHistoryItem(12, 2)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.mockDescribe(s.remoteAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(8, 1), p.NewVersionHistoryItem(14, 3)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.resender.EXPECT().SendSingleWorkflowHistory(
+		testNamespaceID, testWorkflowID, testRunID, int64(8), int64(1), common.EmptyEventID, common.EmptyVersion,
+	).Return(nil).Times(1)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.DivergedCount)
+	s.Equal(1, report.RepairedCount)
+}
+
+func (s *CheckerTestSuite) TestMissingRemotely() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.mockDescribe(s.localAdminClient, []*p.VersionHistoryItem{p.NewVersionHistoryItem(10, 1)}, enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING)
+	s.remoteAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("not found"))
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.MissingRemotelyCount)
+	s.Len(report.Divergences, 1)
+	s.Equal(DivergenceMissingRemotely, report.Divergences[0].Divergence)
+}
+
+func (s *CheckerTestSuite) TestUnreplicatedSkipped() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	ms, err := json.Marshal(&p.WorkflowMutableState{
+		ExecutionInfo: &p.WorkflowExecutionInfo{
+			NamespaceID:  testNamespaceID,
+			WorkflowID:   testWorkflowID,
+			RunID:        testRunID,
+			State:        enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			Status:       enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			Unreplicated: true,
+		},
+	})
+	s.NoError(err)
+	s.localAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(&adminservice.DescribeWorkflowExecutionResponse{
+		MutableStateInDatabase: string(ms),
+	}, nil)
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.SkipCount)
+	s.Empty(report.Divergences)
+}
+
+func (s *CheckerTestSuite) TestWorkflowAlreadyDeleted() {
+	s.mockListNamespaces()
+	s.mockListExecutions()
+	s.localAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("not found"))
+
+	report, err := s.newChecker(true).Run(context.Background())
+	s.NoError(err)
+	s.Equal(1, report.SkipCount)
+	s.Empty(report.Divergences)
+}
+
+func (s *CheckerTestSuite) newChecker(repairEnabled bool) *Checker {
+	checker := NewChecker(
+		ScannerWorkflowParams{
+			RepairEnabled: repairEnabled,
+		},
+		cluster.GetTestClusterMetadata(true, true),
+		s.metadataMgr,
+		s.visibilityMgr,
+		s.clientBean,
+		historyservicemock.NewMockHistoryServiceClient(s.controller),
+		cache.NewMockNamespaceCache(s.controller),
+		p.NewPayloadSerializer(),
+		100,
+		ScannerHeartbeatDetails{},
+		s.metric,
+		s.logger,
+	)
+	checker.resenders[cluster.TestAlternativeClusterName] = s.resender
+	checker.lagRecheckDelay = 0
+	checker.isInTest = true
+	return checker
+}
+
+func (s *CheckerTestSuite) testNamespace(isGlobalNamespace bool) *p.GetNamespaceResponse {
+	return &p.GetNamespaceResponse{
+		Namespace: &persistenceblobs.NamespaceDetail{
+			Info: &persistenceblobs.NamespaceInfo{
+				Id:   testNamespaceID,
+				Name: testNamespace,
+			},
+			Config: &persistenceblobs.NamespaceConfig{},
+			ReplicationConfig: &persistenceblobs.NamespaceReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters:          []string{cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName},
+			},
+		},
+		IsGlobalNamespace: isGlobalNamespace,
+	}
+}
+
+func (s *CheckerTestSuite) mockListNamespaces() {
+	s.metadataMgr.On("ListNamespaces", &p.ListNamespacesRequest{
+		PageSize: namespacePageSize,
+	}).Return(&p.ListNamespacesResponse{
+		Namespaces: []*p.GetNamespaceResponse{s.testNamespace(true)},
+	}, nil).Once()
+}
+
+func (s *CheckerTestSuite) mockListExecutions() {
+	s.visibilityMgr.On("ListOpenWorkflowExecutions", mock.Anything).Return(&p.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{
+			{
+				Execution: &commonpb.WorkflowExecution{
+					WorkflowId: testWorkflowID,
+					RunId:      testRunID,
+				},
+			},
+		},
+	}, nil).Once()
+	s.visibilityMgr.On("ListClosedWorkflowExecutions", mock.Anything).Return(&p.ListWorkflowExecutionsResponse{}, nil).Once()
+}
+
+func (s *CheckerTestSuite) mockDescribe(
+	adminClient *adminservicemock.MockAdminServiceClient,
+	items []*p.VersionHistoryItem,
+	state enumsgenpb.WorkflowExecutionState,
+) {
+	lastItem := items[len(items)-1]
+	status := enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
+	if state == enumsgenpb.WORKFLOW_EXECUTION_STATE_COMPLETED {
+		status = enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+	}
+	ms, err := json.Marshal(&p.WorkflowMutableState{
+		ExecutionInfo: &p.WorkflowExecutionInfo{
+			NamespaceID: testNamespaceID,
+			WorkflowID:  testWorkflowID,
+			RunID:       testRunID,
+			State:       state,
+			Status:      status,
+			NextEventID: lastItem.EventID + 1,
+		},
+		VersionHistories: p.NewVersionHistories(p.NewVersionHistory([]byte("branch-token"), items)),
+	})
+	s.NoError(err)
+	adminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), &adminservice.DescribeWorkflowExecutionRequest{
+		Namespace: testNamespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: testWorkflowID,
+			RunId:      testRunID,
+		},
+	}).Return(&adminservice.DescribeWorkflowExecutionResponse{
+		MutableStateInDatabase: string(ms),
+	}, nil)
+}
@@ -40,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/service/worker/scanner/consistency"
 	"github.com/temporalio/temporal/service/worker/scanner/executions"
 )
 
@@ -70,6 +71,17 @@ type (
 		HistoryScannerEnabled dynamicconfig.BoolPropertyFn
 		// ExecutionsScannerEnabled indicates if executions scanner should be started as part of scanner
 		ExecutionsScannerEnabled dynamicconfig.BoolPropertyFn
+		// ArchivalScannerEnabled indicates if archival scanner should be started as part of scanner
+		ArchivalScannerEnabled dynamicconfig.BoolPropertyFn
+		// ArchivalScannerSamplingRate is the fraction of closed executions verified by archival scanner
+		ArchivalScannerSamplingRate dynamicconfig.FloatPropertyFn
+		// ArchivalScannerCloseGracePeriod is the time after close during which executions are not verified by archival scanner
+		ArchivalScannerCloseGracePeriod dynamicconfig.DurationPropertyFn
+		// ConsistencyScannerEnabled indicates if multi-cluster consistency scanner should be started as part of scanner
+		ConsistencyScannerEnabled dynamicconfig.BoolPropertyFn
+		// ConsistencyScannerRepair indicates if consistency scanner should repair the executions on which
+		// the local cluster is behind or diverged from the remote cluster
+		ConsistencyScannerRepair dynamicconfig.BoolPropertyFn
 	}
 
 	// BootstrapParams contains the set of params needed to bootstrap
@@ -128,6 +140,16 @@ func (s *Scanner) Start() error {
 		go s.startWorkflowWithRetry(executionsScannerWFStartOptions, executionsScannerWFTypeName, defaultExecutionsScannerParams)
 	}
 
+	if s.context.cfg.ArchivalScannerEnabled() && s.context.GetArchivalMetadata().GetHistoryConfig().ClusterConfiguredForArchival() {
+		workerTaskListNames = append(workerTaskListNames, archivalScannerTaskListName)
+		go s.startWorkflowWithRetry(archivalScannerWFStartOptions, archivalScannerWFTypeName)
+	}
+
+	if s.context.cfg.ConsistencyScannerEnabled() && s.context.cfg.ClusterMetadata.IsGlobalNamespaceEnabled() {
+		workerTaskListNames = append(workerTaskListNames, consistencyScannerTaskListName)
+		go s.startWorkflowWithRetry(consistencyScannerWFStartOptions, consistencyScannerWFTypeName, consistency.ScannerWorkflowParams{})
+	}
+
 	if s.context.cfg.Persistence.DefaultStoreType() == config.StoreTypeSQL && s.context.cfg.TaskListScannerEnabled() {
 		go s.startWorkflowWithRetry(tlScannerWFStartOptions, tlScannerWFTypeName)
 		workerTaskListNames = append(workerTaskListNames, tlScannerTaskListName)
@@ -142,9 +164,13 @@ func (s *Scanner) Start() error {
 		work.RegisterWorkflowWithOptions(TaskListScannerWorkflow, workflow.RegisterOptions{Name: tlScannerWFTypeName})
 		work.RegisterWorkflowWithOptions(HistoryScannerWorkflow, workflow.RegisterOptions{Name: historyScannerWFTypeName})
 		work.RegisterWorkflowWithOptions(ExecutionsScannerWorkflow, workflow.RegisterOptions{Name: executionsScannerWFTypeName})
+		work.RegisterWorkflowWithOptions(ArchivalScannerWorkflow, workflow.RegisterOptions{Name: archivalScannerWFTypeName})
+		work.RegisterWorkflowWithOptions(ConsistencyScannerWorkflow, workflow.RegisterOptions{Name: consistencyScannerWFTypeName})
 		work.RegisterActivityWithOptions(TaskListScavengerActivity, activity.RegisterOptions{Name: taskListScavengerActivityName})
 		work.RegisterActivityWithOptions(HistoryScavengerActivity, activity.RegisterOptions{Name: historyScavengerActivityName})
 		work.RegisterActivityWithOptions(ExecutionsScavengerActivity, activity.RegisterOptions{Name: executionsScavengerActivityName})
+		work.RegisterActivityWithOptions(ArchivalScavengerActivity, activity.RegisterOptions{Name: archivalScavengerActivityName})
+		work.RegisterActivityWithOptions(ConsistencyScannerActivity, activity.RegisterOptions{Name: consistencyScannerActivityName})
 
 		if err := work.Start(); err != nil {
 			return err
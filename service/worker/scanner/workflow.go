@@ -34,6 +34,8 @@ import (
 	"go.temporal.io/temporal/workflow"
 
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/service/worker/scanner/archival"
+	"github.com/temporalio/temporal/service/worker/scanner/consistency"
 	"github.com/temporalio/temporal/service/worker/scanner/executions"
 	"github.com/temporalio/temporal/service/worker/scanner/history"
 	"github.com/temporalio/temporal/service/worker/scanner/tasklist"
@@ -64,6 +66,18 @@ const (
 	executionsScannerWFTypeName     = "temporal-sys-executions-scanner-workflow"
 	executionsScannerTaskListName   = "temporal-sys-executions-scanner-tasklist-0"
 	executionsScavengerActivityName = "temporal-sys-executions-scanner-scvg-activity"
+
+	archivalScannerWFID            = "temporal-sys-archival-scanner"
+	archivalScannerWFTypeName      = "temporal-sys-archival-scanner-workflow"
+	archivalScannerTaskListName    = "temporal-sys-archival-scanner-tasklist-0"
+	archivalScavengerActivityName  = "temporal-sys-archival-scanner-scvg-activity"
+	archivalScannerReportQueryType = "report"
+
+	consistencyScannerWFID            = "temporal-sys-consistency-scanner"
+	consistencyScannerWFTypeName      = "temporal-sys-consistency-scanner-workflow"
+	consistencyScannerTaskListName    = "temporal-sys-consistency-scanner-tasklist-0"
+	consistencyScannerActivityName    = "temporal-sys-consistency-scanner-check-activity"
+	consistencyScannerReportQueryType = "report"
 )
 
 var (
@@ -100,6 +114,18 @@ var (
 		WorkflowIDReusePolicy: cclient.WorkflowIDReusePolicyAllowDuplicate,
 		CronSchedule:          "0 */12 * * *",
 	}
+	archivalScannerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                    archivalScannerWFID,
+		TaskList:              archivalScannerTaskListName,
+		WorkflowIDReusePolicy: cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:          "0 */24 * * *",
+	}
+	consistencyScannerWFStartOptions = cclient.StartWorkflowOptions{
+		ID:                    consistencyScannerWFID,
+		TaskList:              consistencyScannerTaskListName,
+		WorkflowIDReusePolicy: cclient.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:          "0 */24 * * *",
+	}
 )
 
 // TaskListScannerWorkflow is the workflow that runs the task-list scanner background daemon
@@ -133,6 +159,61 @@ func ExecutionsScannerWorkflow(
 	return future.Get(ctx, nil)
 }
 
+// ArchivalScannerWorkflow is the workflow that runs the archival scanner background daemon.
+// The report of the last completed run can be retrieved by querying the workflow with archivalScannerReportQueryType.
+func ArchivalScannerWorkflow(
+	ctx workflow.Context,
+) (archival.Report, error) {
+
+	var report archival.Report
+	if workflow.HasLastCompletionResult(ctx) {
+		if err := workflow.GetLastCompletionResult(ctx, &report); err != nil {
+			workflow.GetLogger(ctx).Warn("Failed to load report of last archival scanner run")
+		}
+	}
+	if err := workflow.SetQueryHandler(ctx, archivalScannerReportQueryType, func() (archival.Report, error) {
+		return report, nil
+	}); err != nil {
+		return report, err
+	}
+
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, activityOptions), archivalScavengerActivityName)
+	var result archival.Report
+	if err := future.Get(ctx, &result); err != nil {
+		return report, err
+	}
+	report = result
+	return report, nil
+}
+
+// ConsistencyScannerWorkflow is the workflow that compares the executions of global namespaces between clusters.
+// The report of the last completed run can be retrieved by querying the workflow with consistencyScannerReportQueryType.
+func ConsistencyScannerWorkflow(
+	ctx workflow.Context,
+	consistencyScannerWorkflowParams consistency.ScannerWorkflowParams,
+) (consistency.Report, error) {
+
+	var report consistency.Report
+	if workflow.HasLastCompletionResult(ctx) {
+		if err := workflow.GetLastCompletionResult(ctx, &report); err != nil {
+			workflow.GetLogger(ctx).Warn("Failed to load report of last consistency scanner run")
+		}
+	}
+	if err := workflow.SetQueryHandler(ctx, consistencyScannerReportQueryType, func() (consistency.Report, error) {
+		return report, nil
+	}); err != nil {
+		return report, err
+	}
+
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, activityOptions), consistencyScannerActivityName, consistencyScannerWorkflowParams)
+	var result consistency.Report
+	if err := future.Get(ctx, &result); err != nil {
+		return report, err
+	}
+	report = result
+	return report, nil
+}
+
 // HistoryScavengerActivity is the activity that runs history scavenger
 func HistoryScavengerActivity(
 	activityCtx context.Context,
@@ -201,3 +282,71 @@ func ExecutionsScavengerActivity(
 	}
 	return nil
 }
+
+// ArchivalScavengerActivity is the activity that runs archival scavenger
+func ArchivalScavengerActivity(
+	activityCtx context.Context,
+) (archival.Report, error) {
+
+	ctx := activityCtx.Value(scannerContextKey).(scannerContext)
+	rps := ctx.cfg.PersistenceMaxQPS()
+
+	hbd := archival.ScavengerHeartbeatDetails{}
+	if activity.HasHeartbeatDetails(activityCtx) {
+		if err := activity.GetHeartbeatDetails(activityCtx, &hbd); err != nil {
+			ctx.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+		}
+	}
+
+	scavenger := archival.NewScavenger(
+		ctx.GetMetadataManager(),
+		ctx.GetVisibilityManager(),
+		ctx.GetHistoryManager(),
+		ctx.GetPayloadStoreProvider(),
+		ctx.GetHistoryClient(),
+		ctx.GetArchiverProvider(),
+		ctx.cfg.Persistence.NumHistoryShards,
+		ctx.cfg.ArchivalScannerSamplingRate(),
+		ctx.cfg.ArchivalScannerCloseGracePeriod(),
+		rps,
+		hbd,
+		ctx.GetMetricsClient(),
+		ctx.GetLogger(),
+	)
+	return scavenger.Run(activityCtx)
+}
+
+// ConsistencyScannerActivity is the activity that runs multi-cluster consistency checker
+func ConsistencyScannerActivity(
+	activityCtx context.Context,
+	consistencyScannerWorkflowParams consistency.ScannerWorkflowParams,
+) (consistency.Report, error) {
+
+	ctx := activityCtx.Value(scannerContextKey).(scannerContext)
+	rps := ctx.cfg.PersistenceMaxQPS()
+
+	hbd := consistency.ScannerHeartbeatDetails{}
+	if activity.HasHeartbeatDetails(activityCtx) {
+		if err := activity.GetHeartbeatDetails(activityCtx, &hbd); err != nil {
+			ctx.GetLogger().Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+		}
+	}
+
+	params := consistencyScannerWorkflowParams
+	params.RepairEnabled = params.RepairEnabled || ctx.cfg.ConsistencyScannerRepair()
+	checker := consistency.NewChecker(
+		params,
+		ctx.GetClusterMetadata(),
+		ctx.GetMetadataManager(),
+		ctx.GetVisibilityManager(),
+		ctx.GetClientBean(),
+		ctx.GetHistoryClient(),
+		ctx.GetNamespaceCache(),
+		ctx.GetPayloadSerializer(),
+		rps,
+		hbd,
+		ctx.GetMetricsClient(),
+		ctx.GetLogger(),
+	)
+	return checker.Run(activityCtx)
+}
@@ -41,6 +41,8 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	p "github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/service/worker/scanner/archival"
+	"github.com/temporalio/temporal/service/worker/scanner/consistency"
 )
 
 type scannerWorkflowTestSuite struct {
@@ -55,8 +57,12 @@ func TestScannerWorkflowTestSuite(t *testing.T) {
 func (s *scannerWorkflowTestSuite) registerWorkflows(env *testsuite.TestWorkflowEnvironment) {
 	env.RegisterWorkflowWithOptions(TaskListScannerWorkflow, workflow.RegisterOptions{Name: tlScannerWFTypeName})
 	env.RegisterWorkflowWithOptions(HistoryScannerWorkflow, workflow.RegisterOptions{Name: historyScannerWFTypeName})
+	env.RegisterWorkflowWithOptions(ArchivalScannerWorkflow, workflow.RegisterOptions{Name: archivalScannerWFTypeName})
+	env.RegisterWorkflowWithOptions(ConsistencyScannerWorkflow, workflow.RegisterOptions{Name: consistencyScannerWFTypeName})
 	env.RegisterActivityWithOptions(TaskListScavengerActivity, activity.RegisterOptions{Name: taskListScavengerActivityName})
 	env.RegisterActivityWithOptions(HistoryScavengerActivity, activity.RegisterOptions{Name: historyScavengerActivityName})
+	env.RegisterActivityWithOptions(ArchivalScavengerActivity, activity.RegisterOptions{Name: archivalScavengerActivityName})
+	env.RegisterActivityWithOptions(ConsistencyScannerActivity, activity.RegisterOptions{Name: consistencyScannerActivityName})
 }
 
 func (s *scannerWorkflowTestSuite) registerActivities(env *testsuite.TestActivityEnvironment) {
@@ -72,6 +78,45 @@ func (s *scannerWorkflowTestSuite) TestWorkflow() {
 	s.True(env.IsWorkflowCompleted())
 }
 
+func (s *scannerWorkflowTestSuite) TestArchivalScannerWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	s.registerWorkflows(env)
+	report := archival.Report{VerifiedCount: 10, MissingCount: 1, RearchivedCount: 1}
+	env.OnActivity(archivalScavengerActivityName, mock.Anything).Return(report, nil)
+	env.ExecuteWorkflow(archivalScannerWFTypeName)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	var result archival.Report
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal(report, result)
+
+	queryResult, err := env.QueryWorkflow(archivalScannerReportQueryType)
+	s.NoError(err)
+	s.NoError(queryResult.Get(&result))
+	s.Equal(report, result)
+}
+
+func (s *scannerWorkflowTestSuite) TestConsistencyScannerWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	s.registerWorkflows(env)
+	params := consistency.ScannerWorkflowParams{Namespace: "test-namespace", RemoteCluster: "standby"}
+	report := consistency.Report{ConsistentCount: 10, LocalBehindCount: 1, RepairedCount: 1}
+	env.OnActivity(consistencyScannerActivityName, mock.Anything, params).Return(report, nil)
+	env.ExecuteWorkflow(consistencyScannerWFTypeName, params)
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	var result consistency.Report
+	s.NoError(env.GetWorkflowResult(&result))
+	s.Equal(report, result)
+
+	queryResult, err := env.QueryWorkflow(consistencyScannerReportQueryType)
+	s.NoError(err)
+	s.NoError(queryResult.Get(&result))
+	s.Equal(report, result)
+}
+
 func (s *scannerWorkflowTestSuite) TestScavengerActivity() {
 	env := s.NewTestActivityEnvironment()
 	s.registerActivities(env)
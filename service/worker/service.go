@@ -41,6 +41,7 @@ import (
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/worker/archiver"
 	"github.com/temporalio/temporal/service/worker/batcher"
+	"github.com/temporalio/temporal/service/worker/handover"
 	"github.com/temporalio/temporal/service/worker/indexer"
 	"github.com/temporalio/temporal/service/worker/parentclosepolicy"
 	"github.com/temporalio/temporal/service/worker/replicator"
@@ -68,6 +69,7 @@ type (
 		IndexerCfg                    *indexer.Config
 		ScannerCfg                    *scanner.Config
 		BatcherCfg                    *batcher.Config
+		HandoverCfg                   *handover.Config
 		ThrottledLogRPS               dynamicconfig.IntPropertyFn
 		PersistenceGlobalMaxQPS       dynamicconfig.IntPropertyFn
 		EnableBatcher                 dynamicconfig.BoolPropertyFn
@@ -130,17 +132,25 @@ func NewConfig(params *resource.BootstrapParams) *Config {
 			TimeLimitPerArchivalIteration: dc.GetDurationProperty(dynamicconfig.WorkerTimeLimitPerArchivalIteration, archiver.MaxArchivalIterationTimeout()),
 		},
 		ScannerCfg: &scanner.Config{
-			PersistenceMaxQPS:        dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
-			Persistence:              &params.PersistenceConfig,
-			ClusterMetadata:          params.ClusterMetadata,
-			TaskListScannerEnabled:   dc.GetBoolProperty(dynamicconfig.TaskListScannerEnabled, true),
-			HistoryScannerEnabled:    dc.GetBoolProperty(dynamicconfig.HistoryScannerEnabled, true),
-			ExecutionsScannerEnabled: dc.GetBoolProperty(dynamicconfig.ExecutionsScannerEnabled, false),
+			PersistenceMaxQPS:               dc.GetIntProperty(dynamicconfig.ScannerPersistenceMaxQPS, 100),
+			Persistence:                     &params.PersistenceConfig,
+			ClusterMetadata:                 params.ClusterMetadata,
+			TaskListScannerEnabled:          dc.GetBoolProperty(dynamicconfig.TaskListScannerEnabled, true),
+			HistoryScannerEnabled:           dc.GetBoolProperty(dynamicconfig.HistoryScannerEnabled, true),
+			ExecutionsScannerEnabled:        dc.GetBoolProperty(dynamicconfig.ExecutionsScannerEnabled, false),
+			ArchivalScannerEnabled:          dc.GetBoolProperty(dynamicconfig.ArchivalScannerEnabled, false),
+			ArchivalScannerSamplingRate:     dc.GetFloat64Property(dynamicconfig.ArchivalScannerSamplingRate, 0.01),
+			ArchivalScannerCloseGracePeriod: dc.GetDurationProperty(dynamicconfig.ArchivalScannerCloseGracePeriod, time.Hour),
+			ConsistencyScannerEnabled:       dc.GetBoolProperty(dynamicconfig.ConsistencyScannerEnabled, false),
+			ConsistencyScannerRepair:        dc.GetBoolProperty(dynamicconfig.ConsistencyScannerRepairEnabled, false),
 		},
 		BatcherCfg: &batcher.Config{
 			AdminOperationToken: dc.GetStringProperty(dynamicconfig.AdminOperationToken, common.DefaultAdminOperationToken),
 			ClusterMetadata:     params.ClusterMetadata,
 		},
+		HandoverCfg: &handover.Config{
+			CheckInterval: dc.GetDurationProperty(dynamicconfig.WorkerNamespaceHandoverCheckInterval, 10*time.Second),
+		},
 		EnableBatcher:                 dc.GetBoolProperty(dynamicconfig.EnableBatcher, false),
 		EnableParentClosePolicyWorker: dc.GetBoolProperty(dynamicconfig.EnableParentClosePolicyWorker, true),
 		ThrottledLogRPS:               dc.GetIntProperty(dynamicconfig.WorkerThrottledLogRPS, 20),
@@ -158,6 +168,7 @@ func NewConfig(params *resource.BootstrapParams) *Config {
 			ESProcessorBulkSize:      dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkSize, 2<<24), // 16MB
 			ESProcessorFlushInterval: dc.GetDurationProperty(dynamicconfig.WorkerESProcessorFlushInterval, 1*time.Second),
 			ValidSearchAttributes:    dc.GetMapProperty(dynamicconfig.ValidSearchAttributes, definition.GetDefaultIndexedKeys()),
+			ESIndexManagerInterval:   dc.GetDurationProperty(dynamicconfig.WorkerESIndexManagerInterval, time.Hour),
 		}
 	}
 	return config
@@ -182,6 +193,7 @@ func (s *Service) Start() {
 
 	if s.GetClusterMetadata().IsGlobalNamespaceEnabled() {
 		s.startReplicator()
+		s.startHandoverManager()
 	}
 	if s.GetArchivalMetadata().GetHistoryConfig().ClusterConfiguredForArchival() {
 		s.startArchiver()
@@ -270,12 +282,30 @@ func (s *Service) startReplicator() {
 	}
 }
 
+func (s *Service) startHandoverManager() {
+	handoverManager := handover.NewManager(
+		s.config.HandoverCfg,
+		s.GetClusterMetadata(),
+		s.GetMetadataManager(),
+		s.GetHistoryClient(),
+		s.GetFrontendClient(),
+		s.GetWorkerServiceResolver(),
+		s.GetHostInfo(),
+		s.params.PersistenceConfig.NumHistoryShards,
+		s.GetLogger(),
+		s.GetMetricsClient(),
+	)
+	handoverManager.Start()
+}
+
 func (s *Service) startIndexer() {
 	visibilityIndexer := indexer.NewIndexer(
 		s.config.IndexerCfg,
 		s.GetMessagingClient(),
 		s.params.ESClient,
 		s.params.ESConfig,
+		s.GetMetadataManager(),
+		s.GetVisibilityIndexerDLQ(),
 		s.GetLogger(),
 		s.GetMetricsClient(),
 	)
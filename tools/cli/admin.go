@@ -24,7 +24,11 @@
 
 package cli
 
-import "github.com/urfave/cli"
+import (
+	"github.com/urfave/cli"
+
+	es "github.com/temporalio/temporal/common/elasticsearch"
+)
 
 func newAdminWorkflowCommands() []cli.Command {
 	return []cli.Command{
@@ -112,6 +116,27 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDeleteWorkflow(c)
 			},
 		},
+		{
+			Name:  "delete-execution",
+			Usage: "Terminate workflow execution if it is running and delete its mutable state, history and visibility records through the server",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason you want to delete the workflow",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDeleteWorkflowExecution(c)
+			},
+		},
 	}
 }
 
@@ -603,6 +628,11 @@ func newAdminElasticSearchCommands() []cli.Command {
 					Name:  FlagURL,
 					Usage: "URL of ElasticSearch cluster",
 				},
+				cli.StringFlag{
+					Name:  FlagESVersion,
+					Usage: "Version of ElasticSearch cluster: v6 (default) or v7",
+					Value: es.VersionV6,
+				},
 			},
 			Action: func(c *cli.Context) {
 				AdminCatIndices(c)
@@ -617,6 +647,11 @@ func newAdminElasticSearchCommands() []cli.Command {
 					Name:  FlagURL,
 					Usage: "URL of ElasticSearch cluster",
 				},
+				cli.StringFlag{
+					Name:  FlagESVersion,
+					Usage: "Version of ElasticSearch cluster: v6 (default) or v7",
+					Value: es.VersionV6,
+				},
 				cli.StringFlag{
 					Name:  FlagIndex,
 					Usage: "ElasticSearch target index",
@@ -644,6 +679,11 @@ func newAdminElasticSearchCommands() []cli.Command {
 					Name:  FlagURL,
 					Usage: "URL of ElasticSearch cluster",
 				},
+				cli.StringFlag{
+					Name:  FlagESVersion,
+					Usage: "Version of ElasticSearch cluster: v6 (default) or v7",
+					Value: es.VersionV6,
+				},
 				cli.StringFlag{
 					Name:  FlagIndex,
 					Usage: "ElasticSearch target index",
@@ -677,6 +717,11 @@ func newAdminElasticSearchCommands() []cli.Command {
 					Name:  FlagURL,
 					Usage: "URL of ElasticSearch cluster",
 				},
+				cli.StringFlag{
+					Name:  FlagESVersion,
+					Usage: "Version of ElasticSearch cluster: v6 (default) or v7",
+					Value: es.VersionV6,
+				},
 				cli.StringFlag{
 					Name:  FlagIndex,
 					Usage: "ElasticSearch target index",
@@ -698,6 +743,29 @@ func newAdminElasticSearchCommands() []cli.Command {
 				GenerateReport(c)
 			},
 		},
+		{
+			Name:        "dlq",
+			Usage:       "Run admin operation on visibility requests rejected by ElasticSearch",
+			Subcommands: newAdminVisibilityDLQCommands(),
+		},
+		{
+			Name:    "reindex",
+			Aliases: []string{"ri"},
+			Usage:   "Rebuild visibility document of a workflow from its mutable state",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminReindexWorkflowExecution(c)
+			},
+		},
 	}
 }
 
@@ -788,6 +856,24 @@ func newAdminClusterCommands() []cli.Command {
 				AdminDescribeCluster(c)
 			},
 		},
+		{
+			Name:    "replication-status",
+			Aliases: []string{"rs"},
+			Usage:   "Show how far remote clusters are behind on replication tasks of this cluster",
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  FlagCluster,
+					Usage: "Remote cluster to show, defaults to all enabled remote clusters (can be repeated)",
+				},
+				cli.BoolFlag{
+					Name:  FlagIncludeShardsWithAlias,
+					Usage: "Show the replication status of each shard as well",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetReplicationStatus(c)
+			},
+		},
 	}
 }
 
@@ -800,7 +886,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, visibility, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -830,7 +916,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, visibility, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -848,11 +934,11 @@ func newAdminDLQCommands() []cli.Command {
 		{
 			Name:    "merge",
 			Aliases: []string{"m"},
-			Usage:   "Merge DLQ messages with equal or smaller ids than the provided task id",
+			Usage:   "Merge DLQ messages with equal or smaller ids than the provided task id, transfer and timer tasks are retried",
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, visibility, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -870,6 +956,61 @@ func newAdminDLQCommands() []cli.Command {
 	}
 }
 
+func newAdminVisibilityDLQCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "read",
+			Aliases: []string{"r"},
+			Usage:   "Read visibility DLQ messages",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagMaxMessageCountWithAlias,
+					Usage: "Max message size to fetch",
+				},
+				cli.IntFlag{
+					Name:  FlagLastMessageID,
+					Usage: "The upper boundary of the read message",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Output file to write to, if not provided output is written to stdout",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetVisibilityDLQMessages(c)
+			},
+		},
+		{
+			Name:    "purge",
+			Aliases: []string{"p"},
+			Usage:   "Delete visibility DLQ messages with equal or smaller ids than the provided message id",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagLastMessageID,
+					Usage: "The upper boundary of the read message",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminPurgeVisibilityDLQMessages(c)
+			},
+		},
+		{
+			Name:    "merge",
+			Aliases: []string{"m"},
+			Usage:   "Replay visibility DLQ messages with equal or smaller ids than the provided message id to ElasticSearch",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagLastMessageID,
+					Usage: "The upper boundary of the read message",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminMergeVisibilityDLQMessages(c)
+			},
+		},
+	}
+}
+
 func newDBCommands() []cli.Command {
 	return []cli.Command{
 		{
@@ -26,12 +26,17 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 )
 
 // AdminAddSearchAttribute to whitelist search attribute
@@ -77,6 +82,118 @@ func AdminDescribeCluster(c *cli.Context) {
 	prettyPrintJSONObject(response)
 }
 
+// AdminGetReplicationStatus shows how far remote clusters are behind on replication tasks of this cluster
+func AdminGetReplicationStatus(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	request := &adminservice.GetReplicationStatusRequest{
+		RemoteClusters: c.StringSlice(FlagCluster),
+		IncludeShards:  c.Bool(FlagIncludeShards),
+	}
+	// pending replication tasks are only counted if a namespace is given explicitly
+	if c.GlobalIsSet(FlagNamespace) {
+		request.Namespace = c.GlobalString(FlagNamespace)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetReplicationStatus(ctx, request)
+	if err != nil {
+		ErrorAndExit("Operation GetReplicationStatus failed.", err)
+	}
+
+	printClusterReplicationStatus(response.GetRemoteClusters(), request.GetNamespace() != "")
+	if request.GetIncludeShards() {
+		fmt.Println()
+		printShardReplicationStatus(response.GetShards())
+	}
+}
+
+func printClusterReplicationStatus(statusByCluster map[string]*replicationgenpb.ClusterReplicationStatus, withNamespace bool) {
+	clusters := make([]string, 0, len(statusByCluster))
+	for cluster := range statusByCluster {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	header := []string{"Remote Cluster", "Lagging Shards", "Max Task ID Lag", "Max Time Lag", "DLQ Tasks"}
+	if withNamespace {
+		header = append(header, "Namespace Time Lag", "Pending Namespace Tasks")
+	}
+	table.SetHeader(header)
+	table.SetHeaderLine(false)
+	for _, cluster := range clusters {
+		status := statusByCluster[cluster]
+		timeLag := time.Duration(status.GetMaxTimeLag()).String()
+		if status.GetUnknownTimeLagShards() > 0 {
+			timeLag += fmt.Sprintf(" (unknown for %d shards)", status.GetUnknownTimeLagShards())
+		}
+		taskIDLag := fmt.Sprintf("%d", status.GetMaxTaskIdLag())
+		if status.GetUnknownTaskIdLagShards() > 0 {
+			taskIDLag += fmt.Sprintf(" (unknown for %d shards)", status.GetUnknownTaskIdLagShards())
+		}
+		row := []string{
+			cluster,
+			fmt.Sprintf("%d", status.GetLaggingShards()),
+			taskIDLag,
+			timeLag,
+			fmt.Sprintf("%d", status.GetDlqTasks()),
+		}
+		if withNamespace {
+			row = append(row, time.Duration(status.GetMaxNamespaceTimeLag()).String())
+			pendingTasks := fmt.Sprintf("%d", status.GetPendingNamespaceTasks())
+			if status.GetPendingNamespaceTasksIncompleteShards() > 0 {
+				pendingTasks += fmt.Sprintf("+ (incomplete for %d shards)", status.GetPendingNamespaceTasksIncompleteShards())
+			}
+			row = append(row, pendingTasks)
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func printShardReplicationStatus(shards []*replicationgenpb.ShardReplicationStatus) {
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i].GetShardId() < shards[j].GetShardId()
+	})
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Shard", "Remote Cluster", "Max Task ID", "Acked Task ID", "Last Caught Up", "DLQ Tasks"})
+	table.SetHeaderLine(false)
+	for _, shard := range shards {
+		clusters := make([]string, 0, len(shard.GetRemoteClusters()))
+		for cluster := range shard.GetRemoteClusters() {
+			clusters = append(clusters, cluster)
+		}
+		sort.Strings(clusters)
+		for _, cluster := range clusters {
+			status := shard.GetRemoteClusters()[cluster]
+			caughtUp := "unknown"
+			if status.GetLastCaughtUpTime() != 0 {
+				caughtUp = convertTime(status.GetLastCaughtUpTime(), false)
+			}
+			maxTaskID := "unknown"
+			if !shard.GetMaxReplicationTaskIdUnknown() {
+				maxTaskID = fmt.Sprintf("%d", shard.GetMaxReplicationTaskId())
+			}
+			table.Append([]string{
+				fmt.Sprintf("%d", shard.GetShardId()),
+				cluster,
+				maxTaskID,
+				fmt.Sprintf("%d", status.GetAckedTaskId()),
+				caughtUp,
+				fmt.Sprintf("%d", status.GetDlqTasks()),
+			})
+		}
+	}
+	table.Render()
+}
+
 func intValTypeToString(valType int) string {
 	switch valType {
 	case 0:
@@ -270,6 +270,34 @@ func AdminDeleteWorkflow(c *cli.Context) {
 	fmt.Println("delete current row successfully")
 }
 
+// AdminDeleteWorkflowExecution terminates a workflow execution if it is running and deletes it through the history service
+func AdminDeleteWorkflowExecution(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	reason := c.String(FlagReason)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := adminClient.DeleteWorkflowExecution(ctx, &adminservice.DeleteWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		Reason:   reason,
+		Identity: getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Delete workflow failed", err)
+	} else {
+		fmt.Println("Delete workflow succeeded, workflow records are removed asynchronously.")
+	}
+}
+
 func readOneRow(query *gocql.Query) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	err := query.MapScan(result)
@@ -29,11 +29,13 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/urfave/cli"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
-
+	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/codec"
@@ -46,11 +48,20 @@ const (
 
 // AdminGetDLQMessages gets DLQ metadata
 func AdminGetDLQMessages(c *cli.Context) {
+	dlqType := getRequiredOption(c, FlagDLQType)
+	getDLQMessages(c, toQueueType(dlqType))
+}
+
+// AdminGetVisibilityDLQMessages gets visibility requests rejected by ElasticSearch
+func AdminGetVisibilityDLQMessages(c *cli.Context) {
+	getDLQMessages(c, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY)
+}
+
+func getDLQMessages(c *cli.Context, dlqType enumsgenpb.DeadLetterQueueType) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
 	adminClient := cFactory.AdminClient(c)
-	dlqType := getRequiredOption(c, FlagDLQType)
 	outputFile := getOutputFile(c.String(FlagOutputFilename))
 	defer outputFile.Close()
 
@@ -65,7 +76,8 @@ func AdminGetDLQMessages(c *cli.Context) {
 
 	paginationFunc := func(paginationToken []byte) ([]interface{}, []byte, error) {
 		resp, err := adminClient.ReadDLQMessages(ctx, &adminservice.ReadDLQMessagesRequest{
-			Type:                  toQueueType(dlqType),
+			Type:                  dlqType,
+			ShardId:               int32(c.Int(FlagShardID)),
 			InclusiveEndMessageId: lastMessageID,
 			MaximumPageSize:       defaultPageSize,
 			NextPageToken:         paginationToken,
@@ -77,6 +89,12 @@ func AdminGetDLQMessages(c *cli.Context) {
 		for _, item := range resp.GetReplicationTasks() {
 			paginateItems = append(paginateItems, item)
 		}
+		for _, item := range resp.GetVisibilityMessages() {
+			paginateItems = append(paginateItems, item)
+		}
+		for _, item := range resp.GetTaskMessages() {
+			paginateItems = append(paginateItems, item)
+		}
 		return paginateItems, resp.GetNextPageToken(), err
 	}
 
@@ -88,14 +106,23 @@ func AdminGetDLQMessages(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("fail to read dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		task := item.(*replicationgenpb.ReplicationTask)
+		var message proto.Message
+		var messageID int64
+		switch task := item.(type) {
+		case *replicationgenpb.ReplicationTask:
+			message, messageID = task, task.GetSourceTaskId()
+		case *indexergenpb.DLQMessage:
+			message, messageID = task, task.GetMessageId()
+		case *persistenceblobs.HistoryTaskDLQMessage:
+			message, messageID = task, task.GetMessageId()
+		}
 		encoder := codec.NewJSONPBIndentEncoder(" ")
-		taskStr, err := encoder.Encode(task)
+		taskStr, err := encoder.Encode(message)
 		if err != nil {
 			ErrorAndExit(fmt.Sprintf("fail to encode dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		lastReadMessageID = int(task.SourceTaskId)
+		lastReadMessageID = int(messageID)
 		remainingMessageCount--
 		_, err = outputFile.WriteString(fmt.Sprintf("%v\n", string(taskStr)))
 		if err != nil {
@@ -106,11 +133,19 @@ func AdminGetDLQMessages(c *cli.Context) {
 
 // AdminPurgeDLQMessages deletes messages from DLQ
 func AdminPurgeDLQMessages(c *cli.Context) {
+	dlqType := getRequiredOption(c, FlagDLQType)
+	purgeDLQMessages(c, toQueueType(dlqType))
+}
+
+// AdminPurgeVisibilityDLQMessages deletes visibility requests rejected by ElasticSearch
+func AdminPurgeVisibilityDLQMessages(c *cli.Context) {
+	purgeDLQMessages(c, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY)
+}
+
+func purgeDLQMessages(c *cli.Context, dlqType enumsgenpb.DeadLetterQueueType) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
-	dlqType := getRequiredOption(c, FlagDLQType)
-
 	var lastMessageID int64
 	if c.IsSet(FlagLastMessageID) {
 		lastMessageID = c.Int64(FlagLastMessageID)
@@ -120,7 +155,8 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 
 	adminClient := cFactory.AdminClient(c)
 	if _, err := adminClient.PurgeDLQMessages(ctx, &adminservice.PurgeDLQMessagesRequest{
-		Type:                  toQueueType(dlqType),
+		Type:                  dlqType,
+		ShardId:               int32(c.Int(FlagShardID)),
 		InclusiveEndMessageId: lastMessageID,
 	}); err != nil {
 		ErrorAndExit("Failed to purge dlq", nil)
@@ -130,11 +166,19 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 
 // AdminMergeDLQMessages merges message from DLQ
 func AdminMergeDLQMessages(c *cli.Context) {
+	dlqType := getRequiredOption(c, FlagDLQType)
+	mergeDLQMessages(c, toQueueType(dlqType))
+}
+
+// AdminMergeVisibilityDLQMessages replays visibility requests rejected by ElasticSearch
+func AdminMergeVisibilityDLQMessages(c *cli.Context) {
+	mergeDLQMessages(c, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY)
+}
+
+func mergeDLQMessages(c *cli.Context, dlqType enumsgenpb.DeadLetterQueueType) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
-	dlqType := getRequiredOption(c, FlagDLQType)
-
 	var lastMessageID int64
 	if c.IsSet(FlagLastMessageID) {
 		lastMessageID = c.Int64(FlagLastMessageID)
@@ -144,7 +188,8 @@ func AdminMergeDLQMessages(c *cli.Context) {
 
 	adminClient := cFactory.AdminClient(c)
 	request := &adminservice.MergeDLQMessagesRequest{
-		Type:                  toQueueType(dlqType),
+		Type:                  dlqType,
+		ShardId:               int32(c.Int(FlagShardID)),
 		InclusiveEndMessageId: lastMessageID,
 		MaximumPageSize:       defaultPageSize,
 	}
@@ -169,6 +214,12 @@ func toQueueType(dlqType string) enumsgenpb.DeadLetterQueueType {
 		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_NAMESPACE
 	case "history":
 		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION
+	case "visibility":
+		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_VISIBILITY
+	case "transfer":
+		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER
+	case "timer":
+		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER
 	default:
 		ErrorAndExit("The queue type is not supported.", fmt.Errorf("the queue type is not supported. Type: %v", dlqType))
 	}
@@ -30,15 +30,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
-	"github.com/olivere/elastic"
 	"github.com/urfave/cli"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	indexergenpb "github.com/temporalio/temporal/.gen/proto/indexer/v1"
 	"github.com/temporalio/temporal/common/clock"
@@ -50,9 +52,6 @@ import (
 
 const (
 	esDocIDDelimiter = "~"
-	esDocType        = "_doc"
-
-	versionTypeExternal = "external"
 )
 
 const (
@@ -83,15 +82,17 @@ func timeValProcess(timeStr string) (string, error) {
 	return fmt.Sprintf("%v", parsedTime.UnixNano()), nil
 }
 
-func getESClient(c *cli.Context) *elastic.Client {
-	url := getRequiredOption(c, FlagURL)
-	retrier := elastic.NewBackoffRetrier(elastic.NewExponentialBackoff(128*time.Millisecond, 513*time.Millisecond))
-	var client *elastic.Client
-	var err error
-	if client, err = elastic.NewClient(
-		elastic.SetURL(url),
-		elastic.SetRetrier(retrier),
-	); err != nil {
+func getESClient(c *cli.Context) es.Client {
+	esURL, err := url.Parse(getRequiredOption(c, FlagURL))
+	if err != nil {
+		ErrorAndExit("Unable to parse ElasticSearch URL", err)
+	}
+
+	client, err := es.NewClient(&es.Config{
+		Version: c.String(FlagESVersion),
+		URL:     *esURL,
+	})
+	if err != nil {
 		ErrorAndExit("Unable to create ElasticSearch client", err)
 	}
 
@@ -103,7 +104,7 @@ func AdminCatIndices(c *cli.Context) {
 	esClient := getESClient(c)
 
 	ctx := context.Background()
-	resp, err := esClient.CatIndices().Do(ctx)
+	resp, err := esClient.CatIndices(ctx)
 	if err != nil {
 		ErrorAndExit("Unable to cat indices", err)
 	}
@@ -151,24 +152,24 @@ func AdminIndex(c *cli.Context) {
 	}
 	for i, message := range messages {
 		docID := message.GetWorkflowId() + esDocIDDelimiter + message.GetRunId()
-		var req elastic.BulkableRequest
+		var req *es.BulkableRequest
 		switch message.GetMessageType() {
 		case enumsgenpb.MESSAGE_TYPE_INDEX:
 			doc := generateESDoc(message)
-			req = elastic.NewBulkIndexRequest().
-				Index(indexName).
-				Type(esDocType).
-				Id(docID).
-				VersionType(versionTypeExternal).
-				Version(message.GetVersion()).
-				Doc(doc)
+			req = &es.BulkableRequest{
+				RequestType: es.BulkableIndexRequest,
+				Index:       indexName,
+				ID:          docID,
+				Version:     message.GetVersion(),
+				Doc:         doc,
+			}
 		case enumsgenpb.MESSAGE_TYPE_DELETE:
-			req = elastic.NewBulkDeleteRequest().
-				Index(indexName).
-				Type(esDocType).
-				Id(docID).
-				VersionType(versionTypeExternal).
-				Version(message.GetVersion())
+			req = &es.BulkableRequest{
+				RequestType: es.BulkableDeleteRequest,
+				Index:       indexName,
+				ID:          docID,
+				Version:     message.GetVersion(),
+			}
 		default:
 			ErrorAndExit("Unknown message type", nil)
 		}
@@ -222,12 +223,12 @@ func AdminDelete(c *cli.Context) {
 	for scanner.Scan() {
 		line := strings.Split(scanner.Text(), "|")
 		docID := strings.TrimSpace(line[1]) + esDocIDDelimiter + strings.TrimSpace(line[2])
-		req := elastic.NewBulkDeleteRequest().
-			Index(indexName).
-			Type(esDocType).
-			Id(docID).
-			VersionType(versionTypeExternal).
-			Version(math.MaxInt64)
+		req := &es.BulkableRequest{
+			RequestType: es.BulkableDeleteRequest,
+			Index:       indexName,
+			ID:          docID,
+			Version:     math.MaxInt64,
+		}
 		bulkRequest.Add(req)
 		if i%batchSize == batchSize-1 {
 			bulkConductFn()
@@ -323,7 +324,7 @@ func toTimeStr(s interface{}) string {
 // GenerateReport generate report for an aggregation query to ES
 func GenerateReport(c *cli.Context) {
 	// use url command argument to create client
-	url := getRequiredOption(c, FlagURL)
+	esClient := getESClient(c)
 	index := getRequiredOption(c, FlagIndex)
 	sql := getRequiredOption(c, FlagListQuery)
 	var reportFormat, reportFilePath string
@@ -335,10 +336,6 @@ func GenerateReport(c *cli.Context) {
 	} else {
 		reportFilePath = "./report." + reportFormat
 	}
-	esClient, err := elastic.NewClient(elastic.SetURL(url))
-	if err != nil {
-		ErrorAndExit("Fail to create elastic client", err)
-	}
 	ctx := context.Background()
 
 	// convert sql to dsl
@@ -351,7 +348,7 @@ func GenerateReport(c *cli.Context) {
 	}
 
 	// query client
-	resp, err := esClient.Search(index).Source(dsl).Do(ctx)
+	resp, err := esClient.SearchWithDSL(ctx, index, dsl)
 	if err != nil {
 		ErrorAndExit("Fail to talk with ES", err)
 	}
@@ -361,7 +358,7 @@ func GenerateReport(c *cli.Context) {
 	var headers []string
 	var groupby, bucket map[string]interface{}
 	var buckets []interface{}
-	err = json.Unmarshal(*resp.Aggregations["groupby"], &groupby)
+	err = json.Unmarshal(resp.Aggregations["groupby"], &groupby)
 	if err != nil {
 		ErrorAndExit("Fail to parse groupby", err)
 	}
@@ -556,3 +553,28 @@ func wrapWithTag(content string, tag string, property string) string {
 	}
 	return "<" + tag + property + ">" + content + "</" + tag + ">\n"
 }
+
+// AdminReindexWorkflowExecution rebuilds visibility document of a workflow from its mutable state
+func AdminReindexWorkflowExecution(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := adminClient.ReindexWorkflowExecution(ctx, &adminservice.ReindexWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Reindex workflow execution failed", err)
+	} else {
+		fmt.Println("Reindex workflow execution succeeded.")
+	}
+}
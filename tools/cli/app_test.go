@@ -25,6 +25,8 @@
 package cli
 
 import (
+	"context"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -48,9 +50,12 @@ import (
 	"go.temporal.io/temporal-proto/workflowservicemock/v1"
 	sdkclient "go.temporal.io/temporal/client"
 	sdkmocks "go.temporal.io/temporal/mocks"
+	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock/v1"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
 )
@@ -306,28 +311,25 @@ func (s *cliAppSuite) TestStartWorkflow_Failed() {
 func (s *cliAppSuite) TestRunWorkflow() {
 	resp := &workflowservice.StartWorkflowExecutionResponse{RunId: uuid.New()}
 	s.frontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", mock.Anything, mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("wid")
 
 	// start with wid
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "run", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "-w", "wid", "wrp", "2"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("")
 	// start without wid
 	err = s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "run", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "wrp", "2"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 }
 
 func (s *cliAppSuite) TestRunWorkflow_Failed() {
 	resp := &workflowservice.StartWorkflowExecutionResponse{RunId: uuid.New()}
 	s.frontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, serviceerror.NewInvalidArgument("faked error"))
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("wid")
 	// start with wid
 	errorCode := s.RunErrorExitCode([]string{"", "--ns", cliTestNamespace, "workflow", "run", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "-w", "wid"})
 	s.Equal(1, errorCode)
-	s.sdkClient.AssertExpectations(s.T())
 }
 
 func (s *cliAppSuite) TestTerminateWorkflow() {
@@ -566,28 +568,124 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 	s.sdkClient.AssertExpectations(s.T())
 }
 
+func (s *cliAppSuite) TestDescribeTaskList_Backlog() {
+	resp := &adminservice.DescribeTaskListResponse{
+		Pollers: describeTaskListResponse.Pollers,
+		BacklogStats: &tasklistgenpb.TaskListBacklogStats{
+			ApproximateBacklogCount:    10,
+			ApproximateBacklogAgeNanos: int64(time.Minute),
+			AddTasksPerSecond:          2,
+			DispatchTasksPerSecond:     1,
+		},
+	}
+	s.serverAdminClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any()).Return(resp, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "tasklist", "describe", "-tl", "test-taskList", "--backlog"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminGetReplicationStatus() {
+	resp := &adminservice.GetReplicationStatusResponse{
+		RemoteClusters: map[string]*replicationgenpb.ClusterReplicationStatus{
+			"standby": {MaxTaskIdLag: 10, MaxTimeLag: int64(time.Minute), LaggingShards: 1, DlqTasks: 2, MaxNamespaceTimeLag: int64(time.Minute)},
+		},
+		Shards: []*replicationgenpb.ShardReplicationStatus{
+			{
+				ShardId:              1,
+				MaxReplicationTaskId: 20,
+				RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+					"standby": {AckedTaskId: 10, LastCaughtUpTime: time.Now().UnixNano(), DlqTasks: 2},
+				},
+			},
+			{
+				ShardId:                     2,
+				MaxReplicationTaskIdUnknown: true,
+				RemoteClusters: map[string]*replicationgenpb.ShardReplicationStatusPerCluster{
+					"standby": {AckedTaskId: 10},
+				},
+			},
+		},
+	}
+	s.serverAdminClient.EXPECT().GetReplicationStatus(gomock.Any(), &adminservice.GetReplicationStatusRequest{
+		RemoteClusters: []string{"standby"},
+		Namespace:      cliTestNamespace,
+		IncludeShards:  true,
+	}).Return(resp, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "admin", "cluster", "replication-status", "--cluster", "standby", "--include_shards"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestPauseResumeTaskList() {
+	s.serverAdminClient.EXPECT().PauseTaskList(gomock.Any(), gomock.Any()).Return(&adminservice.PauseTaskListResponse{}, nil)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "tasklist", "pause", "-tl", "test-taskList", "-tlt", "activity", "--reason", "bad deployment"})
+	s.Nil(err)
+
+	s.serverAdminClient.EXPECT().ResumeTaskList(gomock.Any(), gomock.Any()).Return(&adminservice.ResumeTaskListResponse{}, nil)
+	err = s.app.Run([]string{"", "--ns", cliTestNamespace, "tasklist", "resume", "-tl", "test-taskList", "-tlt", "activity"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestObserveWorkflow() {
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("wid")
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("wid")
 	err = s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid", "-sd"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 }
 
 func (s *cliAppSuite) TestObserveWorkflowWithID() {
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("wid")
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observeid", "wid"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.expectStreamWorkflowHistory("wid")
 	err = s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observeid", "wid", "-sd"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestObserveWorkflow_ResumeStream() {
+	stream := adminservicemock.NewMockAdminService_StreamWorkflowExecutionHistoryClient(s.mockCtrl)
+	stream.EXPECT().Recv().Return(&adminservice.StreamWorkflowExecutionHistoryResponse{
+		RunId:   "rid",
+		History: &historypb.History{Events: []*historypb.HistoryEvent{{EventId: 1, EventType: eventType}}},
+	}, nil)
+	stream.EXPECT().Recv().Return(nil, serviceerror.ToStatus(serviceerror.NewUnavailable("faked error")).Err())
+	s.serverAdminClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), &adminservice.StreamWorkflowExecutionHistoryRequest{
+		Namespace:    cliTestNamespace,
+		Execution:    &commonpb.WorkflowExecution{WorkflowId: "wid"},
+		FirstEventId: 1,
+	}).Return(stream, nil)
+
+	resumedStream := adminservicemock.NewMockAdminService_StreamWorkflowExecutionHistoryClient(s.mockCtrl)
+	resumedStream.EXPECT().Recv().Return(nil, io.EOF)
+	s.serverAdminClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), &adminservice.StreamWorkflowExecutionHistoryRequest{
+		Namespace:    cliTestNamespace,
+		Execution:    &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+		FirstEventId: 2,
+	}).Return(resumedStream, nil)
+
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) expectStreamWorkflowHistory(wid string) {
+	stream := adminservicemock.NewMockAdminService_StreamWorkflowExecutionHistoryClient(s.mockCtrl)
+	stream.EXPECT().Recv().Return(&adminservice.StreamWorkflowExecutionHistoryResponse{
+		RunId:   "rid",
+		History: getWorkflowExecutionHistoryResponse.History,
+	}, nil)
+	stream.EXPECT().Recv().Return(nil, io.EOF)
+
+	s.serverAdminClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *adminservice.StreamWorkflowExecutionHistoryRequest, _ ...grpc.CallOption) (adminservice.AdminService_StreamWorkflowExecutionHistoryClient, error) {
+			s.Equal(cliTestNamespace, request.GetNamespace())
+			if wid != "" {
+				s.Equal(wid, request.Execution.GetWorkflowId())
+			}
+			return stream, nil
+		},
+	)
 }
 
 // TestParseTime tests the parsing of date argument in UTC and UnixNano formats
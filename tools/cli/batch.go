@@ -105,6 +105,38 @@ func newBatchCommands() []cli.Command {
 					Name:  FlagInputWithAlias,
 					Usage: "Optional input of signal",
 				},
+				cli.StringFlag{
+					Name:  FlagResetType,
+					Usage: "Required for batch reset, where to reset. Support one of these: " + strings.Join(batcher.AllResetTypes, ","),
+				},
+				cli.StringFlag{
+					Name:  FlagResetBadBinaryChecksum,
+					Usage: "Binary checksum for batch reset with reset type of BadBinary",
+				},
+				cli.StringFlag{
+					Name:  FlagMemoKey,
+					Usage: "Key of memo for batch upsert. If there are multiple keys, concatenate them and separate by space",
+				},
+				cli.StringFlag{
+					Name: FlagMemo,
+					Usage: "Memo for batch upsert, in JSON format. If there are multiple JSON, concatenate them and separate by space. " +
+						"The order must be same as memo_key",
+				},
+				cli.StringFlag{
+					Name: FlagMemoFile,
+					Usage: "Memo for batch upsert, from JSON format file. If there are multiple JSON, concatenate them and separate by space or newline. " +
+						"The order must be same as memo_key",
+				},
+				cli.StringFlag{
+					Name: FlagSearchAttributesKey,
+					Usage: "Search attributes keys for batch upsert. If there are multiple keys, concatenate them and separate by |. " +
+						"Use 'cluster get-search-attr' cmd to list legal keys.",
+				},
+				cli.StringFlag{
+					Name: FlagSearchAttributesVal,
+					Usage: "Search attributes values for batch upsert. If there are multiple keys, concatenate them and separate by |. " +
+						"Use 'cluster get-search-attr' cmd to list legal keys and value types",
+				},
 				cli.IntFlag{
 					Name:  FlagRPS,
 					Value: batcher.DefaultRPS,
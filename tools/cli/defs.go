@@ -60,6 +60,10 @@ const (
 	defaultContextTimeoutForLongPoll             = 2 * time.Minute
 	defaultContextTimeoutForListArchivedWorkflow = 3 * time.Minute
 
+	// graceful namespace failover is polled until the handover cluster is promoted, or the worker had time to force it
+	handoverPollInterval = 2 * time.Second
+	handoverWaitSlack    = time.Minute
+
 	defaultDecisionTimeoutInSeconds = 10
 	defaultPageSizeForList          = 500
 	defaultPageSizeForScan          = 2000
@@ -57,6 +57,10 @@ const (
 	FlagTaskListWithAlias                 = FlagTaskList + ", tl"
 	FlagTaskListType                      = "tasklisttype"
 	FlagTaskListTypeWithAlias             = FlagTaskListType + ", tlt"
+	FlagTaskListBacklog                   = "backlog"
+	FlagTaskListBacklogWithAlias          = FlagTaskListBacklog + ", bl"
+	FlagIncludeShards                     = "include_shards"
+	FlagIncludeShardsWithAlias            = FlagIncludeShards + ", is"
 	FlagWorkflowIDReusePolicy             = "workflowidreusepolicy"
 	FlagWorkflowIDReusePolicyAlias        = FlagWorkflowIDReusePolicy + ", wrp"
 	FlagCronSchedule                      = "cron"
@@ -152,6 +156,8 @@ const (
 	FlagShowDetailWithAlias               = FlagShowDetail + ", sd"
 	FlagActiveClusterName                 = "active_cluster"
 	FlagActiveClusterNameWithAlias        = FlagActiveClusterName + ", ac"
+	FlagGraceful                          = "graceful"
+	FlagGracefulTimeout                   = "graceful_timeout"
 	FlagClusters                          = "clusters"
 	FlagClustersWithAlias                 = FlagClusters + ", cl"
 	FlagClusterMembershipRole             = "role"
@@ -174,6 +180,7 @@ const (
 	FlagMessageType                       = "message_type"
 	FlagMessageTypeWithAlias              = FlagMessageType + ", mt"
 	FlagURL                               = "url"
+	FlagESVersion                         = "es_version"
 	FlagIndex                             = "index"
 	FlagBatchSize                         = "batch_size"
 	FlagBatchSizeWithAlias                = FlagBatchSize + ", bs"
@@ -501,7 +508,7 @@ func getFlagsForCount() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
 			Name:  FlagListQueryWithAlias,
-			Usage: "Optional SQL like query. e.g count all open workflows 'CloseTime = missing'; 'WorkflowType=\"wtype\" and CloseTime > 0'",
+			Usage: "Optional SQL like query. e.g count all open workflows 'CloseTime = missing'; 'WorkflowType=\"wtype\" and CloseTime > 0'; count by status 'CloseTime > 0 group by ExecutionStatus'",
 		},
 	}
 }
@@ -86,6 +86,15 @@ func newNamespaceCommands() []cli.Command {
 				newNamespaceCLI(c, false).UpdateNamespace(c)
 			},
 		},
+		{
+			Name:    "failover",
+			Aliases: []string{"fo"},
+			Usage:   "Fail over global namespace to another cluster",
+			Flags:   failoverNamespaceFlags,
+			Action: func(c *cli.Context) {
+				newNamespaceCLI(c, false).FailoverNamespace(c)
+			},
+		},
 		{
 			Name:    "describe",
 			Aliases: []string{"desc"},
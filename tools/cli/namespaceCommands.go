@@ -287,6 +287,70 @@ func (d *namespaceCLIImpl) UpdateNamespace(c *cli.Context) {
 	}
 }
 
+// FailoverNamespace fails a global namespace over to another cluster
+func (d *namespaceCLIImpl) FailoverNamespace(c *cli.Context) {
+	ns := getRequiredGlobalOption(c, FlagNamespace)
+	activeCluster := getRequiredOption(c, FlagActiveClusterName)
+	graceful := c.Bool(FlagGraceful)
+
+	updateRequest := &workflowservice.UpdateNamespaceRequest{
+		Name: ns,
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: activeCluster,
+		},
+	}
+	var gracefulTimeout time.Duration
+	if graceful {
+		var err error
+		gracefulTimeout, err = time.ParseDuration(c.String(FlagGracefulTimeout))
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagGracefulTimeout), err)
+		}
+		updateRequest.UpdatedInfo = &namespacepb.UpdateNamespaceInfo{
+			Data: map[string]string{namespace.GracefulFailoverTimeoutDataKey: gracefulTimeout.String()},
+		}
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	if err := d.updateNamespace(ctx, updateRequest); err != nil {
+		ErrorAndExit("Operation FailoverNamespace failed.", err)
+	}
+	if !graceful {
+		fmt.Printf("Namespace %s successfully failed over to %s.\n", ns, activeCluster)
+		return
+	}
+
+	fmt.Printf("Namespace %s is handing over to %s, waiting for replication to drain.\n", ns, activeCluster)
+	d.waitForHandover(c, ns, activeCluster, gracefulTimeout+handoverWaitSlack)
+}
+
+// waitForHandover polls the namespace until the handover cluster is promoted
+func (d *namespaceCLIImpl) waitForHandover(c *cli.Context, ns string, activeCluster string, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(handoverPollInterval)
+
+		ctx, cancel := newContext(c)
+		resp, err := d.describeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{Name: ns})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Operation DescribeNamespace failed.", err)
+		}
+
+		if resp.ReplicationConfiguration.GetActiveClusterName() == activeCluster {
+			fmt.Printf("Namespace %s successfully failed over to %s.\n", ns, activeCluster)
+			return
+		}
+		data := resp.NamespaceInfo.GetData()
+		if data[namespace.ReplicationStateDataKey] == "" || data[namespace.HandoverClusterDataKey] != activeCluster {
+			ErrorAndExit(fmt.Sprintf("Handover of namespace %s to %s ended without failing over.", ns, activeCluster), nil)
+		}
+		fmt.Printf("Handover in progress, failover is forced at %s.\n", data[namespace.HandoverExpirationDataKey])
+	}
+	ErrorAndExit(fmt.Sprintf("Timed out waiting for namespace %s to fail over to %s.", ns, activeCluster), nil)
+}
+
 // DescribeNamespace updates a namespace
 func (d *namespaceCLIImpl) DescribeNamespace(c *cli.Context) {
 	namespace := c.GlobalString(FlagNamespace)
@@ -337,6 +401,14 @@ func printNamespace(resp *workflowservice.DescribeNamespaceResponse) {
 		formatStr = formatStr + "VisibilityArchivalURI: %v\n"
 		descValues = append(descValues, resp.Configuration.GetVisibilityArchivalURI())
 	}
+	if state := resp.NamespaceInfo.Data[namespace.ReplicationStateDataKey]; state != "" {
+		formatStr = formatStr + "ReplicationState: %v\nHandoverClusterName: %v\nHandoverExpiration: %v\n"
+		descValues = append(descValues,
+			state,
+			resp.NamespaceInfo.Data[namespace.HandoverClusterDataKey],
+			resp.NamespaceInfo.Data[namespace.HandoverExpirationDataKey],
+		)
+	}
 	fmt.Printf(formatStr, descValues...)
 	if resp.Configuration.BadBinaries != nil {
 		fmt.Println("Bad binaries to reset:")
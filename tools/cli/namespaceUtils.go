@@ -36,6 +36,7 @@ import (
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cluster"
+	es "github.com/temporalio/temporal/common/elasticsearch"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
@@ -168,6 +169,22 @@ var (
 		},
 	}
 
+	failoverNamespaceFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  FlagActiveClusterNameWithAlias,
+			Usage: "Cluster to fail over to",
+		},
+		cli.BoolFlag{
+			Name:  FlagGraceful,
+			Usage: "Stop accepting writes and wait for replication to drain before failing over",
+		},
+		cli.StringFlag{
+			Name:  FlagGracefulTimeout,
+			Value: "10m",
+			Usage: "Duration after which a graceful failover is forced even if replication is not drained",
+		},
+	}
+
 	describeNamespaceFlags = []cli.Flag{
 		cli.StringFlag{
 			Name:  FlagNamespaceID,
@@ -265,6 +282,7 @@ func initializeNamespaceHandler(
 	return namespace.NewHandler(
 		namespace.MinRetentionDays,
 		dynamicconfig.GetIntPropertyFilteredByNamespace(namespace.MaxBadBinaries),
+		dynamicconfig.GetDurationPropertyFn(es.DefaultRolloverInterval),
 		logger,
 		metadataMgr,
 		clusterMetadata,
@@ -42,6 +42,10 @@ func newTaskListCommands() []cli.Command {
 					Value: "decision",
 					Usage: "Optional TaskList type [decision|activity]",
 				},
+				cli.BoolFlag{
+					Name:  FlagTaskListBacklogWithAlias,
+					Usage: "Also show backlog depth, age and task rates aggregated over all partitions",
+				},
 			},
 			Action: func(c *cli.Context) {
 				DescribeTaskList(c)
@@ -61,5 +65,43 @@ func newTaskListCommands() []cli.Command {
 				ListTaskListPartitions(c)
 			},
 		},
+		{
+			Name:  "pause",
+			Usage: "Stop a tasklist from dispatching tasks to pollers, tasks are still accepted and persisted",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Usage: "TaskList type [decision|activity]",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason you want to pause the tasklist",
+				},
+			},
+			Action: func(c *cli.Context) {
+				PauseTaskList(c)
+			},
+		},
+		{
+			Name:  "resume",
+			Usage: "Resume dispatch of a paused tasklist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskListTypeWithAlias,
+					Usage: "TaskList type [decision|activity]",
+				},
+			},
+			Action: func(c *cli.Context) {
+				ResumeTaskList(c)
+			},
+		},
 	}
 }
@@ -25,30 +25,83 @@
 package cli
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
+	tasklistgenpb "github.com/temporalio/temporal/.gen/proto/tasklist/v1"
 )
 
 // DescribeTaskList show pollers info of a given tasklist
 func DescribeTaskList(c *cli.Context) {
-	wfClient := getWorkflowClient(c)
 	taskList := getRequiredOption(c, FlagTaskList)
 	taskListType := strToTaskListType(c.String(FlagTaskListType)) // default type is decision
 
+	if c.Bool(FlagTaskListBacklog) {
+		describeTaskListBacklog(c, taskList, taskListType)
+		return
+	}
+
+	wfClient := getWorkflowClient(c)
 	ctx, cancel := newContext(c)
 	defer cancel()
 	response, err := wfClient.DescribeTaskList(ctx, taskList, taskListType)
 	if err != nil {
 		ErrorAndExit("Operation DescribeTaskList failed.", err)
 	}
+	printTaskListPollers(taskList, taskListType, response.Pollers)
+}
 
-	pollers := response.Pollers
+// describeTaskListBacklog shows the backlog stats and pollers of a given tasklist using the admin API
+func describeTaskListBacklog(c *cli.Context, taskList string, taskListType enumspb.TaskListType) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeTaskList(ctx, &adminservice.DescribeTaskListRequest{
+		Namespace:    namespace,
+		TaskList:     &tasklistpb.TaskList{Name: taskList},
+		TaskListType: taskListType,
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeTaskList failed.", err)
+	}
+	if pauseInfo := response.GetPauseInfo(); pauseInfo != nil {
+		pauseTime, _ := types.TimestampFromProto(pauseInfo.GetPauseTime())
+		fmt.Println(colorMagenta(fmt.Sprintf("Tasklist is paused since %v by %v: %v",
+			convertTime(pauseTime.UnixNano(), false), pauseInfo.GetIdentity(), pauseInfo.GetReason())))
+	}
+	printTaskListBacklogStats(response.GetBacklogStats())
+	printTaskListPollers(taskList, taskListType, response.GetPollers())
+}
+
+func printTaskListBacklogStats(stats *tasklistgenpb.TaskListBacklogStats) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Backlog Count", "Backlog Age", "Add Tasks/s", "Dispatch Tasks/s"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	table.Append([]string{
+		fmt.Sprintf("%d", stats.GetApproximateBacklogCount()),
+		time.Duration(stats.GetApproximateBacklogAgeNanos()).String(),
+		fmt.Sprintf("%.2f", stats.GetAddTasksPerSecond()),
+		fmt.Sprintf("%.2f", stats.GetDispatchTasksPerSecond()),
+	})
+	table.Render()
+}
+
+func printTaskListPollers(taskList string, taskListType enumspb.TaskListType, pollers []*tasklistpb.PollerInfo) {
 	if len(pollers) == 0 {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
 	}
@@ -106,3 +159,46 @@ func printTaskListPartitions(taskListType string, partitions []*tasklistpb.TaskL
 	}
 	table.Render()
 }
+
+// PauseTaskList stops a tasklist from dispatching tasks to pollers
+func PauseTaskList(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskListType := strToTaskListType(getRequiredOption(c, FlagTaskListType))
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.PauseTaskList(ctx, &adminservice.PauseTaskListRequest{
+		Namespace:    namespace,
+		TaskList:     &tasklistpb.TaskList{Name: taskList},
+		TaskListType: taskListType,
+		Reason:       c.String(FlagReason),
+		Identity:     getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Operation PauseTaskList failed.", err)
+	}
+	fmt.Println("Tasklist paused, tasks are persisted but not dispatched until it is resumed.")
+}
+
+// ResumeTaskList resumes dispatch of a paused tasklist
+func ResumeTaskList(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskList := getRequiredOption(c, FlagTaskList)
+	taskListType := strToTaskListType(getRequiredOption(c, FlagTaskListType))
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.ResumeTaskList(ctx, &adminservice.ResumeTaskListRequest{
+		Namespace:    namespace,
+		TaskList:     &tasklistpb.TaskList{Name: taskList},
+		TaskListType: taskListType,
+		Identity:     getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Operation ResumeTaskList failed.", err)
+	}
+	fmt.Println("Tasklist resumed.")
+}
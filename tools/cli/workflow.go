@@ -126,6 +126,48 @@ func newWorkflowCommands() []cli.Command {
 				TerminateWorkflow(c)
 			},
 		},
+		{
+			Name:  "pause",
+			Usage: "pause a workflow execution, its decision and activity tasks are not dispatched until it is unpaused",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason you want to pause the workflow",
+				},
+			},
+			Action: func(c *cli.Context) {
+				PauseWorkflow(c)
+			},
+		},
+		{
+			Name:  "unpause",
+			Usage: "unpause a paused workflow execution",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "The reason you want to unpause the workflow",
+				},
+			},
+			Action: func(c *cli.Context) {
+				UnpauseWorkflow(c)
+			},
+		},
 		{
 			Name:        "list",
 			Aliases:     []string{"l"},
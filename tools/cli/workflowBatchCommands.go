@@ -76,6 +76,12 @@ func DescribeBatchJob(c *cli.Context) {
 			output["msg"] = "batch job stopped status: " + wf.WorkflowExecutionInfo.GetStatus().String()
 		} else {
 			output["msg"] = "batch job is finished successfully"
+			// result contains the final counters and the workflows failed to be processed
+			var hbd batcher.HeartBeatDetails
+			if err := client.GetWorkflow(tcCtx, jobID, "").Get(tcCtx, &hbd); err != nil {
+				ErrorAndExit("Failed to get batch job result", err)
+			}
+			output["result"] = hbd
 		}
 	} else {
 		output["msg"] = "batch job is running"
@@ -151,9 +157,26 @@ func StartBatchJob(c *cli.Context) {
 	}
 	operator := getCurrentUserFromEnv()
 	var sigName, sigVal string
-	if batchType == batcher.BatchTypeSignal {
+	var resetParams batcher.ResetParams
+	var upsertParams batcher.UpsertParams
+	switch batchType {
+	case batcher.BatchTypeSignal:
 		sigName = getRequiredOption(c, FlagSignalName)
 		sigVal = getRequiredOption(c, FlagInput)
+	case batcher.BatchTypeReset:
+		resetParams.ResetType = getRequiredOption(c, FlagResetType)
+		if !validateResetType(resetParams.ResetType) {
+			ErrorAndExit("resetType is not valid, supported:"+strings.Join(batcher.AllResetTypes, ","), nil)
+		}
+		if resetParams.ResetType == batcher.ResetTypeBadBinary {
+			resetParams.BadBinaryChecksum = getRequiredOption(c, FlagResetBadBinaryChecksum)
+		}
+	case batcher.BatchTypeUpsert:
+		upsertParams.Memo = processMemo(c)
+		upsertParams.SearchAttributes = processSearchAttr(c)
+		if len(upsertParams.Memo) == 0 && len(upsertParams.SearchAttributes) == 0 {
+			ErrorAndExit("Memo or search attributes are required for batch upsert.", nil)
+		}
 	}
 	rps := c.Int(FlagRPS)
 
@@ -212,7 +235,9 @@ func StartBatchJob(c *cli.Context) {
 			SignalName: sigName,
 			Input:      sigInput,
 		},
-		RPS: rps,
+		ResetParams:  resetParams,
+		UpsertParams: upsertParams,
+		RPS:          rps,
 	}
 	wf, err := client.ExecuteWorkflow(tcCtx, options, batcher.BatchWFTypeName, params)
 	if err != nil {
@@ -233,3 +258,12 @@ func validateBatchType(bt string) bool {
 	}
 	return false
 }
+
+func validateResetType(rt string) bool {
+	for _, r := range batcher.AllResetTypes {
+		if r == rt {
+			return true
+		}
+	}
+	return false
+}
@@ -30,6 +30,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -55,7 +56,9 @@ import (
 	workflowpb "go.temporal.io/temporal-proto/workflow/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 	"go.temporal.io/temporal/client"
+	"google.golang.org/grpc/status"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	cligenpb "github.com/temporalio/temporal/.gen/proto/cli/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/clock"
@@ -374,7 +377,6 @@ func getPrintableSearchAttr(searchAttr *commonpb.SearchAttributes) string {
 func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	fmt.Println(colorMagenta("Progress:"))
 
-	wfClient := getWorkflowClient(c)
 	timeElapse := 1
 	isTimeElapseExist := false
 	doneChan := make(chan bool)
@@ -391,12 +393,7 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	}
 
 	go func() {
-		iter := wfClient.GetWorkflowHistory(tcCtx, wid, rid, true, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
-		for iter.HasNext() {
-			event, err := iter.Next()
-			if err != nil {
-				ErrorAndExit("Unable to read event.", err)
-			}
+		streamWorkflowHistory(tcCtx, c, wid, rid, func(event *historypb.HistoryEvent) {
 			if isTimeElapseExist {
 				removePrevious2LinesFromTerminal()
 				isTimeElapseExist = false
@@ -407,7 +404,7 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 				fmt.Printf("  %d, %s, %s\n", event.GetEventId(), convertTime(event.GetTimestamp(), false), ColorEvent(event))
 			}
 			lastEvent = event
-		}
+		})
 		doneChan <- true
 	}()
 
@@ -429,6 +426,49 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	}
 }
 
+// streamWorkflowHistory calls fn for each event of the workflow until the close event,
+// a stream broken by an unavailable frontend is resumed from the event after the last received one
+func streamWorkflowHistory(ctx context.Context, c *cli.Context, wid, rid string, fn func(*historypb.HistoryEvent)) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	firstEventID := common.FirstEventID
+	for {
+		stream, err := adminClient.StreamWorkflowExecutionHistory(ctx, &adminservice.StreamWorkflowExecutionHistoryRequest{
+			Namespace: namespace,
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: wid,
+				RunId:      rid,
+			},
+			FirstEventId: firstEventID,
+		})
+		if err != nil {
+			ErrorAndExit("Unable to stream workflow history.", err)
+		}
+
+	Recv_Loop:
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				if _, ok := serviceerror.FromStatus(status.Convert(err)).(*serviceerror.Unavailable); ok {
+					break Recv_Loop
+				}
+				ErrorAndExit("Unable to read event.", err)
+			}
+
+			// pin the run so a resumed stream does not follow a newer run
+			rid = resp.GetRunId()
+			for _, event := range resp.GetHistory().GetEvents() {
+				fn(event)
+				firstEventID = event.GetEventId() + 1
+			}
+		}
+	}
+}
+
 // TerminateWorkflow terminates a workflow execution
 func TerminateWorkflow(c *cli.Context) {
 	wfClient := getWorkflowClient(c)
@@ -448,6 +488,62 @@ func TerminateWorkflow(c *cli.Context) {
 	}
 }
 
+// PauseWorkflow pauses a workflow execution
+func PauseWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	reason := c.String(FlagReason)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.PauseWorkflowExecution(ctx, &adminservice.PauseWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		Reason:   reason,
+		Identity: getCliIdentity(),
+	})
+
+	if err != nil {
+		ErrorAndExit("Pause workflow failed.", err)
+	} else {
+		fmt.Println("Pause workflow succeeded.")
+	}
+}
+
+// UnpauseWorkflow unpauses a paused workflow execution
+func UnpauseWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	reason := c.String(FlagReason)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.UnpauseWorkflowExecution(ctx, &adminservice.UnpauseWorkflowExecutionRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		Reason:   reason,
+		Identity: getCliIdentity(),
+	})
+
+	if err != nil {
+		ErrorAndExit("Unpause workflow failed.", err)
+	} else {
+		fmt.Println("Unpause workflow succeeded.")
+	}
+}
+
 // CancelWorkflow cancels a workflow execution
 func CancelWorkflow(c *cli.Context) {
 	wfClient := getWorkflowClient(c)
@@ -694,9 +790,13 @@ func isQueryOpen(query string) bool {
 
 // CountWorkflow count number of workflows
 func CountWorkflow(c *cli.Context) {
-	wfClient := getWorkflowClient(c)
-
 	query := c.String(FlagListQuery)
+	if isQueryGroupBy(query) {
+		countWorkflowGroupBy(c, query)
+		return
+	}
+
+	wfClient := getWorkflowClient(c)
 	request := &workflowservice.CountWorkflowExecutionsRequest{
 		Query: query,
 	}
@@ -711,6 +811,36 @@ func CountWorkflow(c *cli.Context) {
 	fmt.Println(response.GetCount())
 }
 
+func isQueryGroupBy(query string) bool {
+	var groupByPattern = regexp.MustCompile(`(?i)group[ ]+by`)
+	return groupByPattern.MatchString(query)
+}
+
+// countWorkflowGroupBy prints workflow counts per group as a table,
+// grouped counts are only available through admin API
+func countWorkflowGroupBy(c *cli.Context, query string) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	ctx, cancel := newContextForLongPoll(c)
+	defer cancel()
+	response, err := adminClient.CountWorkflowExecutions(ctx, &adminservice.CountWorkflowExecutionsRequest{
+		Namespace: namespace,
+		Query:     query,
+	})
+	if err != nil {
+		ErrorAndExit("Failed to count workflow.", err)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(append(response.GetGroupBy(), "Count"))
+	for _, group := range response.GetGroups() {
+		table.Append(append(group.GetValues(), strconv.FormatInt(group.GetCount(), 10)))
+	}
+	table.SetFooter(append(make([]string, len(response.GetGroupBy())), strconv.FormatInt(response.GetCount(), 10)))
+	table.Render()
+}
+
 // ListArchivedWorkflow lists archived workflow executions based on filters
 func ListArchivedWorkflow(c *cli.Context) {
 	wfClient := getWorkflowClient(c)